@@ -0,0 +1,21 @@
+// Command fake-tmdb serves the fake TMDB API, so the Movie Tracker API can run
+// without network access. Point TMDB_BASE_URL at http://<addr>/3 to use it.
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"net/http"
+
+	"github.com/movie-tracker/MovieTracker/internal/tmdb/faketmdb"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8890", "address to listen on")
+	flag.Parse()
+
+	slog.Info("fake TMDB listening", "base_url", "http://"+*addr+"/3")
+	if err := http.ListenAndServe(*addr, faketmdb.Handler()); err != nil {
+		slog.Error("fake TMDB stopped", "error", err)
+	}
+}
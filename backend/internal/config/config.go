@@ -30,24 +30,130 @@ type DatabaseConfig struct {
 }
 
 type TMDBConfig struct {
-	ApiKey string
+	// Base URL of the API, pointed at the fake server (cmd/fake-tmdb) to run
+	// offline.
+	BaseURL string
+	ApiKey  string
+
+	// Time to live, in minutes, of the movie catalog cached in the database.
+	CacheTTL int
+
+	// ISO 639-1 codes of the languages whose videos are kept with the movie
+	// details. Other languages get the English videos.
+	VideoLanguages []string
+
+	// Timeout, in seconds, of every request to TMDB, reading the response included.
+	Timeout int
+
+	// Times a request failing with a network error, 429 or 5xx is retried.
+	MaxRetries int
+
+	// Consecutive failures after which TMDB isn't called for BreakerCooldown
+	// seconds. Zero disables the circuit breaker.
+	BreakerThreshold int
+	BreakerCooldown  int
+}
+
+// ContentPolicyConfig holds the rules deciding which titles are shown. Users
+// can override most of them with a parental profile.
+type ContentPolicyConfig struct {
+	// Whether adult titles are shown. Parental profiles can't enable them.
+	IncludeAdult bool
+
+	// Rating scale of the certifications, and the ones allowed in it.
+	CertificationCountry  string
+	AllowedCertifications []string
+
+	// Certifications allowed in the rating scale of other countries. Viewers
+	// from these regions are rated on their own scale, the rest on the one of
+	// CertificationCountry.
+	RegionalCertifications map[string][]string
+
+	// TMDB IDs of the genres never shown.
+	ExcludedGenres []int
+
+	// Titles containing any of these words are hidden.
+	BlockedKeywords []string
+
+	// Titles with at least MinVoteCount votes must average MinVoteAverage.
+	MinVoteAverage float64
+	MinVoteCount   int
+}
+
+type MailConfig struct {
+	// Either "smtp", which fails to start without a host, or "log".
+	Driver string
+	From   string
+
+	Host     string
+	Port     int
+	Username string
+	Password string
+
+	// Directory where the log driver writes the messages. They are logged when empty.
+	Dir string
+}
+
+type NotificationConfig struct {
+	// Minutes between the scans for new notifications. Zero disables them.
+	Interval int
+
+	// Channels notifications are delivered through besides the in-app inbox,
+	// to the users who set them up: "email" and "webhook".
+	Channels []string
+
+	// Key signing the webhook payloads, in the X-Movie-Tracker-Signature
+	// header. They aren't signed when empty.
+	WebhookSecret string
+
+	// Timeout, in seconds, of every webhook request.
+	WebhookTimeout int
 }
 
 type ApiConfig struct {
 	Host string
 	Port int
 
+	// Address of the listener serving the runtime and upstream call metrics
+	// at /debug/vars, kept apart from the API so it can stay internal. Empty
+	// disables it.
+	DebugAddr string
+
 	// Authentication
 	AuthSecret   string
 	AuthTokenTTL int
 
+	// Time to live, in minutes, of a session's refresh token.
+	RefreshTokenTTL int
+
 	// CORS
 	AllowOrigin string
 
+	// Time, in seconds, a request may take before it is cancelled. Imports and
+	// exports get LongRequestTimeout instead.
+	RequestTimeout     int
+	LongRequestTimeout int
+
+	// Base URL of the web app, used in the links sent by email.
+	AppURL string
+
+	// Base URL the API is reached at, used in the calendar feed links.
+	ApiURL string
+
+	// Language and region of the movie data when neither the user nor the
+	// request asks for one.
+	DefaultLanguage string
+	DefaultRegion   string
+
 	Database DatabaseConfig
 	TMDB     TMDBConfig
+	Mail     MailConfig
+	Content  ContentPolicyConfig
+	Notify   NotificationConfig
 }
 
+const defaultTMDBBaseURL = "https://api.themoviedb.org/3"
+
 func NewApiConfig() ApiConfig {
 	err := godotenv.Load()
 	if err != nil {
@@ -57,6 +163,9 @@ func NewApiConfig() ApiConfig {
 	return ApiConfig{
 		Host: envOrDefault("HOST", "127.0.0.1"),
 		Port: envOrDefaultInt("PORT", 8080),
+
+		DebugAddr: envOrDefault("DEBUG_ADDR", ""),
+
 		Database: DatabaseConfig{
 			Name:     panicOnEmpty("DB_NAME"),
 			Host:     panicOnEmpty("DB_HOST"),
@@ -65,13 +174,76 @@ func NewApiConfig() ApiConfig {
 			Password: panicOnEmpty("DB_PASSWORD"),
 		},
 
-		AuthSecret:   panicOnEmpty("AUTH_SECRET"),
-		AuthTokenTTL: envOrDefaultInt("AUTH_TOKEN_TTL", 60*24), // 24 hours
+		AuthSecret:      panicOnEmpty("AUTH_SECRET"),
+		AuthTokenTTL:    envOrDefaultInt("AUTH_TOKEN_TTL", 15),          // 15 minutes
+		RefreshTokenTTL: envOrDefaultInt("REFRESH_TOKEN_TTL", 60*24*30), // 30 days
 
 		AllowOrigin: envOrDefault("CORS_ALLOW_ORIGINS", "*"),
 
+		RequestTimeout:     envOrDefaultInt("REQUEST_TIMEOUT", 15),
+		LongRequestTimeout: envOrDefaultInt("LONG_REQUEST_TIMEOUT", 60*5), // 5 minutes
+
+		AppURL: envOrDefault("APP_URL", "http://localhost:5173"),
+		ApiURL: envOrDefault("API_URL", "http://localhost:8080"),
+
+		DefaultLanguage: envOrDefault("DEFAULT_LANGUAGE", "pt-BR"),
+		DefaultRegion:   envOrDefault("DEFAULT_REGION", "BR"),
+
 		TMDB: TMDBConfig{
-			ApiKey: panicOnEmpty("TMDB_API_KEY"),
+			BaseURL:          envOrDefault("TMDB_BASE_URL", defaultTMDBBaseURL),
+			ApiKey:           tmdbApiKey(),
+			CacheTTL:         envOrDefaultInt("TMDB_CACHE_TTL", 60*12), // 12 hours
+			VideoLanguages:   envOrDefaultList("TMDB_VIDEO_LANGUAGES", []string{"pt", "en"}),
+			Timeout:          envOrDefaultInt("TMDB_TIMEOUT", 10),
+			MaxRetries:       envOrDefaultInt("TMDB_MAX_RETRIES", 3),
+			BreakerThreshold: envOrDefaultInt("TMDB_BREAKER_THRESHOLD", 5),
+			BreakerCooldown:  envOrDefaultInt("TMDB_BREAKER_COOLDOWN", 30),
 		},
+
+		Content: ContentPolicyConfig{
+			IncludeAdult:          envOrDefaultBool("CONTENT_INCLUDE_ADULT", false),
+			CertificationCountry:  envOrDefault("CONTENT_CERTIFICATION_COUNTRY", "BR"),
+			AllowedCertifications: envOrDefaultList("CONTENT_ALLOWED_CERTIFICATIONS", []string{"L", "10", "12"}),
+			RegionalCertifications: envOrDefaultListMap("CONTENT_REGIONAL_CERTIFICATIONS", map[string][]string{
+				"US": {"G", "PG", "PG-13"},
+				"GB": {"U", "PG", "12A", "12"},
+				"PT": {"M/3", "M/6", "M/12"},
+			}),
+			ExcludedGenres:  envOrDefaultIntList("CONTENT_EXCLUDED_GENRES", []int{27, 10749, 10751, 99, 10769}),
+			BlockedKeywords: envOrDefaultList("CONTENT_BLOCKED_KEYWORDS", defaultBlockedKeywords),
+			MinVoteAverage:  envOrDefaultFloat("CONTENT_MIN_VOTE_AVERAGE", 3.0),
+			MinVoteCount:    envOrDefaultInt("CONTENT_MIN_VOTE_COUNT", 100),
+		},
+
+		Mail: MailConfig{
+			Driver:   envOrDefault("MAIL_DRIVER", "smtp"),
+			From:     envOrDefault("MAIL_FROM", "Movie Tracker <no-reply@movie-tracker.local>"),
+			Host:     envOrDefault("SMTP_HOST", ""),
+			Port:     envOrDefaultInt("SMTP_PORT", 587),
+			Username: envOrDefault("SMTP_USERNAME", ""),
+			Password: envOrDefault("SMTP_PASSWORD", ""),
+			Dir:      envOrDefault("MAIL_DIR", ""),
+		},
+
+		Notify: NotificationConfig{
+			Interval:       envOrDefaultInt("NOTIFICATION_INTERVAL", 60),
+			Channels:       envOrDefaultList("NOTIFICATION_CHANNELS", []string{"email", "webhook"}),
+			WebhookSecret:  envOrDefault("NOTIFICATION_WEBHOOK_SECRET", ""),
+			WebhookTimeout: envOrDefaultInt("NOTIFICATION_WEBHOOK_TIMEOUT", 10),
+		},
+	}
+}
+
+var defaultBlockedKeywords = []string{
+	"porn", "xxx", "adult", "sex", "nude", "erotic", "pornographic", "explicit",
+	"hardcore", "softcore",
+}
+
+// tmdbApiKey reads the TMDB token, which only the real API requires. The fake
+// server accepts any token.
+func tmdbApiKey() string {
+	if envOrDefault("TMDB_BASE_URL", defaultTMDBBaseURL) != defaultTMDBBaseURL {
+		return envOrDefault("TMDB_API_KEY", "fake")
 	}
+	return panicOnEmpty("TMDB_API_KEY")
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
 func panicOnEmpty(key string) string {
@@ -30,3 +31,90 @@ func envOrDefault(key string, defaultValue string) string {
 		return defaultValue
 	}
 }
+
+func envOrDefaultBool(key string, defaultValue bool) bool {
+	if s := os.Getenv(key); s != "" {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			panic(err)
+		}
+		return b
+	} else {
+		return defaultValue
+	}
+}
+
+func envOrDefaultFloat(key string, defaultValue float64) float64 {
+	if s := os.Getenv(key); s != "" {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			panic(err)
+		}
+		return f
+	} else {
+		return defaultValue
+	}
+}
+
+// envOrDefaultList reads a comma separated list. Setting the variable to a
+// lone comma yields an empty list.
+func envOrDefaultList(key string, defaultValue []string) []string {
+	s, ok := os.LookupEnv(key)
+	if !ok || s == "" {
+		return defaultValue
+	}
+
+	values := make([]string, 0)
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func envOrDefaultIntList(key string, defaultValue []int) []int {
+	s := envOrDefaultList(key, nil)
+	if s == nil {
+		return defaultValue
+	}
+
+	values := make([]int, len(s))
+	for i, value := range s {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			panic(err)
+		}
+		values[i] = n
+	}
+	return values
+}
+
+// envOrDefaultListMap reads lists keyed by name, as in "US=G|PG;GB=U|PG".
+func envOrDefaultListMap(key string, defaultValue map[string][]string) map[string][]string {
+	s, ok := os.LookupEnv(key)
+	if !ok || s == "" {
+		return defaultValue
+	}
+
+	values := make(map[string][]string)
+	for _, entry := range strings.Split(s, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		name, list, found := strings.Cut(entry, "=")
+		if !found {
+			panic(fmt.Sprintf("%s: %q is not in the form NAME=A|B", key, entry))
+		}
+
+		items := make([]string, 0)
+		for _, item := range strings.Split(list, "|") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		values[strings.ToUpper(strings.TrimSpace(name))] = items
+	}
+	return values
+}
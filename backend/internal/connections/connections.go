@@ -6,10 +6,14 @@ import (
 
 	_ "github.com/lib/pq"
 	"github.com/movie-tracker/MovieTracker/internal/config"
+	"github.com/movie-tracker/MovieTracker/internal/mailer"
+	"github.com/movie-tracker/MovieTracker/internal/tmdb"
 )
 
 type Connections struct {
-	DB *sql.DB
+	DB     *sql.DB
+	Mailer mailer.Mailer
+	TMDB   *tmdb.Client
 }
 
 func NewConnections(cfg config.ApiConfig) (conns Connections, err error) {
@@ -22,5 +26,9 @@ func NewConnections(cfg config.ApiConfig) (conns Connections, err error) {
 		return
 	}
 
+	conns.TMDB = tmdb.NewClient(cfg.TMDB)
+
+	conns.Mailer, err = mailer.NewMailer(cfg.Mail)
+
 	return
 }
@@ -14,24 +14,35 @@ type IAuthController interface {
 }
 
 type AuthController struct {
-	authService services.IAuthService
+	authService    services.IAuthService
+	accountService services.IAccountService
 }
 
 func newAuthController(params ControllerParams) IAuthController {
 	return &AuthController{
-		authService: params.Svcs.AuthService,
+		authService:    params.Svcs.AuthService,
+		accountService: params.Svcs.AccountService,
 	}
 }
 
 func (c *AuthController) RegisterHandlers(params ControllerRegisterParams) {
 	router := params.Public.Group("/auth")
 
-	router.POST("/login", utils.MakeHandler(c.Login))       // POST /auth/login
-	router.POST("/register", utils.MakeHandler(c.Register)) // POST /auth/register
+	router.POST("/login", utils.MakeHandler(c.Login))                    // POST /auth/login
+	router.POST("/register", utils.MakeHandler(c.Register))              // POST /auth/register
+	router.POST("/refresh", utils.MakeHandler(c.Refresh))                // POST /auth/refresh
+	router.POST("/forgot-password", utils.MakeHandler(c.ForgotPassword)) // POST /auth/forgot-password
+	router.POST("/reset-password", utils.MakeHandler(c.ResetPassword))   // POST /auth/reset-password
+	router.POST("/verify-email", utils.MakeHandler(c.VerifyEmail))       // POST /auth/verify-email
+
+	authenticated := params.Authenticated.Group("/auth")
+
+	authenticated.POST("/logout", utils.MakeHandler(c.Logout))                          // POST /auth/logout
+	authenticated.POST("/verify-email/resend", utils.MakeHandler(c.ResendVerification)) // POST /auth/verify-email/resend
 }
 
 // @Summary User login
-// @Description Authenticate user credentials and return JWT token
+// @Description Authenticate user credentials and open a session. Returns a short-lived JWT access token and the refresh token of the session.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -52,15 +63,72 @@ func (c *AuthController) Login(ctx *gin.Context) error {
 	username := loginDTO.Username
 	password := loginDTO.Password
 
-	token, err := c.authService.Login(username, password)
+	client := dto.SessionClientDTO{
+		UserAgent: ctx.Request.UserAgent(),
+		IPAddress: ctx.ClientIP(),
+	}
+
+	tokens, err := c.authService.Login(ctx.Request.Context(), username, password, client)
+	if err != nil {
+		return err
+	}
+
+	ctx.IndentedJSON(http.StatusOK, tokens)
+
+	return nil
+}
+
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token. The refresh token is rotated: the returned one replaces it and the old one can't be used again.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param refresh body dto.RefreshRequestDTO true "Refresh token"
+// @Success 200 {object} dto.AuthResponseDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /auth/refresh [post]
+func (c *AuthController) Refresh(ctx *gin.Context) error {
+	var req dto.RefreshRequestDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.auth.invalid_request", err)
+	}
+
+	tokens, err := c.authService.Refresh(ctx.Request.Context(), req.RefreshToken)
 	if err != nil {
 		return err
 	}
 
-	ctx.IndentedJSON(http.StatusOK, map[string]string{
-		"authToken": token,
-	})
+	ctx.IndentedJSON(http.StatusOK, tokens)
+	return nil
+}
+
+// @Summary User logout
+// @Description Revoke the current session. Its refresh token and access tokens stop working immediately.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /auth/logout [post]
+func (c *AuthController) Logout(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	sessionID, exists := getSessionID(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.session_not_found")
+	}
+
+	if err := c.authService.Logout(ctx.Request.Context(), user.ID, sessionID); err != nil {
+		return err
+	}
 
+	ctx.JSON(http.StatusNoContent, nil)
 	return nil
 }
 
@@ -83,10 +151,109 @@ func (c *AuthController) Register(ctx *gin.Context) error {
 		return utils.NewBadRequestError("error.auth.invalid_request")
 	}
 
-	if userDTO, err = c.authService.Register(userCreateDTO); err != nil {
+	if userDTO, err = c.authService.Register(ctx.Request.Context(), userCreateDTO); err != nil {
 		return err
 	}
 
 	ctx.IndentedJSON(http.StatusCreated, userDTO)
 	return nil
 }
+
+// @Summary Request a password reset
+// @Description Email a single-use link to reset the password of the account with this address. The response is the same whether or not the address belongs to an account.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequestDTO true "Account email"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /auth/forgot-password [post]
+func (c *AuthController) ForgotPassword(ctx *gin.Context) error {
+	var req dto.ForgotPasswordRequestDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.auth.invalid_request", err)
+	}
+
+	if err := c.accountService.RequestPasswordReset(ctx.Request.Context(), req.Email); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Reset password
+// @Description Set a new password with the token of a password reset email. Every session of the account is revoked.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequestDTO true "Reset token and new password"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Router /auth/reset-password [post]
+func (c *AuthController) ResetPassword(ctx *gin.Context) error {
+	var req dto.ResetPasswordRequestDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.auth.invalid_request", err)
+	}
+
+	if err := c.accountService.ResetPassword(ctx.Request.Context(), req.Token, req.Password); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Verify email
+// @Description Confirm the email address of an account with the token of a verification email
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyEmailRequestDTO true "Verification token"
+// @Success 200 {object} dto.UserDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Router /auth/verify-email [post]
+func (c *AuthController) VerifyEmail(ctx *gin.Context) error {
+	var req dto.VerifyEmailRequestDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.auth.invalid_request", err)
+	}
+
+	user, err := c.accountService.VerifyEmail(ctx.Request.Context(), req.Token)
+	if err != nil {
+		return err
+	}
+
+	ctx.IndentedJSON(http.StatusOK, user)
+	return nil
+}
+
+// @Summary Resend verification email
+// @Description Send a new verification email to the authenticated user. Links sent before stop working.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 409 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /auth/verify-email/resend [post]
+func (c *AuthController) ResendVerification(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	if err := c.accountService.SendEmailVerification(ctx.Request.Context(), user); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
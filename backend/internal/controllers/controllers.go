@@ -11,10 +11,19 @@ type IController interface {
 }
 
 type Controllers struct {
-	AuthController      IAuthController
-	UserController      IUserController
-	MovieController     IMovieController
-	WatchlistController IWatchlistController
+	AuthController          IAuthController
+	UserController          IUserController
+	MovieController         IMovieController
+	WatchlistController     IWatchlistController
+	DiaryController         IDiaryController
+	ListController          IListController
+	ImportController        IImportController
+	ShowController          IShowController
+	PersonController        IPersonController
+	GenreController         IGenreController
+	WatchProviderController IWatchProviderController
+	ReleaseController       IReleaseController
+	NotificationController  INotificationController
 }
 
 type ControllerParams struct {
@@ -33,10 +42,19 @@ func NewControllers(cfg config.ApiConfig, services services.Services) Controller
 		Svcs: services,
 	}
 	return Controllers{
-		AuthController:      newAuthController(params),
-		UserController:      newUserController(params),
-		MovieController:     newMovieController(params),
-		WatchlistController: newWatchlistController(params),
+		AuthController:          newAuthController(params),
+		UserController:          newUserController(params),
+		MovieController:         newMovieController(params),
+		WatchlistController:     newWatchlistController(params),
+		DiaryController:         newDiaryController(params),
+		ListController:          newListController(params),
+		ImportController:        newImportController(params),
+		ShowController:          newShowController(params),
+		PersonController:        newPersonController(params),
+		GenreController:         newGenreController(params),
+		WatchProviderController: newWatchProviderController(params),
+		ReleaseController:       newReleaseController(params),
+		NotificationController:  newNotificationController(params),
 	}
 }
 
@@ -45,6 +63,15 @@ func (c *Controllers) RegisterHandlers(params ControllerRegisterParams) {
 	c.UserController.RegisterHandlers(params)
 	c.MovieController.RegisterHandlers(params)
 	c.WatchlistController.RegisterHandlers(params)
+	c.DiaryController.RegisterHandlers(params)
+	c.ListController.RegisterHandlers(params)
+	c.ImportController.RegisterHandlers(params)
+	c.ShowController.RegisterHandlers(params)
+	c.PersonController.RegisterHandlers(params)
+	c.GenreController.RegisterHandlers(params)
+	c.WatchProviderController.RegisterHandlers(params)
+	c.ReleaseController.RegisterHandlers(params)
+	c.NotificationController.RegisterHandlers(params)
 }
 
 func path(prefix string, path string) string {
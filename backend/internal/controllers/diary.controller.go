@@ -0,0 +1,134 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+type IDiaryController interface {
+	IController
+}
+
+type DiaryController struct {
+	diaryService services.IDiaryService
+}
+
+func newDiaryController(params ControllerParams) IDiaryController {
+	return &DiaryController{
+		diaryService: params.Svcs.DiaryService,
+	}
+}
+
+func (c *DiaryController) RegisterHandlers(params ControllerRegisterParams) {
+	router := params.Authenticated.Group("/diary")
+
+	router.GET("", utils.MakeHandler(c.GetDiary))           // GET /diary
+	router.POST("", utils.MakeHandler(c.LogWatch))          // POST /diary
+	router.DELETE("/:id", utils.MakeHandler(c.DeleteEntry)) // DELETE /diary/:id
+}
+
+// @Summary Get viewing diary
+// @Description Get the authenticated user's logged viewings, most recent first
+// @Tags diary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param movie_id query int false "Only viewings of this movie"
+// @Success 200 {array} dto.WatchEventDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /diary [get]
+func (c *DiaryController) GetDiary(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	var movieID *int32
+	if movieIDParam := ctx.Query("movie_id"); movieIDParam != "" {
+		id, err := strconv.Atoi(movieIDParam)
+		if err != nil {
+			return utils.NewValidationError("error.diary.invalid_movie_id", err)
+		}
+		value := int32(id)
+		movieID = &value
+	}
+
+	events, err := c.diaryService.GetByUser(ctx.Request.Context(), user.ID, movieID)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, events)
+	return nil
+}
+
+// @Summary Log a viewing
+// @Description Record that the authenticated user watched a movie. The movie is marked as watched in their watchlist.
+// @Tags diary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param event body dto.WatchEventCreateDTO true "Viewing data"
+// @Success 201 {object} dto.WatchEventDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /diary [post]
+func (c *DiaryController) LogWatch(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	var req dto.WatchEventCreateDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.diary.invalid_request", err)
+	}
+
+	event, err := c.diaryService.LogWatch(ctx.Request.Context(), user.ID, req)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusCreated, event)
+	return nil
+}
+
+// @Summary Delete a diary entry
+// @Description Remove a logged viewing from the authenticated user's diary
+// @Tags diary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Diary entry ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /diary/{id} [delete]
+func (c *DiaryController) DeleteEntry(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	idParam := ctx.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return utils.NewValidationError("error.diary.invalid_id", err)
+	}
+
+	if err = c.diaryService.Delete(ctx.Request.Context(), user.ID, int32(id)); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDiaryService struct {
+	mock.Mock
+}
+
+func (m *MockDiaryService) ProvideServices(services.Services) {}
+
+func (m *MockDiaryService) GetByUser(_ context.Context, userID int32, movieID *int32) ([]dto.WatchEventDTO, error) {
+	args := m.Called(userID, movieID)
+	return args.Get(0).([]dto.WatchEventDTO), args.Error(1)
+}
+
+func (m *MockDiaryService) LogWatch(_ context.Context, userID int32, createDTO dto.WatchEventCreateDTO) (dto.WatchEventDTO, error) {
+	args := m.Called(userID, createDTO)
+	return args.Get(0).(dto.WatchEventDTO), args.Error(1)
+}
+
+func (m *MockDiaryService) Delete(_ context.Context, userID int32, id int32) error {
+	return m.Called(userID, id).Error(0)
+}
+
+func newDiaryTestRouter(service services.IDiaryService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	auth := r.Group("/api")
+	auth.Use(func(c *gin.Context) {
+		c.Set("requester", dto.UserDTO{ID: 1})
+		c.Next()
+	})
+
+	controller := &DiaryController{diaryService: service}
+	controller.RegisterHandlers(ControllerRegisterParams{Authenticated: auth})
+
+	return r
+}
+
+func TestDiaryController_GetDiary(t *testing.T) {
+	// Setup
+	mockService := new(MockDiaryService)
+	r := newDiaryTestRouter(mockService)
+
+	movieID := int32(550)
+	mockService.On("GetByUser", int32(1), &movieID).Return([]dto.WatchEventDTO{{ID: 7, MovieID: 550, WatchedOn: "2025-06-05"}}, nil)
+
+	// Execute
+	req, _ := http.NewRequest("GET", "/api/diary?movie_id=550", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []dto.WatchEventDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	if assert.Len(t, response, 1) {
+		assert.Equal(t, int32(7), response[0].ID)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDiaryController_LogWatch(t *testing.T) {
+	// Setup
+	mockService := new(MockDiaryService)
+	r := newDiaryTestRouter(mockService)
+
+	createDTO := dto.WatchEventCreateDTO{MovieID: 550, WatchedOn: "2025-06-05", Rewatch: true}
+	mockService.On("LogWatch", int32(1), createDTO).Return(dto.WatchEventDTO{ID: 7, MovieID: 550, WatchedOn: "2025-06-05", Rewatch: true}, nil)
+
+	// Execute
+	body, _ := json.Marshal(createDTO)
+	req, _ := http.NewRequest("POST", "/api/diary", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response dto.WatchEventDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int32(7), response.ID)
+	assert.True(t, response.Rewatch)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDiaryController_LogWatch_InvalidRequest(t *testing.T) {
+	// Setup
+	mockService := new(MockDiaryService)
+	r := newDiaryTestRouter(mockService)
+
+	for _, body := range []string{
+		`{}`,
+		`{"movie_id": 550, "watched_on": "05/06/2025"}`,
+		`{"movie_id": 550, "rating": 11}`,
+	} {
+		req, _ := http.NewRequest("POST", "/api/diary", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	mockService.AssertNotCalled(t, "LogWatch", mock.Anything, mock.Anything)
+}
+
+func TestDiaryController_DeleteEntry(t *testing.T) {
+	// Setup
+	mockService := new(MockDiaryService)
+	r := newDiaryTestRouter(mockService)
+
+	mockService.On("Delete", int32(1), int32(7)).Return(nil)
+	mockService.On("Delete", int32(1), int32(8)).Return(utils.NewNotFoundError("error.diary.not_found"))
+
+	for url, status := range map[string]int{
+		"/api/diary/7":   http.StatusNoContent,
+		"/api/diary/8":   http.StatusNotFound,
+		"/api/diary/abc": http.StatusBadRequest,
+	} {
+		req, _ := http.NewRequest("DELETE", url, nil)
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, status, w.Code, url)
+	}
+
+	mockService.AssertExpectations(t)
+}
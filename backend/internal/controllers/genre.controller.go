@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+type IGenreController interface {
+	IController
+}
+
+type GenreController struct {
+	genreService services.IGenreService
+}
+
+func newGenreController(params ControllerParams) IGenreController {
+	return &GenreController{
+		genreService: params.Svcs.GenreService,
+	}
+}
+
+func (c *GenreController) RegisterHandlers(params ControllerRegisterParams) {
+	router := params.Public.Group("/genres")
+
+	router.GET("", utils.MakeHandler(c.GetGenres))                 // GET /genres
+	router.GET("/:id/movies", utils.MakeHandler(c.GetGenreMovies)) // GET /genres/:id/movies
+}
+
+// @Summary Get genres
+// @Description Get the movie genres named in the viewer's language. Genres excluded by the content policy are left out.
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Param Accept-Language header string false "Language of the genre names, unless the user set one"
+// @Success 200 {array} dto.GenreDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /genres [get]
+func (c *GenreController) GetGenres(ctx *gin.Context) error {
+	genres, err := c.genreService.GetMovieGenres(ctx.Request.Context(), getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, genres)
+	return nil
+}
+
+// @Summary Get genre movies
+// @Description Get a page of the movies of a genre, accepting the same filters and sort order as discover. Pages are read with the cursor returned by the previous one.
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Param without_genres query []int false "Genre IDs, movies in any of them are left out" collectionFormat(multi)
+// @Param year_from query int false "First release year"
+// @Param year_to query int false "Last release year"
+// @Param runtime_min query int false "Shortest runtime, in minutes"
+// @Param runtime_max query int false "Longest runtime, in minutes"
+// @Param min_vote_average query number false "Lowest vote average, from 0 to 10"
+// @Param min_vote_count query int false "Fewest votes (default: the minimum of the content policy)"
+// @Param original_language query string false "ISO 639-1 code of the original language"
+// @Param sort_by query string false "Sort order (default: popularity.desc)"
+// @Param hide_watchlisted query bool false "Leave out the movies on the logged in user's watchlist"
+// @Param cursor query string false "Cursor of the page, from next_cursor of the previous one"
+// @Param page_size query int false "Number of movies per page (default: 20, max: 60)"
+// @Param page query int false "Page number, for clients without cursors"
+// @Param Accept-Language header string false "Language of the movie data, unless the user set one"
+// @Success 200 {object} dto.CursorPagination[dto.MovieDTO]
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Excluded by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /genres/{id}/movies [get]
+func (c *GenreController) GetGenreMovies(ctx *gin.Context) error {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return utils.NewValidationError("error.genre.invalid_id", err)
+	}
+
+	filters, page, err := bindDiscoverQuery(ctx)
+	if err != nil {
+		return err
+	}
+
+	movies, err := c.genreService.GetMovies(ctx.Request.Context(), id, filters, page, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, movies)
+	return nil
+}
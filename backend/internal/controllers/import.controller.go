@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/letterboxd"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+type IImportController interface {
+	IController
+}
+
+type ImportController struct {
+	importService services.IImportService
+}
+
+func newImportController(params ControllerParams) IImportController {
+	return &ImportController{
+		importService: params.Svcs.ImportService,
+	}
+}
+
+func (c *ImportController) RegisterHandlers(params ControllerRegisterParams) {
+	router := params.Authenticated.Group("/import")
+
+	router.POST("/letterboxd", utils.MakeHandler(c.ImportLetterboxd)) // POST /import/letterboxd
+}
+
+// @Summary Import a Letterboxd export
+// @Description Match the films of a Letterboxd export (the zip or its watched, ratings, diary and watchlist CSVs) to TMDB movies. Dry runs only report the matches; otherwise matched films are imported into the watchlist and diary. Requires a verified email.
+// @Tags import
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param files formData file true "Export zip or CSV files"
+// @Param dry_run query bool false "Only report the matches (default true)"
+// @Success 200 {object} dto.ImportReportDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /import/letterboxd [post]
+func (c *ImportController) ImportLetterboxd(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	if err := requireVerifiedEmail(user); err != nil {
+		return err
+	}
+
+	dryRun := true
+	if dryRunParam := ctx.Query("dry_run"); dryRunParam != "" {
+		value, err := strconv.ParseBool(dryRunParam)
+		if err != nil {
+			return utils.NewValidationError("error.import.invalid_dry_run", err)
+		}
+		dryRun = value
+	}
+
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		return utils.NewValidationError("error.import.invalid_request", err)
+	}
+
+	files := append(form.File["files"], form.File["file"]...)
+	if len(files) == 0 {
+		return utils.NewValidationError("error.import.no_files", errors.New("no files uploaded"))
+	}
+
+	var rows []letterboxd.Row
+	for _, header := range files {
+		fileRows, err := readLetterboxdFile(header)
+		if errors.Is(err, letterboxd.ErrFileTooLarge) {
+			return utils.NewBadRequestError("error.import.file_too_large")
+		}
+		if err != nil {
+			return utils.NewValidationError("error.import.invalid_file", err)
+		}
+		rows = append(rows, fileRows...)
+	}
+
+	report, err := c.importService.ImportLetterboxd(ctx.Request.Context(), user.ID, rows, dryRun)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, report)
+	return nil
+}
+
+func readLetterboxdFile(header *multipart.FileHeader) ([]letterboxd.Row, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(header.Filename), ".zip") {
+		return letterboxd.ParseZip(file, header.Size)
+	}
+
+	kind, ok := letterboxd.KindFromFilename(header.Filename)
+	if !ok {
+		return nil, errors.New("unsupported file " + header.Filename)
+	}
+
+	return letterboxd.ParseCSV(kind, file)
+}
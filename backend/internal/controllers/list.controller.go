@@ -0,0 +1,349 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+type IListController interface {
+	IController
+}
+
+type ListController struct {
+	listService services.IListService
+}
+
+func newListController(params ControllerParams) IListController {
+	return &ListController{
+		listService: params.Svcs.ListService,
+	}
+}
+
+func (c *ListController) RegisterHandlers(params ControllerRegisterParams) {
+	router := params.Authenticated.Group("/lists")
+
+	router.GET("", utils.MakeHandler(c.GetLists))                            // GET /lists
+	router.POST("", utils.MakeHandler(c.CreateList))                         // POST /lists
+	router.GET("/:id", utils.MakeHandler(c.GetList))                         // GET /lists/:id
+	router.PUT("/:id", utils.MakeHandler(c.UpdateList))                      // PUT /lists/:id
+	router.DELETE("/:id", utils.MakeHandler(c.DeleteList))                   // DELETE /lists/:id
+	router.POST("/:id/entries", utils.MakeHandler(c.AddEntry))               // POST /lists/:id/entries
+	router.PUT("/:id/entries/order", utils.MakeHandler(c.ReorderEntries))    // PUT /lists/:id/entries/order
+	router.DELETE("/:id/entries/:movieId", utils.MakeHandler(c.RemoveEntry)) // DELETE /lists/:id/entries/:movieId
+}
+
+// @Summary Get lists
+// @Description Get the authenticated user's lists, or the public lists of another user
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query int false "Owner of the lists (default: authenticated user)"
+// @Success 200 {array} dto.ListDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /lists [get]
+func (c *ListController) GetLists(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	ownerID := user.ID
+	if userIDParam := ctx.Query("user_id"); userIDParam != "" {
+		id, err := strconv.Atoi(userIDParam)
+		if err != nil {
+			return utils.NewValidationError("error.list.invalid_user_id", err)
+		}
+		ownerID = int32(id)
+	}
+
+	lists, err := c.listService.GetByUser(ctx.Request.Context(), user.ID, ownerID)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, lists)
+	return nil
+}
+
+// @Summary Create list
+// @Description Create a new movie list for the authenticated user. Unlisted and public lists require a verified email.
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param list body dto.ListCreateDTO true "List data"
+// @Success 201 {object} dto.ListDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO
+// @Router /lists [post]
+func (c *ListController) CreateList(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	var req dto.ListCreateDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.list.invalid_request", err)
+	}
+
+	if req.IsShared() {
+		if err := requireVerifiedEmail(user); err != nil {
+			return err
+		}
+	}
+
+	list, err := c.listService.Create(ctx.Request.Context(), user.ID, req)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusCreated, list)
+	return nil
+}
+
+// @Summary Get list
+// @Description Get a list with its entries in order. Private lists are only visible to their owner.
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "List ID"
+// @Success 200 {object} dto.ListDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /lists/{id} [get]
+func (c *ListController) GetList(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	idParam := ctx.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return utils.NewValidationError("error.list.invalid_id", err)
+	}
+
+	list, err := c.listService.GetByID(ctx.Request.Context(), user.ID, int32(id))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, list)
+	return nil
+}
+
+// @Summary Update list
+// @Description Update the name, description or visibility of a list. Making it unlisted or public requires a verified email.
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "List ID"
+// @Param list body dto.ListUpdateDTO true "Updated list data"
+// @Success 200 {object} dto.ListDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /lists/{id} [put]
+func (c *ListController) UpdateList(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	idParam := ctx.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return utils.NewValidationError("error.list.invalid_id", err)
+	}
+
+	var req dto.ListUpdateDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.list.invalid_request", err)
+	}
+
+	if req.IsShared() {
+		if err := requireVerifiedEmail(user); err != nil {
+			return err
+		}
+	}
+
+	list, err := c.listService.Update(ctx.Request.Context(), user.ID, int32(id), req)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, list)
+	return nil
+}
+
+// @Summary Delete list
+// @Description Delete a list and all of its entries
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "List ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /lists/{id} [delete]
+func (c *ListController) DeleteList(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	idParam := ctx.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return utils.NewValidationError("error.list.invalid_id", err)
+	}
+
+	if err = c.listService.Delete(ctx.Request.Context(), user.ID, int32(id)); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Add movie to list
+// @Description Append a movie at the end of a list
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "List ID"
+// @Param entry body dto.ListEntryCreateDTO true "Entry data"
+// @Success 201 {object} dto.ListEntryDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 409 {object} dto.ErrorResponseDTO
+// @Router /lists/{id}/entries [post]
+func (c *ListController) AddEntry(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	idParam := ctx.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return utils.NewValidationError("error.list.invalid_id", err)
+	}
+
+	var req dto.ListEntryCreateDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.list.invalid_request", err)
+	}
+
+	entry, err := c.listService.AddEntry(ctx.Request.Context(), user.ID, int32(id), req)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusCreated, entry)
+	return nil
+}
+
+// @Summary Reorder list
+// @Description Set the order of the entries of a list. The body must contain every movie of the list exactly once.
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "List ID"
+// @Param order body dto.ListReorderDTO true "Movie IDs in the new order"
+// @Success 200 {object} dto.ListDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /lists/{id}/entries/order [put]
+func (c *ListController) ReorderEntries(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	idParam := ctx.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return utils.NewValidationError("error.list.invalid_id", err)
+	}
+
+	var req dto.ListReorderDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.list.invalid_request", err)
+	}
+
+	list, err := c.listService.ReorderEntries(ctx.Request.Context(), user.ID, int32(id), req)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, list)
+	return nil
+}
+
+// @Summary Remove movie from list
+// @Description Remove a movie from a list, closing the gap in the order
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "List ID"
+// @Param movieId path int true "Movie ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /lists/{id}/entries/{movieId} [delete]
+func (c *ListController) RemoveEntry(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	idParam := ctx.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return utils.NewValidationError("error.list.invalid_id", err)
+	}
+
+	movieIDParam := ctx.Param("movieId")
+	movieID, err := strconv.Atoi(movieIDParam)
+	if err != nil {
+		return utils.NewValidationError("error.list.invalid_movie_id", err)
+	}
+
+	if err = c.listService.RemoveEntry(ctx.Request.Context(), user.ID, int32(id), int32(movieID)); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
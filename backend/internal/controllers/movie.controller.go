@@ -1,12 +1,14 @@
 package controllers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
 	"github.com/movie-tracker/MovieTracker/internal/utils"
 )
 
@@ -15,44 +17,60 @@ type IMovieController interface {
 }
 
 type MovieController struct {
-	movieService services.IMovieService
+	movieService          services.IMovieService
+	recommendationService services.IRecommendationService
 }
 
 func newMovieController(params ControllerParams) IMovieController {
 	return &MovieController{
-		movieService: params.Svcs.MovieService,
+		movieService:          params.Svcs.MovieService,
+		recommendationService: params.Svcs.RecommendationService,
 	}
 }
 
 func (c *MovieController) RegisterHandlers(params ControllerRegisterParams) {
 	router := params.Public.Group("/movies")
 
-	router.GET("", utils.MakeHandler(c.DiscoverMovies))      // GET /movies
-	router.GET("/search", utils.MakeHandler(c.SearchMovies)) // GET /movies/search
-	router.GET("/:id", utils.MakeHandler(c.GetMovieByID))    // GET /movies/:id
+	router.GET("", utils.MakeHandler(c.DiscoverMovies))                              // GET /movies
+	router.GET("/search", utils.MakeHandler(c.SearchMovies))                         // GET /movies/search
+	router.GET("/:id", utils.MakeHandler(c.GetMovieByID))                            // GET /movies/:id
+	router.GET("/:id/similar", utils.MakeHandler(c.GetSimilarMovies))                // GET /movies/:id/similar
+	router.GET("/:id/recommendations", utils.MakeHandler(c.GetMovieRecommendations)) // GET /movies/:id/recommendations
+
+	params.Authenticated.GET("/recommendations", utils.MakeHandler(c.GetRecommendations)) // GET /recommendations
 }
 
 // @Summary Discover movies
-// @Description Get a list of popular/recommended movies
+// @Description Get a page of popular/recommended movies, optionally filtered and sorted. Pages are read with the cursor returned by the previous one, with the same filters.
 // @Tags movies
 // @Accept json
 // @Produce json
-// @Param page query int false "Page number (default: 1)"
-// @Success 200 {object} dto.Pagination[dto.MovieDTO]
+// @Param with_genres query []int false "Genre IDs, movies in any of them are included" collectionFormat(multi)
+// @Param without_genres query []int false "Genre IDs, movies in any of them are left out" collectionFormat(multi)
+// @Param year_from query int false "First release year"
+// @Param year_to query int false "Last release year"
+// @Param runtime_min query int false "Shortest runtime, in minutes"
+// @Param runtime_max query int false "Longest runtime, in minutes"
+// @Param min_vote_average query number false "Lowest vote average, from 0 to 10"
+// @Param min_vote_count query int false "Fewest votes (default: the minimum of the content policy)"
+// @Param original_language query string false "ISO 639-1 code of the original language"
+// @Param sort_by query string false "Sort order (default: popularity.desc)" Enums(popularity.desc, popularity.asc, vote_average.desc, vote_average.asc, vote_count.desc, vote_count.asc, primary_release_date.desc, primary_release_date.asc, revenue.desc, revenue.asc, title.asc, title.desc)
+// @Param hide_watchlisted query bool false "Leave out the movies on the logged in user's watchlist"
+// @Param cursor query string false "Cursor of the page, from next_cursor of the previous one"
+// @Param page_size query int false "Number of movies per page (default: 20, max: 60)"
+// @Param page query int false "Page number, for clients without cursors"
+// @Param Accept-Language header string false "Language of the movie data, unless the user set one"
+// @Success 200 {object} dto.CursorPagination[dto.MovieDTO]
+// @Failure 400 {object} dto.ErrorResponseDTO
 // @Failure 500 {object} dto.ErrorResponseDTO
 // @Router /movies [get]
 func (c *MovieController) DiscoverMovies(ctx *gin.Context) error {
-	// Pegar o parâmetro page da query string
-	pageStr := ctx.Query("page")
-	page := 1 // valor padrão
-
-	if pageStr != "" {
-		if pageNum, err := strconv.Atoi(pageStr); err == nil && pageNum > 0 {
-			page = pageNum
-		}
+	filters, page, err := bindDiscoverQuery(ctx)
+	if err != nil {
+		return err
 	}
 
-	movies, err := c.movieService.DiscoverMovies(page)
+	movies, err := c.movieService.DiscoverMovies(ctx.Request.Context(), filters, page, getViewer(ctx))
 	if err != nil {
 		return err
 	}
@@ -62,13 +80,16 @@ func (c *MovieController) DiscoverMovies(ctx *gin.Context) error {
 }
 
 // @Summary Search movies
-// @Description Search for movies by title
+// @Description Search for movies by title. Pages are read with the cursor returned by the previous one.
 // @Tags movies
 // @Accept json
 // @Produce json
 // @Param query query string true "Search query"
-// @Param page query int false "Page number (default: 1)"
-// @Success 200 {object} dto.Pagination[dto.MovieDTO]
+// @Param cursor query string false "Cursor of the page, from next_cursor of the previous one"
+// @Param page_size query int false "Number of movies per page (default: 20, max: 60)"
+// @Param page query int false "Page number, for clients without cursors"
+// @Param Accept-Language header string false "Language of the movie data, unless the user set one"
+// @Success 200 {object} dto.CursorPagination[dto.MovieDTO]
 // @Failure 400 {object} dto.ErrorResponseDTO
 // @Failure 500 {object} dto.ErrorResponseDTO
 // @Router /movies/search [get]
@@ -78,16 +99,13 @@ func (c *MovieController) SearchMovies(ctx *gin.Context) error {
 		return utils.NewValidationError("error.movie.empty_query", fmt.Errorf("query parameter is required"))
 	}
 
-	pageStr := ctx.Query("page")
-	page := 1
+	var page dto.MoviePageQueryDTO
 
-	if pageStr != "" {
-		if pageNum, err := strconv.Atoi(pageStr); err == nil && pageNum > 0 {
-			page = pageNum
-		}
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		return utils.NewValidationError("error.movie.invalid_page", err)
 	}
 
-	movies, err := c.movieService.SearchMovies(query, page)
+	movies, err := c.movieService.SearchMovies(ctx.Request.Context(), query, page, getViewer(ctx))
 	if err != nil {
 		return err
 	}
@@ -102,18 +120,19 @@ func (c *MovieController) SearchMovies(ctx *gin.Context) error {
 // @Accept json
 // @Produce json
 // @Param id path int true "Movie ID"
+// @Param Accept-Language header string false "Language of the movie data, unless the user set one"
 // @Success 200 {object} dto.MovieDTO
 // @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
 // @Failure 404 {object} dto.ErrorResponseDTO
 // @Router /movies/{id} [get]
 func (c *MovieController) GetMovieByID(ctx *gin.Context) error {
-	idParam := ctx.Param("id")
-	id, err := strconv.Atoi(idParam)
+	id, err := parseMovieID(ctx)
 	if err != nil {
-		return utils.NewValidationError("error.movie.invalid_id", err)
+		return err
 	}
 
-	movie, err := c.movieService.GetByID(id)
+	movie, err := c.movieService.GetByID(ctx.Request.Context(), id, getViewer(ctx))
 	if err != nil {
 		return err
 	}
@@ -121,3 +140,125 @@ func (c *MovieController) GetMovieByID(ctx *gin.Context) error {
 	ctx.JSON(http.StatusOK, movie)
 	return nil
 }
+
+// @Summary Get similar movies
+// @Description Get movies similar to a movie, by genres and keywords. Pages are read with the cursor returned by the previous one.
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param cursor query string false "Cursor of the page, from next_cursor of the previous one"
+// @Param page_size query int false "Number of movies per page (default: 20, max: 60)"
+// @Param page query int false "Page number, for clients without cursors"
+// @Param Accept-Language header string false "Language of the movie data, unless the user set one"
+// @Success 200 {object} dto.CursorPagination[dto.MovieDTO]
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /movies/{id}/similar [get]
+func (c *MovieController) GetSimilarMovies(ctx *gin.Context) error {
+	return c.getRelatedMovies(ctx, c.movieService.GetSimilar)
+}
+
+// @Summary Get movie recommendations
+// @Description Get the movies TMDB recommends to those who liked a movie. Pages are read with the cursor returned by the previous one.
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param cursor query string false "Cursor of the page, from next_cursor of the previous one"
+// @Param page_size query int false "Number of movies per page (default: 20, max: 60)"
+// @Param page query int false "Page number, for clients without cursors"
+// @Param Accept-Language header string false "Language of the movie data, unless the user set one"
+// @Success 200 {object} dto.CursorPagination[dto.MovieDTO]
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /movies/{id}/recommendations [get]
+func (c *MovieController) GetMovieRecommendations(ctx *gin.Context) error {
+	return c.getRelatedMovies(ctx, c.movieService.GetRecommendations)
+}
+
+// @Summary Get personal recommendations
+// @Description Get movies recommended from the ones the authenticated user rated highly or favorited, leaving out the ones already on their watchlist. Popular movies are recommended until they do.
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of recommendations (default: 20, max: 60)"
+// @Param Accept-Language header string false "Language of the movie data, unless the user set one"
+// @Success 200 {array} dto.RecommendationDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /recommendations [get]
+func (c *MovieController) GetRecommendations(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	var query dto.RecommendationQueryDTO
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		return utils.NewValidationError("error.recommendation.invalid_query", err)
+	}
+
+	recommendations, err := c.recommendationService.GetForUser(ctx.Request.Context(), user.ID, query, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, recommendations)
+	return nil
+}
+
+func (c *MovieController) getRelatedMovies(ctx *gin.Context, fetch func(ctx context.Context, id int, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (dto.CursorPagination[dto.MovieDTO], error)) error {
+	id, err := parseMovieID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var page dto.MoviePageQueryDTO
+
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		return utils.NewValidationError("error.movie.invalid_page", err)
+	}
+
+	movies, err := fetch(ctx.Request.Context(), id, page, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, movies)
+	return nil
+}
+
+// bindDiscoverQuery binds the discover filters and the page asked for.
+func bindDiscoverQuery(ctx *gin.Context) (filters dto.MovieDiscoverFiltersDTO, page dto.MoviePageQueryDTO, err error) {
+	if err = ctx.ShouldBindQuery(&page); err != nil {
+		return filters, page, utils.NewValidationError("error.movie.invalid_page", err)
+	}
+
+	if err = ctx.ShouldBindQuery(&filters); err != nil {
+		return filters, page, utils.NewValidationError("error.movie.invalid_filters", err)
+	}
+
+	if filters.YearFrom != nil && filters.YearTo != nil && *filters.YearFrom > *filters.YearTo {
+		return filters, page, utils.NewValidationError("error.movie.invalid_year_range", fmt.Errorf("year_from is greater than year_to"))
+	}
+
+	if filters.RuntimeMin != nil && filters.RuntimeMax != nil && *filters.RuntimeMin > *filters.RuntimeMax {
+		return filters, page, utils.NewValidationError("error.movie.invalid_runtime_range", fmt.Errorf("runtime_min is greater than runtime_max"))
+	}
+
+	return filters, page, nil
+}
+
+func parseMovieID(ctx *gin.Context) (int, error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return 0, utils.NewValidationError("error.movie.invalid_id", err)
+	}
+	return id, nil
+}
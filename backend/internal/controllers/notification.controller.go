@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+type INotificationController interface {
+	IController
+}
+
+type NotificationController struct {
+	notificationService services.INotificationService
+}
+
+func newNotificationController(params ControllerParams) INotificationController {
+	return &NotificationController{
+		notificationService: params.Svcs.NotificationService,
+	}
+}
+
+func (c *NotificationController) RegisterHandlers(params ControllerRegisterParams) {
+	router := params.Authenticated.Group("/notifications")
+
+	router.GET("", utils.MakeHandler(c.Find))                     // GET /notifications
+	router.GET("/unread-count", utils.MakeHandler(c.CountUnread)) // GET /notifications/unread-count
+	router.PUT("/read", utils.MakeHandler(c.MarkAllRead))         // PUT /notifications/read
+	router.PUT("/:id/read", utils.MakeHandler(c.MarkRead))        // PUT /notifications/:id/read
+	router.GET("/settings", utils.MakeHandler(c.GetSettings))     // GET /notifications/settings
+	router.PUT("/settings", utils.MakeHandler(c.SaveSettings))    // PUT /notifications/settings
+}
+
+// @Summary Get notifications
+// @Description Get a page of the inbox of the authenticated user, the newest first: movies of the watchlist released or streaming on the user's services, and ratings of the users they follow
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only the unread notifications"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Notifications per page (default: 20, max: 100)"
+// @Success 200 {object} dto.Pagination[dto.NotificationDTO]
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /notifications [get]
+func (c *NotificationController) Find(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	var query dto.NotificationQueryDTO
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		return utils.NewValidationError("error.notification.invalid_query", err)
+	}
+
+	notifications, err := c.notificationService.Find(ctx.Request.Context(), user.ID, query)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, notifications)
+	return nil
+}
+
+// @Summary Count unread notifications
+// @Description Get how many notifications of the authenticated user are unread
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.UnreadCountDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /notifications/unread-count [get]
+func (c *NotificationController) CountUnread(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	count, err := c.notificationService.CountUnread(ctx.Request.Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, count)
+	return nil
+}
+
+// @Summary Mark notification as read
+// @Description Mark a notification of the authenticated user as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Notification ID"
+// @Success 200 {object} dto.NotificationDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /notifications/{id}/read [put]
+func (c *NotificationController) MarkRead(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return utils.NewValidationError("error.notification.invalid_id", err)
+	}
+
+	notification, err := c.notificationService.MarkRead(ctx.Request.Context(), user.ID, int32(id))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, notification)
+	return nil
+}
+
+// @Summary Mark all notifications as read
+// @Description Mark every notification of the authenticated user as read
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /notifications/read [put]
+func (c *NotificationController) MarkAllRead(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	if err := c.notificationService.MarkAllRead(ctx.Request.Context(), user.ID); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Get notification settings
+// @Description Get where the notifications of the authenticated user are delivered besides the inbox
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.NotificationSettingsDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /notifications/settings [get]
+func (c *NotificationController) GetSettings(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	settings, err := c.notificationService.GetSettings(ctx.Request.Context(), user.ID)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+	return nil
+}
+
+// @Summary Save notification settings
+// @Description Set up where the notifications of the authenticated user are delivered besides the inbox: their email address, which must be verified, and an HTTPS URL they are posted to as JSON
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.NotificationSettingsDTO true "Delivery settings"
+// @Success 200 {object} dto.NotificationSettingsDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /notifications/settings [put]
+func (c *NotificationController) SaveSettings(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	var settings dto.NotificationSettingsDTO
+
+	if err := ctx.ShouldBindJSON(&settings); err != nil {
+		return utils.NewValidationError("error.notification.invalid_settings", err)
+	}
+
+	savedSettings, err := c.notificationService.SaveSettings(ctx.Request.Context(), user.ID, settings)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, savedSettings)
+	return nil
+}
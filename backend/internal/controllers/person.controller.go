@@ -0,0 +1,224 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+type IPersonController interface {
+	IController
+}
+
+type PersonController struct {
+	personService services.IPersonService
+}
+
+func newPersonController(params ControllerParams) IPersonController {
+	return &PersonController{
+		personService: params.Svcs.PersonService,
+	}
+}
+
+func (c *PersonController) RegisterHandlers(params ControllerRegisterParams) {
+	router := params.Public.Group("/people")
+
+	router.GET("/:id", utils.MakeHandler(c.GetPersonByID))          // GET /people/:id
+	router.GET("/:id/movies", utils.MakeHandler(c.GetPersonMovies)) // GET /people/:id/movies
+
+	following := params.Authenticated.Group("/people")
+
+	following.GET("/following", utils.MakeHandler(c.GetFollowing)) // GET /people/following
+	following.PUT("/:id/follow", utils.MakeHandler(c.Follow))      // PUT /people/:id/follow
+	following.DELETE("/:id/follow", utils.MakeHandler(c.Unfollow)) // DELETE /people/:id/follow
+
+	params.Authenticated.GET("/feed", utils.MakeHandler(c.GetFeed)) // GET /feed
+}
+
+// @Summary Get person by ID
+// @Description Get an actor or crew member, with the biography in the viewer's language. Logged in viewers are told whether they follow them.
+// @Tags people
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param Accept-Language header string false "Language of the biography, unless the user set one"
+// @Success 200 {object} dto.PersonDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /people/{id} [get]
+func (c *PersonController) GetPersonByID(ctx *gin.Context) error {
+	id, err := parsePersonID(ctx)
+	if err != nil {
+		return err
+	}
+
+	person, err := c.personService.GetByID(ctx.Request.Context(), id, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, person)
+	return nil
+}
+
+// @Summary Get person movies
+// @Description Get the movies a person acted in and worked on, the most recent first. Movies hidden by the content policy are left out.
+// @Tags people
+// @Accept json
+// @Produce json
+// @Param id path int true "Person ID"
+// @Param Accept-Language header string false "Language of the movie data, unless the user set one"
+// @Success 200 {object} dto.PersonMoviesDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /people/{id}/movies [get]
+func (c *PersonController) GetPersonMovies(ctx *gin.Context) error {
+	id, err := parsePersonID(ctx)
+	if err != nil {
+		return err
+	}
+
+	movies, err := c.personService.GetMovies(ctx.Request.Context(), id, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, movies)
+	return nil
+}
+
+// @Summary Get followed people
+// @Description Get the people the authenticated user follows, the most recently followed first
+// @Tags people
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.FollowedPersonDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /people/following [get]
+func (c *PersonController) GetFollowing(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	following, err := c.personService.GetFollowing(ctx.Request.Context(), user.ID, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, following)
+	return nil
+}
+
+// @Summary Follow person
+// @Description Follow an actor or crew member, so their new releases show up in the feed. Following someone already followed does nothing.
+// @Tags people
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Person ID"
+// @Success 200 {object} dto.FollowedPersonDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /people/{id}/follow [put]
+func (c *PersonController) Follow(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	id, err := parsePersonID(ctx)
+	if err != nil {
+		return err
+	}
+
+	followed, err := c.personService.Follow(ctx.Request.Context(), user.ID, int32(id), getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, followed)
+	return nil
+}
+
+// @Summary Unfollow person
+// @Description Stop following a person
+// @Tags people
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Person ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /people/{id}/follow [delete]
+func (c *PersonController) Unfollow(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	id, err := parsePersonID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err = c.personService.Unfollow(ctx.Request.Context(), user.ID, int32(id)); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Get feed
+// @Description Get the movies of the people the authenticated user follows released in the last days, or announced, the most recent first
+// @Tags people
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "How many days back the feed goes (default: 365, max: 3650)"
+// @Param Accept-Language header string false "Language of the movie data, unless the user set one"
+// @Success 200 {array} dto.FeedItemDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /feed [get]
+func (c *PersonController) GetFeed(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	var query dto.FeedQueryDTO
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		return utils.NewValidationError("error.feed.invalid_query", err)
+	}
+
+	feed, err := c.personService.GetFeed(ctx.Request.Context(), user.ID, query, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, feed)
+	return nil
+}
+
+func parsePersonID(ctx *gin.Context) (int, error) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return 0, utils.NewValidationError("error.person.invalid_id", err)
+	}
+	return id, nil
+}
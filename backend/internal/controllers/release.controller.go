@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+type IReleaseController interface {
+	IController
+}
+
+type ReleaseController struct {
+	releaseService services.IReleaseService
+}
+
+func newReleaseController(params ControllerParams) IReleaseController {
+	return &ReleaseController{
+		releaseService: params.Svcs.ReleaseService,
+	}
+}
+
+func (c *ReleaseController) RegisterHandlers(params ControllerRegisterParams) {
+	params.Authenticated.GET("/upcoming", utils.MakeHandler(c.GetUpcoming)) // GET /upcoming
+	params.Public.GET("/calendar/:feed", utils.MakeHandler(c.GetCalendar))  // GET /calendar/:token.ics
+}
+
+// @Summary Get upcoming releases
+// @Description Get the movies on the authenticated user's watchlist, watched ones aside, with a theatrical or digital release in the region within the next days, the soonest first. Movies hidden by the content policy are left out.
+// @Tags releases
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param region query string false "ISO 3166-1 country code (default: the viewer's region)"
+// @Param days query int false "Days ahead (default: 180, max: 730)"
+// @Param Accept-Language header string false "Language of the movie data, unless the user set one"
+// @Success 200 {array} dto.UpcomingReleaseDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /upcoming [get]
+func (c *ReleaseController) GetUpcoming(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	var query dto.UpcomingQueryDTO
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		return utils.NewValidationError("error.release.invalid_query", err)
+	}
+
+	releases, err := c.releaseService.GetUpcoming(ctx.Request.Context(), user.ID, query, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, releases)
+	return nil
+}
+
+// @Summary Get release calendar
+// @Description Get the iCalendar feed of the theatrical and digital releases of the movies on a user's watchlist, for calendar apps to subscribe to. The secret token in the URL is the only authentication; its URL is returned by POST /users/calendar-feed.
+// @Tags releases
+// @Produce text/calendar
+// @Param feed path string true "Secret token followed by .ics"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /calendar/{feed} [get]
+func (c *ReleaseController) GetCalendar(ctx *gin.Context) error {
+	token, ok := strings.CutSuffix(ctx.Param("feed"), ".ics")
+	if !ok || token == "" {
+		return utils.NewNotFoundError("error.calendar.not_found")
+	}
+
+	var calendar bytes.Buffer
+	if err := c.releaseService.WriteCalendar(ctx.Request.Context(), token, &calendar); err != nil {
+		return err
+	}
+
+	ctx.Header("Cache-Control", "private, max-age=3600")
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar.Bytes())
+	return nil
+}
@@ -0,0 +1,476 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+type IShowController interface {
+	IController
+}
+
+type ShowController struct {
+	showService     services.IShowService
+	progressService services.IShowProgressService
+}
+
+func newShowController(params ControllerParams) IShowController {
+	return &ShowController{
+		showService:     params.Svcs.ShowService,
+		progressService: params.Svcs.ShowProgressService,
+	}
+}
+
+func (c *ShowController) RegisterHandlers(params ControllerRegisterParams) {
+	router := params.Public.Group("/shows")
+
+	router.GET("", utils.MakeHandler(c.DiscoverShows))                                    // GET /shows
+	router.GET("/search", utils.MakeHandler(c.SearchShows))                               // GET /shows/search
+	router.GET("/:id", utils.MakeHandler(c.GetShowByID))                                  // GET /shows/:id
+	router.GET("/:id/seasons/:season", utils.MakeHandler(c.GetSeason))                    // GET /shows/:id/seasons/:season
+	router.GET("/:id/seasons/:season/episodes/:episode", utils.MakeHandler(c.GetEpisode)) // GET /shows/:id/seasons/:season/episodes/:episode
+
+	tracking := params.Authenticated.Group("/shows")
+
+	tracking.GET("/tracked", utils.MakeHandler(c.GetTrackedShows))                                        // GET /shows/tracked
+	tracking.GET("/up-next", utils.MakeHandler(c.GetUpNext))                                              // GET /shows/up-next
+	tracking.GET("/:id/progress", utils.MakeHandler(c.GetProgress))                                       // GET /shows/:id/progress
+	tracking.PUT("/:id/progress", utils.MakeHandler(c.TrackShow))                                         // PUT /shows/:id/progress
+	tracking.DELETE("/:id/progress", utils.MakeHandler(c.UntrackShow))                                    // DELETE /shows/:id/progress
+	tracking.PUT("/:id/seasons/:season/watched", utils.MakeHandler(c.MarkSeason))                         // PUT /shows/:id/seasons/:season/watched
+	tracking.PUT("/:id/seasons/:season/episodes/:episode/watched", utils.MakeHandler(c.MarkEpisode))      // PUT /shows/:id/seasons/:season/episodes/:episode/watched
+	tracking.DELETE("/:id/seasons/:season/episodes/:episode/watched", utils.MakeHandler(c.UnmarkEpisode)) // DELETE /shows/:id/seasons/:season/episodes/:episode/watched
+}
+
+// @Summary Discover shows
+// @Description Get a page of popular TV shows. Pages are read with the cursor returned by the previous one.
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Param cursor query string false "Cursor of the page, from next_cursor of the previous one"
+// @Param page_size query int false "Number of shows per page (default: 20, max: 60)"
+// @Param page query int false "Page number, for clients without cursors"
+// @Param Accept-Language header string false "Language of the show data, unless the user set one"
+// @Success 200 {object} dto.CursorPagination[dto.ShowDTO]
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /shows [get]
+func (c *ShowController) DiscoverShows(ctx *gin.Context) error {
+	var page dto.MoviePageQueryDTO
+
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		return utils.NewValidationError("error.show.invalid_page", err)
+	}
+
+	shows, err := c.showService.DiscoverShows(ctx.Request.Context(), page, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, shows)
+	return nil
+}
+
+// @Summary Search shows
+// @Description Search for TV shows by name. Pages are read with the cursor returned by the previous one.
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Param query query string true "Search query"
+// @Param cursor query string false "Cursor of the page, from next_cursor of the previous one"
+// @Param page_size query int false "Number of shows per page (default: 20, max: 60)"
+// @Param page query int false "Page number, for clients without cursors"
+// @Param Accept-Language header string false "Language of the show data, unless the user set one"
+// @Success 200 {object} dto.CursorPagination[dto.ShowDTO]
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /shows/search [get]
+func (c *ShowController) SearchShows(ctx *gin.Context) error {
+	query := ctx.Query("query")
+	if query == "" {
+		return utils.NewValidationError("error.show.empty_query", fmt.Errorf("query parameter is required"))
+	}
+
+	var page dto.MoviePageQueryDTO
+
+	if err := ctx.ShouldBindQuery(&page); err != nil {
+		return utils.NewValidationError("error.show.invalid_page", err)
+	}
+
+	shows, err := c.showService.SearchShows(ctx.Request.Context(), query, page, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, shows)
+	return nil
+}
+
+// @Summary Get show by ID
+// @Description Get detailed information about a TV show, with its seasons
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show ID"
+// @Param Accept-Language header string false "Language of the show data, unless the user set one"
+// @Success 200 {object} dto.ShowDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /shows/{id} [get]
+func (c *ShowController) GetShowByID(ctx *gin.Context) error {
+	params, err := parseShowParams(ctx, "id")
+	if err != nil {
+		return err
+	}
+
+	show, err := c.showService.GetByID(ctx.Request.Context(), params[0], getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, show)
+	return nil
+}
+
+// @Summary Get season
+// @Description Get a season of a TV show with its episodes. Episodes the authenticated user watched carry when they were marked.
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show ID"
+// @Param season path int true "Season number, 0 for specials"
+// @Param Accept-Language header string false "Language of the show data, unless the user set one"
+// @Success 200 {object} dto.SeasonDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /shows/{id}/seasons/{season} [get]
+func (c *ShowController) GetSeason(ctx *gin.Context) error {
+	params, err := parseShowParams(ctx, "id", "season")
+	if err != nil {
+		return err
+	}
+
+	season, err := c.showService.GetSeason(ctx.Request.Context(), params[0], params[1], getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, season)
+	return nil
+}
+
+// @Summary Get episode
+// @Description Get an episode of a TV show
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Param id path int true "Show ID"
+// @Param season path int true "Season number, 0 for specials"
+// @Param episode path int true "Episode number"
+// @Param Accept-Language header string false "Language of the show data, unless the user set one"
+// @Success 200 {object} dto.EpisodeDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /shows/{id}/seasons/{season}/episodes/{episode} [get]
+func (c *ShowController) GetEpisode(ctx *gin.Context) error {
+	params, err := parseShowParams(ctx, "id", "season", "episode")
+	if err != nil {
+		return err
+	}
+
+	episode, err := c.showService.GetEpisode(ctx.Request.Context(), params[0], params[1], params[2], getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, episode)
+	return nil
+}
+
+// @Summary Get tracked shows
+// @Description Get the shows the authenticated user tracks, most recently updated first, with their progress and next episode
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query []string false "Only shows with these statuses" collectionFormat(multi) Enums(unwatched, watching, plan to watch, watched)
+// @Success 200 {array} dto.ShowProgressDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /shows/tracked [get]
+func (c *ShowController) GetTrackedShows(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	var query dto.ShowProgressQueryDTO
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		return utils.NewValidationError("error.show.invalid_query", err)
+	}
+
+	tracked, err := c.progressService.GetTracked(ctx.Request.Context(), user.ID, query, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, tracked)
+	return nil
+}
+
+// @Summary Get up next
+// @Description Get the shows the authenticated user is watching that have an aired episode left, with the next one to watch
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.ShowProgressDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /shows/up-next [get]
+func (c *ShowController) GetUpNext(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	upNext, err := c.progressService.GetUpNext(ctx.Request.Context(), user.ID, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, upNext)
+	return nil
+}
+
+// @Summary Get show progress
+// @Description Get how far the authenticated user got in a tracked show, season by season
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Show ID"
+// @Success 200 {object} dto.ShowProgressDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /shows/{id}/progress [get]
+func (c *ShowController) GetProgress(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	params, err := parseShowParams(ctx, "id")
+	if err != nil {
+		return err
+	}
+
+	progress, err := c.progressService.GetProgress(ctx.Request.Context(), user.ID, int32(params[0]), getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, progress)
+	return nil
+}
+
+// @Summary Track show
+// @Description Start tracking a show, as planned to watch unless a status is given, or update its status, favorite or rating
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Show ID"
+// @Param request body dto.ShowProgressUpdateDTO true "Fields to set"
+// @Success 200 {object} dto.ShowProgressDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /shows/{id}/progress [put]
+func (c *ShowController) TrackShow(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	params, err := parseShowParams(ctx, "id")
+	if err != nil {
+		return err
+	}
+
+	var updateDTO dto.ShowProgressUpdateDTO
+
+	if err = ctx.ShouldBindJSON(&updateDTO); err != nil {
+		return utils.NewValidationError("error.show.invalid_progress", err)
+	}
+
+	progress, err := c.progressService.Track(ctx.Request.Context(), user.ID, int32(params[0]), updateDTO, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, progress)
+	return nil
+}
+
+// @Summary Untrack show
+// @Description Stop tracking a show, forgetting the episodes watched
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Show ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /shows/{id}/progress [delete]
+func (c *ShowController) UntrackShow(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	params, err := parseShowParams(ctx, "id")
+	if err != nil {
+		return err
+	}
+
+	if err = c.progressService.Untrack(ctx.Request.Context(), user.ID, int32(params[0])); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Mark season as watched
+// @Description Mark every aired episode of a season as watched, tracking the show if needed
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Show ID"
+// @Param season path int true "Season number, 0 for specials"
+// @Success 200 {object} dto.ShowProgressDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /shows/{id}/seasons/{season}/watched [put]
+func (c *ShowController) MarkSeason(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	params, err := parseShowParams(ctx, "id", "season")
+	if err != nil {
+		return err
+	}
+
+	progress, err := c.progressService.MarkSeason(ctx.Request.Context(), user.ID, int32(params[0]), int32(params[1]), getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, progress)
+	return nil
+}
+
+// @Summary Mark episode as watched
+// @Description Mark an episode as watched, tracking the show if needed. The status of the show follows.
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Show ID"
+// @Param season path int true "Season number, 0 for specials"
+// @Param episode path int true "Episode number"
+// @Success 200 {object} dto.ShowProgressDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /shows/{id}/seasons/{season}/episodes/{episode}/watched [put]
+func (c *ShowController) MarkEpisode(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	params, err := parseShowParams(ctx, "id", "season", "episode")
+	if err != nil {
+		return err
+	}
+
+	progress, err := c.progressService.MarkEpisode(ctx.Request.Context(), user.ID, int32(params[0]), int32(params[1]), int32(params[2]), getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, progress)
+	return nil
+}
+
+// @Summary Unmark episode as watched
+// @Description Forget an episode was watched. The status of the show follows.
+// @Tags shows
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Show ID"
+// @Param season path int true "Season number, 0 for specials"
+// @Param episode path int true "Episode number"
+// @Success 200 {object} dto.ShowProgressDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 403 {object} dto.ErrorResponseDTO "Hidden by the content policy, with the reasons"
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /shows/{id}/seasons/{season}/episodes/{episode}/watched [delete]
+func (c *ShowController) UnmarkEpisode(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	params, err := parseShowParams(ctx, "id", "season", "episode")
+	if err != nil {
+		return err
+	}
+
+	progress, err := c.progressService.UnmarkEpisode(ctx.Request.Context(), user.ID, int32(params[0]), int32(params[1]), int32(params[2]), getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, progress)
+	return nil
+}
+
+// parseShowParams reads the numbers of the path parameters, in order.
+func parseShowParams(ctx *gin.Context, names ...string) ([]int, error) {
+	values := make([]int, len(names))
+	for i, name := range names {
+		value, err := strconv.Atoi(ctx.Param(name))
+		if err != nil {
+			return nil, utils.NewValidationError("error.show.invalid_"+name, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/movie-tracker/MovieTracker/internal/services"
@@ -15,22 +16,48 @@ type IUserController interface {
 }
 
 type UserController struct {
-	userService services.IUserService
+	userService          services.IUserService
+	sessionService       services.ISessionService
+	contentPolicyService services.IContentPolicyService
+	streamingService     services.IStreamingService
+	releaseService       services.IReleaseService
 }
 
 func newUserController(params ControllerParams) IUserController {
 	return &UserController{
-		userService: params.Svcs.UserService,
+		userService:          params.Svcs.UserService,
+		sessionService:       params.Svcs.SessionService,
+		contentPolicyService: params.Svcs.ContentPolicyService,
+		streamingService:     params.Svcs.StreamingService,
+		releaseService:       params.Svcs.ReleaseService,
 	}
 }
 
 func (c *UserController) RegisterHandlers(params ControllerRegisterParams) {
 	router := params.Authenticated.Group("/users")
 
-	router.GET("", utils.MakeHandler(c.FindAll))                     // GET /users
-	router.GET("/profile", utils.MakeHandler(c.GetProfile))          // GET /users/profile
-	router.GET("/by-email/:email", utils.MakeHandler(c.FindByEmail)) // GET /users/by-email/:email
-	router.POST("", utils.MakeHandler(c.Create))                     // POST /users
+	router.GET("", utils.MakeHandler(c.FindAll))                                       // GET /users
+	router.GET("/profile", utils.MakeHandler(c.GetProfile))                            // GET /users/profile
+	router.GET("/by-email/:email", utils.MakeHandler(c.FindByEmail))                   // GET /users/by-email/:email
+	router.POST("", utils.MakeHandler(c.Create))                                       // POST /users
+	router.PUT("/password", utils.MakeHandler(c.ChangePassword))                       // PUT /users/password
+	router.PUT("/preferences", utils.MakeHandler(c.UpdatePreferences))                 // PUT /users/preferences
+	router.GET("/parental-profile", utils.MakeHandler(c.GetParentalProfile))           // GET /users/parental-profile
+	router.PUT("/parental-profile", utils.MakeHandler(c.SaveParentalProfile))          // PUT /users/parental-profile
+	router.DELETE("/parental-profile", utils.MakeHandler(c.DeleteParentalProfile))     // DELETE /users/parental-profile
+	router.GET("/streaming-services", utils.MakeHandler(c.GetStreamingServices))       // GET /users/streaming-services
+	router.PUT("/streaming-services", utils.MakeHandler(c.SaveStreamingServices))      // PUT /users/streaming-services
+	router.DELETE("/streaming-services", utils.MakeHandler(c.DeleteStreamingServices)) // DELETE /users/streaming-services
+	router.GET("/calendar-feed", utils.MakeHandler(c.GetCalendarFeed))                 // GET /users/calendar-feed
+	router.POST("/calendar-feed", utils.MakeHandler(c.CreateCalendarFeed))             // POST /users/calendar-feed
+	router.DELETE("/calendar-feed", utils.MakeHandler(c.DeleteCalendarFeed))           // DELETE /users/calendar-feed
+	router.GET("/sessions", utils.MakeHandler(c.GetSessions))                          // GET /users/sessions
+	router.DELETE("/sessions/:id", utils.MakeHandler(c.RevokeSession))                 // DELETE /users/sessions/:id
+	router.PUT("/:id/follow", utils.MakeHandler(c.Follow))                             // PUT /users/:id/follow
+	router.DELETE("/:id/follow", utils.MakeHandler(c.Unfollow))                        // DELETE /users/:id/follow
+	router.GET("/follow-requests", utils.MakeHandler(c.GetFollowRequests))             // GET /users/follow-requests
+	router.PUT("/follow-requests/:id", utils.MakeHandler(c.AcceptFollower))            // PUT /users/follow-requests/:id
+	router.DELETE("/followers/:id", utils.MakeHandler(c.RemoveFollower))               // DELETE /users/followers/:id
 }
 
 // @Summary Get all users
@@ -44,7 +71,7 @@ func (c *UserController) RegisterHandlers(params ControllerRegisterParams) {
 // @Failure 500 {object} dto.ErrorResponseDTO
 // @Router /users [get]
 func (c *UserController) FindAll(ctx *gin.Context) error {
-	users, err := c.userService.FindAll()
+	users, err := c.userService.FindAll(ctx.Request.Context())
 	if err != nil {
 		return err
 	}
@@ -67,7 +94,7 @@ func (c *UserController) FindAll(ctx *gin.Context) error {
 func (c *UserController) FindByEmail(ctx *gin.Context) error {
 	email := ctx.Param("email")
 
-	user, err := c.userService.FindByEmail(email)
+	user, err := c.userService.FindByEmail(ctx.Request.Context(), email)
 	if err != nil {
 		fmt.Printf("error: %v\n", err)
 		return err
@@ -96,7 +123,7 @@ func (c *UserController) Create(ctx *gin.Context) error {
 		return err
 	}
 
-	createdUserDTO, err := c.userService.Create(userDTO)
+	createdUserDTO, err := c.userService.Create(ctx.Request.Context(), userDTO)
 	if err != nil {
 		return err
 	}
@@ -124,3 +151,500 @@ func (c UserController) GetProfile(ctx *gin.Context) error {
 	ctx.IndentedJSON(http.StatusOK, requester)
 	return nil
 }
+
+// @Summary List active sessions
+// @Description List the devices the authenticated user is signed in on, most recently used first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.SessionDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /users/sessions [get]
+func (c *UserController) GetSessions(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	sessionID, _ := getSessionID(ctx)
+
+	sessions, err := c.sessionService.GetActive(ctx.Request.Context(), requester.ID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	ctx.IndentedJSON(http.StatusOK, sessions)
+	return nil
+}
+
+// @Summary Revoke a session
+// @Description Sign the authenticated user out of one of their devices
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Session ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /users/sessions/{id} [delete]
+func (c *UserController) RevokeSession(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return utils.NewValidationError("error.session.invalid_id", err)
+	}
+
+	if err = c.sessionService.Revoke(ctx.Request.Context(), requester.ID, int32(id)); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Change password
+// @Description Change the password of the authenticated user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ChangePasswordRequestDTO true "Current and new password"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/password [put]
+func (c *UserController) ChangePassword(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	var req dto.ChangePasswordRequestDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.user.invalid_request", err)
+	}
+
+	if err := c.userService.ChangePassword(ctx.Request.Context(), requester.ID, req.CurrentPassword, req.NewPassword); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Update preferences
+// @Description Set the language and region the authenticated user wants movie data in. They take precedence over the Accept-Language header; null clears them.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.UserPreferencesDTO true "Language and region"
+// @Success 200 {object} dto.UserDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/preferences [put]
+func (c *UserController) UpdatePreferences(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	var req dto.UserPreferencesDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.user.invalid_preferences", err)
+	}
+
+	user, err := c.userService.UpdatePreferences(ctx.Request.Context(), requester.ID, req)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, user)
+	return nil
+}
+
+// @Summary Get parental profile
+// @Description Get the content rules the authenticated user overrides, and the policy deciding which titles they are shown
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.ParentalProfileResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/parental-profile [get]
+func (c *UserController) GetParentalProfile(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	profile, err := c.contentPolicyService.GetProfile(ctx.Request.Context(), requester.ID, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+	return nil
+}
+
+// @Summary Save parental profile
+// @Description Override the allowed certifications, excluded genres, blocked keywords or vote thresholds of the content policy for the authenticated user. Rules left null follow the default policy. Allowed certifications are on the scale of certification_country, the default policy's when null, whatever the viewer's region.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ParentalProfileDTO true "Rules to override"
+// @Success 200 {object} dto.ParentalProfileResponseDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/parental-profile [put]
+func (c *UserController) SaveParentalProfile(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	var req dto.ParentalProfileDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.user.invalid_parental_profile", err)
+	}
+
+	profile, err := c.contentPolicyService.SaveProfile(ctx.Request.Context(), requester.ID, req, getViewer(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, profile)
+	return nil
+}
+
+// @Summary Delete parental profile
+// @Description Go back to the default content policy
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/parental-profile [delete]
+func (c *UserController) DeleteParentalProfile(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	if err := c.contentPolicyService.DeleteProfile(ctx.Request.Context(), requester.ID); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Get streaming services
+// @Description Get the streaming services the authenticated user is subscribed to and the country they watch them in. Both are empty until set.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.StreamingSubscriptionDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/streaming-services [get]
+func (c *UserController) GetStreamingServices(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	subscription, err := c.streamingService.GetSubscription(ctx.Request.Context(), requester.ID)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, subscription)
+	return nil
+}
+
+// @Summary Save streaming services
+// @Description Set the streaming services the authenticated user is subscribed to, by their IDs in GET /watch-providers, and the country they watch them in. They decide which watchlist movies are available on the user's services.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.StreamingSubscriptionDTO true "Country and provider IDs"
+// @Success 200 {object} dto.StreamingSubscriptionDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/streaming-services [put]
+func (c *UserController) SaveStreamingServices(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	var req dto.StreamingSubscriptionDTO
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return utils.NewValidationError("error.user.invalid_streaming_services", err)
+	}
+
+	subscription, err := c.streamingService.SaveSubscription(ctx.Request.Context(), requester.ID, req)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, subscription)
+	return nil
+}
+
+// @Summary Delete streaming services
+// @Description Forget the streaming services of the authenticated user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/streaming-services [delete]
+func (c *UserController) DeleteStreamingServices(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	if err := c.streamingService.DeleteSubscription(ctx.Request.Context(), requester.ID); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Get calendar feed
+// @Description Tell whether the authenticated user has a release calendar feed, and since when. Its URL is only shown when created.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.CalendarFeedDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /users/calendar-feed [get]
+func (c *UserController) GetCalendarFeed(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	feed, err := c.releaseService.GetCalendarFeed(ctx.Request.Context(), requester.ID)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, feed)
+	return nil
+}
+
+// @Summary Create calendar feed
+// @Description Get a secret URL calendar apps can subscribe to for the releases of the movies on the authenticated user's watchlist. Creating it again replaces the URL, and the previous one stops working.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} dto.CalendarFeedDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/calendar-feed [post]
+func (c *UserController) CreateCalendarFeed(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	feed, err := c.releaseService.CreateCalendarFeed(ctx.Request.Context(), requester.ID)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusCreated, feed)
+	return nil
+}
+
+// @Summary Delete calendar feed
+// @Description Stop serving the release calendar of the authenticated user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/calendar-feed [delete]
+func (c *UserController) DeleteCalendarFeed(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	if err := c.releaseService.DeleteCalendarFeed(ctx.Request.Context(), requester.ID); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Follow user
+// @Description Ask to follow another user, to get notified of the movies they rate once they accept. Following someone already followed does nothing.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /users/{id}/follow [put]
+func (c *UserController) Follow(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return utils.NewValidationError("error.user.invalid_id", err)
+	}
+
+	if err = c.userService.Follow(ctx.Request.Context(), requester.ID, int32(id)); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Unfollow user
+// @Description Stop following a user
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/{id}/follow [delete]
+func (c *UserController) Unfollow(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return utils.NewValidationError("error.user.invalid_id", err)
+	}
+
+	if err = c.userService.Unfollow(ctx.Request.Context(), requester.ID, int32(id)); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Get follow requests
+// @Description Get the users waiting for the authenticated user to accept them as followers, oldest first
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.FollowRequestDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/follow-requests [get]
+func (c *UserController) GetFollowRequests(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	requests, err := c.userService.GetFollowRequests(ctx.Request.Context(), requester.ID)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, requests)
+	return nil
+}
+
+// @Summary Accept follower
+// @Description Let a user who asked to follow the authenticated user get notified of the movies they rate. Accepting someone already following does nothing.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Follower user ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 404 {object} dto.ErrorResponseDTO
+// @Router /users/follow-requests/{id} [put]
+func (c *UserController) AcceptFollower(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return utils.NewValidationError("error.user.invalid_id", err)
+	}
+
+	if err = c.userService.AcceptFollower(ctx.Request.Context(), requester.ID, int32(id)); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
+
+// @Summary Remove follower
+// @Description Stop a user following the authenticated user, or decline their request to
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Follower user ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Router /users/followers/{id} [delete]
+func (c *UserController) RemoveFollower(ctx *gin.Context) error {
+	requester, ok := getRequester(ctx)
+	if !ok {
+		return utils.NewUnauthorizedError("error.user.missing_authentication")
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		return utils.NewValidationError("error.user.invalid_id", err)
+	}
+
+	if err = c.userService.RemoveFollower(ctx.Request.Context(), requester.ID, int32(id)); err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+	return nil
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUserService stubs the preferences; the other methods of the service
+// aren't used by these tests and panic when called.
+type MockUserService struct {
+	services.IUserService
+	mock.Mock
+}
+
+func (m *MockUserService) UpdatePreferences(_ context.Context, userID int32, preferences dto.UserPreferencesDTO) (dto.UserDTO, error) {
+	args := m.Called(userID, preferences)
+	return args.Get(0).(dto.UserDTO), args.Error(1)
+}
+
+func newUserTestRouter(service services.IUserService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	auth := r.Group("/api")
+	auth.Use(func(c *gin.Context) {
+		c.Set("requester", dto.UserDTO{ID: 1})
+		c.Next()
+	})
+
+	controller := &UserController{userService: service}
+	controller.RegisterHandlers(ControllerRegisterParams{Authenticated: auth})
+
+	return r
+}
+
+func TestUserController_UpdatePreferences(t *testing.T) {
+	// Setup
+	mockService := new(MockUserService)
+	r := newUserTestRouter(mockService)
+
+	language, region := "en-US", "US"
+	mockService.On("UpdatePreferences", int32(1), dto.UserPreferencesDTO{Language: &language, Region: &region}).
+		Return(dto.UserDTO{ID: 1, Language: &language}, nil)
+	mockService.On("UpdatePreferences", int32(1), dto.UserPreferencesDTO{}).Return(dto.UserDTO{ID: 1}, nil)
+
+	for body, status := range map[string]int{
+		`{"language": "en-US", "region": "US"}`: http.StatusOK,
+		`{"language": null, "region": null}`:    http.StatusOK,
+		`{"language": "english"}`:               http.StatusBadRequest,
+		`{"language": ""}`:                      http.StatusBadRequest,
+		`{"region": "USA"}`:                     http.StatusBadRequest,
+		`{"region": "XX"}`:                      http.StatusBadRequest,
+		`{"language": 1}`:                       http.StatusBadRequest,
+	} {
+		req, _ := http.NewRequest("PUT", "/api/users/preferences", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, status, w.Code, body)
+	}
+
+	mockService.AssertNumberOfCalls(t, "UpdatePreferences", 2)
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
 )
 
 func getRequester(ctx *gin.Context) (dto.UserDTO, bool) {
@@ -19,3 +20,50 @@ func getRequester(ctx *gin.Context) (dto.UserDTO, bool) {
 
 	return requester, true
 }
+
+// requireVerifiedEmail restricts features that expose content to other users
+// or write in bulk to accounts with a verified email.
+func requireVerifiedEmail(user dto.UserDTO) error {
+	if !user.IsEmailVerified() {
+		return utils.NewForbiddenError("error.auth.email_not_verified")
+	}
+	return nil
+}
+
+func getSessionID(ctx *gin.Context) (int32, bool) {
+	value, exists := ctx.Get("session")
+	if !exists {
+		return 0, false
+	}
+
+	sessionID, ok := value.(int32)
+	return sessionID, ok
+}
+
+// getLocale resolves the locale movie data is served in: the requester's
+// preferences first, then the Accept-Language header. The services fill what
+// is still missing with the API defaults.
+func getLocale(ctx *gin.Context) dto.LocaleDTO {
+	var locale dto.LocaleDTO
+	if requester, ok := getRequester(ctx); ok {
+		locale = requester.Locale()
+	}
+
+	if value, exists := ctx.Get("locale"); exists {
+		if requested, ok := value.(dto.LocaleDTO); ok {
+			locale = locale.WithFallback(requested)
+		}
+	}
+
+	return locale
+}
+
+// getViewer identifies who movie data is served to, anonymously when the
+// request carries no valid token.
+func getViewer(ctx *gin.Context) dto.ViewerDTO {
+	viewer := dto.ViewerDTO{Locale: getLocale(ctx)}
+	if requester, ok := getRequester(ctx); ok {
+		viewer.UserID = requester.ID
+	}
+	return viewer
+}
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+type IWatchProviderController interface {
+	IController
+}
+
+type WatchProviderController struct {
+	streamingService services.IStreamingService
+}
+
+func newWatchProviderController(params ControllerParams) IWatchProviderController {
+	return &WatchProviderController{
+		streamingService: params.Svcs.StreamingService,
+	}
+}
+
+func (c *WatchProviderController) RegisterHandlers(params ControllerRegisterParams) {
+	router := params.Public.Group("/watch-providers")
+
+	router.GET("", utils.MakeHandler(c.GetWatchProviders)) // GET /watch-providers
+}
+
+// @Summary Get watch providers
+// @Description Get the streaming services, stores and rental services movies can be watched on in a country, by display priority. Their IDs are the ones accepted as streaming services of the user.
+// @Tags watch-providers
+// @Accept json
+// @Produce json
+// @Param country query string false "ISO 3166-1 country code (default: the viewer's region)"
+// @Success 200 {array} dto.WatchProviderDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /watch-providers [get]
+func (c *WatchProviderController) GetWatchProviders(ctx *gin.Context) error {
+	var query dto.WatchProviderQueryDTO
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		return utils.NewValidationError("error.streaming.invalid_country", err)
+	}
+
+	providers, err := c.streamingService.GetProviders(ctx.Request.Context(), utils.FallbackZero(query.Country, getViewer(ctx).Locale.Region))
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, providers)
+	return nil
+}
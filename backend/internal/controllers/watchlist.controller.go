@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
 
@@ -35,15 +36,29 @@ func (c *WatchlistController) RegisterHandlers(params ControllerRegisterParams)
 	router.PATCH("/:id/status", utils.MakeHandler(c.UpdateStatus))     // PATCH /watchlist/:id/status
 	router.PATCH("/:id/favorite", utils.MakeHandler(c.ToggleFavorite)) // PATCH /watchlist/:id/favorite
 	router.PATCH("/:id/rating", utils.MakeHandler(c.UpdateRating))     // PATCH /watchlist/:id/rating
+	router.GET("/:id/history", utils.MakeHandler(c.GetStatusHistory))  // GET /watchlist/:id/history
+	router.GET("/export", utils.MakeHandler(c.ExportWatchlist))        // GET /watchlist/export
 }
 
 // @Summary Get user watchlist
-// @Description Get the authenticated user's watchlist
+// @Description Get the authenticated user's watchlist, optionally filtered and sorted. The whole list is returned as an array unless page or page_size is set, in which case a pagination envelope is returned. With expand=movie every item also carries its movie metadata. With available_on=mine only the items streaming right now on the user's streaming services are returned, each with the services it is on; the status defaults to plan to watch.
 // @Tags watchlist
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} dto.WatchListDTO
+// @Param status query []string false "Only items with one of these statuses" collectionFormat(multi)
+// @Param favorite query bool false "Only favorite (true) or non-favorite (false) items"
+// @Param rating_min query int false "Minimum rating (1-10)"
+// @Param rating_max query int false "Maximum rating (1-10)"
+// @Param has_comments query bool false "Only items with (true) or without (false) comments"
+// @Param sort_by query string false "Sort field (default: added)" Enums(added, rating, title)
+// @Param order query string false "Sort order (default: asc for title, desc otherwise)" Enums(asc, desc)
+// @Param available_on query string false "Only items streaming on the user's services, which must be set up" Enums(mine)
+// @Param page query int false "Page number"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Param expand query string false "Related data to include" Enums(movie)
+// @Success 200 {array} dto.WatchListMovieDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
 // @Failure 401 {object} dto.ErrorResponseDTO
 // @Failure 500 {object} dto.ErrorResponseDTO
 // @Router /watchlist [get]
@@ -53,15 +68,120 @@ func (c *WatchlistController) GetUserWatchlist(ctx *gin.Context) error {
 		return utils.NewUnauthorizedError("error.auth.user_not_found")
 	}
 
-	watchlist, err := c.watchlistService.GetByUser(user.ID)
+	var query dto.WatchListQueryDTO
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		return utils.NewValidationError("error.watchlist.invalid_query", err)
+	}
+
+	if query.RatingMin != nil && query.RatingMax != nil && *query.RatingMin > *query.RatingMax {
+		return utils.NewValidationError("error.watchlist.invalid_rating_range", fmt.Errorf("rating_min is greater than rating_max"))
+	}
+
+	expand := ctx.Query("expand")
+	if expand != "" && expand != "movie" {
+		return utils.NewValidationError("error.watchlist.invalid_expand", fmt.Errorf("unsupported expand value: %s", expand))
+	}
+
+	watchlist, err := c.watchlistService.Find(ctx.Request.Context(), user.ID, query)
 	if err != nil {
 		return err
 	}
 
-	ctx.JSON(http.StatusOK, watchlist)
+	if expand == "movie" {
+		respondWatchlist(ctx, query, watchlist, c.watchlistService.AttachMovies(ctx.Request.Context(), watchlist.Results, getViewer(ctx)))
+	} else {
+		respondWatchlist(ctx, query, watchlist, watchlist.Results)
+	}
+
 	return nil
 }
 
+// respondWatchlist writes the items as a plain array, or wrapped in the
+// pagination envelope of the page they belong to when a page was requested.
+func respondWatchlist[T any](ctx *gin.Context, query dto.WatchListQueryDTO, page dto.Pagination[dto.WatchListDTO], results []T) {
+	if !query.IsPaginated() {
+		ctx.JSON(http.StatusOK, results)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.Pagination[T]{
+		Results:      results,
+		Page:         page.Page,
+		TotalPages:   page.TotalPages,
+		TotalResults: page.TotalResults,
+	})
+}
+
+// @Summary Export user watchlist
+// @Description Download the authenticated user's whole watchlist with the title, year and IMDb ID of every movie. The file is streamed as it is produced. The letterboxd format can be imported into Letterboxd and only contains watched movies.
+// @Tags watchlist
+// @Produce json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param format query string false "Export format (default: csv)" Enums(csv, json, letterboxd)
+// @Success 200 {array} dto.WatchListExportDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /watchlist/export [get]
+func (c *WatchlistController) ExportWatchlist(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	var query dto.WatchListExportQueryDTO
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		return utils.NewValidationError("error.watchlist.invalid_export_format", err)
+	}
+	format := utils.FallbackZero(query.Format, services.WatchlistExportCSV)
+
+	contentType, extension := services.ExportContentType(format)
+	writer := &exportWriter{
+		ResponseWriter: ctx.Writer,
+		contentType:    contentType,
+		filename:       fmt.Sprintf("watchlist-%s.%s", format, extension),
+	}
+
+	err := c.watchlistService.Export(ctx.Request.Context(), user.ID, format, getLocale(ctx), writer)
+	if err != nil && ctx.Writer.Written() {
+		// The status line is already out, the truncated body is all the client gets.
+		slog.Error("watchlist export interrupted", "error", err)
+		ctx.Abort()
+		return nil
+	}
+
+	return err
+}
+
+// exportWriter only sets the download headers once the export starts writing,
+// so errors raised before that are still answered with a regular JSON error.
+type exportWriter struct {
+	gin.ResponseWriter
+	contentType string
+	filename    string
+}
+
+func (w *exportWriter) Write(data []byte) (int, error) {
+	w.start()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *exportWriter) WriteString(data string) (int, error) {
+	w.start()
+	return w.ResponseWriter.WriteString(data)
+}
+
+func (w *exportWriter) start() {
+	if !w.Written() {
+		w.Header().Set("Content-Type", w.contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
 // @Summary Add movie to watchlist
 // @Description Add a movie to the authenticated user's watchlist
 // @Tags watchlist
@@ -86,7 +206,7 @@ func (c *WatchlistController) AddToWatchlist(ctx *gin.Context) error {
 		return utils.NewValidationError("error.watchlist.invalid_request", err)
 	}
 
-	watchlistItem, err := c.watchlistService.AddToWatchlist(user.ID, req)
+	watchlistItem, err := c.watchlistService.AddToWatchlist(ctx.Request.Context(), user.ID, req)
 	if err != nil {
 		return err
 	}
@@ -126,10 +246,6 @@ func (c *WatchlistController) UpdateWatchlistItem(ctx *gin.Context) error {
 		return utils.NewValidationError("error.watchlist.invalid_request", err)
 	}
 
-	// Adicionar logs de debug
-	fmt.Printf("🔍 DEBUG: Recebido request - Status: '%s', Favorite: %v, Comments: '%s', Rating: %v\n", 
-		req.Status, req.Favorite, req.Comments, req.Rating)
-
 	// CORREÇÃO: Converter tipos corretamente para o service
 	var favoritePtr *bool
 	if req.Favorite != nil {
@@ -141,15 +257,11 @@ func (c *WatchlistController) UpdateWatchlistItem(ctx *gin.Context) error {
 		ratingPtr = req.Rating
 	}
 
-	watchlistItem, err := c.watchlistService.UpdateWatchlistItem(user.ID, id, req.Status, favoritePtr, req.Comments, ratingPtr)
+	watchlistItem, err := c.watchlistService.UpdateWatchlistItem(ctx.Request.Context(), user.ID, id, req.Status, favoritePtr, req.Comments, ratingPtr)
 	if err != nil {
 		return err
 	}
 
-	// Adicionar log da resposta
-	fmt.Printf("🔍 DEBUG: Resposta do service - Status: '%s', Favorite: %v, Comments: '%s', Rating: %v\n", 
-		watchlistItem.Status, watchlistItem.Favorite, watchlistItem.Comments, watchlistItem.Rating)
-
 	ctx.JSON(http.StatusOK, watchlistItem)
 	return nil
 }
@@ -178,7 +290,7 @@ func (c *WatchlistController) RemoveFromWatchlist(ctx *gin.Context) error {
 		return utils.NewValidationError("error.watchlist.invalid_id", err)
 	}
 
-	err = c.watchlistService.RemoveFromWatchlist(user.ID, id)
+	err = c.watchlistService.RemoveFromWatchlist(ctx.Request.Context(), user.ID, id)
 	if err != nil {
 		return err
 	}
@@ -218,7 +330,7 @@ func (c *WatchlistController) UpdateStatus(ctx *gin.Context) error {
 		return utils.NewValidationError("error.watchlist.invalid_status", err)
 	}
 
-	watchlistItem, err := c.watchlistService.UpdateStatus(user.ID, id, req.Status)
+	watchlistItem, err := c.watchlistService.UpdateStatus(ctx.Request.Context(), user.ID, id, req.Status)
 	if err != nil {
 		return err
 	}
@@ -258,7 +370,7 @@ func (c *WatchlistController) ToggleFavorite(ctx *gin.Context) error {
 		return utils.NewValidationError("error.watchlist.invalid_favorite", err)
 	}
 
-	watchlistItem, err := c.watchlistService.ToggleFavorite(user.ID, id, req.Favorite)
+	watchlistItem, err := c.watchlistService.ToggleFavorite(ctx.Request.Context(), user.ID, id, req.Favorite)
 	if err != nil {
 		return err
 	}
@@ -298,7 +410,7 @@ func (c *WatchlistController) UpdateRating(ctx *gin.Context) error {
 		return utils.NewValidationError("error.watchlist.invalid_rating", err)
 	}
 
-	watchlistItem, err := c.watchlistService.UpdateRating(user.ID, id, req.Rating)
+	watchlistItem, err := c.watchlistService.UpdateRating(ctx.Request.Context(), user.ID, id, req.Rating)
 	if err != nil {
 		return err
 	}
@@ -306,3 +418,36 @@ func (c *WatchlistController) UpdateRating(ctx *gin.Context) error {
 	ctx.JSON(http.StatusOK, watchlistItem)
 	return nil
 }
+
+// @Summary Get watchlist status history
+// @Description Get every status change of a watchlist item, oldest first
+// @Tags watchlist
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Watchlist item ID"
+// @Success 200 {array} dto.WatchListStatusChangeDTO
+// @Failure 400 {object} dto.ErrorResponseDTO
+// @Failure 401 {object} dto.ErrorResponseDTO
+// @Failure 500 {object} dto.ErrorResponseDTO
+// @Router /watchlist/{id}/history [get]
+func (c *WatchlistController) GetStatusHistory(ctx *gin.Context) error {
+	user, exists := getRequester(ctx)
+	if !exists {
+		return utils.NewUnauthorizedError("error.auth.user_not_found")
+	}
+
+	idParam := ctx.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		return utils.NewValidationError("error.watchlist.invalid_id", err)
+	}
+
+	history, err := c.watchlistService.GetStatusHistory(ctx.Request.Context(), user.ID, id)
+	if err != nil {
+		return err
+	}
+
+	ctx.JSON(http.StatusOK, history)
+	return nil
+}
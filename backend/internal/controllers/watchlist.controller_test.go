@@ -2,7 +2,10 @@ package controllers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -21,41 +24,61 @@ type MockWatchlistService struct {
 
 func (m *MockWatchlistService) ProvideServices(services.Services) {}
 
-func (m *MockWatchlistService) GetByUser(userID int32) ([]dto.WatchListDTO, error) {
+func (m *MockWatchlistService) GetByUser(_ context.Context, userID int32) ([]dto.WatchListDTO, error) {
 	args := m.Called(userID)
 	return args.Get(0).([]dto.WatchListDTO), args.Error(1)
 }
 
-func (m *MockWatchlistService) AddToWatchlist(userID int32, createDTO dto.WatchListCreateDTO) (dto.WatchListDTO, error) {
+func (m *MockWatchlistService) Find(_ context.Context, userID int32, query dto.WatchListQueryDTO) (dto.Pagination[dto.WatchListDTO], error) {
+	args := m.Called(userID, query)
+	return args.Get(0).(dto.Pagination[dto.WatchListDTO]), args.Error(1)
+}
+
+func (m *MockWatchlistService) AttachMovies(_ context.Context, items []dto.WatchListDTO, viewer dto.ViewerDTO) []dto.WatchListMovieDTO {
+	args := m.Called(items, viewer)
+	return args.Get(0).([]dto.WatchListMovieDTO)
+}
+
+func (m *MockWatchlistService) AddToWatchlist(_ context.Context, userID int32, createDTO dto.WatchListCreateDTO) (dto.WatchListDTO, error) {
 	args := m.Called(userID, createDTO)
 	return args.Get(0).(dto.WatchListDTO), args.Error(1)
 }
 
-func (m *MockWatchlistService) UpdateWatchlistItem(userID int32, movieID int, status string, favorite *bool, comments string, rating *int) (dto.WatchListDTO, error) {
+func (m *MockWatchlistService) UpdateWatchlistItem(_ context.Context, userID int32, movieID int, status string, favorite *bool, comments string, rating *int) (dto.WatchListDTO, error) {
 	args := m.Called(userID, movieID, status, favorite, comments, rating)
 	return args.Get(0).(dto.WatchListDTO), args.Error(1)
 }
 
-func (m *MockWatchlistService) RemoveFromWatchlist(userID int32, movieID int) error {
+func (m *MockWatchlistService) RemoveFromWatchlist(_ context.Context, userID int32, movieID int) error {
 	args := m.Called(userID, movieID)
 	return args.Error(0)
 }
 
-func (m *MockWatchlistService) UpdateStatus(userID int32, movieID int, status string) (dto.WatchListDTO, error) {
+func (m *MockWatchlistService) UpdateStatus(_ context.Context, userID int32, movieID int, status string) (dto.WatchListDTO, error) {
 	args := m.Called(userID, movieID, status)
 	return args.Get(0).(dto.WatchListDTO), args.Error(1)
 }
 
-func (m *MockWatchlistService) ToggleFavorite(userID int32, movieID int, favorite bool) (dto.WatchListDTO, error) {
+func (m *MockWatchlistService) ToggleFavorite(_ context.Context, userID int32, movieID int, favorite bool) (dto.WatchListDTO, error) {
 	args := m.Called(userID, movieID, favorite)
 	return args.Get(0).(dto.WatchListDTO), args.Error(1)
 }
 
-func (m *MockWatchlistService) UpdateRating(userID int32, movieID int, rating *int) (dto.WatchListDTO, error) {
+func (m *MockWatchlistService) UpdateRating(_ context.Context, userID int32, movieID int, rating *int) (dto.WatchListDTO, error) {
 	args := m.Called(userID, movieID, rating)
 	return args.Get(0).(dto.WatchListDTO), args.Error(1)
 }
 
+func (m *MockWatchlistService) GetStatusHistory(_ context.Context, userID int32, movieID int) ([]dto.WatchListStatusChangeDTO, error) {
+	args := m.Called(userID, movieID)
+	return args.Get(0).([]dto.WatchListStatusChangeDTO), args.Error(1)
+}
+
+func (m *MockWatchlistService) Export(_ context.Context, userID int32, format string, locale dto.LocaleDTO, w io.Writer) error {
+	args := m.Called(userID, format, locale, w)
+	return args.Error(0)
+}
+
 func TestWatchlistController_GetUserWatchlist_Success(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
@@ -77,7 +100,12 @@ func TestWatchlistController_GetUserWatchlist_Success(t *testing.T) {
 		},
 	}
 
-	mockService.On("GetByUser", int32(1)).Return(expectedWatchlist, nil)
+	mockService.On("Find", int32(1), dto.WatchListQueryDTO{}).Return(dto.Pagination[dto.WatchListDTO]{
+		Results:      expectedWatchlist,
+		Page:         1,
+		TotalPages:   1,
+		TotalResults: 1,
+	}, nil)
 
 	// Create router and register handlers
 	r := gin.Default()
@@ -111,6 +139,169 @@ func TestWatchlistController_GetUserWatchlist_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestWatchlistController_GetUserWatchlist_ExpandMovie(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockWatchlistService)
+
+	controller := &WatchlistController{
+		watchlistService: mockService,
+	}
+
+	// Mock data
+	movieError := "error.movie.unavailable"
+	expectedWatchlist := []dto.WatchListMovieDTO{
+		{
+			WatchListDTO: dto.WatchListDTO{MovieID: 123, UserID: 1, Status: "watched"},
+			Movie:        &dto.MovieDTO{ID: 123, Title: "Test Movie", Year: "2023"},
+		},
+		{
+			WatchListDTO: dto.WatchListDTO{MovieID: 456, UserID: 1, Status: "plan to watch"},
+			MovieError:   &movieError,
+		},
+	}
+
+	items := []dto.WatchListDTO{expectedWatchlist[0].WatchListDTO, expectedWatchlist[1].WatchListDTO}
+	mockService.On("Find", int32(1), dto.WatchListQueryDTO{}).Return(dto.Pagination[dto.WatchListDTO]{Results: items}, nil)
+	mockService.On("AttachMovies", items, dto.ViewerDTO{UserID: 1}).Return(expectedWatchlist)
+
+	// Create router and register handlers
+	r := gin.Default()
+	auth := r.Group("/api")
+	auth.Use(func(c *gin.Context) {
+		c.Set("requester", dto.UserDTO{ID: 1})
+		c.Next()
+	})
+
+	controller.RegisterHandlers(ControllerRegisterParams{
+		Authenticated: auth,
+	})
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/api/watchlist?expand=movie", nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []dto.WatchListMovieDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 2)
+	assert.Equal(t, int32(123), response[0].MovieID)
+	assert.Equal(t, "Test Movie", response[0].Movie.Title)
+	assert.Nil(t, response[1].Movie)
+	assert.Equal(t, movieError, *response[1].MovieError)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestWatchlistController_GetUserWatchlist_FilteredPage(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockWatchlistService)
+
+	controller := &WatchlistController{
+		watchlistService: mockService,
+	}
+
+	// Mock data
+	favorite := true
+	ratingMin := 7
+	expectedQuery := dto.WatchListQueryDTO{
+		Status:    []string{"watched", "watching"},
+		Favorite:  &favorite,
+		RatingMin: &ratingMin,
+		SortBy:    "rating",
+		Order:     "asc",
+		Page:      2,
+		PageSize:  1,
+	}
+
+	mockService.On("Find", int32(1), expectedQuery).Return(dto.Pagination[dto.WatchListDTO]{
+		Results:      []dto.WatchListDTO{{MovieID: 123, UserID: 1, Status: "watched"}},
+		Page:         2,
+		TotalPages:   3,
+		TotalResults: 3,
+	}, nil)
+
+	// Create router and register handlers
+	r := gin.Default()
+	auth := r.Group("/api")
+	auth.Use(func(c *gin.Context) {
+		c.Set("requester", dto.UserDTO{ID: 1})
+		c.Next()
+	})
+
+	controller.RegisterHandlers(ControllerRegisterParams{
+		Authenticated: auth,
+	})
+
+	// Create request
+	url := "/api/watchlist?status=watched&status=watching&favorite=true&rating_min=7&sort_by=rating&order=asc&page=2&page_size=1"
+	req, _ := http.NewRequest("GET", url, nil)
+	w := httptest.NewRecorder()
+
+	// Execute
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dto.Pagination[dto.WatchListDTO]
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.Page)
+	assert.Equal(t, 3, response.TotalPages)
+	assert.Equal(t, 3, response.TotalResults)
+	assert.Len(t, response.Results, 1)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestWatchlistController_GetUserWatchlist_InvalidQuery(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockWatchlistService)
+
+	controller := &WatchlistController{
+		watchlistService: mockService,
+	}
+
+	// Create router and register handlers
+	r := gin.Default()
+	auth := r.Group("/api")
+	auth.Use(func(c *gin.Context) {
+		c.Set("requester", dto.UserDTO{ID: 1})
+		c.Next()
+	})
+
+	controller.RegisterHandlers(ControllerRegisterParams{
+		Authenticated: auth,
+	})
+
+	for _, url := range []string{
+		"/api/watchlist?status=dropped",
+		"/api/watchlist?rating_min=8&rating_max=3",
+		"/api/watchlist?sort_by=popularity",
+		"/api/watchlist?page_size=1000",
+	} {
+		req, _ := http.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+
+		// Execute
+		r.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+
+	mockService.AssertNotCalled(t, "Find")
+}
+
 func TestWatchlistController_AddToWatchlist_Success(t *testing.T) {
 	// Setup
 	gin.SetMode(gin.TestMode)
@@ -210,3 +401,56 @@ func TestWatchlistController_GetUserWatchlist_Unauthorized(t *testing.T) {
 	// Assert - deveria retornar erro de autorização
 	assert.NotEqual(t, http.StatusOK, w.Code)
 }
+
+func TestWatchlistController_ExportWatchlist(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockService := new(MockWatchlistService)
+
+	controller := &WatchlistController{
+		watchlistService: mockService,
+	}
+
+	r := gin.Default()
+	auth := r.Group("/api")
+	auth.Use(func(c *gin.Context) {
+		c.Set("requester", dto.UserDTO{ID: 1})
+		c.Next()
+	})
+
+	controller.RegisterHandlers(ControllerRegisterParams{
+		Authenticated: auth,
+	})
+
+	mockService.On("Export", int32(1), "json", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		io.WriteString(args.Get(3).(io.Writer), "[]\n")
+	}).Return(nil)
+	mockService.On("Export", int32(1), "csv", mock.Anything, mock.Anything).Return(errors.New("database down"))
+
+	// Execute
+	req, _ := http.NewRequest("GET", "/api/watchlist/export?format=json", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="watchlist-json.json"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "[]\n", w.Body.String())
+
+	// Failures before anything was written are plain errors
+	req, _ = http.NewRequest("GET", "/api/watchlist/export", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+
+	// Unknown formats are rejected
+	req, _ = http.NewRequest("GET", "/api/watchlist/export?format=xml", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -12,13 +12,16 @@ import (
 )
 
 type Users struct {
-	ID        int32 `sql:"primary_key"`
-	Name      string
-	Username  string
-	Phone     *string
-	Email     string
-	Password  string
-	CreatedAt *time.Time
-	UpdatedAt *time.Time
-	DeletedAt *time.Time
+	ID              int32 `sql:"primary_key"`
+	Name            string
+	Username        string
+	Phone           *string
+	Email           string
+	Password        string
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
+	DeletedAt       *time.Time
+	EmailVerifiedAt *time.Time
+	Language        *string
+	Region          *string
 }
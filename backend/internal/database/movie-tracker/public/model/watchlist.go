@@ -7,11 +7,18 @@
 
 package model
 
+import (
+	"time"
+)
+
 type Watchlist struct {
-	MovieID  int32 `sql:"primary_key"`
-	UserID   int32 `sql:"primary_key"`
-	Status   WatchStatus
-	Favorite bool
-	Comments *string
-	Rating   *int32
+	MovieID         int32 `sql:"primary_key"`
+	UserID          int32 `sql:"primary_key"`
+	Status          WatchStatus
+	Favorite        bool
+	Comments        *string
+	Rating          *int32
+	AddedAt         time.Time
+	UpdatedAt       time.Time
+	StatusChangedAt time.Time
 }
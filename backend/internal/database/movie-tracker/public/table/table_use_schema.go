@@ -10,7 +10,25 @@ package table
 // UseSchema sets a new schema name for all generated table SQL builder types. It is recommended to invoke
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
+	CalendarFeeds = CalendarFeeds.FromSchema(schema)
+	FollowedPeople = FollowedPeople.FromSchema(schema)
 	GooseDbVersion = GooseDbVersion.FromSchema(schema)
+	ListEntries = ListEntries.FromSchema(schema)
+	Lists = Lists.FromSchema(schema)
+	MovieQueries = MovieQueries.FromSchema(schema)
+	Movies = Movies.FromSchema(schema)
+	NotificationSettings = NotificationSettings.FromSchema(schema)
+	Notifications = Notifications.FromSchema(schema)
+	ParentalProfiles = ParentalProfiles.FromSchema(schema)
+	Sessions = Sessions.FromSchema(schema)
+	ShowProgress = ShowProgress.FromSchema(schema)
+	StreamingSubscriptions = StreamingSubscriptions.FromSchema(schema)
+	UserFollows = UserFollows.FromSchema(schema)
+	UserTokens = UserTokens.FromSchema(schema)
 	Users = Users.FromSchema(schema)
+	WatchEvents = WatchEvents.FromSchema(schema)
+	WatchedEpisodes = WatchedEpisodes.FromSchema(schema)
 	Watchlist = Watchlist.FromSchema(schema)
+	WatchlistAvailability = WatchlistAvailability.FromSchema(schema)
+	WatchlistStatusHistory = WatchlistStatusHistory.FromSchema(schema)
 }
@@ -17,15 +17,18 @@ type usersTable struct {
 	postgres.Table
 
 	// Columns
-	ID        postgres.ColumnInteger
-	Name      postgres.ColumnString
-	Username  postgres.ColumnString
-	Phone     postgres.ColumnString
-	Email     postgres.ColumnString
-	Password  postgres.ColumnString
-	CreatedAt postgres.ColumnTimestamp
-	UpdatedAt postgres.ColumnTimestamp
-	DeletedAt postgres.ColumnTimestamp
+	ID              postgres.ColumnInteger
+	Name            postgres.ColumnString
+	Username        postgres.ColumnString
+	Phone           postgres.ColumnString
+	Email           postgres.ColumnString
+	Password        postgres.ColumnString
+	CreatedAt       postgres.ColumnTimestamp
+	UpdatedAt       postgres.ColumnTimestamp
+	DeletedAt       postgres.ColumnTimestamp
+	EmailVerifiedAt postgres.ColumnTimestamp
+	Language        postgres.ColumnString
+	Region          postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -66,32 +69,38 @@ func newUsersTable(schemaName, tableName, alias string) *UsersTable {
 
 func newUsersTableImpl(schemaName, tableName, alias string) usersTable {
 	var (
-		IDColumn        = postgres.IntegerColumn("id")
-		NameColumn      = postgres.StringColumn("name")
-		UsernameColumn  = postgres.StringColumn("username")
-		PhoneColumn     = postgres.StringColumn("phone")
-		EmailColumn     = postgres.StringColumn("email")
-		PasswordColumn  = postgres.StringColumn("password")
-		CreatedAtColumn = postgres.TimestampColumn("created_at")
-		UpdatedAtColumn = postgres.TimestampColumn("updated_at")
-		DeletedAtColumn = postgres.TimestampColumn("deleted_at")
-		allColumns      = postgres.ColumnList{IDColumn, NameColumn, UsernameColumn, PhoneColumn, EmailColumn, PasswordColumn, CreatedAtColumn, UpdatedAtColumn, DeletedAtColumn}
-		mutableColumns  = postgres.ColumnList{NameColumn, UsernameColumn, PhoneColumn, EmailColumn, PasswordColumn, CreatedAtColumn, UpdatedAtColumn, DeletedAtColumn}
+		IDColumn              = postgres.IntegerColumn("id")
+		NameColumn            = postgres.StringColumn("name")
+		UsernameColumn        = postgres.StringColumn("username")
+		PhoneColumn           = postgres.StringColumn("phone")
+		EmailColumn           = postgres.StringColumn("email")
+		PasswordColumn        = postgres.StringColumn("password")
+		CreatedAtColumn       = postgres.TimestampColumn("created_at")
+		UpdatedAtColumn       = postgres.TimestampColumn("updated_at")
+		DeletedAtColumn       = postgres.TimestampColumn("deleted_at")
+		EmailVerifiedAtColumn = postgres.TimestampColumn("email_verified_at")
+		LanguageColumn        = postgres.StringColumn("language")
+		RegionColumn          = postgres.StringColumn("region")
+		allColumns            = postgres.ColumnList{IDColumn, NameColumn, UsernameColumn, PhoneColumn, EmailColumn, PasswordColumn, CreatedAtColumn, UpdatedAtColumn, DeletedAtColumn, EmailVerifiedAtColumn, LanguageColumn, RegionColumn}
+		mutableColumns        = postgres.ColumnList{NameColumn, UsernameColumn, PhoneColumn, EmailColumn, PasswordColumn, CreatedAtColumn, UpdatedAtColumn, DeletedAtColumn, EmailVerifiedAtColumn, LanguageColumn, RegionColumn}
 	)
 
 	return usersTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		ID:        IDColumn,
-		Name:      NameColumn,
-		Username:  UsernameColumn,
-		Phone:     PhoneColumn,
-		Email:     EmailColumn,
-		Password:  PasswordColumn,
-		CreatedAt: CreatedAtColumn,
-		UpdatedAt: UpdatedAtColumn,
-		DeletedAt: DeletedAtColumn,
+		ID:              IDColumn,
+		Name:            NameColumn,
+		Username:        UsernameColumn,
+		Phone:           PhoneColumn,
+		Email:           EmailColumn,
+		Password:        PasswordColumn,
+		CreatedAt:       CreatedAtColumn,
+		UpdatedAt:       UpdatedAtColumn,
+		DeletedAt:       DeletedAtColumn,
+		EmailVerifiedAt: EmailVerifiedAtColumn,
+		Language:        LanguageColumn,
+		Region:          RegionColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
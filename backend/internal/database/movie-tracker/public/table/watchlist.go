@@ -17,12 +17,15 @@ type watchlistTable struct {
 	postgres.Table
 
 	// Columns
-	MovieID  postgres.ColumnInteger
-	UserID   postgres.ColumnInteger
-	Status   postgres.ColumnString
-	Favorite postgres.ColumnBool
-	Comments postgres.ColumnString
-	Rating   postgres.ColumnInteger
+	MovieID         postgres.ColumnInteger
+	UserID          postgres.ColumnInteger
+	Status          postgres.ColumnString
+	Favorite        postgres.ColumnBool
+	Comments        postgres.ColumnString
+	Rating          postgres.ColumnInteger
+	AddedAt         postgres.ColumnTimestamp
+	UpdatedAt       postgres.ColumnTimestamp
+	StatusChangedAt postgres.ColumnTimestamp
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -63,26 +66,32 @@ func newWatchlistTable(schemaName, tableName, alias string) *WatchlistTable {
 
 func newWatchlistTableImpl(schemaName, tableName, alias string) watchlistTable {
 	var (
-		MovieIDColumn  = postgres.IntegerColumn("movie_id")
-		UserIDColumn   = postgres.IntegerColumn("user_id")
-		StatusColumn   = postgres.StringColumn("status")
-		FavoriteColumn = postgres.BoolColumn("favorite")
-		CommentsColumn = postgres.StringColumn("comments")
-		RatingColumn   = postgres.IntegerColumn("rating")
-		allColumns     = postgres.ColumnList{MovieIDColumn, UserIDColumn, StatusColumn, FavoriteColumn, CommentsColumn, RatingColumn}
-		mutableColumns = postgres.ColumnList{StatusColumn, FavoriteColumn, CommentsColumn, RatingColumn}
+		MovieIDColumn         = postgres.IntegerColumn("movie_id")
+		UserIDColumn          = postgres.IntegerColumn("user_id")
+		StatusColumn          = postgres.StringColumn("status")
+		FavoriteColumn        = postgres.BoolColumn("favorite")
+		CommentsColumn        = postgres.StringColumn("comments")
+		RatingColumn          = postgres.IntegerColumn("rating")
+		AddedAtColumn         = postgres.TimestampColumn("added_at")
+		UpdatedAtColumn       = postgres.TimestampColumn("updated_at")
+		StatusChangedAtColumn = postgres.TimestampColumn("status_changed_at")
+		allColumns            = postgres.ColumnList{MovieIDColumn, UserIDColumn, StatusColumn, FavoriteColumn, CommentsColumn, RatingColumn, AddedAtColumn, UpdatedAtColumn, StatusChangedAtColumn}
+		mutableColumns        = postgres.ColumnList{StatusColumn, FavoriteColumn, CommentsColumn, RatingColumn, AddedAtColumn, UpdatedAtColumn, StatusChangedAtColumn}
 	)
 
 	return watchlistTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		MovieID:  MovieIDColumn,
-		UserID:   UserIDColumn,
-		Status:   StatusColumn,
-		Favorite: FavoriteColumn,
-		Comments: CommentsColumn,
-		Rating:   RatingColumn,
+		MovieID:         MovieIDColumn,
+		UserID:          UserIDColumn,
+		Status:          StatusColumn,
+		Favorite:        FavoriteColumn,
+		Comments:        CommentsColumn,
+		Rating:          RatingColumn,
+		AddedAt:         AddedAtColumn,
+		UpdatedAt:       UpdatedAtColumn,
+		StatusChangedAt: StatusChangedAtColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
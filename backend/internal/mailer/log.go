@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/config"
+)
+
+// LogMailer is meant for development: it writes every message to a .eml file
+// in a directory. Without a directory only the recipient and template are
+// logged, never the body, which may hold tokens.
+type LogMailer struct {
+	from string
+	dir  string
+}
+
+func newLogMailer(cfg config.MailConfig) *LogMailer {
+	return &LogMailer{
+		from: cfg.From,
+		dir:  cfg.Dir,
+	}
+}
+
+func (m *LogMailer) Send(message Message) error {
+	if m.dir == "" {
+		slog.Info("email not delivered by the log mail driver", "to", message.To, "template", message.Template)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405.000000000"), message.To)
+	path := filepath.Join(m.dir, filepath.Base(name))
+
+	return os.WriteFile(path, formatMessage(m.from, message), 0o644)
+}
@@ -0,0 +1,46 @@
+// Package mailer sends the transactional emails of the API.
+package mailer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/movie-tracker/MovieTracker/internal/config"
+)
+
+const (
+	DriverSMTP = "smtp"
+	DriverLog  = "log"
+)
+
+// Message is a plain text email. Template names the kind of message, e.g.
+// "password_reset"; it is what gets logged, as bodies may carry secrets.
+type Message struct {
+	To       string
+	Template string
+	Subject  string
+	Body     string
+}
+
+type Mailer interface {
+	Send(message Message) error
+}
+
+// NewMailer builds the mailer selected by the configuration.
+func NewMailer(cfg config.MailConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case DriverSMTP:
+		if cfg.Host == "" {
+			return nil, errors.New("the smtp mail driver needs SMTP_HOST; set MAIL_DRIVER=log to only log emails during development")
+		}
+		mailer, err := newSMTPMailer(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return mailer, nil
+	case DriverLog:
+		return newLogMailer(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
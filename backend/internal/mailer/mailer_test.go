@@ -0,0 +1,151 @@
+package mailer
+
+import (
+	"bytes"
+	"log/slog"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/movie-tracker/MovieTracker/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogMailer_WritesMessageFile(t *testing.T) {
+	dir := t.TempDir()
+	mailer, err := NewMailer(config.MailConfig{Driver: DriverLog, From: "no-reply@example.com", Dir: dir})
+	assert.NoError(t, err)
+
+	err = mailer.Send(Message{To: "ana@example.com", Subject: "Olá", Body: "Hello\n"})
+	assert.NoError(t, err)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if assert.Len(t, files, 1) {
+		content, _ := os.ReadFile(files[0])
+		assert.Contains(t, string(content), "To: ana@example.com\r\n")
+		assert.Contains(t, string(content), "Subject: =?utf-8?q?Ol=C3=A1?=\r\n")
+		assert.True(t, strings.HasSuffix(string(content), "\r\n\r\nHello\n"))
+	}
+}
+
+func TestNewMailer_UnknownDriver(t *testing.T) {
+	_, err := NewMailer(config.MailConfig{Driver: "pigeon"})
+	assert.Error(t, err)
+}
+
+func TestLogMailer_NeverLogsTheBody(t *testing.T) {
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	mailer, err := NewMailer(config.MailConfig{Driver: DriverLog})
+	assert.NoError(t, err)
+
+	err = mailer.Send(Message{To: "ana@example.com", Template: "password_reset", Body: "https://movies.example.com/reset-password?token=secret"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, logs.String(), "to=ana@example.com")
+	assert.Contains(t, logs.String(), "template=password_reset")
+	assert.NotContains(t, logs.String(), "secret")
+}
+
+func TestNewMailer_SMTPNeedsHost(t *testing.T) {
+	_, err := NewMailer(config.MailConfig{Driver: DriverSMTP, From: "no-reply@example.com", Port: 587})
+	assert.Error(t, err)
+
+	_, err = NewMailer(config.MailConfig{Driver: DriverSMTP, From: "no-reply@example.com", Host: "smtp.example.com", Port: 587})
+	assert.NoError(t, err)
+}
+
+func TestNewMailer_SMTPNeedsValidSender(t *testing.T) {
+	mailer, err := NewMailer(config.MailConfig{Driver: DriverSMTP, From: "Movie Tracker", Host: "smtp.example.com", Port: 587})
+
+	assert.Error(t, err)
+	assert.Nil(t, mailer)
+}
+
+func TestSMTPMailer_SendsFromBareAddress(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	host, port, _ := net.SplitHostPort(server.addr)
+	portNumber, _ := strconv.Atoi(port)
+
+	mailer, err := NewMailer(config.MailConfig{Driver: DriverSMTP, From: "Movie Tracker <no-reply@movie-tracker.local>", Host: host, Port: portNumber})
+	assert.NoError(t, err)
+
+	err = mailer.Send(Message{To: "ana@example.com", Subject: "Hi", Body: "Hello\n"})
+
+	assert.NoError(t, err)
+	commands, data := server.received()
+	assert.Contains(t, commands, "MAIL FROM:<no-reply@movie-tracker.local>")
+	assert.Contains(t, commands, "RCPT TO:<ana@example.com>")
+	assert.Contains(t, data, "From: \"Movie Tracker\" <no-reply@movie-tracker.local>\n")
+}
+
+// fakeSMTPServer accepts a single message, recording the commands it got and
+// the data of the message.
+type fakeSMTPServer struct {
+	addr     string
+	done     chan struct{}
+	commands []string
+	data     string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeSMTPServer{addr: listener.Addr().String(), done: make(chan struct{})}
+	go server.serve(listener)
+	return server
+}
+
+func (s *fakeSMTPServer) serve(listener net.Listener) {
+	defer close(s.done)
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		s.commands = append(s.commands, line)
+
+		switch {
+		case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+			text.PrintfLine("250 localhost")
+		case line == "DATA":
+			text.PrintfLine("354 go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.data = string(data)
+			text.PrintfLine("250 queued")
+		case line == "QUIT":
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("250 ok")
+		}
+	}
+}
+
+// received waits for the client to quit and returns what the server got.
+func (s *fakeSMTPServer) received() ([]string, string) {
+	<-s.done
+	return s.commands, s.data
+}
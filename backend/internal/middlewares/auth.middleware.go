@@ -16,14 +16,31 @@ func JwtAuthMiddleware(authService services.IAuthService) gin.HandlerFunc {
 			return utils.NewUnauthorizedError("error.auth.missing_token")
 		}
 
-		user, err := authService.ValidateToken(token)
+		user, sessionID, err := authService.ValidateToken(ctx.Request.Context(), token)
 		if err != nil {
 			return err
 		}
 
 		ctx.Set("requester", user)
+		ctx.Set("session", sessionID)
 		ctx.Next()
 
 		return nil
 	})
 }
+
+// OptionalJwtAuthMiddleware identifies the requester on public routes when a
+// valid token is sent, so their preferences apply. Missing or invalid tokens
+// are ignored and the request goes on anonymously.
+func OptionalJwtAuthMiddleware(authService services.IAuthService) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if token, hasPrefix := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer "); hasPrefix {
+			if user, sessionID, err := authService.ValidateToken(ctx.Request.Context(), token); err == nil {
+				ctx.Set("requester", user)
+				ctx.Set("session", sessionID)
+			}
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,111 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAuthService struct {
+	mock.Mock
+}
+
+func (m *MockAuthService) ProvideServices(services.Services) {}
+
+func (m *MockAuthService) Login(_ context.Context, username string, password string, client dto.SessionClientDTO) (dto.AuthResponseDTO, error) {
+	args := m.Called(username, password, client)
+	return args.Get(0).(dto.AuthResponseDTO), args.Error(1)
+}
+
+func (m *MockAuthService) Refresh(_ context.Context, refreshToken string) (dto.AuthResponseDTO, error) {
+	args := m.Called(refreshToken)
+	return args.Get(0).(dto.AuthResponseDTO), args.Error(1)
+}
+
+func (m *MockAuthService) Logout(_ context.Context, userID int32, sessionID int32) error {
+	return m.Called(userID, sessionID).Error(0)
+}
+
+func (m *MockAuthService) Register(_ context.Context, userCreateDTO dto.UserCreateDTO) (dto.UserDTO, error) {
+	args := m.Called(userCreateDTO)
+	return args.Get(0).(dto.UserDTO), args.Error(1)
+}
+
+func (m *MockAuthService) ValidateToken(_ context.Context, authToken string) (dto.UserDTO, int32, error) {
+	args := m.Called(authToken)
+	return args.Get(0).(dto.UserDTO), args.Get(1).(int32), args.Error(2)
+}
+
+// identify sends a request through the middleware and returns the requester
+// and session the handler saw, and the response status.
+func identify(middleware gin.HandlerFunc, authorization string) (any, any, int) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware)
+
+	var requester, session any
+	r.GET("/", func(ctx *gin.Context) {
+		requester, _ = ctx.Get("requester")
+		session, _ = ctx.Get("session")
+		ctx.Status(http.StatusNoContent)
+	})
+
+	req, _ := http.NewRequest("GET", "/", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	return requester, session, w.Code
+}
+
+func TestOptionalJwtAuthMiddleware(t *testing.T) {
+	// Setup
+	authService := new(MockAuthService)
+	authService.On("ValidateToken", "valid").Return(dto.UserDTO{ID: 1}, int32(7), nil)
+	authService.On("ValidateToken", "expired").Return(dto.UserDTO{}, int32(0), utils.NewUnauthorizedError("error.token.invalid_or_expired"))
+	middleware := OptionalJwtAuthMiddleware(authService)
+
+	// Execute & Assert
+	requester, session, code := identify(middleware, "Bearer valid")
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.Equal(t, dto.UserDTO{ID: 1}, requester)
+	assert.Equal(t, int32(7), session)
+
+	for _, authorization := range []string{"", "Bearer expired", "Basic dXNlcjpwYXNz"} {
+		requester, session, code = identify(middleware, authorization)
+		assert.Equal(t, http.StatusNoContent, code, authorization)
+		assert.Nil(t, requester, authorization)
+		assert.Nil(t, session, authorization)
+	}
+
+	authService.AssertNumberOfCalls(t, "ValidateToken", 2)
+}
+
+func TestJwtAuthMiddleware(t *testing.T) {
+	// Setup
+	authService := new(MockAuthService)
+	authService.On("ValidateToken", "valid").Return(dto.UserDTO{ID: 1}, int32(7), nil)
+	authService.On("ValidateToken", "expired").Return(dto.UserDTO{}, int32(0), utils.NewUnauthorizedError("error.token.invalid_or_expired"))
+	middleware := JwtAuthMiddleware(authService)
+
+	// Execute & Assert
+	requester, _, code := identify(middleware, "Bearer valid")
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.Equal(t, dto.UserDTO{ID: 1}, requester)
+
+	for _, authorization := range []string{"", "Bearer expired"} {
+		requester, _, code = identify(middleware, authorization)
+		assert.Equal(t, http.StatusUnauthorized, code, authorization)
+		assert.Nil(t, requester, authorization)
+	}
+}
@@ -0,0 +1,28 @@
+package middlewares
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeadlineMiddleware cancels the context of requests taking longer than their
+// route's deadline, aborting the queries and TMDB calls still running. Routes
+// missing from deadlines, keyed by their full path, get the default one.
+func DeadlineMiddleware(defaultTimeout time.Duration, deadlines map[string]time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		timeout, ok := deadlines[ctx.FullPath()]
+		if !ok {
+			timeout = defaultTimeout
+		}
+
+		if timeout > 0 {
+			requestCtx, cancel := context.WithTimeout(ctx.Request.Context(), timeout)
+			defer cancel()
+			ctx.Request = ctx.Request.WithContext(requestCtx)
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"golang.org/x/text/language"
+)
+
+// LocaleMiddleware stores the preferred locale of the Accept-Language header
+// under "locale". Unset fields are left for the user preferences or the API
+// defaults to fill.
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Writer.Header().Add("Vary", "Accept-Language")
+		ctx.Set("locale", parseAcceptLanguage(ctx.GetHeader("Accept-Language")))
+		ctx.Next()
+	}
+}
+
+// parseAcceptLanguage returns the locale of the highest weighted language of
+// the header, ignoring wildcards and malformed headers.
+func parseAcceptLanguage(header string) dto.LocaleDTO {
+	var locale dto.LocaleDTO
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return locale
+	}
+
+	for _, tag := range tags {
+		// The wildcard is parsed as the "mul" language
+		if base, _ := tag.Base(); tag == language.Und || base.String() == "mul" {
+			continue
+		}
+
+		locale.Language = tag.String()
+		if region, confidence := tag.Region(); confidence == language.Exact {
+			locale.Region = region.String()
+		}
+		break
+	}
+
+	return locale
+}
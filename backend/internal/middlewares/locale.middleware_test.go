@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	for header, expected := range map[string]dto.LocaleDTO{
+		"":                             {},
+		"pt-BR":                        {Language: "pt-BR", Region: "BR"},
+		"en":                           {Language: "en"},
+		"en-US;q=0.5, pt-BR;q=0.9, fr": {Language: "fr"},
+		"en-US;q=0.5, pt-BR;q=0.9":     {Language: "pt-BR", Region: "BR"},
+		"*, es-MX;q=0.8":               {Language: "es-MX", Region: "MX"},
+		"*":                            {},
+		"pt-BR;q=0":                    {},
+		"not a language":               {},
+		"en-US;q=abc":                  {},
+	} {
+		assert.Equal(t, expected, parseAcceptLanguage(header), header)
+	}
+}
+
+func TestLocaleMiddleware(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(LocaleMiddleware())
+
+	var locale any
+	r.GET("/", func(ctx *gin.Context) {
+		locale, _ = ctx.Get("locale")
+	})
+
+	// Execute
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "pt-BR,pt;q=0.9,en;q=0.8")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, dto.LocaleDTO{Language: "pt-BR", Region: "BR"}, locale)
+	assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+}
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE "movies" (
+  "id" int PRIMARY KEY,
+  "payload" jsonb not null,
+  "fetched_at" timestamp default CURRENT_TIMESTAMP not null
+);
+
+CREATE TABLE "movie_queries" (
+  "key" varchar PRIMARY KEY,
+  "payload" jsonb not null,
+  "fetched_at" timestamp default CURRENT_TIMESTAMP not null
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE movie_queries;
+DROP TABLE movies;
+
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "watchlist" ADD COLUMN "added_at" timestamp default CURRENT_TIMESTAMP not null;
+
+CREATE INDEX "watchlist_user_id_added_at_idx" ON "watchlist" ("user_id", "added_at");
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX watchlist_user_id_added_at_idx;
+ALTER TABLE watchlist DROP COLUMN added_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE "watch_events" (
+  "id" SERIAL PRIMARY KEY,
+  "user_id" int not null,
+  "movie_id" int not null,
+  "watched_on" date not null,
+  "rating" int,
+  "rewatch" boolean default false not null,
+  "notes" varchar,
+  "created_at" timestamp default CURRENT_TIMESTAMP not null
+);
+
+ALTER TABLE "watch_events" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE INDEX "watch_events_user_id_watched_on_idx" ON "watch_events" ("user_id", "watched_on");
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE watch_events;
+
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "watchlist" ADD COLUMN "updated_at" timestamp default CURRENT_TIMESTAMP not null;
+ALTER TABLE "watchlist" ADD COLUMN "status_changed_at" timestamp default CURRENT_TIMESTAMP not null;
+
+UPDATE "watchlist" SET "updated_at" = "added_at", "status_changed_at" = "added_at";
+
+CREATE TABLE "watchlist_status_history" (
+  "id" SERIAL PRIMARY KEY,
+  "movie_id" int not null,
+  "user_id" int not null,
+  -- The first entry of an item's history has no previous status
+  "from_status" watch_status,
+  "to_status" watch_status not null,
+  "changed_at" timestamp default CURRENT_TIMESTAMP not null
+);
+
+ALTER TABLE "watchlist_status_history" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE INDEX "watchlist_status_history_user_id_movie_id_idx" ON "watchlist_status_history" ("user_id", "movie_id");
+
+-- Existing items start their history with the status they are in
+INSERT INTO "watchlist_status_history" ("movie_id", "user_id", "from_status", "to_status", "changed_at")
+SELECT "movie_id", "user_id", NULL, "status", "added_at" FROM "watchlist";
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE watchlist_status_history;
+ALTER TABLE watchlist DROP COLUMN status_changed_at;
+ALTER TABLE watchlist DROP COLUMN updated_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE list_visibility as ENUM ('private', 'unlisted', 'public');
+
+CREATE TABLE "lists" (
+  "id" SERIAL PRIMARY KEY,
+  "user_id" int not null,
+  "name" varchar not null,
+  "description" varchar,
+  "visibility" list_visibility default 'private' not null,
+  "created_at" timestamp default CURRENT_TIMESTAMP not null,
+  "updated_at" timestamp default CURRENT_TIMESTAMP not null
+);
+
+ALTER TABLE "lists" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+CREATE TABLE "list_entries" (
+  "list_id" int not null,
+  "movie_id" int not null,
+  "position" int not null,
+  "note" varchar,
+  "added_at" timestamp default CURRENT_TIMESTAMP not null,
+  PRIMARY KEY ("list_id", "movie_id"),
+  -- Checked at commit, so entries can swap positions within a transaction
+  UNIQUE ("list_id", "position") DEFERRABLE INITIALLY DEFERRED
+);
+
+ALTER TABLE "list_entries" ADD FOREIGN KEY ("list_id") REFERENCES "lists" ("id") ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE list_entries;
+DROP TABLE lists;
+DROP TYPE list_visibility;
+
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE "sessions" (
+  "id" SERIAL PRIMARY KEY,
+  "user_id" int not null,
+  "token_hash" varchar unique not null,
+  "previous_token_hash" varchar,
+  "user_agent" varchar,
+  "ip_address" varchar,
+  "created_at" timestamp default CURRENT_TIMESTAMP not null,
+  "last_used_at" timestamp default CURRENT_TIMESTAMP not null,
+  "expires_at" timestamp not null,
+  "revoked_at" timestamp
+);
+
+ALTER TABLE "sessions" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX "sessions_user_id_idx" ON "sessions" ("user_id");
+CREATE INDEX "sessions_previous_token_hash_idx" ON "sessions" ("previous_token_hash");
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE sessions;
+
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "users" ADD COLUMN "email_verified_at" timestamp;
+
+CREATE TYPE user_token_purpose as ENUM ('password_reset', 'email_verification');
+
+CREATE TABLE "user_tokens" (
+  "id" SERIAL PRIMARY KEY,
+  "user_id" int not null,
+  "purpose" user_token_purpose not null,
+  "token_hash" varchar unique not null,
+  "created_at" timestamp default CURRENT_TIMESTAMP not null,
+  "expires_at" timestamp not null,
+  "used_at" timestamp
+);
+
+ALTER TABLE "user_tokens" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX "user_tokens_user_id_purpose_idx" ON "user_tokens" ("user_id", "purpose");
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE user_tokens;
+
+DROP TYPE user_token_purpose;
+
+ALTER TABLE "users" DROP COLUMN "email_verified_at";
+
+-- +goose StatementEnd
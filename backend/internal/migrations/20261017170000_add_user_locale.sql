@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "users" ADD COLUMN "language" varchar;
+ALTER TABLE "users" ADD COLUMN "region" varchar;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE "users" DROP COLUMN "region";
+ALTER TABLE "users" DROP COLUMN "language";
+
+-- +goose StatementEnd
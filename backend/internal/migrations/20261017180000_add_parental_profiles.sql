@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE "parental_profiles" (
+  "user_id" int PRIMARY KEY,
+  "rules" jsonb not null,
+  "updated_at" timestamp default CURRENT_TIMESTAMP not null
+);
+
+ALTER TABLE "parental_profiles" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE parental_profiles;
+
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE "show_progress" (
+  "show_id" int not null,
+  "user_id" int not null,
+  "status" watch_status default 'plan to watch' not null,
+  "favorite" boolean default false not null,
+  "rating" int,
+  "added_at" timestamp default CURRENT_TIMESTAMP not null,
+  "updated_at" timestamp default CURRENT_TIMESTAMP not null,
+  "status_changed_at" timestamp default CURRENT_TIMESTAMP not null,
+  PRIMARY KEY ("show_id", "user_id")
+);
+
+ALTER TABLE "show_progress" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE TABLE "watched_episodes" (
+  "show_id" int not null,
+  "user_id" int not null,
+  "season_number" int not null,
+  "episode_number" int not null,
+  "watched_at" timestamp default CURRENT_TIMESTAMP not null,
+  PRIMARY KEY ("show_id", "user_id", "season_number", "episode_number")
+);
+
+ALTER TABLE "watched_episodes" ADD FOREIGN KEY ("show_id", "user_id") REFERENCES "show_progress" ("show_id", "user_id") ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE watched_episodes;
+DROP TABLE show_progress;
+
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE "followed_people" (
+  "user_id" int not null,
+  "person_id" int not null,
+  "followed_at" timestamp default CURRENT_TIMESTAMP not null,
+  PRIMARY KEY ("user_id", "person_id")
+);
+
+ALTER TABLE "followed_people" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE followed_people;
+
+-- +goose StatementEnd
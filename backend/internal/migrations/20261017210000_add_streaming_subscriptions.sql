@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE "streaming_subscriptions" (
+  "user_id" int PRIMARY KEY,
+  "country" varchar not null,
+  "provider_ids" jsonb not null,
+  "updated_at" timestamp default CURRENT_TIMESTAMP not null
+);
+
+ALTER TABLE "streaming_subscriptions" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE streaming_subscriptions;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE "calendar_feeds" (
+  "user_id" int PRIMARY KEY,
+  "token_hash" varchar unique not null,
+  "created_at" timestamp default CURRENT_TIMESTAMP not null
+);
+
+ALTER TABLE "calendar_feeds" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE calendar_feeds;
+
+-- +goose StatementEnd
@@ -0,0 +1,72 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE "user_follows" (
+  "follower_id" int not null,
+  "followed_id" int not null,
+  "followed_at" timestamp default CURRENT_TIMESTAMP not null,
+  -- Following someone is a request until they accept it
+  "accepted_at" timestamp,
+  PRIMARY KEY ("follower_id", "followed_id")
+);
+
+ALTER TABLE "user_follows" ADD FOREIGN KEY ("follower_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "user_follows" ADD FOREIGN KEY ("followed_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX "user_follows_followed_id_idx" ON "user_follows" ("followed_id");
+
+CREATE TABLE "notifications" (
+  "id" SERIAL PRIMARY KEY,
+  "user_id" int not null,
+  "type" varchar not null,
+  "key" varchar not null,
+  "movie_id" int not null,
+  "actor_id" int,
+  "title" varchar not null,
+  "body" varchar not null,
+  "created_at" timestamp default CURRENT_TIMESTAMP not null,
+  "read_at" timestamp,
+  UNIQUE ("user_id", "key")
+);
+
+ALTER TABLE "notifications" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+ALTER TABLE "notifications" ADD FOREIGN KEY ("actor_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX "notifications_user_id_created_at_idx" ON "notifications" ("user_id", "created_at");
+
+CREATE TABLE "notification_settings" (
+  "user_id" int PRIMARY KEY,
+  "email" boolean default false not null,
+  "webhook_url" varchar,
+  "updated_at" timestamp default CURRENT_TIMESTAMP not null
+);
+
+ALTER TABLE "notification_settings" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX "watchlist_updated_at_idx" ON "watchlist" ("updated_at") WHERE "rating" IS NOT NULL;
+
+-- Where each movie on a watchlist streamed on the last notification scan, so
+-- the next one only notifies the services it was added to since.
+CREATE TABLE "watchlist_availability" (
+  "user_id" int not null,
+  "movie_id" int not null,
+  "country" varchar not null,
+  "provider_ids" jsonb not null,
+  "seen_at" timestamp default CURRENT_TIMESTAMP not null,
+  PRIMARY KEY ("user_id", "movie_id")
+);
+
+ALTER TABLE "watchlist_availability" ADD FOREIGN KEY ("movie_id", "user_id") REFERENCES "watchlist" ("movie_id", "user_id") ON DELETE CASCADE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE watchlist_availability;
+DROP INDEX watchlist_updated_at_idx;
+DROP TABLE notification_settings;
+DROP TABLE notifications;
+DROP TABLE user_follows;
+
+-- +goose StatementEnd
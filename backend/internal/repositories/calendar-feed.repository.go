@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+type ICalendarFeedRepository interface {
+	FindByUser(ctx context.Context, userID int32) (model.CalendarFeeds, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (model.CalendarFeeds, error)
+	Save(ctx context.Context, feed model.CalendarFeeds) (model.CalendarFeeds, error)
+	Delete(ctx context.Context, userID int32) error
+}
+
+type CalendarFeedRepository struct {
+	DB *sql.DB
+}
+
+func newCalendarFeedRepository(params RepositoryParams) ICalendarFeedRepository {
+	return &CalendarFeedRepository{
+		DB: params.DB,
+	}
+}
+
+func (r *CalendarFeedRepository) FindByUser(ctx context.Context, userID int32) (model.CalendarFeeds, error) {
+	var feed model.CalendarFeeds
+
+	qb := SELECT(table.CalendarFeeds.AllColumns).
+		FROM(table.CalendarFeeds).
+		WHERE(table.CalendarFeeds.UserID.EQ(Int32(userID)))
+
+	err := qb.QueryContext(ctx, r.DB, &feed)
+	return feed, err
+}
+
+func (r *CalendarFeedRepository) FindByTokenHash(ctx context.Context, tokenHash string) (model.CalendarFeeds, error) {
+	var feed model.CalendarFeeds
+
+	qb := SELECT(table.CalendarFeeds.AllColumns).
+		FROM(table.CalendarFeeds).
+		WHERE(table.CalendarFeeds.TokenHash.EQ(String(tokenHash)))
+
+	err := qb.QueryContext(ctx, r.DB, &feed)
+	return feed, err
+}
+
+// Save creates the feed of the user or replaces its token, so the previous
+// URL stops working.
+func (r *CalendarFeedRepository) Save(ctx context.Context, feed model.CalendarFeeds) (model.CalendarFeeds, error) {
+	var savedFeed model.CalendarFeeds
+
+	feed.CreatedAt = time.Now()
+
+	stmt := table.CalendarFeeds.INSERT(table.CalendarFeeds.AllColumns).
+		MODEL(feed).
+		ON_CONFLICT(table.CalendarFeeds.UserID).
+		DO_UPDATE(SET(
+			table.CalendarFeeds.TokenHash.SET(table.CalendarFeeds.EXCLUDED.TokenHash),
+			table.CalendarFeeds.CreatedAt.SET(table.CalendarFeeds.EXCLUDED.CreatedAt),
+		)).
+		RETURNING(table.CalendarFeeds.AllColumns)
+
+	err := stmt.QueryContext(ctx, r.DB, &savedFeed)
+	return savedFeed, err
+}
+
+func (r *CalendarFeedRepository) Delete(ctx context.Context, userID int32) error {
+	deleteStmt := table.CalendarFeeds.DELETE().
+		WHERE(table.CalendarFeeds.UserID.EQ(Int32(userID)))
+
+	_, err := deleteStmt.ExecContext(ctx, r.DB)
+	return err
+}
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+type IDiaryRepository interface {
+	FindByUser(ctx context.Context, userID int32, movieID *int32) ([]model.WatchEvents, error)
+	Create(ctx context.Context, event model.WatchEvents) (model.WatchEvents, error)
+	Delete(ctx context.Context, userID int32, id int32) (model.WatchEvents, error)
+}
+
+type DiaryRepository struct {
+	DB *sql.DB
+}
+
+func newDiaryRepository(params RepositoryParams) IDiaryRepository {
+	return &DiaryRepository{
+		DB: params.DB,
+	}
+}
+
+func (r *DiaryRepository) FindByUser(ctx context.Context, userID int32, movieID *int32) ([]model.WatchEvents, error) {
+	condition := table.WatchEvents.UserID.EQ(Int32(userID))
+	if movieID != nil {
+		condition = condition.AND(table.WatchEvents.MovieID.EQ(Int32(*movieID)))
+	}
+
+	qb := SELECT(table.WatchEvents.AllColumns).
+		FROM(table.WatchEvents).
+		WHERE(condition).
+		ORDER_BY(table.WatchEvents.WatchedOn.DESC(), table.WatchEvents.ID.DESC())
+
+	events := make([]model.WatchEvents, 0)
+	err := qb.QueryContext(ctx, conn(ctx, r.DB), &events)
+
+	return events, err
+}
+
+func (r *DiaryRepository) Create(ctx context.Context, event model.WatchEvents) (model.WatchEvents, error) {
+	var createdEvent model.WatchEvents
+
+	insertStmt := table.WatchEvents.INSERT(
+		table.WatchEvents.UserID,
+		table.WatchEvents.MovieID,
+		table.WatchEvents.WatchedOn,
+		table.WatchEvents.Rating,
+		table.WatchEvents.Rewatch,
+		table.WatchEvents.Notes,
+	).MODEL(event).
+		RETURNING(table.WatchEvents.AllColumns)
+
+	err := insertStmt.QueryContext(ctx, conn(ctx, r.DB), &createdEvent)
+	return createdEvent, err
+}
+
+func (r *DiaryRepository) Delete(ctx context.Context, userID int32, id int32) (model.WatchEvents, error) {
+	var deletedEvent model.WatchEvents
+
+	deleteStmt := table.WatchEvents.DELETE().
+		WHERE(table.WatchEvents.ID.EQ(Int32(id)).AND(table.WatchEvents.UserID.EQ(Int32(userID)))).
+		RETURNING(table.WatchEvents.AllColumns)
+
+	err := deleteStmt.QueryContext(ctx, conn(ctx, r.DB), &deletedEvent)
+	return deletedEvent, err
+}
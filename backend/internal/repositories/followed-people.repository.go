@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+// IFollowedPeopleRepository stores the people each user follows.
+type IFollowedPeopleRepository interface {
+	FindByUser(ctx context.Context, userID int32) ([]model.FollowedPeople, error)
+	IsFollowing(ctx context.Context, userID int32, personID int32) (bool, error)
+	Follow(ctx context.Context, userID int32, personID int32) (model.FollowedPeople, error)
+	Unfollow(ctx context.Context, userID int32, personID int32) error
+}
+
+type FollowedPeopleRepository struct {
+	DB *sql.DB
+}
+
+func newFollowedPeopleRepository(params RepositoryParams) IFollowedPeopleRepository {
+	return &FollowedPeopleRepository{
+		DB: params.DB,
+	}
+}
+
+// FindByUser lists the people the user follows, the most recently followed
+// first.
+func (r *FollowedPeopleRepository) FindByUser(ctx context.Context, userID int32) ([]model.FollowedPeople, error) {
+	qb := SELECT(table.FollowedPeople.AllColumns).
+		FROM(table.FollowedPeople).
+		WHERE(table.FollowedPeople.UserID.EQ(Int32(userID))).
+		ORDER_BY(table.FollowedPeople.FollowedAt.DESC(), table.FollowedPeople.PersonID.ASC())
+
+	followed := make([]model.FollowedPeople, 0)
+	err := qb.QueryContext(ctx, r.DB, &followed)
+
+	return followed, err
+}
+
+func (r *FollowedPeopleRepository) IsFollowing(ctx context.Context, userID int32, personID int32) (bool, error) {
+	var followed []model.FollowedPeople
+
+	qb := SELECT(table.FollowedPeople.AllColumns).
+		FROM(table.FollowedPeople).
+		WHERE(followedPersonCondition(userID, personID))
+
+	err := qb.QueryContext(ctx, r.DB, &followed)
+
+	return len(followed) > 0, err
+}
+
+// Follow follows the person, keeping when they were first followed if the
+// user already did.
+func (r *FollowedPeopleRepository) Follow(ctx context.Context, userID int32, personID int32) (model.FollowedPeople, error) {
+	var followed model.FollowedPeople
+
+	stmt := table.FollowedPeople.INSERT(
+		table.FollowedPeople.UserID,
+		table.FollowedPeople.PersonID,
+	).VALUES(userID, personID).
+		ON_CONFLICT(table.FollowedPeople.UserID, table.FollowedPeople.PersonID).
+		DO_UPDATE(SET(
+			table.FollowedPeople.FollowedAt.SET(table.FollowedPeople.FollowedAt),
+		)).
+		RETURNING(table.FollowedPeople.AllColumns)
+
+	err := stmt.QueryContext(ctx, r.DB, &followed)
+	return followed, err
+}
+
+func (r *FollowedPeopleRepository) Unfollow(ctx context.Context, userID int32, personID int32) error {
+	deleteStmt := table.FollowedPeople.DELETE().
+		WHERE(followedPersonCondition(userID, personID))
+
+	_, err := deleteStmt.ExecContext(ctx, r.DB)
+	return err
+}
+
+func followedPersonCondition(userID int32, personID int32) BoolExpression {
+	return table.FollowedPeople.UserID.EQ(Int32(userID)).AND(table.FollowedPeople.PersonID.EQ(Int32(personID)))
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+// CachedGenreRepository keeps the TMDB genre lists in the query cache,
+// served and refreshed the same way as in CachedMovieRepository.
+type CachedGenreRepository struct {
+	DB       *sql.DB
+	upstream IGenreRepository
+	ttl      time.Duration
+}
+
+func newCachedGenreRepository(params RepositoryParams, upstream IGenreRepository) *CachedGenreRepository {
+	return &CachedGenreRepository{
+		DB:       params.DB,
+		upstream: upstream,
+		ttl:      time.Duration(params.cfg.TMDB.CacheTTL) * time.Minute,
+	}
+}
+
+func (r *CachedGenreRepository) GetMovieGenres(ctx context.Context, locale dto.LocaleDTO) ([]dto.GenreDTO, error) {
+	return cachedQuery(ctx, r.DB, r.ttl, "genre/movie/list?"+genreQuery(locale).Encode(), func() ([]dto.GenreDTO, error) {
+		return r.upstream.GetMovieGenres(ctx, locale)
+	})
+}
@@ -0,0 +1,231 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/enum"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+type IListRepository interface {
+	FindByUser(ctx context.Context, userID int32, publicOnly bool) ([]model.Lists, error)
+	FindOne(ctx context.Context, id int32) (model.Lists, error)
+	Create(ctx context.Context, list model.Lists) (model.Lists, error)
+	Update(ctx context.Context, list model.Lists) (model.Lists, error)
+	Delete(ctx context.Context, id int32) error
+	GetEntries(ctx context.Context, listID int32) ([]model.ListEntries, error)
+	AddEntry(ctx context.Context, entry model.ListEntries) (model.ListEntries, error)
+	RemoveEntry(ctx context.Context, listID int32, movieID int32) error
+	ReorderEntries(ctx context.Context, listID int32, movieIDs []int32) error
+}
+
+type ListRepository struct {
+	DB *sql.DB
+}
+
+func newListRepository(params RepositoryParams) IListRepository {
+	return &ListRepository{
+		DB: params.DB,
+	}
+}
+
+func (r *ListRepository) FindByUser(ctx context.Context, userID int32, publicOnly bool) ([]model.Lists, error) {
+	condition := table.Lists.UserID.EQ(Int32(userID))
+	if publicOnly {
+		condition = condition.AND(table.Lists.Visibility.EQ(enum.ListVisibility.Public))
+	}
+
+	qb := SELECT(table.Lists.AllColumns).
+		FROM(table.Lists).
+		WHERE(condition).
+		ORDER_BY(table.Lists.UpdatedAt.DESC())
+
+	lists := make([]model.Lists, 0)
+	err := qb.QueryContext(ctx, r.DB, &lists)
+
+	return lists, err
+}
+
+func (r *ListRepository) FindOne(ctx context.Context, id int32) (model.Lists, error) {
+	var list model.Lists
+
+	qb := SELECT(table.Lists.AllColumns).
+		FROM(table.Lists).
+		WHERE(table.Lists.ID.EQ(Int32(id)))
+
+	err := qb.QueryContext(ctx, r.DB, &list)
+
+	return list, err
+}
+
+func (r *ListRepository) Create(ctx context.Context, list model.Lists) (model.Lists, error) {
+	var createdList model.Lists
+
+	insertStmt := table.Lists.INSERT(
+		table.Lists.UserID,
+		table.Lists.Name,
+		table.Lists.Description,
+		table.Lists.Visibility,
+	).MODEL(list).
+		RETURNING(table.Lists.AllColumns)
+
+	err := insertStmt.QueryContext(ctx, r.DB, &createdList)
+	return createdList, err
+}
+
+func (r *ListRepository) Update(ctx context.Context, list model.Lists) (model.Lists, error) {
+	var updatedList model.Lists
+
+	updateStmt := table.Lists.UPDATE().
+		SET(
+			table.Lists.Name.SET(String(list.Name)),
+			table.Lists.Description.SET(StringExp(nullableString(list.Description))),
+			table.Lists.Visibility.SET(NewEnumValue(list.Visibility.String())),
+			table.Lists.UpdatedAt.SET(LOCALTIMESTAMP()),
+		).
+		WHERE(table.Lists.ID.EQ(Int32(list.ID))).
+		RETURNING(table.Lists.AllColumns)
+
+	err := updateStmt.QueryContext(ctx, r.DB, &updatedList)
+	return updatedList, err
+}
+
+func (r *ListRepository) Delete(ctx context.Context, id int32) error {
+	deleteStmt := table.Lists.DELETE().
+		WHERE(table.Lists.ID.EQ(Int32(id)))
+
+	_, err := deleteStmt.ExecContext(ctx, r.DB)
+	return err
+}
+
+func (r *ListRepository) GetEntries(ctx context.Context, listID int32) ([]model.ListEntries, error) {
+	qb := SELECT(table.ListEntries.AllColumns).
+		FROM(table.ListEntries).
+		WHERE(table.ListEntries.ListID.EQ(Int32(listID))).
+		ORDER_BY(table.ListEntries.Position.ASC())
+
+	entries := make([]model.ListEntries, 0)
+	err := qb.QueryContext(ctx, r.DB, &entries)
+
+	return entries, err
+}
+
+// AddEntry appends the movie at the end of the list. The list row is locked
+// while the next position is computed, so concurrent appends to the same list
+// don't get the same position.
+func (r *ListRepository) AddEntry(ctx context.Context, entry model.ListEntries) (model.ListEntries, error) {
+	var createdEntry model.ListEntries
+
+	err := withTx(ctx, r.DB, func(tx *sql.Tx) error {
+		if err := r.lock(ctx, tx, entry.ListID); err != nil {
+			return err
+		}
+
+		nextPosition := SELECT(
+			Int32(entry.ListID),
+			Int32(entry.MovieID),
+			IntExp(COALESCE(MAXi(table.ListEntries.Position), Int32(0))).ADD(Int32(1)),
+			StringExp(nullableString(entry.Note)),
+		).FROM(table.ListEntries).
+			WHERE(table.ListEntries.ListID.EQ(Int32(entry.ListID)))
+
+		insertStmt := table.ListEntries.INSERT(
+			table.ListEntries.ListID,
+			table.ListEntries.MovieID,
+			table.ListEntries.Position,
+			table.ListEntries.Note,
+		).QUERY(nextPosition).
+			RETURNING(table.ListEntries.AllColumns)
+
+		if err := insertStmt.QueryContext(ctx, tx, &createdEntry); err != nil {
+			return err
+		}
+
+		return r.touch(ctx, tx, entry.ListID)
+	})
+
+	return createdEntry, err
+}
+
+// RemoveEntry deletes the movie from the list and closes the gap it leaves in the positions.
+func (r *ListRepository) RemoveEntry(ctx context.Context, listID int32, movieID int32) error {
+	return withTx(ctx, r.DB, func(tx *sql.Tx) error {
+		if err := r.lock(ctx, tx, listID); err != nil {
+			return err
+		}
+
+		var removed model.ListEntries
+
+		deleteStmt := table.ListEntries.DELETE().
+			WHERE(table.ListEntries.ListID.EQ(Int32(listID)).AND(table.ListEntries.MovieID.EQ(Int32(movieID)))).
+			RETURNING(table.ListEntries.AllColumns)
+
+		if err := deleteStmt.QueryContext(ctx, tx, &removed); err != nil {
+			return err
+		}
+
+		shiftStmt := table.ListEntries.UPDATE().
+			SET(table.ListEntries.Position.SET(table.ListEntries.Position.SUB(Int32(1)))).
+			WHERE(table.ListEntries.ListID.EQ(Int32(listID)).AND(table.ListEntries.Position.GT(Int32(removed.Position))))
+
+		if _, err := shiftStmt.ExecContext(ctx, tx); err != nil {
+			return err
+		}
+
+		return r.touch(ctx, tx, listID)
+	})
+}
+
+// ReorderEntries gives every movie the position of its index in movieIDs, starting at 1.
+func (r *ListRepository) ReorderEntries(ctx context.Context, listID int32, movieIDs []int32) error {
+	return withTx(ctx, r.DB, func(tx *sql.Tx) error {
+		if err := r.lock(ctx, tx, listID); err != nil {
+			return err
+		}
+
+		for i, movieID := range movieIDs {
+			updateStmt := table.ListEntries.UPDATE().
+				SET(table.ListEntries.Position.SET(Int32(int32(i + 1)))).
+				WHERE(table.ListEntries.ListID.EQ(Int32(listID)).AND(table.ListEntries.MovieID.EQ(Int32(movieID))))
+
+			if _, err := updateStmt.ExecContext(ctx, tx); err != nil {
+				return err
+			}
+		}
+
+		return r.touch(ctx, tx, listID)
+	})
+}
+
+// lock locks the list row until the end of the transaction, so changes to the
+// positions of its entries happen one at a time.
+func (r *ListRepository) lock(ctx context.Context, tx *sql.Tx, listID int32) error {
+	var list model.Lists
+
+	lockStmt := SELECT(table.Lists.ID).
+		FROM(table.Lists).
+		WHERE(table.Lists.ID.EQ(Int32(listID))).
+		FOR(UPDATE())
+
+	return lockStmt.QueryContext(ctx, tx, &list)
+}
+
+func (r *ListRepository) touch(ctx context.Context, db qrm.Executable, listID int32) error {
+	updateStmt := table.Lists.UPDATE().
+		SET(table.Lists.UpdatedAt.SET(LOCALTIMESTAMP())).
+		WHERE(table.Lists.ID.EQ(Int32(listID)))
+
+	_, err := updateStmt.ExecContext(ctx, db)
+	return err
+}
+
+func nullableString(value *string) Expression {
+	if value == nil {
+		return NULL
+	}
+	return String(*value)
+}
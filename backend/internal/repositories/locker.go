@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ILocker keeps work from running in more than one instance of the API at a
+// time.
+type ILocker interface {
+	// TryLock runs fn holding the lock of the key, unless another session
+	// already holds it. It reports whether fn ran.
+	TryLock(ctx context.Context, key int64, fn func(ctx context.Context)) (bool, error)
+}
+
+type Locker struct {
+	DB *sql.DB
+}
+
+func newLocker(params RepositoryParams) ILocker {
+	return &Locker{
+		DB: params.DB,
+	}
+}
+
+// TryLock takes a Postgres session advisory lock. The lock belongs to the
+// connection taking it, so it is held on a connection of its own until fn
+// returns.
+func (l *Locker) TryLock(ctx context.Context, key int64, fn func(ctx context.Context)) (bool, error) {
+	conn, err := l.DB.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", key)
+
+	fn(ctx)
+	return true, nil
+}
@@ -0,0 +1,200 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+// CachedMovieRepository keeps the TMDB payloads in the database so repeated
+// lookups don't hit the upstream API. Fresh entries are served directly,
+// expired entries are refreshed and, when the upstream fails, the stale
+// entry is served instead of the error.
+type CachedMovieRepository struct {
+	DB       *sql.DB
+	upstream IMovieRepository
+	ttl      time.Duration
+}
+
+func newCachedMovieRepository(params RepositoryParams, upstream IMovieRepository) *CachedMovieRepository {
+	return &CachedMovieRepository{
+		DB:       params.DB,
+		upstream: upstream,
+		ttl:      time.Duration(params.cfg.TMDB.CacheTTL) * time.Minute,
+	}
+}
+
+type cacheEntry struct {
+	Payload   string
+	FetchedAt time.Time
+}
+
+func (r *CachedMovieRepository) DiscoverMovies(ctx context.Context, page int, filters dto.MovieDiscoverFiltersDTO, locale dto.LocaleDTO, rules dto.ContentPolicyDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
+	return cachedQuery(ctx, r.DB, r.ttl, "discover?"+discoverQuery(page, filters, locale, rules).Encode(), func() (dto.Pagination[dto.TMDBMovieDTO], error) {
+		return r.upstream.DiscoverMovies(ctx, page, filters, locale, rules)
+	})
+}
+
+// GetByID serves the cached details of the movie. Entries cached before all
+// the details were appended are refreshed like expired ones.
+func (r *CachedMovieRepository) GetByID(ctx context.Context, id int) (dto.TMDBMovieDTO, error) {
+	return serveCached(r.ttl, r.findMovie(ctx, id), func() (dto.TMDBMovieDTO, error) {
+		return r.upstream.GetByID(ctx, id)
+	}, func(payload string) error {
+		return r.saveMovie(context.WithoutCancel(ctx), id, payload)
+	})
+}
+
+func (r *CachedMovieRepository) SearchMovies(ctx context.Context, query string, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
+	return cachedQuery(ctx, r.DB, r.ttl, "search?"+searchQuery(query, page, locale).Encode(), func() (dto.Pagination[dto.TMDBMovieDTO], error) {
+		return r.upstream.SearchMovies(ctx, query, page, locale)
+	})
+}
+
+func (r *CachedMovieRepository) GetSimilar(ctx context.Context, id int, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
+	return cachedQuery(ctx, r.DB, r.ttl, fmt.Sprintf("movie/%d/similar?%s", id, relatedQuery(page, locale).Encode()), func() (dto.Pagination[dto.TMDBMovieDTO], error) {
+		return r.upstream.GetSimilar(ctx, id, page, locale)
+	})
+}
+
+func (r *CachedMovieRepository) GetRecommendations(ctx context.Context, id int, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
+	return cachedQuery(ctx, r.DB, r.ttl, fmt.Sprintf("movie/%d/recommendations?%s", id, relatedQuery(page, locale).Encode()), func() (dto.Pagination[dto.TMDBMovieDTO], error) {
+		return r.upstream.GetRecommendations(ctx, id, page, locale)
+	})
+}
+
+// serveCached resolves a value from the cached entry (nil when missing) or
+// the upstream fetch, storing every successful fetch back into the cache.
+// Stores aren't cancelled with the request, so a payload already fetched is
+// kept even when the client went away. Values that can tell they are missing
+// details, like movies cached by an older version, are refreshed like expired
+// ones.
+func serveCached[T any](ttl time.Duration, entry *cacheEntry, fetch func() (T, error), store func(string) error) (T, error) {
+	var cached T
+
+	if entry != nil {
+		if err := json.Unmarshal([]byte(entry.Payload), &cached); err != nil {
+			slog.Warn("discarding unreadable cache entry", "error", err)
+			entry = nil
+		} else if time.Since(entry.FetchedAt) < ttl && hasAllDetails(cached) {
+			return cached, nil
+		}
+	}
+
+	value, err := fetch()
+	if err != nil {
+		if entry != nil {
+			slog.Warn("upstream failed, serving stale cache entry", "error", err, "fetched_at", entry.FetchedAt)
+			return cached, nil
+		}
+		return value, err
+	}
+
+	payload, err := json.Marshal(value)
+	if err == nil {
+		err = store(string(payload))
+	}
+	if err != nil {
+		slog.Warn("failed to update movie cache", "error", err)
+	}
+
+	return value, nil
+}
+
+func hasAllDetails(value any) bool {
+	if detailed, ok := value.(interface{ HasAllDetails() bool }); ok {
+		return detailed.HasAllDetails()
+	}
+	return true
+}
+
+// cachedQuery serves the value cached under the key in the query cache.
+func cachedQuery[T any](ctx context.Context, db *sql.DB, ttl time.Duration, key string, fetch func() (T, error)) (T, error) {
+	return serveCached(ttl, findCachedQuery(ctx, db, key), fetch, func(payload string) error {
+		return saveCachedQuery(context.WithoutCancel(ctx), db, key, payload)
+	})
+}
+
+func (r *CachedMovieRepository) findMovie(ctx context.Context, id int) *cacheEntry {
+	var movie model.Movies
+
+	qb := SELECT(table.Movies.AllColumns).
+		FROM(table.Movies).
+		WHERE(table.Movies.ID.EQ(Int32(int32(id))))
+
+	if err := qb.QueryContext(ctx, r.DB, &movie); err != nil {
+		logCacheLookupError(err)
+		return nil
+	}
+
+	return &cacheEntry{Payload: movie.Payload, FetchedAt: movie.FetchedAt}
+}
+
+func (r *CachedMovieRepository) saveMovie(ctx context.Context, id int, payload string) error {
+	movie := model.Movies{
+		ID:        int32(id),
+		Payload:   payload,
+		FetchedAt: time.Now(),
+	}
+
+	stmt := table.Movies.INSERT(table.Movies.AllColumns).
+		MODEL(movie).
+		ON_CONFLICT(table.Movies.ID).
+		DO_UPDATE(SET(
+			table.Movies.Payload.SET(table.Movies.EXCLUDED.Payload),
+			table.Movies.FetchedAt.SET(table.Movies.EXCLUDED.FetchedAt),
+		))
+
+	_, err := stmt.ExecContext(ctx, r.DB)
+	return err
+}
+
+func findCachedQuery(ctx context.Context, db *sql.DB, key string) *cacheEntry {
+	var query model.MovieQueries
+
+	qb := SELECT(table.MovieQueries.AllColumns).
+		FROM(table.MovieQueries).
+		WHERE(table.MovieQueries.Key.EQ(String(key)))
+
+	if err := qb.QueryContext(ctx, db, &query); err != nil {
+		logCacheLookupError(err)
+		return nil
+	}
+
+	return &cacheEntry{Payload: query.Payload, FetchedAt: query.FetchedAt}
+}
+
+func saveCachedQuery(ctx context.Context, db *sql.DB, key string, payload string) error {
+	query := model.MovieQueries{
+		Key:       key,
+		Payload:   payload,
+		FetchedAt: time.Now(),
+	}
+
+	stmt := table.MovieQueries.INSERT(table.MovieQueries.AllColumns).
+		MODEL(query).
+		ON_CONFLICT(table.MovieQueries.Key).
+		DO_UPDATE(SET(
+			table.MovieQueries.Payload.SET(table.MovieQueries.EXCLUDED.Payload),
+			table.MovieQueries.FetchedAt.SET(table.MovieQueries.EXCLUDED.FetchedAt),
+		))
+
+	_, err := stmt.ExecContext(ctx, db)
+	return err
+}
+
+func logCacheLookupError(err error) {
+	if !errors.Is(err, qrm.ErrNoRows) {
+		slog.Warn("movie cache lookup failed", "error", err)
+	}
+}
@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCacheTTL = time.Hour
+
+// cacheProbe records the upstream fetches and cache stores of a lookup.
+type cacheProbe struct {
+	fetches int
+	stored  []string
+	value   dto.TMDBMovieDTO
+	err     error
+}
+
+func (p *cacheProbe) fetch() (dto.TMDBMovieDTO, error) {
+	p.fetches++
+	return p.value, p.err
+}
+
+func (p *cacheProbe) store(payload string) error {
+	p.stored = append(p.stored, payload)
+	return nil
+}
+
+// detailedMovie returns a movie with every detail GetByID appends.
+func detailedMovie(id int, title string) dto.TMDBMovieDTO {
+	return dto.TMDBMovieDTO{
+		ID:             id,
+		Title:          title,
+		Translations:   &dto.TranslationsDTO{},
+		ReleaseDates:   &dto.ReleaseDatesDTO{},
+		Credits:        &dto.CreditsDTO{},
+		WatchProviders: &dto.WatchProvidersDTO{},
+		Videos:         &dto.VideosDTO{},
+	}
+}
+
+func cachedMovie(t *testing.T, movie dto.TMDBMovieDTO, age time.Duration) *cacheEntry {
+	payload, err := json.Marshal(movie)
+	assert.NoError(t, err)
+	return &cacheEntry{Payload: string(payload), FetchedAt: time.Now().Add(-age)}
+}
+
+func TestServeCached_Hit(t *testing.T) {
+	probe := &cacheProbe{value: detailedMovie(1, "Upstream")}
+
+	movie, err := serveCached(testCacheTTL, cachedMovie(t, detailedMovie(1, "Cached"), time.Minute), probe.fetch, probe.store)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Cached", movie.Title)
+	assert.Zero(t, probe.fetches)
+	assert.Empty(t, probe.stored)
+}
+
+func TestServeCached_Miss(t *testing.T) {
+	probe := &cacheProbe{value: detailedMovie(1, "Upstream")}
+
+	movie, err := serveCached(testCacheTTL, nil, probe.fetch, probe.store)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Upstream", movie.Title)
+	assert.Equal(t, 1, probe.fetches)
+	if assert.Len(t, probe.stored, 1) {
+		assert.JSONEq(t, cachedMovie(t, probe.value, 0).Payload, probe.stored[0])
+	}
+}
+
+func TestServeCached_MissUpstreamError(t *testing.T) {
+	probe := &cacheProbe{err: errors.New("upstream down")}
+
+	_, err := serveCached(testCacheTTL, nil, probe.fetch, probe.store)
+
+	assert.EqualError(t, err, "upstream down")
+	assert.Empty(t, probe.stored)
+}
+
+func TestServeCached_MissingDetails(t *testing.T) {
+	probe := &cacheProbe{value: detailedMovie(1, "Upstream")}
+	outdated := dto.TMDBMovieDTO{ID: 1, Title: "Cached", Translations: &dto.TranslationsDTO{}, ReleaseDates: &dto.ReleaseDatesDTO{}}
+
+	movie, err := serveCached(testCacheTTL, cachedMovie(t, outdated, time.Minute), probe.fetch, probe.store)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Upstream", movie.Title)
+	assert.NotNil(t, movie.WatchProviders)
+	assert.Len(t, probe.stored, 1)
+
+	// Still better than an error when TMDB is down
+	probe = &cacheProbe{err: errors.New("upstream down")}
+	movie, err = serveCached(testCacheTTL, cachedMovie(t, outdated, time.Minute), probe.fetch, probe.store)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Cached", movie.Title)
+}
+
+func TestServeCached_Expired(t *testing.T) {
+	probe := &cacheProbe{value: detailedMovie(1, "Upstream")}
+
+	movie, err := serveCached(testCacheTTL, cachedMovie(t, detailedMovie(1, "Cached"), 2*testCacheTTL), probe.fetch, probe.store)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Upstream", movie.Title)
+	assert.Equal(t, 1, probe.fetches)
+	assert.Len(t, probe.stored, 1)
+}
+
+func TestServeCached_ExpiredUpstreamError(t *testing.T) {
+	probe := &cacheProbe{err: errors.New("upstream down")}
+
+	movie, err := serveCached(testCacheTTL, cachedMovie(t, detailedMovie(1, "Cached"), 2*testCacheTTL), probe.fetch, probe.store)
+
+	// The stale entry beats an error
+	assert.NoError(t, err)
+	assert.Equal(t, "Cached", movie.Title)
+	assert.Empty(t, probe.stored)
+}
+
+func TestServeCached_UnreadableEntry(t *testing.T) {
+	probe := &cacheProbe{value: detailedMovie(1, "Upstream")}
+
+	movie, err := serveCached(testCacheTTL, &cacheEntry{Payload: "{", FetchedAt: time.Now()}, probe.fetch, probe.store)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Upstream", movie.Title)
+	assert.Len(t, probe.stored, 1)
+}
+
+func TestServeCached_StoreErrorIsNotFatal(t *testing.T) {
+	movie, err := serveCached(testCacheTTL, nil, func() (dto.TMDBMovieDTO, error) {
+		return detailedMovie(1, "Upstream"), nil
+	}, func(string) error {
+		return errors.New("database down")
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Upstream", movie.Title)
+}
+
+func TestCachedQueryKeys(t *testing.T) {
+	ptBR := dto.LocaleDTO{Language: "pt-BR", Region: "BR"}
+	key := func(page int, filters dto.MovieDiscoverFiltersDTO, locale dto.LocaleDTO) string {
+		return discoverQuery(page, filters, locale, dto.ContentPolicyDTO{}).Encode()
+	}
+
+	// Equal requests share an entry whatever the order the filters were given in
+	assert.Equal(t, key(1, dto.MovieDiscoverFiltersDTO{WithoutGenres: []int{27, 16}}, ptBR), key(1, dto.MovieDiscoverFiltersDTO{WithoutGenres: []int{16, 27}}, ptBR))
+	assert.NotEqual(t, key(1, dto.MovieDiscoverFiltersDTO{}, ptBR), key(2, dto.MovieDiscoverFiltersDTO{}, ptBR))
+	assert.NotEqual(t, key(1, dto.MovieDiscoverFiltersDTO{}, ptBR), key(1, dto.MovieDiscoverFiltersDTO{}, dto.LocaleDTO{}))
+	assert.NotEqual(t, key(1, dto.MovieDiscoverFiltersDTO{}, ptBR), key(1, dto.MovieDiscoverFiltersDTO{WithGenres: []int{18}}, ptBR))
+
+	assert.NotEqual(t, searchQuery("alien", 1, ptBR).Encode(), searchQuery("alien", 2, ptBR).Encode())
+	assert.NotEqual(t, searchQuery("alien", 1, ptBR).Encode(), searchQuery("alien", 1, dto.LocaleDTO{}).Encode())
+	assert.NotEqual(t, relatedQuery(1, ptBR).Encode(), relatedQuery(1, dto.LocaleDTO{Language: "en-US"}).Encode())
+}
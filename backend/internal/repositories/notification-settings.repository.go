@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+// INotificationSettingsRepository stores where each user wants their
+// notifications delivered, besides the inbox.
+type INotificationSettingsRepository interface {
+	FindByUser(ctx context.Context, userID int32) (model.NotificationSettings, error)
+	Save(ctx context.Context, settings model.NotificationSettings) (model.NotificationSettings, error)
+}
+
+type NotificationSettingsRepository struct {
+	DB *sql.DB
+}
+
+func newNotificationSettingsRepository(params RepositoryParams) INotificationSettingsRepository {
+	return &NotificationSettingsRepository{
+		DB: params.DB,
+	}
+}
+
+func (r *NotificationSettingsRepository) FindByUser(ctx context.Context, userID int32) (model.NotificationSettings, error) {
+	var settings model.NotificationSettings
+
+	qb := SELECT(table.NotificationSettings.AllColumns).
+		FROM(table.NotificationSettings).
+		WHERE(table.NotificationSettings.UserID.EQ(Int32(userID)))
+
+	err := qb.QueryContext(ctx, r.DB, &settings)
+	return settings, err
+}
+
+// Save sets up the delivery channels of the user, replacing the previous ones.
+func (r *NotificationSettingsRepository) Save(ctx context.Context, settings model.NotificationSettings) (model.NotificationSettings, error) {
+	var savedSettings model.NotificationSettings
+
+	settings.UpdatedAt = time.Now()
+
+	stmt := table.NotificationSettings.INSERT(table.NotificationSettings.AllColumns).
+		MODEL(settings).
+		ON_CONFLICT(table.NotificationSettings.UserID).
+		DO_UPDATE(SET(
+			table.NotificationSettings.Email.SET(table.NotificationSettings.EXCLUDED.Email),
+			table.NotificationSettings.WebhookURL.SET(table.NotificationSettings.EXCLUDED.WebhookURL),
+			table.NotificationSettings.UpdatedAt.SET(table.NotificationSettings.EXCLUDED.UpdatedAt),
+		)).
+		RETURNING(table.NotificationSettings.AllColumns)
+
+	err := stmt.QueryContext(ctx, r.DB, &savedSettings)
+	return savedSettings, err
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+// INotificationRepository stores the inbox of every user. The key of a
+// notification is unique per user, so the same event is never notified twice.
+type INotificationRepository interface {
+	FindByUser(ctx context.Context, userID int32, query dto.NotificationQueryDTO) ([]model.Notifications, int64, error)
+	CountUnread(ctx context.Context, userID int32) (int64, error)
+	Create(ctx context.Context, notification model.Notifications) (model.Notifications, error)
+	MarkRead(ctx context.Context, userID int32, id int32) (model.Notifications, error)
+	MarkAllRead(ctx context.Context, userID int32) error
+}
+
+type NotificationRepository struct {
+	DB *sql.DB
+}
+
+func newNotificationRepository(params RepositoryParams) INotificationRepository {
+	return &NotificationRepository{
+		DB: params.DB,
+	}
+}
+
+// FindByUser returns a page of the user's notifications, the newest first,
+// along with the total number of them.
+func (r *NotificationRepository) FindByUser(ctx context.Context, userID int32, query dto.NotificationQueryDTO) ([]model.Notifications, int64, error) {
+	var total struct {
+		Count int64
+	}
+
+	condition := table.Notifications.UserID.EQ(Int32(userID))
+	if query.Unread {
+		condition = condition.AND(table.Notifications.ReadAt.IS_NULL())
+	}
+
+	countStmt := SELECT(COUNT(STAR).AS("count")).
+		FROM(table.Notifications).
+		WHERE(condition)
+
+	if err := countStmt.QueryContext(ctx, r.DB, &total); err != nil {
+		return nil, 0, err
+	}
+
+	qb := SELECT(table.Notifications.AllColumns).
+		FROM(table.Notifications).
+		WHERE(condition).
+		ORDER_BY(table.Notifications.CreatedAt.DESC(), table.Notifications.ID.DESC()).
+		LIMIT(int64(query.PageSize)).
+		OFFSET(int64((query.Page - 1) * query.PageSize))
+
+	notifications := make([]model.Notifications, 0)
+	err := qb.QueryContext(ctx, r.DB, &notifications)
+
+	return notifications, total.Count, err
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID int32) (int64, error) {
+	var unread struct {
+		Count int64
+	}
+
+	countStmt := SELECT(COUNT(STAR).AS("count")).
+		FROM(table.Notifications).
+		WHERE(table.Notifications.UserID.EQ(Int32(userID)).AND(table.Notifications.ReadAt.IS_NULL()))
+
+	err := countStmt.QueryContext(ctx, r.DB, &unread)
+	return unread.Count, err
+}
+
+// Create adds the notification to the inbox. When the user already got one
+// with the same key, nothing is added and qrm.ErrNoRows is returned.
+func (r *NotificationRepository) Create(ctx context.Context, notification model.Notifications) (model.Notifications, error) {
+	var createdNotification model.Notifications
+
+	insertStmt := table.Notifications.INSERT(
+		table.Notifications.UserID,
+		table.Notifications.Type,
+		table.Notifications.Key,
+		table.Notifications.MovieID,
+		table.Notifications.ActorID,
+		table.Notifications.Title,
+		table.Notifications.Body,
+	).MODEL(notification).
+		ON_CONFLICT(table.Notifications.UserID, table.Notifications.Key).
+		DO_NOTHING().
+		RETURNING(table.Notifications.AllColumns)
+
+	err := insertStmt.QueryContext(ctx, r.DB, &createdNotification)
+	return createdNotification, err
+}
+
+// MarkRead marks a notification of the user as read, keeping when it was
+// first read.
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID int32, id int32) (model.Notifications, error) {
+	var notification model.Notifications
+
+	updateStmt := table.Notifications.UPDATE().
+		SET(table.Notifications.ReadAt.SET(
+			TimestampExp(COALESCE(table.Notifications.ReadAt, LOCALTIMESTAMP())),
+		)).
+		WHERE(table.Notifications.ID.EQ(Int32(id)).AND(table.Notifications.UserID.EQ(Int32(userID)))).
+		RETURNING(table.Notifications.AllColumns)
+
+	err := updateStmt.QueryContext(ctx, r.DB, &notification)
+	return notification, err
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID int32) error {
+	updateStmt := table.Notifications.UPDATE().
+		SET(table.Notifications.ReadAt.SET(LOCALTIMESTAMP())).
+		WHERE(table.Notifications.UserID.EQ(Int32(userID)).AND(table.Notifications.ReadAt.IS_NULL()))
+
+	_, err := updateStmt.ExecContext(ctx, r.DB)
+	return err
+}
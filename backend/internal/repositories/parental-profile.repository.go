@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+type IParentalProfileRepository interface {
+	FindByUser(ctx context.Context, userID int32) (model.ParentalProfiles, error)
+	Save(ctx context.Context, profile model.ParentalProfiles) (model.ParentalProfiles, error)
+	Delete(ctx context.Context, userID int32) error
+}
+
+type ParentalProfileRepository struct {
+	DB *sql.DB
+}
+
+func newParentalProfileRepository(params RepositoryParams) IParentalProfileRepository {
+	return &ParentalProfileRepository{
+		DB: params.DB,
+	}
+}
+
+func (r *ParentalProfileRepository) FindByUser(ctx context.Context, userID int32) (model.ParentalProfiles, error) {
+	var profile model.ParentalProfiles
+
+	qb := SELECT(table.ParentalProfiles.AllColumns).
+		FROM(table.ParentalProfiles).
+		WHERE(table.ParentalProfiles.UserID.EQ(Int32(userID)))
+
+	err := qb.QueryContext(ctx, r.DB, &profile)
+	return profile, err
+}
+
+// Save creates the profile of the user or replaces the existing one.
+func (r *ParentalProfileRepository) Save(ctx context.Context, profile model.ParentalProfiles) (model.ParentalProfiles, error) {
+	var savedProfile model.ParentalProfiles
+
+	profile.UpdatedAt = time.Now()
+
+	stmt := table.ParentalProfiles.INSERT(table.ParentalProfiles.AllColumns).
+		MODEL(profile).
+		ON_CONFLICT(table.ParentalProfiles.UserID).
+		DO_UPDATE(SET(
+			table.ParentalProfiles.Rules.SET(table.ParentalProfiles.EXCLUDED.Rules),
+			table.ParentalProfiles.UpdatedAt.SET(table.ParentalProfiles.EXCLUDED.UpdatedAt),
+		)).
+		RETURNING(table.ParentalProfiles.AllColumns)
+
+	err := stmt.QueryContext(ctx, r.DB, &savedProfile)
+	return savedProfile, err
+}
+
+func (r *ParentalProfileRepository) Delete(ctx context.Context, userID int32) error {
+	deleteStmt := table.ParentalProfiles.DELETE().
+		WHERE(table.ParentalProfiles.UserID.EQ(Int32(userID)))
+
+	_, err := deleteStmt.ExecContext(ctx, r.DB)
+	return err
+}
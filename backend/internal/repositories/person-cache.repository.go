@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+// CachedPersonRepository keeps the TMDB person payloads in the query cache,
+// served and refreshed the same way as in CachedMovieRepository.
+type CachedPersonRepository struct {
+	DB       *sql.DB
+	upstream IPersonRepository
+	ttl      time.Duration
+}
+
+func newCachedPersonRepository(params RepositoryParams, upstream IPersonRepository) *CachedPersonRepository {
+	return &CachedPersonRepository{
+		DB:       params.DB,
+		upstream: upstream,
+		ttl:      time.Duration(params.cfg.TMDB.CacheTTL) * time.Minute,
+	}
+}
+
+func (r *CachedPersonRepository) GetPerson(ctx context.Context, id int) (dto.TMDBPersonDTO, error) {
+	return cachedQuery(ctx, r.DB, r.ttl, fmt.Sprintf("person/%d", id), func() (dto.TMDBPersonDTO, error) {
+		return r.upstream.GetPerson(ctx, id)
+	})
+}
+
+func (r *CachedPersonRepository) GetMovieCredits(ctx context.Context, id int, locale dto.LocaleDTO) (dto.TMDBPersonMovieCreditsDTO, error) {
+	key := fmt.Sprintf("person/%d/movie_credits?language=%s", id, locale.Language)
+
+	return cachedQuery(ctx, r.DB, r.ttl, key, func() (dto.TMDBPersonMovieCreditsDTO, error) {
+		return r.upstream.GetMovieCredits(ctx, id, locale)
+	})
+}
@@ -5,17 +5,38 @@ import (
 
 	"github.com/movie-tracker/MovieTracker/internal/config"
 	"github.com/movie-tracker/MovieTracker/internal/connections"
+	"github.com/movie-tracker/MovieTracker/internal/tmdb"
 )
 
 type RepositoryParams struct {
-	DB  *sql.DB
-	cfg config.ApiConfig
+	DB   *sql.DB
+	TMDB *tmdb.Client
+	cfg  config.ApiConfig
 }
 
 type Repositories struct {
-	UserRepo      IUserRepository
-	MovieRepo     IMovieRepository
-	WatchListRepo IWatchListRepository
+	Transactor          ITransactor
+	Locker              ILocker
+	UserRepo            IUserRepository
+	MovieRepo           IMovieRepository
+	ShowRepo            IShowRepository
+	WatchListRepo       IWatchListRepository
+	DiaryRepo           IDiaryRepository
+	ListRepo            IListRepository
+	SessionRepo         ISessionRepository
+	UserTokenRepo       IUserTokenRepository
+	ParentalProfileRepo IParentalProfileRepository
+	ShowProgressRepo    IShowProgressRepository
+	PersonRepo          IPersonRepository
+	FollowedPeopleRepo  IFollowedPeopleRepository
+	GenreRepo           IGenreRepository
+	WatchProviderRepo   IWatchProviderRepository
+	SubscriptionRepo    IStreamingSubscriptionRepository
+	CalendarFeedRepo    ICalendarFeedRepository
+	UserFollowRepo      IUserFollowRepository
+	NotificationRepo    INotificationRepository
+	NotifySettingsRepo  INotificationSettingsRepository
+	AvailabilityRepo    IWatchlistAvailabilityRepository
 }
 
 var gRepositories Repositories
@@ -24,13 +45,35 @@ func InitRepositories(cfg config.ApiConfig, conns connections.Connections) Repos
 	gRepositories = Repositories{}
 
 	params := RepositoryParams{
-		DB:  conns.DB,
-		cfg: cfg,
+		DB:   conns.DB,
+		TMDB: conns.TMDB,
+		cfg:  cfg,
 	}
 
+	tmdbRepo := newTMDBRepository(params)
+
+	gRepositories.Transactor = newTransactor(params)
+	gRepositories.Locker = newLocker(params)
 	gRepositories.UserRepo = newUserRepository(params)
-	gRepositories.MovieRepo = newTMDBRepository(params)
+	gRepositories.MovieRepo = newCachedMovieRepository(params, tmdbRepo)
+	gRepositories.ShowRepo = newCachedShowRepository(params, tmdbRepo)
 	gRepositories.WatchListRepo = newWatchListRepository(params)
+	gRepositories.DiaryRepo = newDiaryRepository(params)
+	gRepositories.ListRepo = newListRepository(params)
+	gRepositories.SessionRepo = newSessionRepository(params)
+	gRepositories.UserTokenRepo = newUserTokenRepository(params)
+	gRepositories.ParentalProfileRepo = newParentalProfileRepository(params)
+	gRepositories.ShowProgressRepo = newShowProgressRepository(params)
+	gRepositories.PersonRepo = newCachedPersonRepository(params, tmdbRepo)
+	gRepositories.FollowedPeopleRepo = newFollowedPeopleRepository(params)
+	gRepositories.GenreRepo = newCachedGenreRepository(params, tmdbRepo)
+	gRepositories.WatchProviderRepo = newCachedWatchProviderRepository(params, tmdbRepo)
+	gRepositories.SubscriptionRepo = newStreamingSubscriptionRepository(params)
+	gRepositories.CalendarFeedRepo = newCalendarFeedRepository(params)
+	gRepositories.UserFollowRepo = newUserFollowRepository(params)
+	gRepositories.NotificationRepo = newNotificationRepository(params)
+	gRepositories.NotifySettingsRepo = newNotificationSettingsRepository(params)
+	gRepositories.AvailabilityRepo = newWatchlistAvailabilityRepository(params)
 
 	return gRepositories
 }
@@ -0,0 +1,132 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+type ISessionRepository interface {
+	FindOne(ctx context.Context, id int32) (model.Sessions, error)
+	FindActiveByUser(ctx context.Context, userID int32) ([]model.Sessions, error)
+	Create(ctx context.Context, session model.Sessions) (model.Sessions, error)
+	Rotate(ctx context.Context, tokenHash string, newTokenHash string, expiresAt time.Time) (model.Sessions, error)
+	RevokeByPreviousToken(ctx context.Context, tokenHash string) (model.Sessions, error)
+	Revoke(ctx context.Context, userID int32, id int32) (model.Sessions, error)
+	RevokeAllByUser(ctx context.Context, userID int32) error
+}
+
+type SessionRepository struct {
+	DB *sql.DB
+}
+
+func newSessionRepository(params RepositoryParams) ISessionRepository {
+	return &SessionRepository{
+		DB: params.DB,
+	}
+}
+
+func (r *SessionRepository) FindOne(ctx context.Context, id int32) (model.Sessions, error) {
+	var session model.Sessions
+
+	qb := SELECT(table.Sessions.AllColumns).
+		FROM(table.Sessions).
+		WHERE(table.Sessions.ID.EQ(Int32(id)))
+
+	err := qb.QueryContext(ctx, r.DB, &session)
+	return session, err
+}
+
+func (r *SessionRepository) FindActiveByUser(ctx context.Context, userID int32) ([]model.Sessions, error) {
+	qb := SELECT(table.Sessions.AllColumns).
+		FROM(table.Sessions).
+		WHERE(table.Sessions.UserID.EQ(Int32(userID)).AND(sessionIsActive())).
+		ORDER_BY(table.Sessions.LastUsedAt.DESC())
+
+	sessions := make([]model.Sessions, 0)
+	err := qb.QueryContext(ctx, r.DB, &sessions)
+
+	return sessions, err
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session model.Sessions) (model.Sessions, error) {
+	var createdSession model.Sessions
+
+	insertStmt := table.Sessions.INSERT(
+		table.Sessions.UserID,
+		table.Sessions.TokenHash,
+		table.Sessions.UserAgent,
+		table.Sessions.IpAddress,
+		table.Sessions.ExpiresAt,
+	).MODEL(session).
+		RETURNING(table.Sessions.AllColumns)
+
+	err := insertStmt.QueryContext(ctx, r.DB, &createdSession)
+	return createdSession, err
+}
+
+// Rotate replaces the refresh token of an active session in a single
+// statement, so a token can only ever be exchanged once.
+func (r *SessionRepository) Rotate(ctx context.Context, tokenHash string, newTokenHash string, expiresAt time.Time) (model.Sessions, error) {
+	var session model.Sessions
+
+	updateStmt := table.Sessions.UPDATE().
+		SET(
+			table.Sessions.PreviousTokenHash.SET(table.Sessions.TokenHash),
+			table.Sessions.TokenHash.SET(String(newTokenHash)),
+			table.Sessions.LastUsedAt.SET(LOCALTIMESTAMP()),
+			table.Sessions.ExpiresAt.SET(TimestampT(expiresAt)),
+		).
+		WHERE(table.Sessions.TokenHash.EQ(String(tokenHash)).AND(sessionIsActive())).
+		RETURNING(table.Sessions.AllColumns)
+
+	err := updateStmt.QueryContext(ctx, r.DB, &session)
+	return session, err
+}
+
+// RevokeByPreviousToken revokes the session a refresh token was already
+// rotated out of. Seeing such a token again means it was copied.
+func (r *SessionRepository) RevokeByPreviousToken(ctx context.Context, tokenHash string) (model.Sessions, error) {
+	var session model.Sessions
+
+	updateStmt := table.Sessions.UPDATE().
+		SET(table.Sessions.RevokedAt.SET(LOCALTIMESTAMP())).
+		WHERE(table.Sessions.PreviousTokenHash.EQ(String(tokenHash)).AND(table.Sessions.RevokedAt.IS_NULL())).
+		RETURNING(table.Sessions.AllColumns)
+
+	err := updateStmt.QueryContext(ctx, r.DB, &session)
+	return session, err
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, userID int32, id int32) (model.Sessions, error) {
+	var session model.Sessions
+
+	updateStmt := table.Sessions.UPDATE().
+		SET(table.Sessions.RevokedAt.SET(LOCALTIMESTAMP())).
+		WHERE(
+			table.Sessions.ID.EQ(Int32(id)).
+				AND(table.Sessions.UserID.EQ(Int32(userID))).
+				AND(table.Sessions.RevokedAt.IS_NULL()),
+		).
+		RETURNING(table.Sessions.AllColumns)
+
+	err := updateStmt.QueryContext(ctx, r.DB, &session)
+	return session, err
+}
+
+func (r *SessionRepository) RevokeAllByUser(ctx context.Context, userID int32) error {
+	updateStmt := table.Sessions.UPDATE().
+		SET(table.Sessions.RevokedAt.SET(LOCALTIMESTAMP())).
+		WHERE(table.Sessions.UserID.EQ(Int32(userID)).AND(table.Sessions.RevokedAt.IS_NULL()))
+
+	_, err := updateStmt.ExecContext(ctx, r.DB)
+	return err
+}
+
+func sessionIsActive() BoolExpression {
+	return table.Sessions.RevokedAt.IS_NULL().AND(table.Sessions.ExpiresAt.GT(LOCALTIMESTAMP()))
+}
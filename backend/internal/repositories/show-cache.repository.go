@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+// CachedShowRepository keeps the TMDB TV payloads in the query cache shared
+// with movies, served and refreshed the same way as in CachedMovieRepository.
+type CachedShowRepository struct {
+	DB       *sql.DB
+	upstream IShowRepository
+	ttl      time.Duration
+}
+
+func newCachedShowRepository(params RepositoryParams, upstream IShowRepository) *CachedShowRepository {
+	return &CachedShowRepository{
+		DB:       params.DB,
+		upstream: upstream,
+		ttl:      time.Duration(params.cfg.TMDB.CacheTTL) * time.Minute,
+	}
+}
+
+func (r *CachedShowRepository) DiscoverShows(ctx context.Context, page int, locale dto.LocaleDTO, rules dto.ContentPolicyDTO) (dto.Pagination[dto.TMDBShowDTO], error) {
+	return cachedQuery(ctx, r.DB, r.ttl, "discover/tv?"+discoverShowsQuery(page, locale, rules).Encode(), func() (dto.Pagination[dto.TMDBShowDTO], error) {
+		return r.upstream.DiscoverShows(ctx, page, locale, rules)
+	})
+}
+
+func (r *CachedShowRepository) SearchShows(ctx context.Context, query string, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBShowDTO], error) {
+	return cachedQuery(ctx, r.DB, r.ttl, "search/tv?"+searchQuery(query, page, locale).Encode(), func() (dto.Pagination[dto.TMDBShowDTO], error) {
+		return r.upstream.SearchShows(ctx, query, page, locale)
+	})
+}
+
+func (r *CachedShowRepository) GetShow(ctx context.Context, id int) (dto.TMDBShowDTO, error) {
+	return cachedQuery(ctx, r.DB, r.ttl, fmt.Sprintf("tv/%d", id), func() (dto.TMDBShowDTO, error) {
+		return r.upstream.GetShow(ctx, id)
+	})
+}
+
+func (r *CachedShowRepository) GetSeason(ctx context.Context, showID int, seasonNumber int, locale dto.LocaleDTO) (dto.TMDBSeasonDTO, error) {
+	key := fmt.Sprintf("tv/%d/season/%d?language=%s", showID, seasonNumber, locale.Language)
+
+	return cachedQuery(ctx, r.DB, r.ttl, key, func() (dto.TMDBSeasonDTO, error) {
+		return r.upstream.GetSeason(ctx, showID, seasonNumber, locale)
+	})
+}
+
+func (r *CachedShowRepository) GetEpisode(ctx context.Context, showID int, seasonNumber int, episodeNumber int, locale dto.LocaleDTO) (dto.TMDBEpisodeDTO, error) {
+	key := fmt.Sprintf("tv/%d/season/%d/episode/%d?language=%s", showID, seasonNumber, episodeNumber, locale.Language)
+
+	return cachedQuery(ctx, r.DB, r.ttl, key, func() (dto.TMDBEpisodeDTO, error) {
+		return r.upstream.GetEpisode(ctx, showID, seasonNumber, episodeNumber, locale)
+	})
+}
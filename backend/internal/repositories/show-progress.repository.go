@@ -0,0 +1,172 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+// IShowProgressRepository stores the shows each user tracks and the episodes
+// they watched. Episodes can only be recorded for tracked shows, and are
+// dropped along with them.
+type IShowProgressRepository interface {
+	FindByUser(ctx context.Context, userID int32, statuses []model.WatchStatus) ([]model.ShowProgress, error)
+	FindOne(ctx context.Context, userID int32, showID int32) (model.ShowProgress, error)
+	Save(ctx context.Context, progress model.ShowProgress) (model.ShowProgress, error)
+	Delete(ctx context.Context, userID int32, showID int32) error
+	GetWatchedEpisodes(ctx context.Context, userID int32, showIDs []int32) ([]model.WatchedEpisodes, error)
+	MarkWatched(ctx context.Context, episodes []model.WatchedEpisodes) error
+	UnmarkWatched(ctx context.Context, userID int32, showID int32, seasonNumber int32, episodeNumber int32) error
+}
+
+type ShowProgressRepository struct {
+	DB *sql.DB
+}
+
+func newShowProgressRepository(params RepositoryParams) IShowProgressRepository {
+	return &ShowProgressRepository{
+		DB: params.DB,
+	}
+}
+
+// FindByUser lists the tracked shows, the most recently updated first,
+// optionally only those with one of the statuses.
+func (r *ShowProgressRepository) FindByUser(ctx context.Context, userID int32, statuses []model.WatchStatus) ([]model.ShowProgress, error) {
+	condition := table.ShowProgress.UserID.EQ(Int32(userID))
+	if len(statuses) > 0 {
+		values := make([]Expression, len(statuses))
+		for i, status := range statuses {
+			values[i] = NewEnumValue(status.String())
+		}
+		condition = condition.AND(table.ShowProgress.Status.IN(values...))
+	}
+
+	qb := SELECT(table.ShowProgress.AllColumns).
+		FROM(table.ShowProgress).
+		WHERE(condition).
+		ORDER_BY(table.ShowProgress.UpdatedAt.DESC(), table.ShowProgress.ShowID.ASC())
+
+	progress := make([]model.ShowProgress, 0)
+	err := qb.QueryContext(ctx, conn(ctx, r.DB), &progress)
+
+	return progress, err
+}
+
+func (r *ShowProgressRepository) FindOne(ctx context.Context, userID int32, showID int32) (model.ShowProgress, error) {
+	var progress model.ShowProgress
+
+	qb := SELECT(table.ShowProgress.AllColumns).
+		FROM(table.ShowProgress).
+		WHERE(showProgressCondition(userID, showID))
+
+	err := qb.QueryContext(ctx, conn(ctx, r.DB), &progress)
+
+	return progress, err
+}
+
+// Save starts tracking the show or updates its tracking, bumping updated_at,
+// and status_changed_at when the status changes.
+func (r *ShowProgressRepository) Save(ctx context.Context, progress model.ShowProgress) (model.ShowProgress, error) {
+	var saved model.ShowProgress
+
+	stmt := table.ShowProgress.INSERT(
+		table.ShowProgress.ShowID,
+		table.ShowProgress.UserID,
+		table.ShowProgress.Status,
+		table.ShowProgress.Favorite,
+		table.ShowProgress.Rating,
+	).MODEL(progress).
+		ON_CONFLICT(table.ShowProgress.ShowID, table.ShowProgress.UserID).
+		DO_UPDATE(SET(
+			table.ShowProgress.Status.SET(table.ShowProgress.EXCLUDED.Status),
+			table.ShowProgress.Favorite.SET(table.ShowProgress.EXCLUDED.Favorite),
+			table.ShowProgress.Rating.SET(table.ShowProgress.EXCLUDED.Rating),
+			table.ShowProgress.UpdatedAt.SET(LOCALTIMESTAMP()),
+			table.ShowProgress.StatusChangedAt.SET(TimestampExp(
+				CASE().
+					WHEN(table.ShowProgress.Status.NOT_EQ(table.ShowProgress.EXCLUDED.Status)).THEN(LOCALTIMESTAMP()).
+					ELSE(table.ShowProgress.StatusChangedAt),
+			)),
+		)).
+		RETURNING(table.ShowProgress.AllColumns)
+
+	err := stmt.QueryContext(ctx, conn(ctx, r.DB), &saved)
+	return saved, err
+}
+
+func (r *ShowProgressRepository) Delete(ctx context.Context, userID int32, showID int32) error {
+	deleteStmt := table.ShowProgress.DELETE().
+		WHERE(showProgressCondition(userID, showID))
+
+	_, err := deleteStmt.ExecContext(ctx, conn(ctx, r.DB))
+	return err
+}
+
+func (r *ShowProgressRepository) GetWatchedEpisodes(ctx context.Context, userID int32, showIDs []int32) ([]model.WatchedEpisodes, error) {
+	episodes := make([]model.WatchedEpisodes, 0)
+	if len(showIDs) == 0 {
+		return episodes, nil
+	}
+
+	ids := make([]Expression, len(showIDs))
+	for i, id := range showIDs {
+		ids[i] = Int32(id)
+	}
+
+	qb := SELECT(table.WatchedEpisodes.AllColumns).
+		FROM(table.WatchedEpisodes).
+		WHERE(table.WatchedEpisodes.UserID.EQ(Int32(userID)).AND(table.WatchedEpisodes.ShowID.IN(ids...))).
+		ORDER_BY(
+			table.WatchedEpisodes.ShowID.ASC(),
+			table.WatchedEpisodes.SeasonNumber.ASC(),
+			table.WatchedEpisodes.EpisodeNumber.ASC(),
+		)
+
+	err := qb.QueryContext(ctx, conn(ctx, r.DB), &episodes)
+
+	return episodes, err
+}
+
+// MarkWatched records the episodes, keeping when the ones already recorded
+// were first watched.
+func (r *ShowProgressRepository) MarkWatched(ctx context.Context, episodes []model.WatchedEpisodes) error {
+	if len(episodes) == 0 {
+		return nil
+	}
+
+	stmt := table.WatchedEpisodes.INSERT(
+		table.WatchedEpisodes.ShowID,
+		table.WatchedEpisodes.UserID,
+		table.WatchedEpisodes.SeasonNumber,
+		table.WatchedEpisodes.EpisodeNumber,
+	).MODELS(episodes).
+		ON_CONFLICT(
+			table.WatchedEpisodes.ShowID,
+			table.WatchedEpisodes.UserID,
+			table.WatchedEpisodes.SeasonNumber,
+			table.WatchedEpisodes.EpisodeNumber,
+		).DO_NOTHING()
+
+	_, err := stmt.ExecContext(ctx, conn(ctx, r.DB))
+	return err
+}
+
+func (r *ShowProgressRepository) UnmarkWatched(ctx context.Context, userID int32, showID int32, seasonNumber int32, episodeNumber int32) error {
+	deleteStmt := table.WatchedEpisodes.DELETE().
+		WHERE(
+			table.WatchedEpisodes.ShowID.EQ(Int32(showID)).
+				AND(table.WatchedEpisodes.UserID.EQ(Int32(userID))).
+				AND(table.WatchedEpisodes.SeasonNumber.EQ(Int32(seasonNumber))).
+				AND(table.WatchedEpisodes.EpisodeNumber.EQ(Int32(episodeNumber))),
+		)
+
+	_, err := deleteStmt.ExecContext(ctx, conn(ctx, r.DB))
+	return err
+}
+
+func showProgressCondition(userID int32, showID int32) BoolExpression {
+	return table.ShowProgress.ShowID.EQ(Int32(showID)).AND(table.ShowProgress.UserID.EQ(Int32(userID)))
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+type IStreamingSubscriptionRepository interface {
+	FindByUser(ctx context.Context, userID int32) (model.StreamingSubscriptions, error)
+	Save(ctx context.Context, subscription model.StreamingSubscriptions) (model.StreamingSubscriptions, error)
+	Delete(ctx context.Context, userID int32) error
+}
+
+type StreamingSubscriptionRepository struct {
+	DB *sql.DB
+}
+
+func newStreamingSubscriptionRepository(params RepositoryParams) IStreamingSubscriptionRepository {
+	return &StreamingSubscriptionRepository{
+		DB: params.DB,
+	}
+}
+
+func (r *StreamingSubscriptionRepository) FindByUser(ctx context.Context, userID int32) (model.StreamingSubscriptions, error) {
+	var subscription model.StreamingSubscriptions
+
+	qb := SELECT(table.StreamingSubscriptions.AllColumns).
+		FROM(table.StreamingSubscriptions).
+		WHERE(table.StreamingSubscriptions.UserID.EQ(Int32(userID)))
+
+	err := qb.QueryContext(ctx, r.DB, &subscription)
+	return subscription, err
+}
+
+// Save sets up the streaming services of the user, replacing the previous ones.
+func (r *StreamingSubscriptionRepository) Save(ctx context.Context, subscription model.StreamingSubscriptions) (model.StreamingSubscriptions, error) {
+	var savedSubscription model.StreamingSubscriptions
+
+	subscription.UpdatedAt = time.Now()
+
+	stmt := table.StreamingSubscriptions.INSERT(table.StreamingSubscriptions.AllColumns).
+		MODEL(subscription).
+		ON_CONFLICT(table.StreamingSubscriptions.UserID).
+		DO_UPDATE(SET(
+			table.StreamingSubscriptions.Country.SET(table.StreamingSubscriptions.EXCLUDED.Country),
+			table.StreamingSubscriptions.ProviderIds.SET(table.StreamingSubscriptions.EXCLUDED.ProviderIds),
+			table.StreamingSubscriptions.UpdatedAt.SET(table.StreamingSubscriptions.EXCLUDED.UpdatedAt),
+		)).
+		RETURNING(table.StreamingSubscriptions.AllColumns)
+
+	err := stmt.QueryContext(ctx, r.DB, &savedSubscription)
+	return savedSubscription, err
+}
+
+func (r *StreamingSubscriptionRepository) Delete(ctx context.Context, userID int32) error {
+	deleteStmt := table.StreamingSubscriptions.DELETE().
+		WHERE(table.StreamingSubscriptions.UserID.EQ(Int32(userID)))
+
+	_, err := deleteStmt.ExecContext(ctx, r.DB)
+	return err
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+// IGenreRepository fetches the movie genres, named in the requested language.
+type IGenreRepository interface {
+	GetMovieGenres(ctx context.Context, locale dto.LocaleDTO) ([]dto.GenreDTO, error)
+}
+
+func (r *TMDBRepository) GetMovieGenres(ctx context.Context, locale dto.LocaleDTO) ([]dto.GenreDTO, error) {
+	var list struct {
+		Genres []dto.GenreDTO `json:"genres"`
+	}
+
+	err := r.fetchJSON(ctx, genreQuery(locale), &list, "genre", "/genre/movie/list")
+	return list.Genres, err
+}
+
+// genreQuery builds the parameters of a genre list request. Genre names
+// don't vary by region, so only the language is sent.
+func genreQuery(locale dto.LocaleDTO) url.Values {
+	q := url.Values{}
+	if locale.Language != "" {
+		q.Set("language", locale.Language)
+	}
+	return q
+}
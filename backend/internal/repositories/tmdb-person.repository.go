@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+// IPersonRepository fetches the people in the catalog. Like movies, people
+// come in the default language with the translations of their biography,
+// while the movies they worked on are fetched in the requested locale.
+type IPersonRepository interface {
+	GetPerson(ctx context.Context, id int) (dto.TMDBPersonDTO, error)
+	GetMovieCredits(ctx context.Context, id int, locale dto.LocaleDTO) (dto.TMDBPersonMovieCreditsDTO, error)
+}
+
+func (r *TMDBRepository) GetPerson(ctx context.Context, id int) (dto.TMDBPersonDTO, error) {
+	var person dto.TMDBPersonDTO
+
+	q := url.Values{}
+	q.Set("language", r.language)
+	q.Set("append_to_response", "translations")
+
+	err := r.fetchJSON(ctx, q, &person, "person", "/person/%d", id)
+	return person, err
+}
+
+func (r *TMDBRepository) GetMovieCredits(ctx context.Context, id int, locale dto.LocaleDTO) (dto.TMDBPersonMovieCreditsDTO, error) {
+	var credits dto.TMDBPersonMovieCreditsDTO
+
+	q := url.Values{}
+	setLocaleParams(q, locale)
+
+	err := r.fetchJSON(ctx, q, &credits, "person", "/person/%d/movie_credits", id)
+	return credits, err
+}
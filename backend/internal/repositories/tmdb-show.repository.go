@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+// IShowRepository fetches the TV catalog. Like movies, show details come in
+// the default language with all their translations, while lists, seasons and
+// episodes are fetched in the requested locale. Shows, seasons and episodes
+// TMDB doesn't know are reported as not found.
+type IShowRepository interface {
+	DiscoverShows(ctx context.Context, page int, locale dto.LocaleDTO, rules dto.ContentPolicyDTO) (dto.Pagination[dto.TMDBShowDTO], error)
+	SearchShows(ctx context.Context, query string, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBShowDTO], error)
+	GetShow(ctx context.Context, id int) (dto.TMDBShowDTO, error)
+	GetSeason(ctx context.Context, showID int, seasonNumber int, locale dto.LocaleDTO) (dto.TMDBSeasonDTO, error)
+	GetEpisode(ctx context.Context, showID int, seasonNumber int, episodeNumber int, locale dto.LocaleDTO) (dto.TMDBEpisodeDTO, error)
+}
+
+func (r *TMDBRepository) DiscoverShows(ctx context.Context, page int, locale dto.LocaleDTO, rules dto.ContentPolicyDTO) (dto.Pagination[dto.TMDBShowDTO], error) {
+	var shows dto.Pagination[dto.TMDBShowDTO]
+
+	err := r.fetchJSON(ctx, discoverShowsQuery(page, locale, rules), &shows, "show", "/discover/tv")
+	return shows, err
+}
+
+func (r *TMDBRepository) SearchShows(ctx context.Context, query string, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBShowDTO], error) {
+	var shows dto.Pagination[dto.TMDBShowDTO]
+
+	err := r.fetchJSON(ctx, searchQuery(query, page, locale), &shows, "show", "/search/tv")
+	return shows, err
+}
+
+func (r *TMDBRepository) GetShow(ctx context.Context, id int) (dto.TMDBShowDTO, error) {
+	var show dto.TMDBShowDTO
+
+	q := url.Values{}
+	q.Set("language", r.language)
+	q.Set("append_to_response", "translations,content_ratings")
+
+	err := r.fetchJSON(ctx, q, &show, "show", "/tv/%d", id)
+	return show, err
+}
+
+func (r *TMDBRepository) GetSeason(ctx context.Context, showID int, seasonNumber int, locale dto.LocaleDTO) (dto.TMDBSeasonDTO, error) {
+	var season dto.TMDBSeasonDTO
+
+	q := url.Values{}
+	setLocaleParams(q, locale)
+
+	err := r.fetchJSON(ctx, q, &season, "show", "/tv/%d/season/%d", showID, seasonNumber)
+	return season, err
+}
+
+func (r *TMDBRepository) GetEpisode(ctx context.Context, showID int, seasonNumber int, episodeNumber int, locale dto.LocaleDTO) (dto.TMDBEpisodeDTO, error) {
+	var episode dto.TMDBEpisodeDTO
+
+	q := url.Values{}
+	setLocaleParams(q, locale)
+
+	err := r.fetchJSON(ctx, q, &episode, "show", "/tv/%d/season/%d/episode/%d", showID, seasonNumber, episodeNumber)
+	return episode, err
+}
+
+// discoverShowsQuery builds the parameters of a TV discover request. TMDB
+// doesn't filter shows by certification, so only the genres and the votes are
+// checked upstream and the show service checks the rest.
+func discoverShowsQuery(page int, locale dto.LocaleDTO, rules dto.ContentPolicyDTO) url.Values {
+	q := url.Values{}
+	q.Set("page", fmt.Sprintf("%d", page))
+	q.Set("sort_by", "popularity.desc")
+	q.Set("include_adult", strconv.FormatBool(rules.IncludeAdult))
+	setLocaleParams(q, locale)
+	if len(rules.ExcludedGenres) > 0 {
+		genres := make([]string, len(rules.ExcludedGenres))
+		for i, genre := range rules.ExcludedGenres {
+			genres[i] = strconv.Itoa(genre)
+		}
+		q.Set("without_genres", strings.Join(genres, ","))
+	}
+	setVoteParams(q, nil, 0, rules)
+	return q
+}
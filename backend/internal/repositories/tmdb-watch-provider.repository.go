@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+// IWatchProviderRepository fetches the streaming services, stores and rental
+// services movies can be watched on in a country.
+type IWatchProviderRepository interface {
+	GetMovieProviders(ctx context.Context, country string) ([]dto.WatchProviderDTO, error)
+}
+
+func (r *TMDBRepository) GetMovieProviders(ctx context.Context, country string) ([]dto.WatchProviderDTO, error) {
+	var list struct {
+		Results []dto.WatchProviderDTO `json:"results"`
+	}
+
+	err := r.fetchJSON(ctx, watchProviderQuery(country), &list, "watch_provider", "/watch/providers/movie")
+	return list.Results, err
+}
+
+func watchProviderQuery(country string) url.Values {
+	q := url.Values{}
+	q.Set("watch_region", country)
+	return q
+}
@@ -1,42 +1,79 @@
 package repositories
 
 import (
+	"cmp"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/movie-tracker/MovieTracker/internal/config"
 	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/tmdb"
 	"github.com/movie-tracker/MovieTracker/internal/utils"
 )
 
+// IMovieRepository fetches the movie catalog. Lists are fetched in the
+// requested locale, while movie details come in the default language along
+// with all their translations and the videos in every configured language,
+// so a single copy serves every locale. Results are returned unfiltered,
+// except for the rules discover applies upstream.
 type IMovieRepository interface {
-	DiscoverMovies(page int) (dto.Pagination[dto.TMDBMovieDTO], error)
-	GetByID(id int) (dto.TMDBMovieDTO, error)
-	SearchMovies(query string, page int) (dto.Pagination[dto.TMDBMovieDTO], error)
+	DiscoverMovies(ctx context.Context, page int, filters dto.MovieDiscoverFiltersDTO, locale dto.LocaleDTO, rules dto.ContentPolicyDTO) (dto.Pagination[dto.TMDBMovieDTO], error)
+	GetByID(ctx context.Context, id int) (dto.TMDBMovieDTO, error)
+	SearchMovies(ctx context.Context, query string, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error)
+	GetSimilar(ctx context.Context, id int, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error)
+	GetRecommendations(ctx context.Context, id int, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error)
 }
 
 type TMDBRepository struct {
-	baseURL  string
-	apiToken string
+	client         *tmdb.Client
+	baseURL        string
+	language       string
+	videoLanguages string
 }
 
 func newTMDBRepository(params RepositoryParams) *TMDBRepository {
 	return &TMDBRepository{
-		baseURL:  "https://api.themoviedb.org/3",
-		apiToken: params.cfg.TMDB.ApiKey,
+		client:         params.TMDB,
+		baseURL:        params.cfg.TMDB.BaseURL,
+		language:       params.cfg.DefaultLanguage,
+		videoLanguages: videoLanguages(params.cfg),
 	}
 }
 
-func (r *TMDBRepository) login() error {
+// videoLanguages lists the languages of the videos fetched with the movie
+// details: the configured ones, the default language and English, which the
+// rest fall back to.
+func videoLanguages(cfg config.ApiConfig) string {
+	languages := slices.Clone(cfg.TMDB.VideoLanguages)
+	languages = append(languages, dto.LocaleDTO{Language: cfg.DefaultLanguage}.LanguageCode(), "en")
+
+	unique := make([]string, 0, len(languages))
+	for _, language := range languages {
+		language = strings.ToLower(strings.TrimSpace(language))
+		if language != "" && !slices.Contains(unique, language) {
+			unique = append(unique, language)
+		}
+	}
+	return strings.Join(unique, ",")
+}
+
+func (r *TMDBRepository) login(ctx context.Context) error {
 	endpoint, err := r.getEndpoint("/authentication")
 	if err != nil {
 		return err
 	}
 
-	response, err := r.fetchNoRetry("GET", endpoint, nil)
+	response, err := r.client.Do(ctx, http.MethodGet, endpoint)
+	if err != nil {
+		return upstreamError(err)
+	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
@@ -58,7 +95,7 @@ func (r *TMDBRepository) login() error {
 	return nil
 }
 
-func (r *TMDBRepository) DiscoverMovies(page int) (dto.Pagination[dto.TMDBMovieDTO], error) {
+func (r *TMDBRepository) DiscoverMovies(ctx context.Context, page int, filters dto.MovieDiscoverFiltersDTO, locale dto.LocaleDTO, rules dto.ContentPolicyDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
 	var err error
 	var movies dto.Pagination[dto.TMDBMovieDTO]
 
@@ -72,23 +109,9 @@ func (r *TMDBRepository) DiscoverMovies(page int) (dto.Pagination[dto.TMDBMovieD
 		return movies, err
 	}
 
-	q := u.Query()
-	q.Set("page", fmt.Sprintf("%d", page))
-	q.Set("sort_by", "popularity.desc")
-	q.Set("include_adult", "false")
-	q.Set("include_video", "false")
-	q.Set("language", "pt-BR")
-	q.Set("region", "BR")
-	q.Set("certification_country", "BR")
-	q.Set("certification.lte", "12")
-	q.Set("with_release_type", "2|3")
-	q.Set("without_genres", "27,10749,10751,99,10769")
-	q.Set("vote_count.gte", "50")
-	q.Set("vote_average.gte", "3.0")
+	u.RawQuery = discoverQuery(page, filters, locale, rules).Encode()
 
-	u.RawQuery = q.Encode()
-
-	response, err := r.fetch("GET", u.String(), nil)
+	response, err := r.fetch(ctx, http.MethodGet, u.String())
 	if err != nil {
 		return movies, err
 	}
@@ -99,54 +122,22 @@ func (r *TMDBRepository) DiscoverMovies(page int) (dto.Pagination[dto.TMDBMovieD
 		return movies, fmt.Errorf("failed to decode movie data: %w", err)
 	}
 
-	filteredResults := r.filterInappropriateMovies(movies.Results)
-	movies.Results = filteredResults
-	movies.TotalResults = len(filteredResults)
-
 	return movies, nil
 }
 
-func (r *TMDBRepository) GetByID(id int) (dto.TMDBMovieDTO, error) {
+func (r *TMDBRepository) GetByID(ctx context.Context, id int) (dto.TMDBMovieDTO, error) {
 	var movie dto.TMDBMovieDTO
-	endpoint, err := r.getEndpoint("/movie/%d", id)
-	if err != nil {
-		return movie, err
-	}
-
-	u, err := url.Parse(endpoint)
-	if err != nil {
-		return movie, err
-	}
-
-	q := u.Query()
-	q.Set("language", "pt-BR")
-	q.Set("append_to_response", "translations")
-
-	u.RawQuery = q.Encode()
-
-	response, err := r.fetch("GET", u.String(), nil)
-
-	if err != nil {
-		return movie, err
-	}
 
-	defer response.Body.Close()
-	if response.StatusCode != http.StatusOK {
-		return movie, fmt.Errorf("failed to fetch movie data: %s", response.Status)
-	}
-
-	if err := json.NewDecoder(response.Body).Decode(&movie); err != nil {
-		return movie, fmt.Errorf("failed to decode movie data: %w", err)
-	}
-
-	if isAppropriate, reason := r.isMovieAppropriate(movie); !isAppropriate {
-		return movie, fmt.Errorf("filme com %s não permitido", reason)
-	}
+	q := url.Values{}
+	q.Set("language", r.language)
+	q.Set("append_to_response", "translations,release_dates,credits,watch/providers,videos")
+	q.Set("include_video_language", r.videoLanguages)
 
-	return movie, nil
+	err := r.fetchJSON(ctx, q, &movie, "movie", "/movie/%d", id)
+	return movie, err
 }
 
-func (r *TMDBRepository) SearchMovies(query string, page int) (dto.Pagination[dto.TMDBMovieDTO], error) {
+func (r *TMDBRepository) SearchMovies(ctx context.Context, query string, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
 	var err error
 	var movies dto.Pagination[dto.TMDBMovieDTO]
 
@@ -160,22 +151,9 @@ func (r *TMDBRepository) SearchMovies(query string, page int) (dto.Pagination[dt
 		return movies, err
 	}
 
-	q := u.Query()
-	q.Set("query", query)
-	q.Set("page", fmt.Sprintf("%d", page))
-	q.Set("include_adult", "false")
-	q.Set("language", "pt-BR")
-	q.Set("region", "BR")
-	q.Set("certification_country", "BR")
-	q.Set("certification.lte", "12")
-	q.Set("with_release_type", "2|3")
-	q.Set("without_genres", "27,10749,10751,99,10769")
-	q.Set("vote_count.gte", "10")
-	q.Set("vote_average.gte", "3.0")
-
-	u.RawQuery = q.Encode()
+	u.RawQuery = searchQuery(query, page, locale).Encode()
 
-	response, err := r.fetch("GET", u.String(), nil)
+	response, err := r.fetch(ctx, http.MethodGet, u.String())
 	if err != nil {
 		return movies, err
 	}
@@ -186,91 +164,188 @@ func (r *TMDBRepository) SearchMovies(query string, page int) (dto.Pagination[dt
 		return movies, fmt.Errorf("failed to decode search results: %w", err)
 	}
 
-	filteredResults := r.filterInappropriateMovies(movies.Results)
-	movies.Results = filteredResults
-	movies.TotalResults = len(filteredResults)
-
 	return movies, nil
 }
 
-func (r *TMDBRepository) getEndpoint(path string, args ...any) (string, error) {
-	return url.JoinPath(r.baseURL, fmt.Sprintf(path, args...))
+// GetSimilar returns the movies TMDB finds alike in genres and keywords.
+func (r *TMDBRepository) GetSimilar(ctx context.Context, id int, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
+	var movies dto.Pagination[dto.TMDBMovieDTO]
+
+	err := r.fetchJSON(ctx, relatedQuery(page, locale), &movies, "movie", "/movie/%d/similar", id)
+	return movies, err
 }
 
-func (r *TMDBRepository) fetchNoRetry(method string, endpoint string, body io.Reader) (*http.Response, error) {
-	client := http.Client{}
-	req, err := http.NewRequest(method, endpoint, body)
-	if err != nil {
-		return nil, err
+// GetRecommendations returns the movies TMDB recommends to those who liked
+// the movie.
+func (r *TMDBRepository) GetRecommendations(ctx context.Context, id int, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
+	var movies dto.Pagination[dto.TMDBMovieDTO]
+
+	err := r.fetchJSON(ctx, relatedQuery(page, locale), &movies, "movie", "/movie/%d/recommendations", id)
+	return movies, err
+}
+
+// discoverQuery builds the parameters of a discover request. The content
+// policy is applied upstream as far as TMDB supports it; the rest is checked
+// on the results by the movie service. Where the filters and the policy
+// overlap, the strictest wins.
+func discoverQuery(page int, filters dto.MovieDiscoverFiltersDTO, locale dto.LocaleDTO, rules dto.ContentPolicyDTO) url.Values {
+	q := url.Values{}
+	q.Set("page", fmt.Sprintf("%d", page))
+	q.Set("sort_by", cmp.Or(filters.SortBy, "popularity.desc"))
+	q.Set("include_adult", strconv.FormatBool(rules.IncludeAdult))
+	q.Set("include_video", "false")
+	setLocaleParams(q, locale)
+	if len(rules.AllowedCertifications) > 0 {
+		q.Set("certification_country", rules.CertificationCountry)
+		q.Set("certification", strings.Join(rules.AllowedCertifications, "|"))
 	}
+	q.Set("with_release_type", "2|3")
+	if len(filters.WithGenres) > 0 {
+		q.Set("with_genres", joinIDs(filters.WithGenres, "|"))
+	}
+	if excluded := slices.Concat(rules.ExcludedGenres, filters.WithoutGenres); len(excluded) > 0 {
+		slices.Sort(excluded)
+		q.Set("without_genres", joinIDs(slices.Compact(excluded), ","))
+	}
+	if filters.YearFrom != nil {
+		q.Set("primary_release_date.gte", fmt.Sprintf("%04d-01-01", *filters.YearFrom))
+	}
+	if filters.YearTo != nil {
+		q.Set("primary_release_date.lte", fmt.Sprintf("%04d-12-31", *filters.YearTo))
+	}
+	if filters.RuntimeMin != nil {
+		q.Set("with_runtime.gte", strconv.Itoa(*filters.RuntimeMin))
+	}
+	if filters.RuntimeMax != nil {
+		q.Set("with_runtime.lte", strconv.Itoa(*filters.RuntimeMax))
+	}
+	if filters.OriginalLanguage != "" {
+		q.Set("with_original_language", filters.OriginalLanguage)
+	}
+	setVoteParams(q, filters.MinVoteCount, filters.MinVoteAverage, rules)
+	return q
+}
+
+// setVoteParams asks for titles with the votes the filters want, by default
+// as many as the policy needs to judge their average. The policy only hides
+// the poorly rated titles among those with enough votes, so its lowest
+// average is applied upstream only when every title returned has them.
+func setVoteParams(q url.Values, minVoteCount *int, minVoteAverage float64, rules dto.ContentPolicyDTO) {
+	voteCount := utils.Fallback(minVoteCount, rules.MinVoteCount)
+	if voteCount >= rules.MinVoteCount {
+		minVoteAverage = max(minVoteAverage, rules.MinVoteAverage)
+	}
+
+	q.Set("vote_count.gte", strconv.Itoa(voteCount))
+	q.Set("vote_average.gte", strconv.FormatFloat(minVoteAverage, 'f', -1, 64))
+}
 
-	authorization := fmt.Sprintf("Bearer %s", r.apiToken)
-	req.Header.Set("Authorization", authorization)
+func joinIDs(ids []int, separator string) string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = strconv.Itoa(id)
+	}
+	return strings.Join(values, separator)
+}
+
+func searchQuery(query string, page int, locale dto.LocaleDTO) url.Values {
+	q := url.Values{}
+	q.Set("query", query)
+	q.Set("page", fmt.Sprintf("%d", page))
+	q.Set("include_adult", "false")
+	setLocaleParams(q, locale)
+	return q
+}
 
-	resp, err := client.Do(req)
+// relatedQuery builds the parameters of a request for the movies related to
+// another.
+func relatedQuery(page int, locale dto.LocaleDTO) url.Values {
+	q := url.Values{}
+	q.Set("page", fmt.Sprintf("%d", page))
+	setLocaleParams(q, locale)
+	return q
+}
+
+// setLocaleParams asks TMDB for titles and overviews in the locale's language
+// and for the release dates of its region.
+func setLocaleParams(q url.Values, locale dto.LocaleDTO) {
+	if locale.Language != "" {
+		q.Set("language", locale.Language)
+	}
+	if locale.Region != "" {
+		q.Set("region", locale.Region)
+	}
+}
 
-	return resp, err
+func (r *TMDBRepository) getEndpoint(path string, args ...any) (string, error) {
+	return url.JoinPath(r.baseURL, fmt.Sprintf(path, args...))
 }
 
-func (r *TMDBRepository) fetch(method string, endpoint string, body io.Reader) (*http.Response, error) {
-	resp, err := r.fetchNoRetry(method, endpoint, body)
+// fetch sends a request through the shared client, trying once more after
+// checking the token when TMDB rejects it.
+func (r *TMDBRepository) fetch(ctx context.Context, method string, endpoint string) (*http.Response, error) {
+	resp, err := r.client.Do(ctx, method, endpoint)
 	if err != nil {
+		return nil, upstreamError(err)
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	if err = r.login(ctx); err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusUnauthorized {
-		r.login()
+	resp, err = r.client.Do(ctx, method, endpoint)
+	if err != nil {
+		return nil, upstreamError(err)
 	}
 
-	return r.fetchNoRetry(method, endpoint, body)
+	return resp, nil
 }
 
-// Função utilitária para verificar se um filme é adequado
-func (r *TMDBRepository) isMovieAppropriate(movie dto.TMDBMovieDTO) (bool, string) {
-	if movie.Adult {
-		return false, "filme com conteúdo adulto"
+// fetchJSON decodes the response of a GET request to the path, reporting a
+// missing resource as not found with the message of its kind, e.g.
+// "error.show.not_found".
+func (r *TMDBRepository) fetchJSON(ctx context.Context, q url.Values, value any, resource string, path string, args ...any) error {
+	endpoint, err := r.getEndpoint(path, args...)
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
 	}
+	u.RawQuery = q.Encode()
 
-	inappropriateKeywords := []string{
-		"porn", "xxx", "adult", "sex", "nude", "erotic", "pornographic", "explicit",
-		"hardcore", "softcore", "adult film", "adult movie", "sex film", "sex movie",
-		"nude film", "nude movie", "erotic film", "erotic movie", "porn film", "porn movie",
-		"xxx film", "xxx movie", "adult content", "adult entertainment", "adult video",
-		"sex video", "nude video", "erotic video", "porn video", "xxx video",
-		"hardcore porn", "softcore porn", "adult porn", "sex porn", "nude porn",
-		"erotic porn", "adult xxx", "sex xxx", "nude xxx", "erotic xxx",
-		"adult sex", "nude sex", "erotic sex", "porn sex", "xxx sex",
-		"adult nude", "sex nude", "erotic nude", "porn nude", "xxx nude",
-		"adult erotic", "sex erotic", "nude erotic", "porn erotic", "xxx erotic",
+	response, err := r.fetch(ctx, http.MethodGet, u.String())
+	if err != nil {
+		return err
 	}
+	defer response.Body.Close()
 
-	titleLower := strings.ToLower(movie.Title)
-	originalTitleLower := strings.ToLower(movie.OriginalTitle)
+	if response.StatusCode == http.StatusNotFound {
+		return utils.NewNotFoundError(fmt.Sprintf("error.%s.not_found", resource))
+	}
 
-	for _, keyword := range inappropriateKeywords {
-		if strings.Contains(titleLower, keyword) || strings.Contains(originalTitleLower, keyword) {
-			return false, "filme com palavra-chave inadequada"
-		}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch %s data: %s", resource, response.Status)
 	}
 
-	if movie.VoteAverage > 0 && movie.VoteCount > 0 {
-		if movie.VoteCount > 100 && movie.VoteAverage < 3.0 {
-			return false, "filme com baixa avaliação"
-		}
+	if err = json.NewDecoder(response.Body).Decode(value); err != nil {
+		return fmt.Errorf("failed to decode %s data: %w", resource, err)
 	}
 
-	return true, ""
+	return nil
 }
 
-// Função utilitária para filtrar uma lista de filmes
-func (r *TMDBRepository) filterInappropriateMovies(movies []dto.TMDBMovieDTO) []dto.TMDBMovieDTO {
-	var filteredResults []dto.TMDBMovieDTO
-
-	for _, movie := range movies {
-		if isAppropriate, _ := r.isMovieAppropriate(movie); isAppropriate {
-			filteredResults = append(filteredResults, movie)
-		}
+// upstreamError reports TMDB being down as such instead of an internal error.
+func upstreamError(err error) error {
+	if errors.Is(err, tmdb.ErrCircuitOpen) {
+		return utils.NewServiceUnavailableError("error.tmdb.unavailable")
 	}
-
-	return filteredResults
+	return err
 }
@@ -0,0 +1,373 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/movie-tracker/MovieTracker/internal/config"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/tmdb"
+	"github.com/movie-tracker/MovieTracker/internal/tmdb/faketmdb"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFakeTMDBRepository(t *testing.T) *TMDBRepository {
+	server := httptest.NewServer(faketmdb.Handler())
+	t.Cleanup(server.Close)
+
+	cfg := config.ApiConfig{
+		DefaultLanguage: "en-US",
+		TMDB:            config.TMDBConfig{BaseURL: server.URL + "/3", ApiKey: "fake", Timeout: 5, VideoLanguages: []string{"pt"}},
+	}
+
+	return newTMDBRepository(RepositoryParams{TMDB: tmdb.NewClient(cfg.TMDB), cfg: cfg})
+}
+
+func TestTMDBRepository_DiscoverMovies_AppliesRules(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	rules := dto.ContentPolicyDTO{
+		CertificationCountry:  "BR",
+		AllowedCertifications: []string{"L"},
+		ExcludedGenres:        []int{16},
+	}
+	page, err := repo.DiscoverMovies(context.Background(), 1, dto.MovieDiscoverFiltersDTO{}, dto.LocaleDTO{Language: "pt-BR"}, rules)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, page.TotalResults)
+	if assert.Len(t, page.Results, 2) {
+		assert.Equal(t, "De Volta para o Futuro", page.Results[0].Title)
+		assert.Equal(t, []int{12, 35, 878}, page.Results[0].GenreIDs)
+		assert.Equal(t, "La La Land: Cantando Estações", page.Results[1].Title)
+	}
+}
+
+func TestTMDBRepository_DiscoverMovies_Paginates(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	first, err := repo.DiscoverMovies(context.Background(), 1, dto.MovieDiscoverFiltersDTO{}, dto.LocaleDTO{}, dto.ContentPolicyDTO{})
+	assert.NoError(t, err)
+	last, err := repo.DiscoverMovies(context.Background(), first.TotalPages, dto.MovieDiscoverFiltersDTO{}, dto.LocaleDTO{}, dto.ContentPolicyDTO{})
+	assert.NoError(t, err)
+
+	assert.Len(t, first.Results, 20)
+	assert.Greater(t, first.TotalPages, 1)
+	assert.Equal(t, first.TotalResults, (first.TotalPages-1)*20+len(last.Results))
+	assert.GreaterOrEqual(t, first.Results[0].Popularity, first.Results[1].Popularity)
+}
+
+func TestTMDBRepository_DiscoverMovies_AppliesFilters(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	yearFrom, yearTo, runtimeMax := 1980, 1999, 130
+	filters := dto.MovieDiscoverFiltersDTO{
+		WithGenres:    []int{878},
+		WithoutGenres: []int{53},
+		YearFrom:      &yearFrom,
+		YearTo:        &yearTo,
+		RuntimeMax:    &runtimeMax,
+		SortBy:        "primary_release_date.asc",
+	}
+	page, err := repo.DiscoverMovies(context.Background(), 1, filters, dto.LocaleDTO{}, dto.ContentPolicyDTO{})
+
+	assert.NoError(t, err)
+	if assert.Len(t, page.Results, 2) {
+		assert.Equal(t, "Back to the Future", page.Results[0].Title)
+		assert.Equal(t, "Jurassic Park", page.Results[1].Title)
+	}
+}
+
+func TestTMDBRepository_GetByID_IncludesTranslations(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	movie, err := repo.GetByID(context.Background(), 603)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "The Matrix", movie.Title)
+	assert.Equal(t, "14", movie.Certification("BR"))
+	if assert.NotNil(t, movie.Translations) {
+		assert.Contains(t, movie.Translations.Translations, dto.TranslationDTO{
+			ISO31661: "BR", ISO6391: "pt", Name: "Português", EnglishName: "Portuguese",
+			Data: dto.TranslationDataDTO{
+				Title:    "Matrix",
+				Overview: "Um hacker descobre que o mundo em que vive é uma simulação criada por máquinas.",
+			},
+		})
+	}
+}
+
+func TestTMDBRepository_GetByID_Unknown(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	_, err := repo.GetByID(context.Background(), 1)
+
+	var apiErr *utils.ApiError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusNotFound, apiErr.Code)
+		assert.Equal(t, "error.movie.not_found", apiErr.Message)
+	}
+}
+
+func TestTMDBRepository_SearchMovies_MatchesTranslatedTitles(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	page, err := repo.SearchMovies(context.Background(), "poderoso", 1, dto.LocaleDTO{Language: "en-US"})
+
+	assert.NoError(t, err)
+	if assert.Len(t, page.Results, 1) {
+		assert.Equal(t, 238, page.Results[0].ID)
+		assert.Equal(t, "The Godfather", page.Results[0].Title)
+	}
+}
+
+func TestTMDBRepository_GetShow_IncludesSeasonsAndRatings(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	show, err := repo.GetShow(context.Background(), 1396)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Breaking Bad", show.Name)
+	assert.Equal(t, "16", show.Certification("BR"))
+	assert.True(t, show.HasEnded())
+	assert.Len(t, show.Seasons, 6)
+	if assert.NotNil(t, show.LastEpisodeToAir) {
+		assert.Equal(t, 5, show.LastEpisodeToAir.SeasonNumber)
+		assert.Equal(t, 16, show.LastEpisodeToAir.EpisodeNumber)
+	}
+}
+
+func TestTMDBRepository_GetSeason_Localized(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	season, err := repo.GetSeason(context.Background(), 1396, 1, dto.LocaleDTO{Language: "pt-BR"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Temporada 1", season.Name)
+	if assert.Len(t, season.Episodes, 7) {
+		assert.Equal(t, "Piloto", season.Episodes[0].Name)
+	}
+}
+
+func TestTMDBRepository_GetSeason_Unknown(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	_, err := repo.GetSeason(context.Background(), 1396, 9, dto.LocaleDTO{})
+
+	assert.Error(t, err)
+}
+
+func TestTMDBRepository_SearchShows_MatchesTranslatedNames(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	page, err := repo.SearchShows(context.Background(), "química", 1, dto.LocaleDTO{Language: "en-US"})
+
+	assert.NoError(t, err)
+	if assert.Len(t, page.Results, 1) {
+		assert.Equal(t, 1396, page.Results[0].ID)
+	}
+}
+
+func TestTMDBRepository_GetByID_IncludesCredits(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	movie, err := repo.GetByID(context.Background(), 603)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, movie.Credits) {
+		assert.Equal(t, "Keanu Reeves", movie.Credits.Cast[0].Name)
+		assert.Equal(t, "Neo", movie.Credits.Cast[0].Character)
+		assert.Equal(t, "Director", movie.Credits.Crew[0].Job)
+	}
+}
+
+func TestTMDBRepository_GetPerson_IncludesTranslations(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	person, err := repo.GetPerson(context.Background(), 525)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Christopher Nolan", person.Name)
+	assert.Equal(t, "Directing", person.KnownForDepartment)
+	if assert.NotNil(t, person.Translations) {
+		assert.Len(t, person.Translations.Translations, 2)
+	}
+}
+
+func TestTMDBRepository_GetPerson_Unknown(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	_, err := repo.GetPerson(context.Background(), 999999)
+
+	var apiErr *utils.ApiError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, "error.person.not_found", apiErr.Message)
+	}
+}
+
+func TestTMDBRepository_GetMovieCredits_Localized(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	credits, err := repo.GetMovieCredits(context.Background(), 525, dto.LocaleDTO{Language: "pt-BR"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, credits.Cast)
+	titles := make([]string, len(credits.Crew))
+	for i, credit := range credits.Crew {
+		assert.Equal(t, "Director", credit.Job)
+		titles[i] = credit.Title
+	}
+	assert.Contains(t, titles, "A Origem")
+}
+
+func TestTMDBRepository_GetSimilar_SharesGenres(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	page, err := repo.GetSimilar(context.Background(), 27205, 1, dto.LocaleDTO{Language: "en-US"})
+
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, page.Results) {
+		assert.Equal(t, "Star Wars", page.Results[0].Title)
+		assert.Equal(t, []int{12, 28, 878}, page.Results[0].GenreIDs)
+	}
+	for _, movie := range page.Results {
+		assert.NotEqual(t, 27205, movie.ID)
+	}
+}
+
+func TestTMDBRepository_GetRecommendations_Unknown(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	_, err := repo.GetRecommendations(context.Background(), 1, 1, dto.LocaleDTO{})
+
+	var apiErr *utils.ApiError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, "error.movie.not_found", apiErr.Message)
+	}
+}
+
+func TestTMDBRepository_GetMovieGenres_Localized(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	genres, err := repo.GetMovieGenres(context.Background(), dto.LocaleDTO{Language: "pt-BR", Region: "BR"})
+
+	assert.NoError(t, err)
+	assert.Len(t, genres, 19)
+	assert.Contains(t, genres, dto.GenreDTO{ID: 28, Name: "Ação"})
+}
+
+func TestTMDBRepository_GetByID_IncludesWatchProviders(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	movie, err := repo.GetByID(context.Background(), 603)
+
+	assert.NoError(t, err)
+	availability := movie.WatchAvailability("BR")
+	if assert.NotNil(t, availability) {
+		assert.Equal(t, "BR", availability.Country)
+		assert.Len(t, availability.Flatrate, 1)
+		assert.Equal(t, "Netflix", availability.Flatrate[0].ProviderName)
+		assert.NotEmpty(t, availability.Rent)
+	}
+	assert.Nil(t, movie.WatchAvailability("JP"))
+}
+
+func TestTMDBRepository_GetByID_WithoutAppendedFixtures(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	// These fixtures have no providers, credits or videos
+	for _, id := range []int{999001, 999003} {
+		movie, err := repo.GetByID(context.Background(), id)
+
+		assert.NoError(t, err)
+		assert.True(t, movie.HasAllDetails(), id)
+		assert.Nil(t, movie.WatchAvailability("BR"), id)
+
+		for _, path := range []string{"/movie/%d/watch/providers", "/movie/%d/credits"} {
+			response, err := repo.client.Do(context.Background(), http.MethodGet, repo.baseURL+fmt.Sprintf(path, id))
+			if assert.NoError(t, err) {
+				assert.Equal(t, http.StatusOK, response.StatusCode, path)
+				response.Body.Close()
+			}
+		}
+	}
+}
+
+func TestTMDBRepository_GetMovieProviders(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	providers, err := repo.GetMovieProviders(context.Background(), "BR")
+
+	assert.NoError(t, err)
+	ids := make([]int, len(providers))
+	for i, provider := range providers {
+		ids[i] = provider.ProviderID
+	}
+	assert.Contains(t, ids, 8)
+	assert.Contains(t, ids, 119)
+	assert.NotContains(t, ids, 9)
+	assert.Equal(t, "Netflix", providers[0].ProviderName)
+}
+
+func TestTMDBRepository_GetByID_IncludesVideos(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	movie, err := repo.GetByID(context.Background(), 129)
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, movie.Videos) {
+		languages := make([]string, len(movie.Videos.Results))
+		for i, video := range movie.Videos.Results {
+			languages[i] = video.ISO6391
+		}
+		assert.Contains(t, languages, "pt")
+		assert.Contains(t, languages, "en")
+		assert.NotContains(t, languages, "ja")
+	}
+}
+
+func TestTMDBRepository_GetByID_IncludesReleases(t *testing.T) {
+	repo := newFakeTMDBRepository(t)
+
+	movie, err := repo.GetByID(context.Background(), 27205)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.ReleaseDTO{
+		{Type: dto.ReleaseTheatrical, Date: "2010-07-15"},
+		{Type: dto.ReleaseDigital, Date: "2010-11-21"},
+	}, movie.Releases("BR"))
+	assert.Empty(t, movie.Releases("ZZ"))
+}
+
+func TestVideoLanguages(t *testing.T) {
+	cfg := config.ApiConfig{DefaultLanguage: "es-MX", TMDB: config.TMDBConfig{VideoLanguages: []string{"pt", " EN", "es"}}}
+
+	assert.Equal(t, "pt,en,es", videoLanguages(cfg))
+	assert.Equal(t, "en", videoLanguages(config.ApiConfig{}))
+}
+
+func TestDiscoverQuery_VoteParams(t *testing.T) {
+	rules := dto.ContentPolicyDTO{MinVoteAverage: 3, MinVoteCount: 100}
+	votes := func(filters dto.MovieDiscoverFiltersDTO) [2]string {
+		q := discoverQuery(1, filters, dto.LocaleDTO{}, rules)
+		return [2]string{q.Get("vote_count.gte"), q.Get("vote_average.gte")}
+	}
+	few, many := 10, 500
+
+	// By default every title has the votes the policy needs to judge it
+	assert.Equal(t, [2]string{"100", "3"}, votes(dto.MovieDiscoverFiltersDTO{}))
+	assert.Equal(t, [2]string{"500", "6.5"}, votes(dto.MovieDiscoverFiltersDTO{MinVoteCount: &many, MinVoteAverage: 6.5}))
+	// Titles with fewer votes aren't judged on their average by the policy
+	assert.Equal(t, [2]string{"10", "0"}, votes(dto.MovieDiscoverFiltersDTO{MinVoteCount: &few}))
+	assert.Equal(t, [2]string{"10", "2"}, votes(dto.MovieDiscoverFiltersDTO{MinVoteCount: &few, MinVoteAverage: 2}))
+}
+
+func TestDiscoverShowsQuery_VoteParams(t *testing.T) {
+	q := discoverShowsQuery(1, dto.LocaleDTO{}, dto.ContentPolicyDTO{MinVoteAverage: 3, MinVoteCount: 100})
+
+	assert.Equal(t, "100", q.Get("vote_count.gte"))
+	assert.Equal(t, "3", q.Get("vote_average.gte"))
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-jet/jet/v2/qrm"
+)
+
+// ITransactor runs several repository calls as a single unit of work.
+type ITransactor interface {
+	// InTx runs fn in a transaction, committed when fn returns nil and rolled
+	// back otherwise. Repository calls made with the context handed to fn take
+	// part in the transaction; nested calls join the outer one.
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type Transactor struct {
+	DB *sql.DB
+}
+
+func newTransactor(params RepositoryParams) ITransactor {
+	return &Transactor{
+		DB: params.DB,
+	}
+}
+
+type txKey struct{}
+
+func (t *Transactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withTx(ctx, t.DB, func(tx *sql.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction carried by the context, if any, and the
+// database otherwise.
+func conn(ctx context.Context, db *sql.DB) qrm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// withTx runs fn in the transaction carried by the context or, when there is
+// none, in a new one committed when fn succeeds.
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+// IUserFollowRepository stores the users each user follows. A follow is a
+// request until the followed user accepts it.
+type IUserFollowRepository interface {
+	FindFollowers(ctx context.Context, userID int32) ([]model.UserFollows, error)
+	FindRequests(ctx context.Context, userID int32) ([]model.UserFollows, error)
+	Follow(ctx context.Context, followerID int32, followedID int32) (model.UserFollows, error)
+	Accept(ctx context.Context, followerID int32, followedID int32) (model.UserFollows, error)
+	Unfollow(ctx context.Context, followerID int32, followedID int32) error
+}
+
+type UserFollowRepository struct {
+	DB *sql.DB
+}
+
+func newUserFollowRepository(params RepositoryParams) IUserFollowRepository {
+	return &UserFollowRepository{
+		DB: params.DB,
+	}
+}
+
+// FindFollowers lists who follows the user with their consent.
+func (r *UserFollowRepository) FindFollowers(ctx context.Context, userID int32) ([]model.UserFollows, error) {
+	return r.find(ctx, table.UserFollows.FollowedID.EQ(Int32(userID)).AND(table.UserFollows.AcceptedAt.IS_NOT_NULL()))
+}
+
+// FindRequests lists who asked to follow the user and is still waiting for an
+// answer.
+func (r *UserFollowRepository) FindRequests(ctx context.Context, userID int32) ([]model.UserFollows, error) {
+	return r.find(ctx, table.UserFollows.FollowedID.EQ(Int32(userID)).AND(table.UserFollows.AcceptedAt.IS_NULL()))
+}
+
+func (r *UserFollowRepository) find(ctx context.Context, condition BoolExpression) ([]model.UserFollows, error) {
+	qb := SELECT(table.UserFollows.AllColumns).
+		FROM(table.UserFollows).
+		WHERE(condition).
+		ORDER_BY(table.UserFollows.FollowedAt.ASC(), table.UserFollows.FollowerID.ASC())
+
+	follows := make([]model.UserFollows, 0)
+	err := qb.QueryContext(ctx, r.DB, &follows)
+
+	return follows, err
+}
+
+// Follow asks to follow the user, keeping when they were first asked and
+// whether they accepted if the follower already did.
+func (r *UserFollowRepository) Follow(ctx context.Context, followerID int32, followedID int32) (model.UserFollows, error) {
+	var follow model.UserFollows
+
+	stmt := table.UserFollows.INSERT(
+		table.UserFollows.FollowerID,
+		table.UserFollows.FollowedID,
+	).VALUES(followerID, followedID).
+		ON_CONFLICT(table.UserFollows.FollowerID, table.UserFollows.FollowedID).
+		DO_UPDATE(SET(
+			table.UserFollows.FollowedAt.SET(table.UserFollows.FollowedAt),
+		)).
+		RETURNING(table.UserFollows.AllColumns)
+
+	err := stmt.QueryContext(ctx, r.DB, &follow)
+	return follow, err
+}
+
+// Accept lets the follower follow the user. It returns qrm.ErrNoRows when
+// there is no such request, and the follow as is when it was already accepted.
+func (r *UserFollowRepository) Accept(ctx context.Context, followerID int32, followedID int32) (model.UserFollows, error) {
+	var follow model.UserFollows
+
+	stmt := table.UserFollows.UPDATE(table.UserFollows.AcceptedAt).
+		SET(COALESCE(table.UserFollows.AcceptedAt, LOCALTIMESTAMP())).
+		WHERE(followCondition(followerID, followedID)).
+		RETURNING(table.UserFollows.AllColumns)
+
+	err := stmt.QueryContext(ctx, r.DB, &follow)
+	return follow, err
+}
+
+// Unfollow stops following the user, or withdraws or declines the request.
+func (r *UserFollowRepository) Unfollow(ctx context.Context, followerID int32, followedID int32) error {
+	deleteStmt := table.UserFollows.DELETE().
+		WHERE(followCondition(followerID, followedID))
+
+	_, err := deleteStmt.ExecContext(ctx, r.DB)
+	return err
+}
+
+func followCondition(followerID int32, followedID int32) BoolExpression {
+	return table.UserFollows.FollowerID.EQ(Int32(followerID)).AND(table.UserFollows.FollowedID.EQ(Int32(followedID)))
+}
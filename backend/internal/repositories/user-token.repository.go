@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/enum"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+type IUserTokenRepository interface {
+	Create(ctx context.Context, token model.UserTokens) (model.UserTokens, error)
+	Consume(ctx context.Context, purpose model.UserTokenPurpose, tokenHash string) (model.UserTokens, error)
+	InvalidateByUser(ctx context.Context, userID int32, purpose model.UserTokenPurpose) error
+}
+
+type UserTokenRepository struct {
+	DB *sql.DB
+}
+
+func newUserTokenRepository(params RepositoryParams) IUserTokenRepository {
+	return &UserTokenRepository{
+		DB: params.DB,
+	}
+}
+
+func (r *UserTokenRepository) Create(ctx context.Context, token model.UserTokens) (model.UserTokens, error) {
+	var createdToken model.UserTokens
+
+	insertStmt := table.UserTokens.INSERT(
+		table.UserTokens.UserID,
+		table.UserTokens.Purpose,
+		table.UserTokens.TokenHash,
+		table.UserTokens.ExpiresAt,
+	).MODEL(token).
+		RETURNING(table.UserTokens.AllColumns)
+
+	err := insertStmt.QueryContext(ctx, r.DB, &createdToken)
+	return createdToken, err
+}
+
+// Consume marks an unused, unexpired token as used and returns it. The check
+// and the update are a single statement, so a token can't be used twice.
+func (r *UserTokenRepository) Consume(ctx context.Context, purpose model.UserTokenPurpose, tokenHash string) (model.UserTokens, error) {
+	var token model.UserTokens
+
+	updateStmt := table.UserTokens.UPDATE().
+		SET(table.UserTokens.UsedAt.SET(LOCALTIMESTAMP())).
+		WHERE(
+			table.UserTokens.TokenHash.EQ(String(tokenHash)).
+				AND(table.UserTokens.Purpose.EQ(userTokenPurposeExpression(purpose))).
+				AND(table.UserTokens.UsedAt.IS_NULL()).
+				AND(table.UserTokens.ExpiresAt.GT(LOCALTIMESTAMP())),
+		).
+		RETURNING(table.UserTokens.AllColumns)
+
+	err := updateStmt.QueryContext(ctx, r.DB, &token)
+	return token, err
+}
+
+// InvalidateByUser marks the outstanding tokens of the user as used, so only
+// the most recently sent one works.
+func (r *UserTokenRepository) InvalidateByUser(ctx context.Context, userID int32, purpose model.UserTokenPurpose) error {
+	updateStmt := table.UserTokens.UPDATE().
+		SET(table.UserTokens.UsedAt.SET(LOCALTIMESTAMP())).
+		WHERE(
+			table.UserTokens.UserID.EQ(Int32(userID)).
+				AND(table.UserTokens.Purpose.EQ(userTokenPurposeExpression(purpose))).
+				AND(table.UserTokens.UsedAt.IS_NULL()),
+		)
+
+	_, err := updateStmt.ExecContext(ctx, r.DB)
+	return err
+}
+
+func userTokenPurposeExpression(purpose model.UserTokenPurpose) StringExpression {
+	switch purpose {
+	case model.UserTokenPurpose_PasswordReset:
+		return enum.UserTokenPurpose.PasswordReset
+	default:
+		return enum.UserTokenPurpose.EmailVerification
+	}
+}
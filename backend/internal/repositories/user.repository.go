@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"context"
 	"database/sql"
 
 	. "github.com/go-jet/jet/v2/postgres"
@@ -9,12 +10,16 @@ import (
 )
 
 type IUserRepository interface {
-	FindAll() ([]model.Users, error)
-	FindOne(int32) (model.Users, error)
-	FindByEmail(string) (model.Users, error)
-	FindByUsername(string) (model.Users, error)
-	Create(user model.Users) (model.Users, error)
-	Update(user model.Users) (model.Users, error)
+	FindAll(ctx context.Context) ([]model.Users, error)
+	FindOne(ctx context.Context, id int32) (model.Users, error)
+	FindActiveAfter(ctx context.Context, afterID int32, limit int64) ([]model.Users, error)
+	FindByEmail(ctx context.Context, email string) (model.Users, error)
+	FindByUsername(ctx context.Context, username string) (model.Users, error)
+	Create(ctx context.Context, user model.Users) (model.Users, error)
+	Update(ctx context.Context, user model.Users) (model.Users, error)
+	UpdatePassword(ctx context.Context, id int32, password string) (model.Users, error)
+	MarkEmailVerified(ctx context.Context, id int32) (model.Users, error)
+	UpdatePreferences(ctx context.Context, id int32, language *string, region *string) (model.Users, error)
 }
 
 type UserRepository struct {
@@ -27,65 +32,122 @@ func newUserRepository(params RepositoryParams) IUserRepository {
 	}
 }
 
-func (r UserRepository) FindAll() ([]model.Users, error) {
+func (r UserRepository) FindAll(ctx context.Context) ([]model.Users, error) {
 	var err error
 	var users []model.Users
 
 	qb := SELECT(table.Users.AllColumns).FROM(table.Users)
 
-	err = qb.Query(r.DB, &users)
+	err = qb.QueryContext(ctx, r.DB, &users)
 
 	return users, err
 }
 
-func (r UserRepository) FindOne(id int32) (model.Users, error) {
+func (r UserRepository) FindOne(ctx context.Context, id int32) (model.Users, error) {
 	var err error
 	var user model.Users
 
 	qb := SELECT(table.Users.AllColumns).FROM(table.Users).WHERE(table.Users.ID.EQ(Int32(id)))
 
-	err = qb.Query(r.DB, &user)
+	err = qb.QueryContext(ctx, r.DB, &user)
 
 	return user, err
 }
 
-func (r UserRepository) FindByEmail(email string) (model.Users, error) {
+// FindActiveAfter returns up to limit users not deleted with an ID above the
+// one passed, ordered by ID, so every user can be gone through in batches.
+func (r UserRepository) FindActiveAfter(ctx context.Context, afterID int32, limit int64) ([]model.Users, error) {
+	users := make([]model.Users, 0)
+
+	qb := SELECT(table.Users.AllColumns).
+		FROM(table.Users).
+		WHERE(table.Users.ID.GT(Int32(afterID)).AND(table.Users.DeletedAt.IS_NULL())).
+		ORDER_BY(table.Users.ID.ASC()).
+		LIMIT(limit)
+
+	err := qb.QueryContext(ctx, r.DB, &users)
+
+	return users, err
+}
+
+func (r UserRepository) FindByEmail(ctx context.Context, email string) (model.Users, error) {
 	var err error
 	var user model.Users
 
 	qb := SELECT(table.Users.AllColumns).FROM(table.Users).WHERE(table.Users.Email.EQ(String(email)))
 
-	err = qb.Query(r.DB, &user)
+	err = qb.QueryContext(ctx, r.DB, &user)
 
 	return user, err
 }
 
-func (r UserRepository) FindByUsername(username string) (model.Users, error) {
+func (r UserRepository) FindByUsername(ctx context.Context, username string) (model.Users, error) {
 	var err error
 	var user model.Users
 
 	qb := SELECT(table.Users.AllColumns).
 		FROM(table.Users).
 		WHERE(table.Users.Username.EQ(String(username)))
-	err = qb.Query(r.DB, &user)
+	err = qb.QueryContext(ctx, r.DB, &user)
 
 	return user, err
 }
 
-func (r UserRepository) Create(user model.Users) (model.Users, error) {
+func (r UserRepository) Create(ctx context.Context, user model.Users) (model.Users, error) {
 	var err error
 	var createdUser model.Users
 
-	err = table.Users.INSERT(table.Users.MutableColumns).MODEL(user).RETURNING(table.Users.AllColumns).Query(r.DB, &createdUser)
+	err = table.Users.INSERT(table.Users.MutableColumns).MODEL(user).RETURNING(table.Users.AllColumns).QueryContext(ctx, r.DB, &createdUser)
 
 	return createdUser, err
 }
 
-func (r UserRepository) Update(user model.Users) (model.Users, error) {
+func (r UserRepository) Update(ctx context.Context, user model.Users) (model.Users, error) {
 	var err error
 	var updatedUser model.Users
 
-	err = table.Users.UPDATE(table.Users.AllColumns).MODEL(user).WHERE(table.Users.ID.EQ(Int32(user.ID))).RETURNING(table.Users.AllColumns).Query(r.DB, &updatedUser)
+	err = table.Users.UPDATE(table.Users.AllColumns).MODEL(user).WHERE(table.Users.ID.EQ(Int32(user.ID))).RETURNING(table.Users.AllColumns).QueryContext(ctx, r.DB, &updatedUser)
+
+	return updatedUser, err
+}
+
+func (r UserRepository) UpdatePassword(ctx context.Context, id int32, password string) (model.Users, error) {
+	var updatedUser model.Users
+
+	updateStmt := table.Users.UPDATE().
+		SET(
+			table.Users.Password.SET(String(password)),
+			table.Users.UpdatedAt.SET(LOCALTIMESTAMP()),
+		).
+		WHERE(table.Users.ID.EQ(Int32(id))).
+		RETURNING(table.Users.AllColumns)
+
+	err := updateStmt.QueryContext(ctx, r.DB, &updatedUser)
+	return updatedUser, err
+}
+
+// MarkEmailVerified records the verification time, keeping the first one when
+// the email was already verified.
+func (r UserRepository) MarkEmailVerified(ctx context.Context, id int32) (model.Users, error) {
+	var updatedUser model.Users
+
+	updateStmt := table.Users.UPDATE().
+		SET(table.Users.EmailVerifiedAt.SET(TimestampExp(COALESCE(table.Users.EmailVerifiedAt, LOCALTIMESTAMP())))).
+		WHERE(table.Users.ID.EQ(Int32(id))).
+		RETURNING(table.Users.AllColumns)
+
+	err := updateStmt.QueryContext(ctx, r.DB, &updatedUser)
+	return updatedUser, err
+}
+
+func (r UserRepository) UpdatePreferences(ctx context.Context, id int32, language *string, region *string) (model.Users, error) {
+	var updatedUser model.Users
+
+	updateStmt := table.Users.UPDATE(table.Users.Language, table.Users.Region, table.Users.UpdatedAt).
+		SET(language, region, LOCALTIMESTAMP()).
+		WHERE(table.Users.ID.EQ(Int32(id))).
+		RETURNING(table.Users.AllColumns)
 
+	err := updateStmt.QueryContext(ctx, r.DB, &updatedUser)
 	return updatedUser, err
 }
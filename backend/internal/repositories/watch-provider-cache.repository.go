@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+// CachedWatchProviderRepository keeps the TMDB watch provider lists in the
+// query cache, served and refreshed the same way as in CachedMovieRepository.
+type CachedWatchProviderRepository struct {
+	DB       *sql.DB
+	upstream IWatchProviderRepository
+	ttl      time.Duration
+}
+
+func newCachedWatchProviderRepository(params RepositoryParams, upstream IWatchProviderRepository) *CachedWatchProviderRepository {
+	return &CachedWatchProviderRepository{
+		DB:       params.DB,
+		upstream: upstream,
+		ttl:      time.Duration(params.cfg.TMDB.CacheTTL) * time.Minute,
+	}
+}
+
+func (r *CachedWatchProviderRepository) GetMovieProviders(ctx context.Context, country string) ([]dto.WatchProviderDTO, error) {
+	return cachedQuery(ctx, r.DB, r.ttl, "watch/providers/movie?"+watchProviderQuery(country).Encode(), func() ([]dto.WatchProviderDTO, error) {
+		return r.upstream.GetMovieProviders(ctx, country)
+	})
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+)
+
+// IWatchlistAvailabilityRepository stores where the movies on each watchlist
+// streamed when the notifications were last scanned.
+type IWatchlistAvailabilityRepository interface {
+	FindByUser(ctx context.Context, userID int32) ([]model.WatchlistAvailability, error)
+	Save(ctx context.Context, availability []model.WatchlistAvailability) error
+}
+
+type WatchlistAvailabilityRepository struct {
+	DB *sql.DB
+}
+
+func newWatchlistAvailabilityRepository(params RepositoryParams) IWatchlistAvailabilityRepository {
+	return &WatchlistAvailabilityRepository{
+		DB: params.DB,
+	}
+}
+
+func (r *WatchlistAvailabilityRepository) FindByUser(ctx context.Context, userID int32) ([]model.WatchlistAvailability, error) {
+	qb := SELECT(table.WatchlistAvailability.AllColumns).
+		FROM(table.WatchlistAvailability).
+		WHERE(table.WatchlistAvailability.UserID.EQ(Int32(userID)))
+
+	availability := make([]model.WatchlistAvailability, 0)
+	err := qb.QueryContext(ctx, r.DB, &availability)
+
+	return availability, err
+}
+
+// Save replaces where the movies were last seen streaming.
+func (r *WatchlistAvailabilityRepository) Save(ctx context.Context, availability []model.WatchlistAvailability) error {
+	if len(availability) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for i := range availability {
+		availability[i].SeenAt = now
+	}
+
+	stmt := table.WatchlistAvailability.INSERT(table.WatchlistAvailability.AllColumns).
+		MODELS(availability).
+		ON_CONFLICT(table.WatchlistAvailability.UserID, table.WatchlistAvailability.MovieID).
+		DO_UPDATE(SET(
+			table.WatchlistAvailability.Country.SET(table.WatchlistAvailability.EXCLUDED.Country),
+			table.WatchlistAvailability.ProviderIds.SET(table.WatchlistAvailability.EXCLUDED.ProviderIds),
+			table.WatchlistAvailability.SeenAt.SET(table.WatchlistAvailability.EXCLUDED.SeenAt),
+		))
+
+	_, err := stmt.ExecContext(ctx, r.DB)
+	return err
+}
@@ -1,25 +1,31 @@
 package repositories
 
 import (
-    "database/sql"
-    "fmt"
-
-    "github.com/go-jet/jet/v2/postgres" // Importação para as funções do Postgres
-    . "github.com/go-jet/jet/v2/postgres" // Dot import para facilitar o uso
-    "github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/enum"
-    "github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
-    "github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
-    "github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres" // Dot import para facilitar o uso
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/enum"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/table"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
 )
 
 type IWatchListRepository interface {
-	GetByUser(userID int32) ([]model.Watchlist, error)
-	AddToWatchlist(userID int32, createDTO dto.WatchListCreateDTO) (model.Watchlist, error)
-	UpdateWatchlistItem(userID int32, movieID int, status string, favorite *bool, comments string, rating *int) (model.Watchlist, error)
-	RemoveFromWatchlist(userID int32, movieID int) error
-	UpdateStatus(userID int32, movieID int, status string) (model.Watchlist, error)
-	ToggleFavorite(userID int32, movieID int, favorite bool) (model.Watchlist, error)
-	UpdateRating(userID int32, movieID int, rating *int) (model.Watchlist, error)
+	GetByUser(ctx context.Context, userID int32) ([]model.Watchlist, error)
+	FindByUser(ctx context.Context, userID int32, query dto.WatchListQueryDTO) ([]model.Watchlist, int64, error)
+	FindBatch(ctx context.Context, userID int32, after *model.Watchlist, limit int) ([]model.Watchlist, error)
+	AddToWatchlist(ctx context.Context, userID int32, createDTO dto.WatchListCreateDTO) (model.Watchlist, error)
+	UpdateWatchlistItem(ctx context.Context, userID int32, movieID int, status string, favorite *bool, comments string, rating *int) (model.Watchlist, error)
+	RemoveFromWatchlist(ctx context.Context, userID int32, movieID int) error
+	UpdateStatus(ctx context.Context, userID int32, movieID int, status string) (model.Watchlist, error)
+	ToggleFavorite(ctx context.Context, userID int32, movieID int, favorite bool) (model.Watchlist, error)
+	UpdateRating(ctx context.Context, userID int32, movieID int, rating *int) (model.Watchlist, error)
+	GetStatusHistory(ctx context.Context, userID int32, movieID int) ([]model.WatchlistStatusHistory, error)
+	FindRatedSince(ctx context.Context, since time.Time) ([]model.Watchlist, error)
 }
 
 type WatchListRepository struct {
@@ -32,18 +38,134 @@ func newWatchListRepository(params RepositoryParams) IWatchListRepository {
 	}
 }
 
-func (r *WatchListRepository) GetByUser(userID int32) ([]model.Watchlist, error) {
+func (r *WatchListRepository) GetByUser(ctx context.Context, userID int32) ([]model.Watchlist, error) {
 	qb := SELECT(table.Watchlist.AllColumns).
 		FROM(table.Watchlist).
 		WHERE(table.Watchlist.UserID.EQ(Int32(userID)))
 
 	var watchList []model.Watchlist
-	err := qb.Query(r.DB, &watchList)
+	err := qb.QueryContext(ctx, conn(ctx, r.DB), &watchList)
 
 	return watchList, err
 }
 
-func (r *WatchListRepository) AddToWatchlist(userID int32, createDTO dto.WatchListCreateDTO) (model.Watchlist, error) {
+// FindByUser returns the user's items matching the query filters in the requested
+// order, along with the total number of matches. Only a single page is returned
+// when the query sets a page size.
+func (r *WatchListRepository) FindByUser(ctx context.Context, userID int32, query dto.WatchListQueryDTO) ([]model.Watchlist, int64, error) {
+	var total struct {
+		Count int64
+	}
+
+	condition := watchlistConditions(userID, query)
+
+	countStmt := SELECT(COUNT(STAR).AS("count")).
+		FROM(table.Watchlist).
+		WHERE(condition)
+
+	if err := countStmt.QueryContext(ctx, conn(ctx, r.DB), &total); err != nil {
+		return nil, 0, err
+	}
+
+	from := table.Watchlist.LEFT_JOIN(table.Movies, table.Movies.ID.EQ(table.Watchlist.MovieID))
+
+	qb := SELECT(table.Watchlist.AllColumns).
+		FROM(from).
+		WHERE(condition).
+		ORDER_BY(watchlistOrderBy(query)...)
+
+	if query.PageSize > 0 {
+		qb = qb.LIMIT(int64(query.PageSize)).OFFSET(int64((query.Page - 1) * query.PageSize))
+	}
+
+	watchList := make([]model.Watchlist, 0)
+	err := qb.QueryContext(ctx, conn(ctx, r.DB), &watchList)
+
+	return watchList, total.Count, err
+}
+
+// FindBatch returns up to limit items of the user in the order they were
+// added, starting after the given item (nil for the first batch). Batches are
+// read by key rather than by offset, so items added or removed meanwhile don't
+// shift the following batches.
+func (r *WatchListRepository) FindBatch(ctx context.Context, userID int32, after *model.Watchlist, limit int) ([]model.Watchlist, error) {
+	condition := table.Watchlist.UserID.EQ(Int32(userID))
+	if after != nil {
+		addedAt := TimestampT(after.AddedAt)
+		condition = condition.AND(table.Watchlist.AddedAt.GT(addedAt).OR(
+			table.Watchlist.AddedAt.EQ(addedAt).AND(table.Watchlist.MovieID.GT(Int32(after.MovieID))),
+		))
+	}
+
+	qb := SELECT(table.Watchlist.AllColumns).
+		FROM(table.Watchlist).
+		WHERE(condition).
+		ORDER_BY(table.Watchlist.AddedAt.ASC(), table.Watchlist.MovieID.ASC()).
+		LIMIT(int64(limit))
+
+	batch := make([]model.Watchlist, 0, limit)
+	err := qb.QueryContext(ctx, conn(ctx, r.DB), &batch)
+
+	return batch, err
+}
+
+func watchlistConditions(userID int32, query dto.WatchListQueryDTO) BoolExpression {
+	condition := table.Watchlist.UserID.EQ(Int32(userID))
+
+	if len(query.Status) > 0 {
+		statuses := make([]Expression, len(query.Status))
+		for i, status := range query.Status {
+			statuses[i] = NewEnumValue(status)
+		}
+		condition = condition.AND(table.Watchlist.Status.IN(statuses...))
+	}
+
+	if query.Favorite != nil {
+		condition = condition.AND(table.Watchlist.Favorite.EQ(Bool(*query.Favorite)))
+	}
+
+	if query.RatingMin != nil {
+		condition = condition.AND(table.Watchlist.Rating.GT_EQ(Int32(int32(*query.RatingMin))))
+	}
+
+	if query.RatingMax != nil {
+		condition = condition.AND(table.Watchlist.Rating.LT_EQ(Int32(int32(*query.RatingMax))))
+	}
+
+	if query.HasComments != nil {
+		hasComments := table.Watchlist.Comments.IS_NOT_NULL().AND(table.Watchlist.Comments.NOT_EQ(String("")))
+		if !*query.HasComments {
+			hasComments = NOT(hasComments)
+		}
+		condition = condition.AND(hasComments)
+	}
+
+	return condition
+}
+
+// watchlistOrderBy sorts by the date the item was added unless told otherwise,
+// newest first except for titles. Titles come from the cached TMDB payload, so
+// items never cached sort last.
+func watchlistOrderBy(query dto.WatchListQueryDTO) []OrderByClause {
+	var column Expression
+
+	switch query.SortBy {
+	case "rating":
+		column = table.Watchlist.Rating
+	case "title":
+		column = Raw("movies.payload->>'title'")
+	default:
+		column = table.Watchlist.AddedAt
+	}
+
+	if query.Order == "asc" || (query.Order == "" && query.SortBy == "title") {
+		return []OrderByClause{column.ASC().NULLS_LAST(), table.Watchlist.MovieID.ASC()}
+	}
+
+	return []OrderByClause{column.DESC().NULLS_LAST(), table.Watchlist.MovieID.ASC()}
+}
+
+func (r *WatchListRepository) AddToWatchlist(ctx context.Context, userID int32, createDTO dto.WatchListCreateDTO) (model.Watchlist, error) {
 	var watchlistItem model.Watchlist
 	watchlistModel := model.Watchlist{
 		MovieID:  createDTO.MovieID,
@@ -64,52 +186,30 @@ func (r *WatchListRepository) AddToWatchlist(userID int32, createDTO dto.WatchLi
 	).MODEL(watchlistModel).
 		RETURNING(table.Watchlist.AllColumns)
 
-	err := insertStatement.Query(r.DB, &watchlistItem)
-	return watchlistItem, err
-}
+	err := withTx(ctx, r.DB, func(tx *sql.Tx) error {
+		if err := insertStatement.QueryContext(ctx, tx, &watchlistItem); err != nil {
+			return err
+		}
 
-func (r *WatchListRepository) UpdateWatchlistItem(userID int32, movieID int, status string, favorite *bool, comments string, rating *int) (model.Watchlist, error) {
-	var watchlistItem model.Watchlist
+		return insertStatusChange(ctx, tx, watchlistItem, nil)
+	})
 
-	fmt.Printf("🔍 DEBUG: Repository - Parâmetros recebidos - Status: '%s', Favorite: %v, Comments: '%s', Rating: %v\n",
-		status, favorite, comments, rating)
-	fmt.Printf("🔍 DEBUG: Repository - UserID: %d, MovieID: %d\n", userID, movieID)
+	return watchlistItem, err
+}
 
-	// First, check if the item exists
-	checkStmt := SELECT(table.Watchlist.AllColumns).
-		FROM(table.Watchlist).
-		WHERE(table.Watchlist.MovieID.EQ(Int32(int32(movieID))).AND(table.Watchlist.UserID.EQ(Int32(userID))))
+func (r *WatchListRepository) UpdateWatchlistItem(ctx context.Context, userID int32, movieID int, status string, favorite *bool, comments string, rating *int) (model.Watchlist, error) {
+	var newStatus *model.WatchStatus
 
-	var existingItem model.Watchlist
-	err := checkStmt.Query(r.DB, &existingItem)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return model.Watchlist{}, fmt.Errorf("watchlist item not found for movie_id=%d and user_id=%d", movieID, userID)
-		}
-		return model.Watchlist{}, err
-	}
-
-	fmt.Printf("🔍 DEBUG: Item encontrado - Status atual: '%s', Favorite: %v, Comments: '%s', Rating: %v\n",
-		existingItem.Status, existingItem.Favorite, existingItem.Comments, existingItem.Rating)
-	
 	// Create a slice of assignable expressions
-	assignments := []interface{}{}
+	assignments := []any{}
 
 	if status != "" {
-		var statusEnum postgres.StringExpression
-		switch status {
-		case "unwatched":
-			statusEnum = enum.WatchStatus.Unwatched
-		case "watching":
-			statusEnum = enum.WatchStatus.Watching
-		case "plan to watch":
-			statusEnum = enum.WatchStatus.PlanToWatch
-		case "watched":
-			statusEnum = enum.WatchStatus.Watched
-		default:
-			return model.Watchlist{}, fmt.Errorf("invalid status: %s", status)
+		statusEnum, err := watchStatusExpression(status)
+		if err != nil {
+			return model.Watchlist{}, err
 		}
 		assignments = append(assignments, table.Watchlist.Status.SET(statusEnum))
+		newStatus = (*model.WatchStatus)(&status)
 	}
 	if favorite != nil {
 		assignments = append(assignments, table.Watchlist.Favorite.SET(Bool(*favorite)))
@@ -122,96 +222,160 @@ func (r *WatchListRepository) UpdateWatchlistItem(userID int32, movieID int, sta
 	}
 
 	if len(assignments) == 0 {
-		fmt.Println("🔍 DEBUG: Nenhuma alteração foi solicitada, retornando o item existente.")
-		return existingItem, nil
-	}
-	
-	// Build the UPDATE statement with a single SET call
-	updateStmt := table.Watchlist.UPDATE().
-		SET(assignments[0], assignments[1:]...).
-		WHERE(table.Watchlist.MovieID.EQ(Int32(int32(movieID))).AND(table.Watchlist.UserID.EQ(Int32(userID)))).
-		RETURNING(table.Watchlist.AllColumns)
-
-	// Log the generated SQL query
-	sql, args := updateStmt.Sql()
-	fmt.Printf("🔍 DEBUG: SQL Query: %s\n", sql)
-	fmt.Printf("🔍 DEBUG: SQL Args: %v\n", args)
-	fmt.Printf("🔍 DEBUG: Número de argumentos SQL: %d\n", len(args))
+		var existingItem model.Watchlist
 
-	err = updateStmt.Query(r.DB, &watchlistItem)
+		checkStmt := SELECT(table.Watchlist.AllColumns).
+			FROM(table.Watchlist).
+			WHERE(watchlistItemCondition(userID, movieID))
 
-	if err != nil {
-		fmt.Printf("❌ DEBUG: Erro na query: %v\n", err)
-		return model.Watchlist{}, err
+		err := checkStmt.QueryContext(ctx, conn(ctx, r.DB), &existingItem)
+		if err == qrm.ErrNoRows {
+			return model.Watchlist{}, fmt.Errorf("watchlist item not found for movie_id=%d and user_id=%d", movieID, userID)
+		}
+		return existingItem, err
 	}
 
-	fmt.Printf("🔍 DEBUG: Repository - Resultado - Status: '%s', Favorite: %v, Comments: '%s', Rating: %v\n",
-		watchlistItem.Status, watchlistItem.Favorite, watchlistItem.Comments, watchlistItem.Rating)
-
-	return watchlistItem, err
+	return r.updateItem(ctx, userID, movieID, newStatus, assignments...)
 }
 
-func (r *WatchListRepository) RemoveFromWatchlist(userID int32, movieID int) error {
+func (r *WatchListRepository) RemoveFromWatchlist(ctx context.Context, userID int32, movieID int) error {
 	deleteStmt := table.Watchlist.DELETE().
-		WHERE(table.Watchlist.MovieID.EQ(Int32(int32(movieID))).AND(table.Watchlist.UserID.EQ(Int32(userID))))
+		WHERE(watchlistItemCondition(userID, movieID))
 
-	_, err := deleteStmt.Exec(r.DB)
+	_, err := deleteStmt.ExecContext(ctx, conn(ctx, r.DB))
 	return err
 }
 
-func (r *WatchListRepository) UpdateStatus(userID int32, movieID int, status string) (model.Watchlist, error) {
-	var watchlistItem model.Watchlist
+func (r *WatchListRepository) UpdateStatus(ctx context.Context, userID int32, movieID int, status string) (model.Watchlist, error) {
+	statusEnum, err := watchStatusExpression(status)
+	if err != nil {
+		return model.Watchlist{}, err
+	}
 
-	// Converter string para enum value
-	var statusEnum postgres.StringExpression
-	switch status {
-	case "unwatched":
-		statusEnum = enum.WatchStatus.Unwatched
-	case "watching":
-		statusEnum = enum.WatchStatus.Watching
-	case "plan to watch":
-		statusEnum = enum.WatchStatus.PlanToWatch
-	case "watched":
-		statusEnum = enum.WatchStatus.Watched
-	default:
-		return model.Watchlist{}, fmt.Errorf("invalid status: %s", status)
+	return r.updateItem(ctx, userID, movieID, (*model.WatchStatus)(&status), table.Watchlist.Status.SET(statusEnum))
+}
+
+func (r *WatchListRepository) ToggleFavorite(ctx context.Context, userID int32, movieID int, favorite bool) (model.Watchlist, error) {
+	return r.updateItem(ctx, userID, movieID, nil, table.Watchlist.Favorite.SET(Bool(favorite)))
+}
+
+func (r *WatchListRepository) UpdateRating(ctx context.Context, userID int32, movieID int, rating *int) (model.Watchlist, error) {
+	if rating != nil {
+		return r.updateItem(ctx, userID, movieID, nil, table.Watchlist.Rating.SET(Int32(int32(*rating))))
 	}
 
-	updateStmt := table.Watchlist.UPDATE().
-		SET(table.Watchlist.Status.SET(statusEnum)).
-		WHERE(table.Watchlist.MovieID.EQ(Int32(int32(movieID))).AND(table.Watchlist.UserID.EQ(Int32(userID)))).
-		RETURNING(table.Watchlist.AllColumns)
+	return r.updateItem(ctx, userID, movieID, nil, table.Watchlist.Rating.SET(CAST(NULL).AS_INTEGER()))
+}
 
-	err := updateStmt.Query(r.DB, &watchlistItem)
-	return watchlistItem, err
+func (r *WatchListRepository) GetStatusHistory(ctx context.Context, userID int32, movieID int) ([]model.WatchlistStatusHistory, error) {
+	qb := SELECT(table.WatchlistStatusHistory.AllColumns).
+		FROM(table.WatchlistStatusHistory).
+		WHERE(table.WatchlistStatusHistory.MovieID.EQ(Int32(int32(movieID))).AND(table.WatchlistStatusHistory.UserID.EQ(Int32(userID)))).
+		ORDER_BY(table.WatchlistStatusHistory.ChangedAt.ASC(), table.WatchlistStatusHistory.ID.ASC())
+
+	history := make([]model.WatchlistStatusHistory, 0)
+	err := qb.QueryContext(ctx, conn(ctx, r.DB), &history)
+
+	return history, err
 }
 
-func (r *WatchListRepository) ToggleFavorite(userID int32, movieID int, favorite bool) (model.Watchlist, error) {
+// updateItem applies the assignments to a single item and bumps updated_at.
+// When newStatus differs from the stored status, status_changed_at is bumped
+// as well and the transition is appended to the status history.
+func (r *WatchListRepository) updateItem(ctx context.Context, userID int32, movieID int, newStatus *model.WatchStatus, assignments ...any) (model.Watchlist, error) {
 	var watchlistItem model.Watchlist
 
-	updateStmt := table.Watchlist.UPDATE().
-		SET(table.Watchlist.Favorite.SET(Bool(favorite))).
-		WHERE(table.Watchlist.MovieID.EQ(Int32(int32(movieID))).AND(table.Watchlist.UserID.EQ(Int32(userID)))).
-		RETURNING(table.Watchlist.AllColumns)
+	err := withTx(ctx, r.DB, func(tx *sql.Tx) error {
+		var previous model.Watchlist
+		if newStatus != nil {
+			lockStmt := SELECT(table.Watchlist.AllColumns).
+				FROM(table.Watchlist).
+				WHERE(watchlistItemCondition(userID, movieID)).
+				FOR(UPDATE())
+
+			if err := lockStmt.QueryContext(ctx, tx, &previous); err != nil {
+				return err
+			}
+
+			if previous.Status == *newStatus {
+				newStatus = nil
+			} else {
+				assignments = append(assignments, table.Watchlist.StatusChangedAt.SET(LOCALTIMESTAMP()))
+			}
+		}
+
+		assignments = append(assignments, table.Watchlist.UpdatedAt.SET(LOCALTIMESTAMP()))
+
+		updateStmt := table.Watchlist.UPDATE().
+			SET(assignments[0], assignments[1:]...).
+			WHERE(watchlistItemCondition(userID, movieID)).
+			RETURNING(table.Watchlist.AllColumns)
+
+		if err := updateStmt.QueryContext(ctx, tx, &watchlistItem); err != nil {
+			return err
+		}
+
+		if newStatus == nil {
+			return nil
+		}
+
+		return insertStatusChange(ctx, tx, watchlistItem, &previous.Status)
+	})
 
-	err := updateStmt.Query(r.DB, &watchlistItem)
 	return watchlistItem, err
 }
 
-func (r *WatchListRepository) UpdateRating(userID int32, movieID int, rating *int) (model.Watchlist, error) {
-	var watchlistItem model.Watchlist
+// insertStatusChange appends the item's current status to its history. The
+// initial status of an item is recorded with no previous status.
+func insertStatusChange(ctx context.Context, db qrm.Executable, item model.Watchlist, fromStatus *model.WatchStatus) error {
+	historyStmt := table.WatchlistStatusHistory.INSERT(
+		table.WatchlistStatusHistory.MovieID,
+		table.WatchlistStatusHistory.UserID,
+		table.WatchlistStatusHistory.FromStatus,
+		table.WatchlistStatusHistory.ToStatus,
+		table.WatchlistStatusHistory.ChangedAt,
+	).MODEL(model.WatchlistStatusHistory{
+		MovieID:    item.MovieID,
+		UserID:     item.UserID,
+		FromStatus: fromStatus,
+		ToStatus:   item.Status,
+		ChangedAt:  item.StatusChangedAt,
+	})
+
+	_, err := historyStmt.ExecContext(ctx, db)
+	return err
+}
 
-	updateStmt := table.Watchlist.UPDATE().
-		WHERE(table.Watchlist.MovieID.EQ(Int32(int32(movieID))).AND(table.Watchlist.UserID.EQ(Int32(userID))))
+func watchlistItemCondition(userID int32, movieID int) BoolExpression {
+	return table.Watchlist.MovieID.EQ(Int32(int32(movieID))).AND(table.Watchlist.UserID.EQ(Int32(userID)))
+}
 
-	if rating != nil {
-		updateStmt = updateStmt.SET(table.Watchlist.Rating.SET(Int32(int32(*rating))))
-	} else {
-		updateStmt = updateStmt.SET(table.Watchlist.Rating.SET(CAST(NULL).AS_INTEGER()))
+func watchStatusExpression(status string) (StringExpression, error) {
+	switch status {
+	case "unwatched":
+		return enum.WatchStatus.Unwatched, nil
+	case "watching":
+		return enum.WatchStatus.Watching, nil
+	case "plan to watch":
+		return enum.WatchStatus.PlanToWatch, nil
+	case "watched":
+		return enum.WatchStatus.Watched, nil
+	default:
+		return nil, fmt.Errorf("invalid status: %s", status)
 	}
+}
+
+// FindRatedSince returns the rated items of every user updated at or after
+// since, the oldest first. Any change bumps updated_at, so items whose rating stayed
+// the same come along too.
+func (r *WatchListRepository) FindRatedSince(ctx context.Context, since time.Time) ([]model.Watchlist, error) {
+	qb := SELECT(table.Watchlist.AllColumns).
+		FROM(table.Watchlist).
+		WHERE(table.Watchlist.Rating.IS_NOT_NULL().AND(table.Watchlist.UpdatedAt.GT_EQ(TimestampT(since)))).
+		ORDER_BY(table.Watchlist.UpdatedAt.ASC(), table.Watchlist.UserID.ASC(), table.Watchlist.MovieID.ASC())
 
-	updateStmt = updateStmt.RETURNING(table.Watchlist.AllColumns)
+	rated := make([]model.Watchlist, 0)
+	err := qb.QueryContext(ctx, conn(ctx, r.DB), &rated)
 
-	err := updateStmt.Query(r.DB, &watchlistItem)
-	return watchlistItem, err
+	return rated, err
 }
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/mailer"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+const (
+	passwordResetTokenTTL     = time.Hour
+	emailVerificationTokenTTL = 48 * time.Hour
+)
+
+// IAccountService handles the flows confirmed through single-use tokens sent
+// by email: password recovery and email verification.
+type IAccountService interface {
+	IService
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) error
+	SendEmailVerification(ctx context.Context, user dto.UserDTO) error
+	VerifyEmail(ctx context.Context, token string) (dto.UserDTO, error)
+}
+
+type AccountService struct {
+	tokenRepo      repositories.IUserTokenRepository
+	mailer         mailer.Mailer
+	appURL         string
+	userService    IUserService
+	sessionService ISessionService
+}
+
+func newAccountService(params ServicesParams) IAccountService {
+	return &AccountService{
+		tokenRepo: params.Repos.UserTokenRepo,
+		mailer:    params.Conns.Mailer,
+		appURL:    params.Cfg.AppURL,
+	}
+}
+
+func (s *AccountService) ProvideServices(services Services) {
+	s.userService = services.UserService
+	s.sessionService = services.SessionService
+}
+
+// RequestPasswordReset emails a reset link when the address belongs to a
+// user. Unknown addresses are silently ignored and failures after the lookup
+// are only logged, so the outcome never tells which addresses are registered.
+func (s *AccountService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.userService.FindByEmail(ctx, email)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	token, err := s.issueToken(ctx, user.ID, model.UserTokenPurpose_PasswordReset, passwordResetTokenTTL)
+	if err != nil {
+		slog.Error("failed to issue a password reset token", "user_id", user.ID, "error", err)
+		return nil
+	}
+
+	err = s.mailer.Send(mailer.Message{
+		To:       user.Email,
+		Template: "password_reset",
+		Subject:  "Reset your Movie Tracker password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Someone asked to reset the password of your Movie Tracker account. "+
+			"Open the link below within the next hour to choose a new one:\n\n%s\n\n"+
+			"If it wasn't you, you can ignore this email.\n",
+			user.Name, s.link("/reset-password", token)),
+	})
+	if err != nil {
+		slog.Error("failed to send the password reset email", "user_id", user.ID, "error", err)
+	}
+
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token and signs the user out
+// of every device.
+func (s *AccountService) ResetPassword(ctx context.Context, token string, password string) error {
+	userToken, err := s.consumeToken(ctx, model.UserTokenPurpose_PasswordReset, token)
+	if err != nil {
+		return err
+	}
+
+	if err = s.userService.SetPassword(ctx, userToken.UserID, password); err != nil {
+		return err
+	}
+
+	return s.sessionService.RevokeAll(ctx, userToken.UserID)
+}
+
+func (s *AccountService) SendEmailVerification(ctx context.Context, user dto.UserDTO) error {
+	if user.IsEmailVerified() {
+		return utils.NewConflictError("error.account.email_already_verified")
+	}
+
+	token, err := s.issueToken(ctx, user.ID, model.UserTokenPurpose_EmailVerification, emailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(mailer.Message{
+		To:       user.Email,
+		Template: "email_verification",
+		Subject:  "Confirm your Movie Tracker email",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Open the link below to confirm this is your email address:\n\n%s\n",
+			user.Name, s.link("/verify-email", token)),
+	})
+}
+
+func (s *AccountService) VerifyEmail(ctx context.Context, token string) (dto.UserDTO, error) {
+	userToken, err := s.consumeToken(ctx, model.UserTokenPurpose_EmailVerification, token)
+	if err != nil {
+		return dto.UserDTO{}, err
+	}
+
+	return s.userService.MarkEmailVerified(ctx, userToken.UserID)
+}
+
+// issueToken creates a token for the user, invalidating the ones sent before.
+func (s *AccountService) issueToken(ctx context.Context, userID int32, purpose model.UserTokenPurpose, ttl time.Duration) (string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.tokenRepo.InvalidateByUser(ctx, userID, purpose); err != nil {
+		return "", err
+	}
+
+	_, err = s.tokenRepo.Create(ctx, model.UserTokens{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashSecretToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *AccountService) consumeToken(ctx context.Context, purpose model.UserTokenPurpose, token string) (model.UserTokens, error) {
+	userToken, err := s.tokenRepo.Consume(ctx, purpose, hashSecretToken(token))
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return userToken, utils.NewBadRequestError("error.account.invalid_token")
+		default:
+			return userToken, err
+		}
+	}
+
+	return userToken, nil
+}
+
+func (s *AccountService) link(path string, token string) string {
+	return s.appURL + path + "?token=" + url.QueryEscape(token)
+}
+
+func isNotFound(err error) bool {
+	var apiErr *utils.ApiError
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
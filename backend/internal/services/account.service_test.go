@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/mailer"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserTokenRepository) Create(_ context.Context, token model.UserTokens) (model.UserTokens, error) {
+	args := m.Called(token)
+	return args.Get(0).(model.UserTokens), args.Error(1)
+}
+
+func (m *MockUserTokenRepository) Consume(_ context.Context, purpose model.UserTokenPurpose, tokenHash string) (model.UserTokens, error) {
+	args := m.Called(purpose, tokenHash)
+	return args.Get(0).(model.UserTokens), args.Error(1)
+}
+
+func (m *MockUserTokenRepository) InvalidateByUser(_ context.Context, userID int32, purpose model.UserTokenPurpose) error {
+	args := m.Called(userID, purpose)
+	return args.Error(0)
+}
+
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) ProvideServices(Services) {}
+
+func (m *MockUserService) FindAll(_ context.Context) ([]dto.UserDTO, error) {
+	args := m.Called()
+	return args.Get(0).([]dto.UserDTO), args.Error(1)
+}
+
+func (m *MockUserService) FindByEmail(_ context.Context, email string) (dto.UserDTO, error) {
+	args := m.Called(email)
+	return args.Get(0).(dto.UserDTO), args.Error(1)
+}
+
+func (m *MockUserService) FindByUsername(_ context.Context, username string) (dto.UserDTO, error) {
+	args := m.Called(username)
+	return args.Get(0).(dto.UserDTO), args.Error(1)
+}
+
+func (m *MockUserService) FindByID(_ context.Context, id int32) (dto.UserDTO, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.UserDTO), args.Error(1)
+}
+
+func (m *MockUserService) Create(_ context.Context, userCreateDTO dto.UserCreateDTO) (dto.UserDTO, error) {
+	args := m.Called(userCreateDTO)
+	return args.Get(0).(dto.UserDTO), args.Error(1)
+}
+
+func (m *MockUserService) ValidatePassword(_ context.Context, username string, password string) error {
+	args := m.Called(username, password)
+	return args.Error(0)
+}
+
+func (m *MockUserService) ChangePassword(_ context.Context, userID int32, currentPassword string, newPassword string) error {
+	args := m.Called(userID, currentPassword, newPassword)
+	return args.Error(0)
+}
+
+func (m *MockUserService) SetPassword(_ context.Context, userID int32, password string) error {
+	args := m.Called(userID, password)
+	return args.Error(0)
+}
+
+func (m *MockUserService) MarkEmailVerified(_ context.Context, userID int32) (dto.UserDTO, error) {
+	args := m.Called(userID)
+	return args.Get(0).(dto.UserDTO), args.Error(1)
+}
+
+func (m *MockUserService) UpdatePreferences(_ context.Context, userID int32, preferences dto.UserPreferencesDTO) (dto.UserDTO, error) {
+	args := m.Called(userID, preferences)
+	return args.Get(0).(dto.UserDTO), args.Error(1)
+}
+
+func (m *MockUserService) Follow(_ context.Context, followerID int32, followedID int32) error {
+	args := m.Called(followerID, followedID)
+	return args.Error(0)
+}
+
+func (m *MockUserService) Unfollow(_ context.Context, followerID int32, followedID int32) error {
+	args := m.Called(followerID, followedID)
+	return args.Error(0)
+}
+
+func (m *MockUserService) GetFollowRequests(_ context.Context, userID int32) ([]dto.FollowRequestDTO, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]dto.FollowRequestDTO), args.Error(1)
+}
+
+func (m *MockUserService) AcceptFollower(_ context.Context, userID int32, followerID int32) error {
+	args := m.Called(userID, followerID)
+	return args.Error(0)
+}
+
+func (m *MockUserService) RemoveFollower(_ context.Context, userID int32, followerID int32) error {
+	args := m.Called(userID, followerID)
+	return args.Error(0)
+}
+
+// recordingMailer keeps the sent messages instead of delivering them.
+type recordingMailer struct {
+	sent []mailer.Message
+}
+
+func (m *recordingMailer) Send(message mailer.Message) error {
+	m.sent = append(m.sent, message)
+	return nil
+}
+
+func TestAccountService_RequestPasswordReset_SendsLink(t *testing.T) {
+	tokenRepo := new(MockUserTokenRepository)
+	userService := new(MockUserService)
+	sentMail := new(recordingMailer)
+	service := &AccountService{
+		tokenRepo:   tokenRepo,
+		mailer:      sentMail,
+		appURL:      "https://movies.example.com",
+		userService: userService,
+	}
+
+	userService.On("FindByEmail", "ana@example.com").Return(dto.UserDTO{ID: 1, Name: "Ana", Email: "ana@example.com"}, nil)
+	tokenRepo.On("InvalidateByUser", int32(1), model.UserTokenPurpose_PasswordReset).Return(nil)
+
+	var stored model.UserTokens
+	tokenRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(model.UserTokens)
+	}).Return(model.UserTokens{}, nil)
+
+	err := service.RequestPasswordReset(context.Background(), "ana@example.com")
+
+	assert.NoError(t, err)
+	if assert.Len(t, sentMail.sent, 1) {
+		assert.Equal(t, "ana@example.com", sentMail.sent[0].To)
+
+		// The emailed token is the one whose hash was stored
+		_, link, _ := strings.Cut(sentMail.sent[0].Body, "https://movies.example.com/reset-password?token=")
+		token, _, _ := strings.Cut(link, "\n")
+		assert.Equal(t, hashSecretToken(token), stored.TokenHash)
+	}
+	assert.Equal(t, model.UserTokenPurpose_PasswordReset, stored.Purpose)
+}
+
+func TestAccountService_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	tokenRepo := new(MockUserTokenRepository)
+	userService := new(MockUserService)
+	sentMail := new(recordingMailer)
+	service := &AccountService{tokenRepo: tokenRepo, mailer: sentMail, userService: userService}
+
+	userService.On("FindByEmail", "nobody@example.com").Return(dto.UserDTO{}, utils.NewNotFoundError("error.user.not_found"))
+
+	err := service.RequestPasswordReset(context.Background(), "nobody@example.com")
+
+	assert.NoError(t, err)
+	assert.Empty(t, sentMail.sent)
+	tokenRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+// failingMailer fails to deliver every message.
+type failingMailer struct{}
+
+func (failingMailer) Send(mailer.Message) error {
+	return errors.New("connection refused")
+}
+
+func TestAccountService_RequestPasswordReset_HidesMailerErrors(t *testing.T) {
+	tokenRepo := new(MockUserTokenRepository)
+	userService := new(MockUserService)
+	service := &AccountService{tokenRepo: tokenRepo, mailer: failingMailer{}, userService: userService}
+
+	userService.On("FindByEmail", "ana@example.com").Return(dto.UserDTO{ID: 1, Name: "Ana", Email: "ana@example.com"}, nil)
+	tokenRepo.On("InvalidateByUser", int32(1), model.UserTokenPurpose_PasswordReset).Return(nil)
+	tokenRepo.On("Create", mock.Anything).Return(model.UserTokens{}, nil)
+
+	err := service.RequestPasswordReset(context.Background(), "ana@example.com")
+
+	// Registered addresses answer like unknown ones even when the email can't be sent
+	assert.NoError(t, err)
+}
+
+func TestAccountService_ResetPassword_RevokesSessions(t *testing.T) {
+	tokenRepo := new(MockUserTokenRepository)
+	userService := new(MockUserService)
+	sessionRepo := new(MockSessionRepository)
+	service := &AccountService{
+		tokenRepo:      tokenRepo,
+		userService:    userService,
+		sessionService: &SessionService{repo: sessionRepo},
+	}
+
+	tokenRepo.On("Consume", model.UserTokenPurpose_PasswordReset, hashSecretToken("valid")).Return(model.UserTokens{UserID: 1}, nil)
+	tokenRepo.On("Consume", model.UserTokenPurpose_PasswordReset, hashSecretToken("used")).Return(model.UserTokens{}, qrm.ErrNoRows)
+	userService.On("SetPassword", int32(1), "new-password").Return(nil)
+	sessionRepo.On("RevokeAllByUser", int32(1)).Return(nil)
+
+	assert.NoError(t, service.ResetPassword(context.Background(), "valid", "new-password"))
+	assertApiError(t, service.ResetPassword(context.Background(), "used", "new-password"), http.StatusBadRequest, "error.account.invalid_token")
+
+	userService.AssertNumberOfCalls(t, "SetPassword", 1)
+	sessionRepo.AssertExpectations(t)
+}
+
+func TestAccountService_SendEmailVerification_AlreadyVerified(t *testing.T) {
+	sentMail := new(recordingMailer)
+	service := &AccountService{mailer: sentMail}
+
+	verifiedAt := time.Now()
+	err := service.SendEmailVerification(context.Background(), dto.UserDTO{ID: 1, EmailVerifiedAt: &verifiedAt})
+
+	assertApiError(t, err, http.StatusConflict, "error.account.email_already_verified")
+	assert.Empty(t, sentMail.sent)
+}
@@ -1,7 +1,9 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"log/slog"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -11,15 +13,19 @@ import (
 
 type IAuthService interface {
 	IService
-	Login(username string, password string) (string, error)
-	Register(userCreateDTO dto.UserCreateDTO) (dto.UserDTO, error)
-	ValidateToken(authToken string) (dto.UserDTO, error)
+	Login(ctx context.Context, username string, password string, client dto.SessionClientDTO) (dto.AuthResponseDTO, error)
+	Refresh(ctx context.Context, refreshToken string) (dto.AuthResponseDTO, error)
+	Logout(ctx context.Context, userID int32, sessionID int32) error
+	Register(ctx context.Context, userCreateDTO dto.UserCreateDTO) (dto.UserDTO, error)
+	ValidateToken(ctx context.Context, authToken string) (dto.UserDTO, int32, error)
 }
 
 type AuthService struct {
-	userService  IUserService
-	authSecret   []byte
-	authTokenTTL time.Duration
+	userService    IUserService
+	sessionService ISessionService
+	accountService IAccountService
+	authSecret     []byte
+	authTokenTTL   time.Duration
 }
 
 func newAuthService(params ServicesParams) IAuthService {
@@ -31,22 +37,61 @@ func newAuthService(params ServicesParams) IAuthService {
 
 func (s *AuthService) ProvideServices(services Services) {
 	s.userService = services.UserService
+	s.sessionService = services.SessionService
+	s.accountService = services.AccountService
 }
 
 type Claims struct {
-	Username string `json:"username"`
+	Username  string `json:"username"`
+	SessionID int32  `json:"sid"`
 	jwt.RegisteredClaims
 }
 
-func (s *AuthService) Login(username string, password string) (string, error) {
+func (s *AuthService) Login(ctx context.Context, username string, password string, client dto.SessionClientDTO) (dto.AuthResponseDTO, error) {
 	// Verificar a senha
-	if s.userService.ValidatePassword(username, password) != nil {
-		return "", utils.NewUnauthorizedError("error.login.invalid_credentials")
+	if s.userService.ValidatePassword(ctx, username, password) != nil {
+		return dto.AuthResponseDTO{}, utils.NewUnauthorizedError("error.login.invalid_credentials")
 	}
 
+	user, err := s.userService.FindByUsername(ctx, username)
+	if err != nil {
+		return dto.AuthResponseDTO{}, err
+	}
+
+	session, refreshToken, err := s.sessionService.Create(ctx, user.ID, client)
+	if err != nil {
+		return dto.AuthResponseDTO{}, err
+	}
+
+	return s.issueTokens(user.Username, session.ID, refreshToken)
+}
+
+// Refresh rotates the refresh token of a session and issues a new access token for it.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (dto.AuthResponseDTO, error) {
+	session, newRefreshToken, err := s.sessionService.Refresh(ctx, refreshToken)
+	if err != nil {
+		return dto.AuthResponseDTO{}, err
+	}
+
+	user, err := s.userService.FindByID(ctx, session.UserID)
+	if err != nil {
+		return dto.AuthResponseDTO{}, utils.NewUnauthorizedError("error.token.user_not_found")
+	}
+
+	return s.issueTokens(user.Username, session.ID, newRefreshToken)
+}
+
+// Logout revokes the session, which invalidates both its refresh token and
+// the access tokens issued for it.
+func (s *AuthService) Logout(ctx context.Context, userID int32, sessionID int32) error {
+	return s.sessionService.Revoke(ctx, userID, sessionID)
+}
+
+func (s *AuthService) issueTokens(username string, sessionID int32, refreshToken string) (dto.AuthResponseDTO, error) {
 	// Criar as claims do JWT
 	claims := &Claims{
-		Username: username,
+		Username:  username,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.authTokenTTL)),
 		},
@@ -56,22 +101,34 @@ func (s *AuthService) Login(username string, password string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString(s.authSecret)
 	if err != nil {
-		return "", errors.New("error.login.token_generation_failed")
+		return dto.AuthResponseDTO{}, errors.New("error.login.token_generation_failed")
 	}
 
-	return tokenString, nil
+	return dto.AuthResponseDTO{
+		AuthToken:    tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(s.authTokenTTL.Seconds()),
+	}, nil
 }
 
-func (s *AuthService) Register(userCreateDTO dto.UserCreateDTO) (dto.UserDTO, error) {
-	user, err := s.userService.Create(userCreateDTO)
+func (s *AuthService) Register(ctx context.Context, userCreateDTO dto.UserCreateDTO) (dto.UserDTO, error) {
+	user, err := s.userService.Create(ctx, userCreateDTO)
 	if err != nil {
 		return dto.UserDTO{}, err
 	}
 
+	// The account works without a verified email, so a failed email only
+	// gets logged; the user can ask for it again.
+	if err = s.accountService.SendEmailVerification(ctx, user); err != nil {
+		slog.Warn("failed to send email verification", "user_id", user.ID, "error", err)
+	}
+
 	return user, nil
 }
 
-func (s *AuthService) ValidateToken(authToken string) (dto.UserDTO, error) {
+// ValidateToken returns the user an access token belongs to and the session
+// it was issued for. Tokens of revoked or expired sessions are rejected.
+func (s *AuthService) ValidateToken(ctx context.Context, authToken string) (dto.UserDTO, int32, error) {
 	var claims Claims
 
 	token, err := jwt.ParseWithClaims(authToken, &claims, func(token *jwt.Token) (interface{}, error) {
@@ -81,15 +138,19 @@ func (s *AuthService) ValidateToken(authToken string) (dto.UserDTO, error) {
 		return s.authSecret, nil
 	})
 
-	if err != nil || !token.Valid {
-		return dto.UserDTO{}, utils.NewUnauthorizedError("error.token.invalid_or_expired")
+	if err != nil || !token.Valid || claims.SessionID == 0 {
+		return dto.UserDTO{}, 0, utils.NewUnauthorizedError("error.token.invalid_or_expired")
 	}
 
 	username := claims.Username
-	user, err := s.userService.FindByUsername(username)
+	user, err := s.userService.FindByUsername(ctx, username)
 	if err != nil {
-		return dto.UserDTO{}, utils.NewUnauthorizedError("error.token.user_not_found")
+		return dto.UserDTO{}, 0, utils.NewUnauthorizedError("error.token.user_not_found")
 	}
 
-	return user, nil
+	if err = s.sessionService.Validate(ctx, user.ID, claims.SessionID); err != nil {
+		return dto.UserDTO{}, 0, err
+	}
+
+	return user, claims.SessionID, nil
 }
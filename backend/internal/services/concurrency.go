@@ -0,0 +1,22 @@
+package services
+
+import "sync"
+
+// forEachConcurrently calls fn for every index up to n, running up to limit
+// calls at the same time, and waits for all of them.
+func forEachConcurrently(n int, limit int, fn func(i int)) {
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, limit)
+
+	for i := range n {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fn(i)
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+)
+
+// IContentPolicyService resolves the content policy applied to each viewer:
+// the one from the config, overridden by the user's parental profile.
+type IContentPolicyService interface {
+	IService
+	Resolve(ctx context.Context, viewer dto.ViewerDTO) (dto.ViewerDTO, error)
+	GetProfile(ctx context.Context, userID int32, viewer dto.ViewerDTO) (dto.ParentalProfileResponseDTO, error)
+	SaveProfile(ctx context.Context, userID int32, profile dto.ParentalProfileDTO, viewer dto.ViewerDTO) (dto.ParentalProfileResponseDTO, error)
+	DeleteProfile(ctx context.Context, userID int32) error
+}
+
+type ContentPolicyService struct {
+	repo          repositories.IParentalProfileRepository
+	defaultPolicy dto.ContentPolicyDTO
+
+	// Allowed certifications by the country whose rating scale they are in.
+	regionalCertifications map[string][]string
+}
+
+func newContentPolicyService(params ServicesParams) IContentPolicyService {
+	content := params.Cfg.Content
+
+	return &ContentPolicyService{
+		repo: params.Repos.ParentalProfileRepo,
+		defaultPolicy: dto.ContentPolicyDTO{
+			IncludeAdult:          content.IncludeAdult,
+			CertificationCountry:  content.CertificationCountry,
+			AllowedCertifications: content.AllowedCertifications,
+			ExcludedGenres:        content.ExcludedGenres,
+			BlockedKeywords:       content.BlockedKeywords,
+			MinVoteAverage:        content.MinVoteAverage,
+			MinVoteCount:          content.MinVoteCount,
+		},
+		regionalCertifications: content.RegionalCertifications,
+	}
+}
+
+func (s *ContentPolicyService) ProvideServices(services Services) {}
+
+// Resolve fills in the policy of the viewer, unless it was already resolved.
+// Viewers get the default policy, rated on the scale of their region when
+// there is one for it, unless their profile allows certifications of its own.
+func (s *ContentPolicyService) Resolve(ctx context.Context, viewer dto.ViewerDTO) (dto.ViewerDTO, error) {
+	if viewer.Policy != nil {
+		return viewer, nil
+	}
+
+	var profile dto.ParentalProfileDTO
+	if viewer.UserID != 0 {
+		var err error
+		if profile, err = s.findProfile(ctx, viewer.UserID); err != nil {
+			return viewer, err
+		}
+	}
+
+	rules := s.policyFor(viewer.Locale.Region, profile)
+	viewer.Policy = &rules
+	return viewer, nil
+}
+
+// GetProfile returns the profile of the user along with the policy it results
+// in for the viewer.
+func (s *ContentPolicyService) GetProfile(ctx context.Context, userID int32, viewer dto.ViewerDTO) (dto.ParentalProfileResponseDTO, error) {
+	profile, err := s.findProfile(ctx, userID)
+	if err != nil {
+		return dto.ParentalProfileResponseDTO{}, err
+	}
+
+	return s.profileResponse(profile, viewer), nil
+}
+
+// SaveProfile replaces the profile of the user. Certifications saved without
+// a country are kept on the scale of the default policy they were chosen from.
+func (s *ContentPolicyService) SaveProfile(ctx context.Context, userID int32, profile dto.ParentalProfileDTO, viewer dto.ViewerDTO) (dto.ParentalProfileResponseDTO, error) {
+	profile = s.withCertificationCountry(profile)
+	rules, err := json.Marshal(profile)
+	if err != nil {
+		return dto.ParentalProfileResponseDTO{}, err
+	}
+
+	saved, err := s.repo.Save(ctx, model.ParentalProfiles{UserID: userID, Rules: string(rules)})
+	if err != nil {
+		return dto.ParentalProfileResponseDTO{}, err
+	}
+
+	var savedProfile dto.ParentalProfileDTO
+	if err = savedProfile.FromModel(saved); err != nil {
+		return dto.ParentalProfileResponseDTO{}, err
+	}
+
+	return s.profileResponse(savedProfile, viewer), nil
+}
+
+// DeleteProfile goes back to the default policy.
+func (s *ContentPolicyService) DeleteProfile(ctx context.Context, userID int32) error {
+	return s.repo.Delete(ctx, userID)
+}
+
+// regionalPolicy returns the default policy with the certifications of the
+// region's rating scale, if one is configured.
+func (s *ContentPolicyService) regionalPolicy(region string) dto.ContentPolicyDTO {
+	rules := s.defaultPolicy
+
+	region = strings.ToUpper(region)
+	if certifications, ok := s.regionalCertifications[region]; ok && region != rules.CertificationCountry {
+		rules.CertificationCountry = region
+		rules.AllowedCertifications = certifications
+	}
+	return rules
+}
+
+// policyFor returns the policy applied in the region with the profile.
+func (s *ContentPolicyService) policyFor(region string, profile dto.ParentalProfileDTO) dto.ContentPolicyDTO {
+	return policy.WithProfile(s.regionalPolicy(region), s.withCertificationCountry(profile))
+}
+
+// withCertificationCountry sets the country of the certifications the profile
+// allows, when it has some, to the one of the default policy unless it names
+// one, as that is the scale they were chosen on.
+func (s *ContentPolicyService) withCertificationCountry(profile dto.ParentalProfileDTO) dto.ParentalProfileDTO {
+	if profile.AllowedCertifications != nil && profile.CertificationCountry == nil {
+		country := s.defaultPolicy.CertificationCountry
+		profile.CertificationCountry = &country
+	}
+	return profile
+}
+
+// findProfile returns the profile of the user, empty when they have none.
+func (s *ContentPolicyService) findProfile(ctx context.Context, userID int32) (dto.ParentalProfileDTO, error) {
+	var profile dto.ParentalProfileDTO
+
+	saved, err := s.repo.FindByUser(ctx, userID)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return profile, nil
+		default:
+			return profile, err
+		}
+	}
+
+	err = profile.FromModel(saved)
+	return profile, err
+}
+
+func (s *ContentPolicyService) profileResponse(profile dto.ParentalProfileDTO, viewer dto.ViewerDTO) dto.ParentalProfileResponseDTO {
+	return dto.ParentalProfileResponseDTO{
+		Profile: profile,
+		Policy:  s.policyFor(viewer.Locale.Region, profile),
+	}
+}
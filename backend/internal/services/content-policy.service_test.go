@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockParentalProfileRepository struct {
+	mock.Mock
+}
+
+func (m *MockParentalProfileRepository) FindByUser(_ context.Context, userID int32) (model.ParentalProfiles, error) {
+	args := m.Called(userID)
+	return args.Get(0).(model.ParentalProfiles), args.Error(1)
+}
+
+func (m *MockParentalProfileRepository) Save(_ context.Context, profile model.ParentalProfiles) (model.ParentalProfiles, error) {
+	args := m.Called(profile)
+	return args.Get(0).(model.ParentalProfiles), args.Error(1)
+}
+
+func (m *MockParentalProfileRepository) Delete(_ context.Context, userID int32) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func TestContentPolicyService_Resolve(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParentalProfileRepository)
+	service := &ContentPolicyService{
+		repo:          mockRepo,
+		defaultPolicy: dto.ContentPolicyDTO{ExcludedGenres: []int{27}, MinVoteAverage: 3},
+	}
+
+	mockRepo.On("FindByUser", int32(1)).Return(model.ParentalProfiles{UserID: 1, Rules: `{"excluded_genres":[27,16]}`}, nil)
+	mockRepo.On("FindByUser", int32(2)).Return(model.ParentalProfiles{}, qrm.ErrNoRows)
+
+	// Act
+	anonymous, errAnonymous := service.Resolve(context.Background(), dto.ViewerDTO{})
+	withProfile, errWithProfile := service.Resolve(context.Background(), dto.ViewerDTO{UserID: 1})
+	withoutProfile, errWithoutProfile := service.Resolve(context.Background(), dto.ViewerDTO{UserID: 2})
+
+	// Assert
+	assert.NoError(t, errors.Join(errAnonymous, errWithProfile, errWithoutProfile))
+	assert.Equal(t, []int{27}, anonymous.Policy.ExcludedGenres)
+	assert.Equal(t, []int{27, 16}, withProfile.Policy.ExcludedGenres)
+	assert.Equal(t, 3.0, withProfile.Policy.MinVoteAverage)
+	assert.Equal(t, []int{27}, withoutProfile.Policy.ExcludedGenres)
+	mockRepo.AssertNotCalled(t, "FindByUser", int32(0))
+}
+
+func TestContentPolicyService_Resolve_RegionalCertifications(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParentalProfileRepository)
+	service := &ContentPolicyService{
+		repo:                   mockRepo,
+		defaultPolicy:          dto.ContentPolicyDTO{CertificationCountry: "BR", AllowedCertifications: []string{"L", "10", "12"}},
+		regionalCertifications: map[string][]string{"US": {"G", "PG", "PG-13"}},
+	}
+
+	mockRepo.On("FindByUser", int32(1)).Return(model.ParentalProfiles{UserID: 1, Rules: `{"excluded_genres":[27]}`}, nil)
+
+	// Act
+	brazil, errBrazil := service.Resolve(context.Background(), dto.ViewerDTO{Locale: dto.LocaleDTO{Region: "BR"}})
+	us, errUS := service.Resolve(context.Background(), dto.ViewerDTO{Locale: dto.LocaleDTO{Region: "us"}})
+	japan, errJapan := service.Resolve(context.Background(), dto.ViewerDTO{Locale: dto.LocaleDTO{Region: "JP"}})
+	usProfile, errUSProfile := service.Resolve(context.Background(), dto.ViewerDTO{UserID: 1, Locale: dto.LocaleDTO{Region: "US"}})
+
+	// Assert
+	assert.NoError(t, errors.Join(errBrazil, errUS, errJapan, errUSProfile))
+	assert.Equal(t, "BR", brazil.Policy.CertificationCountry)
+	assert.Equal(t, []string{"L", "10", "12"}, brazil.Policy.AllowedCertifications)
+	assert.Equal(t, "US", us.Policy.CertificationCountry)
+	assert.Equal(t, []string{"G", "PG", "PG-13"}, us.Policy.AllowedCertifications)
+	// Regions without a scale of their own are rated on the default one
+	assert.Equal(t, "BR", japan.Policy.CertificationCountry)
+	assert.Equal(t, []string{"L", "10", "12"}, japan.Policy.AllowedCertifications)
+	// Profiles without certifications of their own keep the regional scale
+	assert.Equal(t, "US", usProfile.Policy.CertificationCountry)
+	assert.Equal(t, []string{"G", "PG", "PG-13"}, usProfile.Policy.AllowedCertifications)
+	assert.Equal(t, []int{27}, usProfile.Policy.ExcludedGenres)
+}
+
+func TestContentPolicyService_Resolve_ProfileCertificationsInOtherRegion(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParentalProfileRepository)
+	service := &ContentPolicyService{
+		repo:                   mockRepo,
+		defaultPolicy:          dto.ContentPolicyDTO{CertificationCountry: "BR", AllowedCertifications: []string{"L", "10", "12"}},
+		regionalCertifications: map[string][]string{"US": {"G", "PG", "PG-13"}, "GB": {"U", "PG", "12A"}},
+	}
+
+	mockRepo.On("FindByUser", int32(1)).Return(model.ParentalProfiles{UserID: 1, Rules: `{"allowed_certifications":["L","10"]}`}, nil)
+	mockRepo.On("FindByUser", int32(2)).Return(model.ParentalProfiles{UserID: 2, Rules: `{"allowed_certifications":["G"],"certification_country":"US"}`}, nil)
+
+	// Act
+	defaultScale, errDefault := service.Resolve(context.Background(), dto.ViewerDTO{UserID: 1, Locale: dto.LocaleDTO{Region: "US"}})
+	ownScale, errOwn := service.Resolve(context.Background(), dto.ViewerDTO{UserID: 2, Locale: dto.LocaleDTO{Region: "GB"}})
+
+	// Assert
+	assert.NoError(t, errors.Join(errDefault, errOwn))
+	// Certifications saved without a country are on the default scale
+	assert.Equal(t, "BR", defaultScale.Policy.CertificationCountry)
+	assert.Equal(t, []string{"L", "10"}, defaultScale.Policy.AllowedCertifications)
+	assert.Equal(t, "US", ownScale.Policy.CertificationCountry)
+	assert.Equal(t, []string{"G"}, ownScale.Policy.AllowedCertifications)
+}
+
+func TestContentPolicyService_SaveProfile_KeepsCertificationCountry(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockParentalProfileRepository)
+	service := &ContentPolicyService{
+		repo:                   mockRepo,
+		defaultPolicy:          dto.ContentPolicyDTO{CertificationCountry: "BR", AllowedCertifications: []string{"L", "10", "12"}},
+		regionalCertifications: map[string][]string{"US": {"G", "PG", "PG-13"}},
+	}
+	certifications := []string{"L"}
+	saved := model.ParentalProfiles{UserID: 1, Rules: `{"allowed_certifications":["L"],"certification_country":"BR","excluded_genres":null,"blocked_keywords":null,"min_vote_average":null,"min_vote_count":null}`}
+
+	mockRepo.On("Save", saved).Return(saved, nil)
+
+	// Act
+	response, err := service.SaveProfile(context.Background(), 1, dto.ParentalProfileDTO{AllowedCertifications: &certifications}, dto.ViewerDTO{UserID: 1, Locale: dto.LocaleDTO{Region: "US"}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "BR", *response.Profile.CertificationCountry)
+	assert.Equal(t, "BR", response.Policy.CertificationCountry)
+	assert.Equal(t, []string{"L"}, response.Policy.AllowedCertifications)
+}
+
+func TestMovieService_GetByID_HiddenByProfile(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockMovieRepository)
+	profileRepo := new(MockParentalProfileRepository)
+	service := &MovieService{
+		movieRepo:     mockRepo,
+		genreRepo:     newMockGenreRepository(),
+		contentPolicy: &ContentPolicyService{repo: profileRepo},
+	}
+
+	mockRepo.On("GetByID", 8587).Return(dto.TMDBMovieDTO{ID: 8587, Title: "The Lion King", GenreIDs: []int{16, 10751}}, nil)
+	profileRepo.On("FindByUser", int32(1)).Return(model.ParentalProfiles{UserID: 1, Rules: `{"excluded_genres":[16]}`}, nil)
+
+	// Act
+	_, errAnonymous := service.GetByID(context.Background(), 8587, dto.ViewerDTO{})
+	_, errUser := service.GetByID(context.Background(), 8587, dto.ViewerDTO{UserID: 1})
+
+	// Assert
+	assert.NoError(t, errAnonymous)
+
+	var hiddenErr *policy.HiddenError
+	if assert.ErrorAs(t, errUser, &hiddenErr) {
+		assert.Equal(t, http.StatusForbidden, hiddenErr.Code)
+		assert.Equal(t, []dto.HiddenReasonDTO{{Rule: policy.RuleExcludedGenre, Value: "16"}}, hiddenErr.Reasons)
+	}
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+type IDiaryService interface {
+	IService
+	GetByUser(ctx context.Context, userID int32, movieID *int32) ([]dto.WatchEventDTO, error)
+	LogWatch(ctx context.Context, userID int32, createDTO dto.WatchEventCreateDTO) (dto.WatchEventDTO, error)
+	Delete(ctx context.Context, userID int32, id int32) error
+}
+
+type DiaryService struct {
+	repo             repositories.IDiaryRepository
+	transactor       repositories.ITransactor
+	watchlistService IWatchList
+}
+
+func newDiaryService(params ServicesParams) IDiaryService {
+	return &DiaryService{
+		repo:       params.Repos.DiaryRepo,
+		transactor: params.Repos.Transactor,
+	}
+}
+
+func (s *DiaryService) ProvideServices(services Services) {
+	s.watchlistService = services.WatchlistService
+}
+
+func (s *DiaryService) GetByUser(ctx context.Context, userID int32, movieID *int32) ([]dto.WatchEventDTO, error) {
+	events, err := s.repo.FindByUser(ctx, userID, movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventDTOs := make([]dto.WatchEventDTO, len(events))
+	for i, event := range events {
+		eventDTOs[i].FromModel(event)
+	}
+
+	return eventDTOs, nil
+}
+
+// LogWatch records a viewing and moves the movie to watched in the user's
+// watchlist, adding it there first if needed. Both happen in one transaction,
+// so a viewing is never logged without its watchlist update.
+func (s *DiaryService) LogWatch(ctx context.Context, userID int32, createDTO dto.WatchEventCreateDTO) (dto.WatchEventDTO, error) {
+	var eventDTO dto.WatchEventDTO
+
+	event, err := createDTO.ToModel(userID)
+	if err != nil {
+		return eventDTO, utils.NewBadRequestError("error.diary.invalid_date")
+	}
+
+	var createdEvent model.WatchEvents
+	err = s.transactor.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if createdEvent, err = s.repo.Create(ctx, event); err != nil {
+			return err
+		}
+
+		return s.markWatched(ctx, userID, createdEvent.MovieID)
+	})
+	if err != nil {
+		return eventDTO, err
+	}
+
+	eventDTO.FromModel(createdEvent)
+	return eventDTO, nil
+}
+
+func (s *DiaryService) markWatched(ctx context.Context, userID int32, movieID int32) error {
+	_, err := s.watchlistService.UpdateStatus(ctx, userID, int(movieID), string(model.WatchStatus_Watched))
+	if errors.Is(err, qrm.ErrNoRows) {
+		_, err = s.watchlistService.AddToWatchlist(ctx, userID, dto.WatchListCreateDTO{
+			MovieID: movieID,
+			Status:  model.WatchStatus_Watched,
+		})
+	}
+
+	return err
+}
+
+func (s *DiaryService) Delete(ctx context.Context, userID int32, id int32) error {
+	_, err := s.repo.Delete(ctx, userID, id)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return utils.NewNotFoundError("error.diary.not_found")
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
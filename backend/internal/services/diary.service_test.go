@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeTransactor runs the unit of work inline and records its outcome, so
+// tests can tell whether it would have been committed or rolled back.
+type fakeTransactor struct {
+	calls      int
+	rolledBack bool
+}
+
+func (t *fakeTransactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.calls++
+	err := fn(ctx)
+	t.rolledBack = err != nil
+	return err
+}
+
+type MockDiaryRepository struct {
+	mock.Mock
+}
+
+func (m *MockDiaryRepository) FindByUser(_ context.Context, userID int32, movieID *int32) ([]model.WatchEvents, error) {
+	args := m.Called(userID, movieID)
+	return args.Get(0).([]model.WatchEvents), args.Error(1)
+}
+
+func (m *MockDiaryRepository) Create(_ context.Context, event model.WatchEvents) (model.WatchEvents, error) {
+	args := m.Called(event)
+	return args.Get(0).(model.WatchEvents), args.Error(1)
+}
+
+func (m *MockDiaryRepository) Delete(_ context.Context, userID int32, id int32) (model.WatchEvents, error) {
+	args := m.Called(userID, id)
+	return args.Get(0).(model.WatchEvents), args.Error(1)
+}
+
+func newTestDiaryService() (*DiaryService, *MockDiaryRepository, *MockWatchListRepository, *fakeTransactor) {
+	diaryRepo := new(MockDiaryRepository)
+	watchlistRepo := new(MockWatchListRepository)
+	transactor := &fakeTransactor{}
+
+	return &DiaryService{
+		repo:             diaryRepo,
+		transactor:       transactor,
+		watchlistService: &WatchListService{repo: watchlistRepo},
+	}, diaryRepo, watchlistRepo, transactor
+}
+
+func TestDiaryService_LogWatch_UpdatesWatchlistItem(t *testing.T) {
+	// Arrange
+	service, diaryRepo, watchlistRepo, transactor := newTestDiaryService()
+	rating := int32(8)
+	watchedOn := time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC)
+
+	diaryRepo.On("Create", model.WatchEvents{UserID: 1, MovieID: 550, WatchedOn: watchedOn, Rating: &rating}).
+		Return(model.WatchEvents{ID: 7, UserID: 1, MovieID: 550, WatchedOn: watchedOn, Rating: &rating}, nil)
+	watchlistRepo.On("UpdateStatus", int32(1), 550, "watched").
+		Return(model.Watchlist{UserID: 1, MovieID: 550, Status: model.WatchStatus_Watched}, nil)
+
+	// Act
+	event, err := service.LogWatch(context.Background(), 1, dto.WatchEventCreateDTO{MovieID: 550, WatchedOn: "2025-06-05", Rating: &rating})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), event.ID)
+	assert.Equal(t, "2025-06-05", event.WatchedOn)
+	assert.Equal(t, 1, transactor.calls)
+	assert.False(t, transactor.rolledBack)
+	watchlistRepo.AssertNotCalled(t, "AddToWatchlist", mock.Anything, mock.Anything)
+	diaryRepo.AssertExpectations(t)
+	watchlistRepo.AssertExpectations(t)
+}
+
+func TestDiaryService_LogWatch_AddsMissingWatchlistItem(t *testing.T) {
+	// Arrange
+	service, diaryRepo, watchlistRepo, transactor := newTestDiaryService()
+
+	diaryRepo.On("Create", mock.Anything).Return(model.WatchEvents{ID: 7, UserID: 1, MovieID: 550}, nil)
+	watchlistRepo.On("UpdateStatus", int32(1), 550, "watched").Return(model.Watchlist{}, qrm.ErrNoRows)
+	watchlistRepo.On("AddToWatchlist", int32(1), dto.WatchListCreateDTO{MovieID: 550, Status: model.WatchStatus_Watched}).
+		Return(model.Watchlist{UserID: 1, MovieID: 550, Status: model.WatchStatus_Watched}, nil)
+
+	// Act
+	_, err := service.LogWatch(context.Background(), 1, dto.WatchEventCreateDTO{MovieID: 550})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, transactor.rolledBack)
+	watchlistRepo.AssertExpectations(t)
+}
+
+func TestDiaryService_LogWatch_RollsBackWhenWatchlistFails(t *testing.T) {
+	// Arrange
+	service, diaryRepo, watchlistRepo, transactor := newTestDiaryService()
+
+	diaryRepo.On("Create", mock.Anything).Return(model.WatchEvents{ID: 7, UserID: 1, MovieID: 550}, nil)
+	watchlistRepo.On("UpdateStatus", int32(1), 550, "watched").Return(model.Watchlist{}, errors.New("connection reset"))
+
+	// Act
+	_, err := service.LogWatch(context.Background(), 1, dto.WatchEventCreateDTO{MovieID: 550})
+
+	// Assert
+	assert.EqualError(t, err, "connection reset")
+	assert.True(t, transactor.rolledBack)
+}
+
+func TestDiaryService_LogWatch_InvalidDate(t *testing.T) {
+	// Arrange
+	service, diaryRepo, _, transactor := newTestDiaryService()
+
+	// Act
+	_, err := service.LogWatch(context.Background(), 1, dto.WatchEventCreateDTO{MovieID: 550, WatchedOn: "2025-02-30"})
+
+	// Assert
+	assertApiError(t, err, http.StatusBadRequest, "error.diary.invalid_date")
+	assert.Zero(t, transactor.calls)
+	diaryRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestDiaryService_Delete(t *testing.T) {
+	// Arrange
+	service, diaryRepo, _, _ := newTestDiaryService()
+
+	diaryRepo.On("Delete", int32(1), int32(7)).Return(model.WatchEvents{ID: 7, UserID: 1}, nil)
+	diaryRepo.On("Delete", int32(1), int32(8)).Return(model.WatchEvents{}, qrm.ErrNoRows)
+
+	// Act & Assert
+	assert.NoError(t, service.Delete(context.Background(), 1, 7))
+	assertApiError(t, service.Delete(context.Background(), 1, 8), http.StatusNotFound, "error.diary.not_found")
+}
@@ -2,7 +2,14 @@ package dto
 
 // AuthResponseDTO represents the authentication response
 type AuthResponseDTO struct {
-	AuthToken string `json:"authToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	AuthToken    string `json:"authToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	RefreshToken string `json:"refreshToken" example:"q2w8ZP0sJ1l6cY..."`
+	ExpiresIn    int    `json:"expiresIn" example:"900"`
+}
+
+// RefreshRequestDTO represents the request body for exchanging a refresh token
+type RefreshRequestDTO struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
 }
 
 // ErrorResponseDTO represents an error response
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+)
+
+// ContentPolicyDTO is the set of rules deciding which titles are shown
+type ContentPolicyDTO struct {
+	IncludeAdult          bool     `json:"include_adult"`
+	CertificationCountry  string   `json:"certification_country" example:"BR"`
+	AllowedCertifications []string `json:"allowed_certifications" example:"L,10,12"`
+	ExcludedGenres        []int    `json:"excluded_genres" example:"27,10749"`
+	BlockedKeywords       []string `json:"blocked_keywords"`
+	MinVoteAverage        float64  `json:"min_vote_average" example:"3"`
+	MinVoteCount          int      `json:"min_vote_count" example:"100"`
+}
+
+// ParentalProfileDTO overrides the rules of the content policy for a user.
+// Rules left null keep the value of the default policy. The allowed
+// certifications are on the rating scale of CertificationCountry, the one of
+// the default policy when null, whatever the region of the viewer.
+type ParentalProfileDTO struct {
+	AllowedCertifications *[]string `json:"allowed_certifications" binding:"omitempty,dive,required"`
+	CertificationCountry  *string   `json:"certification_country" binding:"omitempty,iso3166_1_alpha2" example:"BR"`
+	ExcludedGenres        *[]int    `json:"excluded_genres" binding:"omitempty,dive,min=1"`
+	BlockedKeywords       *[]string `json:"blocked_keywords" binding:"omitempty,dive,required"`
+	MinVoteAverage        *float64  `json:"min_vote_average" binding:"omitempty,min=0,max=10"`
+	MinVoteCount          *int      `json:"min_vote_count" binding:"omitempty,min=0"`
+}
+
+func (p *ParentalProfileDTO) FromModel(profile model.ParentalProfiles) error {
+	*p = ParentalProfileDTO{}
+	return json.Unmarshal([]byte(profile.Rules), p)
+}
+
+// ParentalProfileResponseDTO represents the parental profile of a user along
+// with the policy it results in
+type ParentalProfileResponseDTO struct {
+	Profile ParentalProfileDTO `json:"profile"`
+	Policy  ContentPolicyDTO   `json:"policy"`
+}
+
+// HiddenReasonDTO explains which rule of the content policy hid a title
+type HiddenReasonDTO struct {
+	Rule  string `json:"rule" example:"excluded_genre"`
+	Value string `json:"value,omitempty" example:"27"`
+}
+
+// ViewerDTO identifies who movie data is served to: the locale it is
+// translated to and the user whose parental profile filters it. UserID is
+// zero for anonymous requests.
+type ViewerDTO struct {
+	UserID int32
+	Locale LocaleDTO
+
+	// Policy of the viewer, looked up from UserID when nil.
+	Policy *ContentPolicyDTO
+}
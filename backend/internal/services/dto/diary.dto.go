@@ -0,0 +1,61 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+)
+
+// WatchEventDTO represents a single viewing logged in the user's diary
+type WatchEventDTO struct {
+	ID        int32     `json:"id"`
+	MovieID   int32     `json:"movie_id"`
+	UserID    int32     `json:"user_id"`
+	WatchedOn string    `json:"watched_on" example:"2025-06-05"`
+	Rating    *int32    `json:"rating,omitempty"`
+	Rewatch   bool      `json:"rewatch"`
+	Notes     *string   `json:"notes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (e *WatchEventDTO) FromModel(event model.WatchEvents) {
+	*e = WatchEventDTO{
+		ID:        event.ID,
+		MovieID:   event.MovieID,
+		UserID:    event.UserID,
+		WatchedOn: event.WatchedOn.Format(time.DateOnly),
+		Rating:    event.Rating,
+		Rewatch:   event.Rewatch,
+		Notes:     event.Notes,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+// WatchEventCreateDTO represents the request body for logging a viewing. WatchedOn defaults to today.
+type WatchEventCreateDTO struct {
+	MovieID   int32   `json:"movie_id" binding:"required"`
+	WatchedOn string  `json:"watched_on,omitempty" binding:"omitempty,datetime=2006-01-02" example:"2025-06-05"`
+	Rating    *int32  `json:"rating,omitempty" binding:"omitempty,min=1,max=10" example:"8"`
+	Rewatch   bool    `json:"rewatch,omitempty" example:"false"`
+	Notes     *string `json:"notes,omitempty" example:"Watched it at the cinema"`
+}
+
+func (e WatchEventCreateDTO) ToModel(userID int32) (model.WatchEvents, error) {
+	watchedOn := time.Now()
+
+	if e.WatchedOn != "" {
+		var err error
+		if watchedOn, err = time.Parse(time.DateOnly, e.WatchedOn); err != nil {
+			return model.WatchEvents{}, err
+		}
+	}
+
+	return model.WatchEvents{
+		UserID:    userID,
+		MovieID:   e.MovieID,
+		WatchedOn: watchedOn,
+		Rating:    e.Rating,
+		Rewatch:   e.Rewatch,
+		Notes:     e.Notes,
+	}, nil
+}
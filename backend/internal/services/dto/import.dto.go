@@ -0,0 +1,40 @@
+package dto
+
+// ImportReportDTO represents the outcome of an import. Imported is only set when the import was committed.
+// Failed holds the rows that couldn't be searched for, which importing the file again retries.
+type ImportReportDTO struct {
+	DryRun    bool              `json:"dry_run"`
+	Matched   []ImportRowDTO    `json:"matched"`
+	Ambiguous []ImportRowDTO    `json:"ambiguous"`
+	Unmatched []ImportRowDTO    `json:"unmatched"`
+	Failed    []ImportRowDTO    `json:"failed"`
+	Imported  *ImportSummaryDTO `json:"imported,omitempty"`
+}
+
+// ImportRowDTO represents a single row of an imported file and the movie it was matched to
+type ImportRowDTO struct {
+	Source     string               `json:"source" example:"ratings"`
+	Line       int                  `json:"line" example:"2"`
+	Name       string               `json:"name" example:"Parasite"`
+	Year       string               `json:"year" example:"2019"`
+	Rating     *int32               `json:"rating,omitempty" example:"9"`
+	WatchedOn  string               `json:"watched_on,omitempty" example:"2024-02-03"`
+	Rewatch    bool                 `json:"rewatch,omitempty"`
+	MovieID    *int32               `json:"movie_id,omitempty" example:"496243"`
+	Candidates []ImportCandidateDTO `json:"candidates,omitempty"`
+}
+
+// ImportCandidateDTO represents a movie that could correspond to an ambiguous row
+type ImportCandidateDTO struct {
+	ID            int    `json:"id" example:"496243"`
+	Title         string `json:"title" example:"Parasita"`
+	OriginalTitle string `json:"original_title" example:"기생충"`
+	Year          string `json:"year" example:"2019"`
+}
+
+// ImportSummaryDTO represents what a committed import changed
+type ImportSummaryDTO struct {
+	WatchlistAdded   int `json:"watchlist_added"`
+	WatchlistUpdated int `json:"watchlist_updated"`
+	DiaryEntries     int `json:"diary_entries"`
+}
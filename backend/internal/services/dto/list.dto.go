@@ -0,0 +1,83 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+)
+
+// ListDTO represents a named, user-owned movie list
+type ListDTO struct {
+	ID          int32                `json:"id"`
+	UserID      int32                `json:"user_id"`
+	Name        string               `json:"name" example:"Halloween marathon"`
+	Description *string              `json:"description,omitempty"`
+	Visibility  model.ListVisibility `json:"visibility" example:"private"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+	Entries     []ListEntryDTO       `json:"entries,omitempty"`
+}
+
+func (l *ListDTO) FromModel(list model.Lists) {
+	*l = ListDTO{
+		ID:          list.ID,
+		UserID:      list.UserID,
+		Name:        list.Name,
+		Description: list.Description,
+		Visibility:  list.Visibility,
+		CreatedAt:   list.CreatedAt,
+		UpdatedAt:   list.UpdatedAt,
+	}
+}
+
+// ListEntryDTO represents a movie in a list. Entries are ordered by position, starting at 1.
+type ListEntryDTO struct {
+	MovieID  int32     `json:"movie_id"`
+	Position int32     `json:"position"`
+	Note     *string   `json:"note,omitempty"`
+	AddedAt  time.Time `json:"added_at"`
+}
+
+func (e *ListEntryDTO) FromModel(entry model.ListEntries) {
+	*e = ListEntryDTO{
+		MovieID:  entry.MovieID,
+		Position: entry.Position,
+		Note:     entry.Note,
+		AddedAt:  entry.AddedAt,
+	}
+}
+
+// ListCreateDTO represents the request body for creating a list
+type ListCreateDTO struct {
+	Name        string               `json:"name" binding:"required,max=100" example:"Oscar 2026 nominees"`
+	Description *string              `json:"description,omitempty" binding:"omitempty,max=1000"`
+	Visibility  model.ListVisibility `json:"visibility,omitempty" binding:"omitempty,oneof=private unlisted public" example:"private"`
+}
+
+// IsShared reports whether the list would be visible to other users.
+func (l ListCreateDTO) IsShared() bool {
+	return l.Visibility != "" && l.Visibility != model.ListVisibility_Private
+}
+
+// ListUpdateDTO represents the request body for updating a list. Omitted fields are left unchanged.
+type ListUpdateDTO struct {
+	Name        *string               `json:"name,omitempty" binding:"omitempty,min=1,max=100"`
+	Description *string               `json:"description,omitempty" binding:"omitempty,max=1000"`
+	Visibility  *model.ListVisibility `json:"visibility,omitempty" binding:"omitempty,oneof=private unlisted public"`
+}
+
+// IsShared reports whether the update makes the list visible to other users.
+func (l ListUpdateDTO) IsShared() bool {
+	return l.Visibility != nil && *l.Visibility != model.ListVisibility_Private
+}
+
+// ListEntryCreateDTO represents the request body for adding a movie to a list
+type ListEntryCreateDTO struct {
+	MovieID int32   `json:"movie_id" binding:"required" example:"550"`
+	Note    *string `json:"note,omitempty" binding:"omitempty,max=1000"`
+}
+
+// ListReorderDTO represents the request body for reordering a list. It must contain every movie of the list exactly once.
+type ListReorderDTO struct {
+	MovieIDs []int32 `json:"movie_ids" binding:"required" example:"550,680,13"`
+}
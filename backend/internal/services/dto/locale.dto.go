@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"golang.org/x/text/language"
+)
+
+// LocaleDTO is the language and region movie data is served in. Empty fields
+// fall back to the API defaults.
+type LocaleDTO struct {
+	Language string `json:"language" example:"pt-BR"` // BCP 47 language tag
+	Region   string `json:"region" example:"BR"`      // ISO 3166-1 alpha-2 country code
+}
+
+// WithFallback fills the fields missing from the locale with the ones of fallback.
+func (l LocaleDTO) WithFallback(fallback LocaleDTO) LocaleDTO {
+	if l.Language == "" {
+		l.Language = fallback.Language
+	}
+	if l.Region == "" {
+		l.Region = fallback.Region
+	}
+	return l
+}
+
+// LanguageCode returns the ISO 639-1 code of the language, e.g. "pt" for "pt-BR".
+func (l LocaleDTO) LanguageCode() string {
+	if l.Language == "" {
+		return ""
+	}
+
+	base, _ := language.Make(l.Language).Base()
+	return base.String()
+}
+
+// CountryCode returns the country of the language variant, e.g. "BR" for
+// "pt-BR", falling back to the region when the language names none.
+func (l LocaleDTO) CountryCode() string {
+	if l.Language != "" {
+		if region, confidence := language.Make(l.Language).Region(); confidence == language.Exact {
+			return region.String()
+		}
+	}
+	return l.Region
+}
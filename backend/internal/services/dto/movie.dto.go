@@ -1,28 +1,90 @@
 package dto
 
 type MovieDTO struct {
-	ID                  int      `json:"id"`
-	Title               string   `json:"title"`
-	PosterPath          string   `json:"poster_path"`
-	BackgroundPath      string   `json:"background_path"`
-	Year                string   `json:"year"`
-	Description         string   `json:"description"`
-	Genre               []string `json:"genre"`
-	Duration            string   `json:"duration"`
-	Tagline             string   `json:"tagline"`
-	VoteAverage         float64  `json:"vote_average"`
-	VoteCount           int      `json:"vote_count"`
-	Popularity          float64  `json:"popularity"`
-	Status              string   `json:"status"`
-	ReleaseDate         string   `json:"release_date"`
-	OriginalTitle       string   `json:"original_title"`
-	OriginalLanguage    string   `json:"original_language"`
-	Homepage            string   `json:"homepage"`
-	ImdbID              *string  `json:"imdb_id"`
-	Budget              int      `json:"budget"`
-	Revenue             int      `json:"revenue"`
-	Runtime             int      `json:"runtime"`
-	ProductionCompanies []any    `json:"production_companies"`
-	ProductionCountries []any    `json:"production_countries"`
-	SpokenLanguages     []any    `json:"spoken_languages"`
+	ID                  int                   `json:"id"`
+	Title               string                `json:"title"`
+	PosterPath          string                `json:"poster_path"`
+	BackgroundPath      string                `json:"background_path"`
+	Year                string                `json:"year"`
+	Description         string                `json:"description"`
+	Genre               []string              `json:"genre"` // Deprecated: names differ by language, use Genres
+	Genres              []GenreDTO            `json:"genres"`
+	Duration            string                `json:"duration"`
+	Tagline             string                `json:"tagline"`
+	VoteAverage         float64               `json:"vote_average"`
+	VoteCount           int                   `json:"vote_count"`
+	Popularity          float64               `json:"popularity"`
+	Status              string                `json:"status"`
+	ReleaseDate         string                `json:"release_date"`
+	OriginalTitle       string                `json:"original_title"`
+	OriginalLanguage    string                `json:"original_language"`
+	Homepage            string                `json:"homepage"`
+	ImdbID              *string               `json:"imdb_id"`
+	Budget              int                   `json:"budget"`
+	Revenue             int                   `json:"revenue"`
+	Runtime             int                   `json:"runtime"`
+	ProductionCompanies []any                 `json:"production_companies"`
+	ProductionCountries []any                 `json:"production_countries"`
+	SpokenLanguages     []any                 `json:"spoken_languages"`
+	Cast                []CastDTO             `json:"cast,omitempty"`
+	Crew                []CrewDTO             `json:"crew,omitempty"`
+	WatchProviders      *WatchAvailabilityDTO `json:"watch_providers,omitempty"`
+	Videos              []VideoDTO            `json:"videos,omitempty"`
+	Trailer             *VideoDTO             `json:"trailer,omitempty"`
+}
+
+// VideoDTO represents a trailer, teaser, clip or featurette of a movie, hosted
+// on Site under Key. URL is empty for sites that can't be linked to.
+type VideoDTO struct {
+	ID          string `json:"id"`
+	Name        string `json:"name" example:"Trailer Legendado"`
+	Type        string `json:"type" example:"Trailer"`
+	Site        string `json:"site" example:"YouTube"`
+	Key         string `json:"key"`
+	URL         string `json:"url"`
+	Language    string `json:"language" example:"pt"`
+	Country     string `json:"country" example:"BR"`
+	Official    bool   `json:"official"`
+	Size        int    `json:"size" example:"1080"`
+	PublishedAt string `json:"published_at"`
+}
+
+// MoviePageQueryDTO represents the page of movies asked for: the cursor
+// returned with the previous page, or nothing for the first one. Page numbers
+// are still accepted for older clients but may skip titles.
+type MoviePageQueryDTO struct {
+	Cursor   string `form:"cursor"`
+	Page     int    `form:"page" binding:"omitempty,min=1,max=500"`
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=60"`
+}
+
+// MovieDiscoverFiltersDTO represents the filters and sort order accepted when
+// discovering movies. Movies matching any of the genres are included, and
+// those in any of the excluded genres left out. Ranges are inclusive.
+type MovieDiscoverFiltersDTO struct {
+	WithGenres       []int   `form:"with_genres" binding:"omitempty,dive,min=1"`
+	WithoutGenres    []int   `form:"without_genres" binding:"omitempty,dive,min=1"`
+	YearFrom         *int    `form:"year_from" binding:"omitempty,min=1870,max=2100"`
+	YearTo           *int    `form:"year_to" binding:"omitempty,min=1870,max=2100"`
+	RuntimeMin       *int    `form:"runtime_min" binding:"omitempty,min=0,max=1000"`
+	RuntimeMax       *int    `form:"runtime_max" binding:"omitempty,min=0,max=1000"`
+	MinVoteAverage   float64 `form:"min_vote_average" binding:"omitempty,min=0,max=10"`
+	MinVoteCount     *int    `form:"min_vote_count" binding:"omitempty,min=0"`
+	OriginalLanguage string  `form:"original_language" binding:"omitempty,len=2,lowercase"`
+	SortBy           string  `form:"sort_by" binding:"omitempty,oneof=popularity.desc popularity.asc vote_average.desc vote_average.asc vote_count.desc vote_count.asc primary_release_date.desc primary_release_date.asc revenue.desc revenue.asc title.asc title.desc"`
+	HideWatchlisted  bool    `form:"hide_watchlisted"`
+}
+
+// RecommendationQueryDTO represents how many personal recommendations to return
+type RecommendationQueryDTO struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=60"`
+}
+
+// RecommendationDTO represents a movie recommended to the user, along with
+// the movies of their watchlist it was recommended for. BecauseOf is empty
+// when the user had nothing rated highly or favorited yet and the movie is
+// just popular.
+type RecommendationDTO struct {
+	Movie     MovieDTO `json:"movie"`
+	BecauseOf []int32  `json:"because_of"`
 }
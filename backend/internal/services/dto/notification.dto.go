@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+// Kinds of notification.
+const (
+	// A movie on the watchlist came out in theaters or on digital.
+	NotificationReleased = "released"
+	// A movie on the watchlist started streaming on one of the user's services.
+	NotificationAvailable = "available"
+	// A followed user rated a movie.
+	NotificationRated = "rated"
+)
+
+// NotificationDTO represents a notification of the user's inbox
+type NotificationDTO struct {
+	ID        int32      `json:"id"`
+	Type      string     `json:"type" example:"released"`
+	MovieID   int32      `json:"movie_id" example:"603"`
+	ActorID   *int32     `json:"actor_id,omitempty"`
+	Title     string     `json:"title" example:"Matrix is out"`
+	Body      string     `json:"body" example:"Matrix is now in theaters."`
+	Link      string     `json:"link" example:"https://app.example.com/movie/603"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadAt    *time.Time `json:"read_at"`
+}
+
+func (n *NotificationDTO) FromModel(notification model.Notifications) {
+	*n = NotificationDTO{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		MovieID:   notification.MovieID,
+		ActorID:   notification.ActorID,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		CreatedAt: notification.CreatedAt,
+		ReadAt:    notification.ReadAt,
+	}
+}
+
+// NotificationQueryDTO represents the page of the inbox requested, unread
+// notifications only when Unread is set
+type NotificationQueryDTO struct {
+	Unread   bool `form:"unread"`
+	Page     int  `form:"page" binding:"omitempty,min=1"`
+	PageSize int  `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// UnreadCountDTO represents the number of unread notifications of the user
+type UnreadCountDTO struct {
+	Count int64 `json:"count" example:"3"`
+}
+
+// NotificationSettingsDTO represents where the notifications of the user are
+// delivered besides the inbox: their email address, and an HTTPS URL they
+// are posted to.
+type NotificationSettingsDTO struct {
+	Email      bool   `json:"email"`
+	WebhookURL string `json:"webhook_url,omitempty" binding:"omitempty,url,startswith=https://" example:"https://hooks.example.com/movie-tracker"`
+}
+
+func (s *NotificationSettingsDTO) FromModel(settings model.NotificationSettings) {
+	*s = NotificationSettingsDTO{
+		Email:      settings.Email,
+		WebhookURL: utils.Fallback(settings.WebhookURL, ""),
+	}
+}
@@ -6,3 +6,16 @@ type Pagination[T any] struct {
 	TotalPages   int `json:"total_pages"`
 	TotalResults int `json:"total_results"`
 }
+
+// CursorPagination is a page of a result set read with cursors. NextCursor
+// is null on the last page. When only part of the set has been read, the
+// totals are estimated and TotalsEstimated is set.
+type CursorPagination[T any] struct {
+	Results         []T     `json:"results"`
+	Page            int     `json:"page"`
+	PageSize        int     `json:"page_size"`
+	NextCursor      *string `json:"next_cursor"`
+	TotalPages      int     `json:"total_pages"`
+	TotalResults    int     `json:"total_results"`
+	TotalsEstimated bool    `json:"totals_estimated"`
+}
@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+)
+
+// PersonDTO represents a person with the biography in the viewer's locale.
+// Following is only set for logged in viewers.
+type PersonDTO struct {
+	ID                 int      `json:"id"`
+	Name               string   `json:"name"`
+	AlsoKnownAs        []string `json:"also_known_as"`
+	Biography          string   `json:"biography"`
+	Birthday           string   `json:"birthday" example:"1964-09-02"`
+	Deathday           string   `json:"deathday"`
+	PlaceOfBirth       string   `json:"place_of_birth"`
+	KnownForDepartment string   `json:"known_for_department" example:"Acting"`
+	ProfilePath        string   `json:"profile_path"`
+	Homepage           string   `json:"homepage"`
+	ImdbID             string   `json:"imdb_id"`
+	Popularity         float64  `json:"popularity"`
+	Following          *bool    `json:"following,omitempty"`
+}
+
+// CastDTO represents an actor in the cast of a movie
+type CastDTO struct {
+	PersonID    int    `json:"person_id"`
+	Name        string `json:"name"`
+	Character   string `json:"character"`
+	ProfilePath string `json:"profile_path"`
+}
+
+// CrewDTO represents a member of the crew of a movie in one of their jobs
+type CrewDTO struct {
+	PersonID    int    `json:"person_id"`
+	Name        string `json:"name"`
+	Job         string `json:"job" example:"Director"`
+	Department  string `json:"department" example:"Directing"`
+	ProfilePath string `json:"profile_path"`
+}
+
+// PersonMoviesDTO represents the movies a person acted in and worked on, the
+// most recent first. Movies hidden by the content policy are left out.
+type PersonMoviesDTO struct {
+	PersonID int               `json:"person_id"`
+	Cast     []PersonCreditDTO `json:"cast"`
+	Crew     []PersonCreditDTO `json:"crew"`
+}
+
+// PersonCreditDTO represents a movie with the part a person had in it: the
+// character they played, or their job in the crew.
+type PersonCreditDTO struct {
+	Movie      MovieDTO `json:"movie"`
+	Character  string   `json:"character,omitempty"`
+	Job        string   `json:"job,omitempty"`
+	Department string   `json:"department,omitempty"`
+}
+
+// FollowedPersonDTO represents a person followed by a user. PersonError is
+// set instead of Person when the person could not be resolved.
+type FollowedPersonDTO struct {
+	PersonID    int32      `json:"person_id"`
+	FollowedAt  time.Time  `json:"followed_at"`
+	Person      *PersonDTO `json:"person,omitempty"`
+	PersonError *string    `json:"person_error,omitempty" example:"error.person.unavailable"`
+}
+
+func (f *FollowedPersonDTO) FromModel(followed model.FollowedPeople) {
+	*f = FollowedPersonDTO{
+		PersonID:   followed.PersonID,
+		FollowedAt: followed.FollowedAt,
+	}
+}
+
+// FeedQueryDTO represents how far back the feed goes, in days
+type FeedQueryDTO struct {
+	Days int `form:"days" binding:"omitempty,min=1,max=3650"`
+}
+
+// FeedItemDTO represents a movie released by people the user follows, with
+// the part each of them had in it. Movies not released yet are included.
+type FeedItemDTO struct {
+	Movie  MovieDTO        `json:"movie"`
+	People []FeedCreditDTO `json:"people"`
+}
+
+type FeedCreditDTO struct {
+	PersonID  int    `json:"person_id"`
+	Name      string `json:"name"`
+	Character string `json:"character,omitempty"`
+	Job       string `json:"job,omitempty"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+)
+
+// Kinds of release tracked.
+const (
+	ReleaseTheatrical = "theatrical"
+	ReleaseDigital    = "digital"
+)
+
+// ReleaseDTO represents the date a movie comes out in theaters or on digital
+// platforms in a country
+type ReleaseDTO struct {
+	Type string `json:"type" example:"theatrical"`
+	Date string `json:"date" example:"2026-11-20"`
+	Note string `json:"note,omitempty"`
+}
+
+// UpcomingReleaseDTO represents a watchlisted movie with releases still to
+// come in the region
+type UpcomingReleaseDTO struct {
+	MovieID  int32             `json:"movie_id"`
+	Status   model.WatchStatus `json:"status"`
+	Region   string            `json:"region" example:"BR"`
+	Releases []ReleaseDTO      `json:"releases"`
+	Movie    MovieDTO          `json:"movie"`
+}
+
+// UpcomingQueryDTO represents the region releases are looked up in, and how
+// many days ahead
+type UpcomingQueryDTO struct {
+	Region string `form:"region" binding:"omitempty,iso3166_1_alpha2"`
+	Days   int    `form:"days" binding:"omitempty,min=1,max=730"`
+}
+
+// CalendarFeedDTO represents the calendar feed of a user. The URL holds the
+// secret token, so it is only returned when the feed is created.
+type CalendarFeedDTO struct {
+	URL       string    `json:"url,omitempty" example:"https://api.example.com/api/calendar/0Fq3...ics"`
+	CreatedAt time.Time `json:"created_at"`
+}
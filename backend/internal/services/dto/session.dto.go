@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+)
+
+// SessionDTO represents a signed in device of a user
+type SessionDTO struct {
+	ID         int32     `json:"id"`
+	UserID     int32     `json:"user_id"`
+	UserAgent  *string   `json:"user_agent" example:"Mozilla/5.0 (X11; Linux x86_64)"`
+	IPAddress  *string   `json:"ip_address" example:"203.0.113.7"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+func (s *SessionDTO) FromModel(session model.Sessions) {
+	*s = SessionDTO{
+		ID:         session.ID,
+		UserID:     session.UserID,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IpAddress,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
+
+// SessionClientDTO describes the client a session is opened from
+type SessionClientDTO struct {
+	UserAgent string
+	IPAddress string
+}
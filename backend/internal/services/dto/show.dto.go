@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+)
+
+type ShowDTO struct {
+	ID               int                `json:"id"`
+	Name             string             `json:"name"`
+	OriginalName     string             `json:"original_name"`
+	PosterPath       string             `json:"poster_path"`
+	BackgroundPath   string             `json:"background_path"`
+	Year             string             `json:"year"`
+	Description      string             `json:"description"`
+	Tagline          string             `json:"tagline"`
+	Genre            []string           `json:"genre"`
+	Status           string             `json:"status" example:"Returning Series"`
+	FirstAirDate     string             `json:"first_air_date"`
+	LastAirDate      string             `json:"last_air_date"`
+	OriginalLanguage string             `json:"original_language"`
+	VoteAverage      float64            `json:"vote_average"`
+	VoteCount        int                `json:"vote_count"`
+	Popularity       float64            `json:"popularity"`
+	NumberOfSeasons  int                `json:"number_of_seasons"`
+	NumberOfEpisodes int                `json:"number_of_episodes"`
+	Seasons          []SeasonSummaryDTO `json:"seasons,omitempty"`
+	NextEpisodeToAir *EpisodeDTO        `json:"next_episode_to_air,omitempty"`
+}
+
+// SeasonSummaryDTO represents a season as listed in the show, without its episodes
+type SeasonSummaryDTO struct {
+	SeasonNumber int    `json:"season_number"`
+	Name         string `json:"name"`
+	EpisodeCount int    `json:"episode_count"`
+	AirDate      string `json:"air_date"`
+	PosterPath   string `json:"poster_path"`
+}
+
+type SeasonDTO struct {
+	ShowID       int          `json:"show_id"`
+	SeasonNumber int          `json:"season_number"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	AirDate      string       `json:"air_date"`
+	PosterPath   string       `json:"poster_path"`
+	Episodes     []EpisodeDTO `json:"episodes"`
+}
+
+// EpisodeDTO represents an episode. WatchedAt is set when the viewer watched it.
+type EpisodeDTO struct {
+	SeasonNumber  int        `json:"season_number"`
+	EpisodeNumber int        `json:"episode_number"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	AirDate       string     `json:"air_date"`
+	Runtime       int        `json:"runtime"`
+	StillPath     string     `json:"still_path"`
+	WatchedAt     *time.Time `json:"watched_at,omitempty"`
+}
+
+// ShowProgressDTO represents a show tracked by a user with how far they got.
+// Only aired episodes of the regular seasons count, specials are left out.
+// NextEpisode is the first aired episode not watched, null when caught up.
+// ShowError is set instead of Show when the show could not be resolved, or
+// was hidden by the content policy for the HiddenReasons; the counts are
+// then unknown and left at zero.
+type ShowProgressDTO struct {
+	ShowID          int32               `json:"show_id"`
+	UserID          int32               `json:"user_id"`
+	Status          model.WatchStatus   `json:"status"`
+	Favorite        bool                `json:"favorite"`
+	Rating          *int32              `json:"rating,omitempty"`
+	AddedAt         time.Time           `json:"added_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+	StatusChangedAt time.Time           `json:"status_changed_at"`
+	WatchedEpisodes int                 `json:"watched_episodes"`
+	AiredEpisodes   int                 `json:"aired_episodes"`
+	Seasons         []SeasonProgressDTO `json:"seasons,omitempty"`
+	NextEpisode     *EpisodeDTO         `json:"next_episode"`
+	Show            *ShowDTO            `json:"show,omitempty"`
+	ShowError       *string             `json:"show_error,omitempty" example:"error.show.unavailable"`
+	HiddenReasons   []HiddenReasonDTO   `json:"hidden_reasons,omitempty"`
+}
+
+func (p *ShowProgressDTO) FromModel(progress model.ShowProgress) {
+	*p = ShowProgressDTO{
+		ShowID:          progress.ShowID,
+		UserID:          progress.UserID,
+		Status:          progress.Status,
+		Favorite:        progress.Favorite,
+		Rating:          progress.Rating,
+		AddedAt:         progress.AddedAt,
+		UpdatedAt:       progress.UpdatedAt,
+		StatusChangedAt: progress.StatusChangedAt,
+	}
+}
+
+// SeasonProgressDTO represents how many aired episodes of a season were watched
+type SeasonProgressDTO struct {
+	SeasonNumber    int `json:"season_number"`
+	WatchedEpisodes int `json:"watched_episodes"`
+	AiredEpisodes   int `json:"aired_episodes"`
+}
+
+// ShowProgressQueryDTO represents the filters accepted when listing the tracked shows
+type ShowProgressQueryDTO struct {
+	Status []string `form:"status" binding:"omitempty,dive,oneof=unwatched watching 'plan to watch' watched"`
+}
+
+// ShowProgressUpdateDTO represents the request body for tracking a show or
+// updating its tracking. Fields left out keep their value.
+type ShowProgressUpdateDTO struct {
+	Status   *model.WatchStatus `json:"status" binding:"omitempty,oneof=unwatched watching 'plan to watch' watched" example:"plan to watch"`
+	Favorite *bool              `json:"favorite" example:"true"`
+	Rating   *int32             `json:"rating" binding:"omitempty,min=1,max=10" example:"8"`
+}
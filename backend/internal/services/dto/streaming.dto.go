@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+)
+
+// WatchProviderDTO represents a streaming service, store or rental service
+// TMDB knows movies to be available on
+type WatchProviderDTO struct {
+	ProviderID      int    `json:"provider_id" example:"8"`
+	ProviderName    string `json:"provider_name" example:"Netflix"`
+	LogoPath        string `json:"logo_path"`
+	DisplayPriority int    `json:"display_priority"`
+}
+
+// WatchAvailabilityDTO represents where a movie can be watched in a country.
+// Flatrate lists the subscription services, while Free and Ads list the
+// services streaming it at no cost. Link leads to the TMDB page crediting
+// JustWatch, which TMDB requires to be shown along with the data.
+type WatchAvailabilityDTO struct {
+	Country  string             `json:"country,omitempty" example:"BR"`
+	Link     string             `json:"link"`
+	Flatrate []WatchProviderDTO `json:"flatrate,omitempty"`
+	Free     []WatchProviderDTO `json:"free,omitempty"`
+	Ads      []WatchProviderDTO `json:"ads,omitempty"`
+	Rent     []WatchProviderDTO `json:"rent,omitempty"`
+	Buy      []WatchProviderDTO `json:"buy,omitempty"`
+}
+
+// StreamingOn returns the providers among the given ones streaming the movie
+// right now, either as part of a subscription or for free.
+func (a WatchAvailabilityDTO) StreamingOn(providerIDs []int) []WatchProviderDTO {
+	var providers []WatchProviderDTO
+	for _, offers := range [][]WatchProviderDTO{a.Flatrate, a.Free, a.Ads} {
+		for _, provider := range offers {
+			if !slices.Contains(providerIDs, provider.ProviderID) {
+				continue
+			}
+			if slices.ContainsFunc(providers, func(p WatchProviderDTO) bool { return p.ProviderID == provider.ProviderID }) {
+				continue
+			}
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+// StreamingProviderIDs returns the IDs of every provider streaming the movie
+// right now, either as part of a subscription or for free, in ascending order.
+func (a WatchAvailabilityDTO) StreamingProviderIDs() []int {
+	providerIDs := make([]int, 0)
+	for _, offers := range [][]WatchProviderDTO{a.Flatrate, a.Free, a.Ads} {
+		for _, provider := range offers {
+			providerIDs = append(providerIDs, provider.ProviderID)
+		}
+	}
+	slices.Sort(providerIDs)
+	return slices.Compact(providerIDs)
+}
+
+// StreamingSubscriptionDTO represents the streaming services a user pays for
+// and the country they watch them in, which decides the catalogs available.
+type StreamingSubscriptionDTO struct {
+	Country     string `json:"country" binding:"required,iso3166_1_alpha2" example:"BR"`
+	ProviderIDs []int  `json:"provider_ids" binding:"dive,min=1" example:"8,119"`
+}
+
+func (s *StreamingSubscriptionDTO) FromModel(subscription model.StreamingSubscriptions) error {
+	*s = StreamingSubscriptionDTO{Country: subscription.Country}
+	return json.Unmarshal([]byte(subscription.ProviderIds), &s.ProviderIDs)
+}
+
+// IsEmpty reports whether the user has no streaming service set up.
+func (s StreamingSubscriptionDTO) IsEmpty() bool {
+	return s.Country == "" || len(s.ProviderIDs) == 0
+}
+
+// WatchProviderQueryDTO represents the country whose streaming services are listed
+type WatchProviderQueryDTO struct {
+	Country string `form:"country" binding:"omitempty,iso3166_1_alpha2"`
+}
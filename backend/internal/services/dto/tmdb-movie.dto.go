@@ -1,11 +1,19 @@
 package dto
 
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+)
+
 type TMDBMovieDTO struct {
 	Adult               bool                `json:"adult"`
 	BackdropPath        *string             `json:"backdrop_path"`
 	BelongsToCollection interface{}         `json:"belongs_to_collection"`
 	Budget              int                 `json:"budget"`
 	Genres              []GenreDTO          `json:"genres"`
+	GenreIDs            []int               `json:"genre_ids,omitempty"`
 	Homepage            string              `json:"homepage"`
 	ID                  int                 `json:"id"`
 	ImdbID              *string             `json:"imdb_id"`
@@ -28,6 +36,104 @@ type TMDBMovieDTO struct {
 	VoteAverage         float64             `json:"vote_average"`
 	VoteCount           int                 `json:"vote_count"`
 	Translations        *TranslationsDTO    `json:"translations,omitempty"`
+	ReleaseDates        *ReleaseDatesDTO    `json:"release_dates,omitempty"`
+	Credits             *CreditsDTO         `json:"credits,omitempty"`
+	WatchProviders      *WatchProvidersDTO  `json:"watch/providers,omitempty"`
+	Videos              *VideosDTO          `json:"videos,omitempty"`
+}
+
+// AllGenreIDs returns the IDs of the genres of the movie, which come as
+// objects in details and as bare IDs in lists.
+func (m TMDBMovieDTO) AllGenreIDs() []int {
+	if len(m.GenreIDs) > 0 {
+		return m.GenreIDs
+	}
+
+	ids := make([]int, len(m.Genres))
+	for i, genre := range m.Genres {
+		ids[i] = genre.ID
+	}
+	return ids
+}
+
+// HasAllDetails reports whether the movie came with every detail appended to
+// it: translations, release dates, credits, watch providers and videos.
+// Payloads cached before some of them were appended lack them.
+func (m TMDBMovieDTO) HasAllDetails() bool {
+	return m.Translations != nil && m.ReleaseDates != nil && m.Credits != nil && m.WatchProviders != nil && m.Videos != nil
+}
+
+// Certification returns the age rating of the movie in a country, empty when
+// unknown. Only details fetched with their release dates carry it.
+func (m TMDBMovieDTO) Certification(country string) string {
+	if m.ReleaseDates == nil {
+		return ""
+	}
+
+	for _, result := range m.ReleaseDates.Results {
+		if result.ISO31661 != country {
+			continue
+		}
+		for _, release := range result.ReleaseDates {
+			if release.Certification != "" {
+				return release.Certification
+			}
+		}
+	}
+	return ""
+}
+
+// Releases returns the first theatrical release of the movie in a country,
+// limited ones included, and its first digital release, in date order. Only
+// details fetched with their release dates carry them.
+func (m TMDBMovieDTO) Releases(country string) []ReleaseDTO {
+	if m.ReleaseDates == nil {
+		return nil
+	}
+
+	firsts := make(map[string]ReleaseDTO)
+	for _, result := range m.ReleaseDates.Results {
+		if result.ISO31661 != country {
+			continue
+		}
+		for _, release := range result.ReleaseDates {
+			var kind string
+			switch release.Type {
+			case ReleaseTypeTheatricalLimited, ReleaseTypeTheatrical:
+				kind = ReleaseTheatrical
+			case ReleaseTypeDigital:
+				kind = ReleaseDigital
+			default:
+				continue
+			}
+
+			date, _, _ := strings.Cut(release.ReleaseDate, "T")
+			if first, ok := firsts[kind]; !ok || date < first.Date {
+				firsts[kind] = ReleaseDTO{Type: kind, Date: date, Note: release.Note}
+			}
+		}
+	}
+
+	releases := slices.Collect(maps.Values(firsts))
+	slices.SortFunc(releases, func(a, b ReleaseDTO) int {
+		return cmp.Or(cmp.Compare(a.Date, b.Date), cmp.Compare(b.Type, a.Type))
+	})
+	return releases
+}
+
+// WatchAvailability returns where the movie can be watched in a country, nil
+// when unknown. Only details fetched with their watch providers carry it.
+func (m TMDBMovieDTO) WatchAvailability(country string) *WatchAvailabilityDTO {
+	if m.WatchProviders == nil {
+		return nil
+	}
+
+	availability, ok := m.WatchProviders.Results[country]
+	if !ok {
+		return nil
+	}
+	availability.Country = country
+	return &availability
 }
 
 type GenreDTO struct {
@@ -65,8 +171,84 @@ type TranslationDTO struct {
 	Data        TranslationDataDTO `json:"data"`
 }
 
+// TranslationDataDTO holds the translated texts. Shows carry their title in
+// Name instead of Title, and people only have a Biography.
 type TranslationDataDTO struct {
-	Title    string `json:"title"`
-	Overview string `json:"overview"`
-	Tagline  string `json:"tagline"`
+	Title     string `json:"title"`
+	Name      string `json:"name,omitempty"`
+	Overview  string `json:"overview"`
+	Tagline   string `json:"tagline"`
+	Biography string `json:"biography,omitempty"`
+}
+
+type ReleaseDatesDTO struct {
+	Results []CountryReleaseDatesDTO `json:"results"`
+}
+
+type CountryReleaseDatesDTO struct {
+	ISO31661     string           `json:"iso_3166_1"`
+	ReleaseDates []ReleaseDateDTO `json:"release_dates"`
+}
+
+// Types of the TMDB release dates.
+const (
+	ReleaseTypePremiere          = 1
+	ReleaseTypeTheatricalLimited = 2
+	ReleaseTypeTheatrical        = 3
+	ReleaseTypeDigital           = 4
+	ReleaseTypePhysical          = 5
+	ReleaseTypeTV                = 6
+)
+
+type ReleaseDateDTO struct {
+	Certification string `json:"certification"`
+	ISO6391       string `json:"iso_639_1"`
+	Note          string `json:"note"`
+	ReleaseDate   string `json:"release_date"`
+	Type          int    `json:"type"`
+}
+
+// WatchProvidersDTO holds the availability of a movie by country code.
+type WatchProvidersDTO struct {
+	Results map[string]WatchAvailabilityDTO `json:"results"`
+}
+
+type VideosDTO struct {
+	Results []TMDBVideoDTO `json:"results"`
+}
+
+type TMDBVideoDTO struct {
+	ID          string `json:"id"`
+	ISO6391     string `json:"iso_639_1"`
+	ISO31661    string `json:"iso_3166_1"`
+	Name        string `json:"name"`
+	Key         string `json:"key"`
+	Site        string `json:"site"`
+	Size        int    `json:"size"`
+	Type        string `json:"type"`
+	Official    bool   `json:"official"`
+	PublishedAt string `json:"published_at"`
+}
+
+type CreditsDTO struct {
+	Cast []CastCreditDTO `json:"cast"`
+	Crew []CrewCreditDTO `json:"crew"`
+}
+
+type CastCreditDTO struct {
+	ID                 int     `json:"id"`
+	Name               string  `json:"name"`
+	Character          string  `json:"character"`
+	Order              int     `json:"order"`
+	KnownForDepartment string  `json:"known_for_department"`
+	ProfilePath        *string `json:"profile_path"`
+}
+
+type CrewCreditDTO struct {
+	ID                 int     `json:"id"`
+	Name               string  `json:"name"`
+	Job                string  `json:"job"`
+	Department         string  `json:"department"`
+	KnownForDepartment string  `json:"known_for_department"`
+	ProfilePath        *string `json:"profile_path"`
 }
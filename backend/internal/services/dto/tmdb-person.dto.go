@@ -0,0 +1,40 @@
+package dto
+
+type TMDBPersonDTO struct {
+	Adult              bool             `json:"adult"`
+	AlsoKnownAs        []string         `json:"also_known_as"`
+	Biography          string           `json:"biography"`
+	Birthday           *string          `json:"birthday"`
+	Deathday           *string          `json:"deathday"`
+	Gender             int              `json:"gender"`
+	Homepage           *string          `json:"homepage"`
+	ID                 int              `json:"id"`
+	ImdbID             *string          `json:"imdb_id"`
+	KnownForDepartment string           `json:"known_for_department"`
+	Name               string           `json:"name"`
+	PlaceOfBirth       *string          `json:"place_of_birth"`
+	Popularity         float64          `json:"popularity"`
+	ProfilePath        *string          `json:"profile_path"`
+	Translations       *TranslationsDTO `json:"translations,omitempty"`
+}
+
+// TMDBPersonMovieCreditsDTO holds the movies a person worked on, as list
+// items along with their part in each.
+type TMDBPersonMovieCreditsDTO struct {
+	ID   int                 `json:"id"`
+	Cast []TMDBPersonCastDTO `json:"cast"`
+	Crew []TMDBPersonCrewDTO `json:"crew"`
+}
+
+type TMDBPersonCastDTO struct {
+	TMDBMovieDTO
+	Character string `json:"character"`
+	CreditID  string `json:"credit_id"`
+}
+
+type TMDBPersonCrewDTO struct {
+	TMDBMovieDTO
+	Job        string `json:"job"`
+	Department string `json:"department"`
+	CreditID   string `json:"credit_id"`
+}
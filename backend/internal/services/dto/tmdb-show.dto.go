@@ -0,0 +1,109 @@
+package dto
+
+type TMDBShowDTO struct {
+	Adult            bool                   `json:"adult"`
+	BackdropPath     *string                `json:"backdrop_path"`
+	FirstAirDate     string                 `json:"first_air_date"`
+	Genres           []GenreDTO             `json:"genres"`
+	GenreIDs         []int                  `json:"genre_ids,omitempty"`
+	Homepage         string                 `json:"homepage"`
+	ID               int                    `json:"id"`
+	InProduction     bool                   `json:"in_production"`
+	LastAirDate      string                 `json:"last_air_date"`
+	LastEpisodeToAir *TMDBEpisodeDTO        `json:"last_episode_to_air"`
+	Name             string                 `json:"name"`
+	NextEpisodeToAir *TMDBEpisodeDTO        `json:"next_episode_to_air"`
+	NumberOfEpisodes int                    `json:"number_of_episodes"`
+	NumberOfSeasons  int                    `json:"number_of_seasons"`
+	OriginCountry    []string               `json:"origin_country"`
+	OriginalLanguage string                 `json:"original_language"`
+	OriginalName     string                 `json:"original_name"`
+	Overview         string                 `json:"overview"`
+	Popularity       float64                `json:"popularity"`
+	PosterPath       string                 `json:"poster_path"`
+	Seasons          []TMDBSeasonSummaryDTO `json:"seasons"`
+	Status           string                 `json:"status"`
+	Tagline          string                 `json:"tagline"`
+	VoteAverage      float64                `json:"vote_average"`
+	VoteCount        int                    `json:"vote_count"`
+	Translations     *TranslationsDTO       `json:"translations,omitempty"`
+	ContentRatings   *ContentRatingsDTO     `json:"content_ratings,omitempty"`
+}
+
+// AllGenreIDs returns the IDs of the genres of the show, which come as
+// objects in details and as bare IDs in lists.
+func (s TMDBShowDTO) AllGenreIDs() []int {
+	if len(s.GenreIDs) > 0 {
+		return s.GenreIDs
+	}
+
+	ids := make([]int, len(s.Genres))
+	for i, genre := range s.Genres {
+		ids[i] = genre.ID
+	}
+	return ids
+}
+
+// Certification returns the age rating of the show in a country, empty when
+// unknown. Only details fetched with their content ratings carry it.
+func (s TMDBShowDTO) Certification(country string) string {
+	if s.ContentRatings == nil {
+		return ""
+	}
+
+	for _, rating := range s.ContentRatings.Results {
+		if rating.ISO31661 == country {
+			return rating.Rating
+		}
+	}
+	return ""
+}
+
+// HasEnded reports whether no more episodes are expected.
+func (s TMDBShowDTO) HasEnded() bool {
+	return !s.InProduction && s.NextEpisodeToAir == nil && (s.Status == "Ended" || s.Status == "Canceled")
+}
+
+// TMDBSeasonSummaryDTO is a season as listed in the show details, without
+// its episodes.
+type TMDBSeasonSummaryDTO struct {
+	AirDate      string `json:"air_date"`
+	EpisodeCount int    `json:"episode_count"`
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Overview     string `json:"overview"`
+	PosterPath   string `json:"poster_path"`
+	SeasonNumber int    `json:"season_number"`
+}
+
+type TMDBSeasonDTO struct {
+	AirDate      string           `json:"air_date"`
+	Episodes     []TMDBEpisodeDTO `json:"episodes"`
+	ID           int              `json:"id"`
+	Name         string           `json:"name"`
+	Overview     string           `json:"overview"`
+	PosterPath   string           `json:"poster_path"`
+	SeasonNumber int              `json:"season_number"`
+}
+
+type TMDBEpisodeDTO struct {
+	AirDate       string  `json:"air_date"`
+	EpisodeNumber int     `json:"episode_number"`
+	ID            int     `json:"id"`
+	Name          string  `json:"name"`
+	Overview      string  `json:"overview"`
+	Runtime       int     `json:"runtime"`
+	SeasonNumber  int     `json:"season_number"`
+	ShowID        int     `json:"show_id"`
+	StillPath     *string `json:"still_path"`
+	VoteAverage   float64 `json:"vote_average"`
+}
+
+type ContentRatingsDTO struct {
+	Results []ContentRatingDTO `json:"results"`
+}
+
+type ContentRatingDTO struct {
+	ISO31661 string `json:"iso_3166_1"`
+	Rating   string `json:"rating"`
+}
@@ -1,16 +1,39 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserDTO struct {
-	ID       int32   `json:"id"`
-	Name     string  `json:"name"`
-	Username string  `json:"username"`
-	Email    string  `json:"email"`
-	Phone    *string `json:"phone,omitempty"`
+	ID              int32      `json:"id"`
+	Name            string     `json:"name"`
+	Username        string     `json:"username"`
+	Email           string     `json:"email"`
+	Phone           *string    `json:"phone,omitempty"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	Language        *string    `json:"language"`
+	Region          *string    `json:"region"`
+}
+
+// IsEmailVerified reports whether the user confirmed they own their email address.
+func (u UserDTO) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// Locale returns the language and region the user chose, leaving empty the
+// ones they didn't.
+func (u UserDTO) Locale() LocaleDTO {
+	var locale LocaleDTO
+	if u.Language != nil {
+		locale.Language = *u.Language
+	}
+	if u.Region != nil {
+		locale.Region = *u.Region
+	}
+	return locale
 }
 
 func (u UserDTO) ToModel() model.Users {
@@ -25,11 +48,14 @@ func (u UserDTO) ToModel() model.Users {
 
 func (u *UserDTO) FromModel(user model.Users) {
 	*u = UserDTO{
-		ID:       user.ID,
-		Name:     user.Name,
-		Username: user.Username,
-		Email:    user.Email,
-		Phone:    user.Phone,
+		ID:              user.ID,
+		Name:            user.Name,
+		Username:        user.Username,
+		Email:           user.Email,
+		Phone:           user.Phone,
+		EmailVerifiedAt: user.EmailVerifiedAt,
+		Language:        user.Language,
+		Region:          user.Region,
 	}
 }
 
@@ -53,3 +79,48 @@ func (u UserCreateDTO) ToModel() model.Users {
 		Password: string(password),
 	}
 }
+
+// ChangePasswordRequestDTO represents the request body for changing the password of the authenticated user
+type ChangePasswordRequestDTO struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
+// ForgotPasswordRequestDTO represents the request body for requesting a password reset email
+type ForgotPasswordRequestDTO struct {
+	Email string `json:"email" binding:"required,email" example:"user@example.com"`
+}
+
+// ResetPasswordRequestDTO represents the request body for setting a new password with an emailed token
+type ResetPasswordRequestDTO struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// VerifyEmailRequestDTO represents the request body for confirming an email address with an emailed token
+type VerifyEmailRequestDTO struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UserPreferencesDTO represents the request body for updating the preferences of the authenticated user
+type UserPreferencesDTO struct {
+	Language *string `json:"language" binding:"omitempty,bcp47_language_tag" example:"en-US"`
+	Region   *string `json:"region" binding:"omitempty,iso3166_1_alpha2" example:"US"`
+}
+
+// FollowRequestDTO represents a user waiting for the authenticated user to let them follow
+type FollowRequestDTO struct {
+	FollowerID  int32     `json:"follower_id"`
+	Username    string    `json:"username"`
+	Name        string    `json:"name"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+func (f *FollowRequestDTO) FromModel(follow model.UserFollows, follower model.Users) {
+	*f = FollowRequestDTO{
+		FollowerID:  follow.FollowerID,
+		Username:    follower.Username,
+		Name:        follower.Name,
+		RequestedAt: follow.FollowedAt,
+	}
+}
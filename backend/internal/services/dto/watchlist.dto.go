@@ -1,17 +1,79 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
 )
 
+// WatchListDTO represents an item of the watchlist. StreamingOn is only
+// filled when filtering by availability, with the user's services streaming
+// the movie.
 type WatchListDTO struct {
-	ID       int32             `json:"id"`
-	MovieID  int32             `json:"movie_id"`
-	UserID   int32             `json:"user_id"`
-	Status   model.WatchStatus `json:"status"`
-	Favorite bool              `json:"favorite"`
-	Comments *string           `json:"comments"`
-	Rating   *int32            `json:"rating,omitempty"`
+	ID              int32              `json:"id"`
+	MovieID         int32              `json:"movie_id"`
+	UserID          int32              `json:"user_id"`
+	Status          model.WatchStatus  `json:"status"`
+	Favorite        bool               `json:"favorite"`
+	Comments        *string            `json:"comments"`
+	Rating          *int32             `json:"rating,omitempty"`
+	AddedAt         time.Time          `json:"added_at"`
+	UpdatedAt       time.Time          `json:"updated_at"`
+	StatusChangedAt time.Time          `json:"status_changed_at"`
+	StreamingOn     []WatchProviderDTO `json:"streaming_on,omitempty"`
+}
+
+func (w *WatchListDTO) FromModel(item model.Watchlist) {
+	*w = WatchListDTO{
+		MovieID:         item.MovieID,
+		UserID:          item.UserID,
+		Status:          item.Status,
+		Favorite:        item.Favorite,
+		Comments:        item.Comments,
+		Rating:          item.Rating,
+		AddedAt:         item.AddedAt,
+		UpdatedAt:       item.UpdatedAt,
+		StatusChangedAt: item.StatusChangedAt,
+	}
+}
+
+// WatchListStatusChangeDTO represents a single status transition of a watchlist item.
+// FromStatus is null on the first entry, the status the item was added with.
+type WatchListStatusChangeDTO struct {
+	FromStatus *model.WatchStatus `json:"from_status" example:"plan to watch"`
+	ToStatus   model.WatchStatus  `json:"to_status" example:"watched"`
+	ChangedAt  time.Time          `json:"changed_at"`
+}
+
+// WatchListMovieDTO represents a watchlist item joined with its movie metadata.
+// MovieError is set instead of Movie when the movie could not be resolved, or
+// was hidden by the content policy for the HiddenReasons.
+type WatchListMovieDTO struct {
+	WatchListDTO
+	Movie         *MovieDTO         `json:"movie,omitempty"`
+	MovieError    *string           `json:"movie_error,omitempty" example:"error.movie.unavailable"`
+	HiddenReasons []HiddenReasonDTO `json:"hidden_reasons,omitempty"`
+}
+
+// WatchListQueryDTO represents the filters, sort order and page accepted when listing the watchlist.
+// AvailableOn=mine keeps the movies streaming on the user's services, which only
+// makes sense for the ones still to watch, so the status defaults to plan to watch.
+type WatchListQueryDTO struct {
+	Status      []string `form:"status" binding:"omitempty,dive,oneof=unwatched watching 'plan to watch' watched"`
+	Favorite    *bool    `form:"favorite"`
+	RatingMin   *int     `form:"rating_min" binding:"omitempty,min=1,max=10"`
+	RatingMax   *int     `form:"rating_max" binding:"omitempty,min=1,max=10"`
+	HasComments *bool    `form:"has_comments"`
+	SortBy      string   `form:"sort_by" binding:"omitempty,oneof=added rating title"`
+	Order       string   `form:"order" binding:"omitempty,oneof=asc desc"`
+	AvailableOn string   `form:"available_on" binding:"omitempty,oneof=mine"`
+	Page        int      `form:"page" binding:"omitempty,min=1"`
+	PageSize    int      `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// IsPaginated reports whether the caller asked for a single page instead of the whole list.
+func (q WatchListQueryDTO) IsPaginated() bool {
+	return q.Page > 0 || q.PageSize > 0
 }
 
 type WatchListCreateDTO struct {
@@ -44,3 +106,22 @@ type UpdateWatchlistRequestDTO struct {
 	Comments string `json:"comments,omitempty" example:"Great movie!"`
 	Rating   *int   `json:"rating,omitempty" example:"9"`
 }
+
+// WatchListExportQueryDTO represents the format requested when exporting the watchlist
+type WatchListExportQueryDTO struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv json letterboxd"`
+}
+
+// WatchListExportDTO represents a watchlist item flattened with the movie data kept in exports
+type WatchListExportDTO struct {
+	MovieID         int32             `json:"movie_id"`
+	Title           string            `json:"title"`
+	Year            string            `json:"year"`
+	ImdbID          *string           `json:"imdb_id"`
+	Status          model.WatchStatus `json:"status"`
+	Favorite        bool              `json:"favorite"`
+	Rating          *int32            `json:"rating"`
+	Comments        *string           `json:"comments"`
+	AddedAt         time.Time         `json:"added_at"`
+	StatusChangedAt time.Time         `json:"status_changed_at"`
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/mappers"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+// IGenreService serves the movie genres named in the viewer's language, and
+// the movies of each. Genres are identified by their TMDB ID, the same in
+// every language.
+type IGenreService interface {
+	IService
+	GetMovieGenres(ctx context.Context, viewer dto.ViewerDTO) ([]dto.GenreDTO, error)
+	GetMovies(ctx context.Context, id int, filters dto.MovieDiscoverFiltersDTO, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (dto.CursorPagination[dto.MovieDTO], error)
+}
+
+type GenreService struct {
+	genreRepo     repositories.IGenreRepository
+	defaultLocale dto.LocaleDTO
+	contentPolicy IContentPolicyService
+	movieService  IMovieService
+}
+
+func newGenreService(params ServicesParams) IGenreService {
+	return &GenreService{
+		genreRepo: params.Repos.GenreRepo,
+		defaultLocale: dto.LocaleDTO{
+			Language: params.Cfg.DefaultLanguage,
+			Region:   params.Cfg.DefaultRegion,
+		},
+	}
+}
+
+func (s *GenreService) ProvideServices(svcs Services) {
+	s.contentPolicy = svcs.ContentPolicyService
+	s.movieService = svcs.MovieService
+}
+
+// GetMovieGenres lists the genres, leaving out the ones the viewer's policy
+// excludes.
+func (s *GenreService) GetMovieGenres(ctx context.Context, viewer dto.ViewerDTO) ([]dto.GenreDTO, error) {
+	viewer, err := s.resolveViewer(ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	genres, err := s.genreRepo.GetMovieGenres(ctx, viewer.Locale)
+	if err != nil {
+		return nil, err
+	}
+
+	shown := make([]dto.GenreDTO, 0, len(genres))
+	for _, genre := range genres {
+		if len(policy.EvaluateGenre(*viewer.Policy, genre.ID)) == 0 {
+			shown = append(shown, genre)
+		}
+	}
+	return shown, nil
+}
+
+// GetMovies discovers the movies of the genre with the other filters, or
+// returns a policy.HiddenError when the viewer's policy excludes the genre.
+func (s *GenreService) GetMovies(ctx context.Context, id int, filters dto.MovieDiscoverFiltersDTO, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (movies dto.CursorPagination[dto.MovieDTO], err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
+	if err != nil {
+		return movies, err
+	}
+
+	genres, err := s.genreRepo.GetMovieGenres(ctx, viewer.Locale)
+	if err != nil {
+		return movies, err
+	}
+	if !slices.ContainsFunc(genres, func(genre dto.GenreDTO) bool { return genre.ID == id }) {
+		return movies, utils.NewNotFoundError("error.genre.not_found")
+	}
+
+	if reasons := policy.EvaluateGenre(*viewer.Policy, id); len(reasons) > 0 {
+		return movies, policy.NewHiddenGenreError(reasons)
+	}
+
+	filters.WithGenres = []int{id}
+	return s.movieService.DiscoverMovies(ctx, filters, page, viewer)
+}
+
+// resolveViewer fills the locale with the defaults and resolves the policy.
+func (s *GenreService) resolveViewer(ctx context.Context, viewer dto.ViewerDTO) (dto.ViewerDTO, error) {
+	viewer.Locale = viewer.Locale.WithFallback(s.defaultLocale)
+	return s.contentPolicy.Resolve(ctx, viewer)
+}
+
+// nameGenres names the genres of the movies in the locale's language, as
+// listed movies only carry the genre IDs and detailed ones are named in the
+// default language. When the genre list can't be fetched the names are left
+// as they came.
+func nameGenres(ctx context.Context, repo repositories.IGenreRepository, locale dto.LocaleDTO, movies []dto.MovieDTO) {
+	nameGenresOf(ctx, repo, locale, movies, func(movie *dto.MovieDTO) *dto.MovieDTO { return movie })
+}
+
+// nameGenresOf names the genres of the movies held by the items, like
+// nameGenres.
+func nameGenresOf[T any](ctx context.Context, repo repositories.IGenreRepository, locale dto.LocaleDTO, items []T, movieOf func(*T) *dto.MovieDTO) {
+	if len(items) == 0 {
+		return
+	}
+
+	genres, err := repo.GetMovieGenres(ctx, locale)
+	if err != nil {
+		slog.Warn("failed to name the movie genres", "language", locale.Language, "error", err)
+		return
+	}
+
+	names := make(map[int]string, len(genres))
+	for _, genre := range genres {
+		names[genre.ID] = genre.Name
+	}
+
+	for i := range items {
+		movie := movieOf(&items[i])
+		for j, genre := range movie.Genres {
+			if name, ok := names[genre.ID]; ok {
+				movie.Genres[j].Name = name
+			}
+		}
+		movie.Genre = mappers.GenreNames(movie.Genres)
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGenreRepository struct {
+	mock.Mock
+}
+
+func (m *MockGenreRepository) GetMovieGenres(_ context.Context, locale dto.LocaleDTO) ([]dto.GenreDTO, error) {
+	args := m.Called(locale)
+	return args.Get(0).([]dto.GenreDTO), args.Error(1)
+}
+
+// newMockGenreRepository lists a few genres in English for any locale.
+func newMockGenreRepository() *MockGenreRepository {
+	genreRepo := new(MockGenreRepository)
+	genreRepo.On("GetMovieGenres", mock.Anything).Return([]dto.GenreDTO{
+		{ID: 28, Name: "Action"},
+		{ID: 18, Name: "Drama"},
+		{ID: 27, Name: "Horror"},
+	}, nil).Maybe()
+	return genreRepo
+}
+
+func TestNameGenres(t *testing.T) {
+	genreRepo := new(MockGenreRepository)
+	genreRepo.On("GetMovieGenres", dto.LocaleDTO{Language: "pt-BR"}).Return([]dto.GenreDTO{
+		{ID: 28, Name: "Ação"},
+		{ID: 18, Name: "Drama"},
+	}, nil)
+
+	movies := []dto.MovieDTO{
+		{ID: 1, Genres: []dto.GenreDTO{{ID: 28}, {ID: 18}}},
+		{ID: 2, Genres: []dto.GenreDTO{{ID: 28, Name: "Action"}, {ID: 99, Name: "Documentary"}}},
+	}
+	nameGenres(context.Background(), genreRepo, dto.LocaleDTO{Language: "pt-BR"}, movies)
+
+	assert.Equal(t, []dto.GenreDTO{{ID: 28, Name: "Ação"}, {ID: 18, Name: "Drama"}}, movies[0].Genres)
+	assert.Equal(t, []string{"Ação", "Drama"}, movies[0].Genre)
+	// Genres missing from the list keep the name they came with
+	assert.Equal(t, []string{"Ação", "Documentary"}, movies[1].Genre)
+}
+
+func TestNameGenres_ListUnavailable(t *testing.T) {
+	genreRepo := new(MockGenreRepository)
+	genreRepo.On("GetMovieGenres", dto.LocaleDTO{}).Return([]dto.GenreDTO{}, errors.New("upstream down"))
+
+	movies := []dto.MovieDTO{{ID: 1, Genre: []string{"Action"}, Genres: []dto.GenreDTO{{ID: 28, Name: "Action"}}}}
+	nameGenres(context.Background(), genreRepo, dto.LocaleDTO{}, movies)
+
+	assert.Equal(t, []string{"Action"}, movies[0].Genre)
+}
+
+func TestGenreService_GetMovieGenres_LeavesOutExcluded(t *testing.T) {
+	service := &GenreService{genreRepo: newMockGenreRepository(), contentPolicy: &ContentPolicyService{}}
+
+	genres, err := service.GetMovieGenres(context.Background(), dto.ViewerDTO{Policy: &dto.ContentPolicyDTO{ExcludedGenres: []int{27}}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.GenreDTO{{ID: 28, Name: "Action"}, {ID: 18, Name: "Drama"}}, genres)
+}
+
+func TestGenreService_GetMovies(t *testing.T) {
+	// Arrange
+	movieRepo := new(MockMovieRepository)
+	genreRepo := newMockGenreRepository()
+	service := &GenreService{
+		genreRepo:     genreRepo,
+		contentPolicy: &ContentPolicyService{},
+		movieService:  &MovieService{movieRepo: movieRepo, genreRepo: genreRepo, contentPolicy: &ContentPolicyService{}},
+	}
+
+	rules := dto.ContentPolicyDTO{ExcludedGenres: []int{27}}
+	viewer := dto.ViewerDTO{Policy: &rules}
+	filters := dto.MovieDiscoverFiltersDTO{WithGenres: []int{18}, SortBy: "vote_average.desc"}
+	movieRepo.On("DiscoverMovies", 1, filters, dto.LocaleDTO{}, rules).Return(dto.Pagination[dto.TMDBMovieDTO]{
+		Page:         1,
+		TotalPages:   1,
+		TotalResults: 1,
+		Results:      []dto.TMDBMovieDTO{{ID: 238, Title: "The Godfather", GenreIDs: []int{18, 80}}},
+	}, nil)
+
+	// Act
+	movies, err := service.GetMovies(context.Background(), 18, dto.MovieDiscoverFiltersDTO{SortBy: "vote_average.desc"}, dto.MoviePageQueryDTO{}, viewer)
+	_, hiddenErr := service.GetMovies(context.Background(), 27, dto.MovieDiscoverFiltersDTO{}, dto.MoviePageQueryDTO{}, viewer)
+	_, unknownErr := service.GetMovies(context.Background(), 12345, dto.MovieDiscoverFiltersDTO{}, dto.MoviePageQueryDTO{}, viewer)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, movies.Results, 1) {
+		assert.Equal(t, []dto.GenreDTO{{ID: 18, Name: "Drama"}, {ID: 80}}, movies.Results[0].Genres)
+		assert.Equal(t, []string{"Drama"}, movies.Results[0].Genre)
+	}
+
+	var hidden *policy.HiddenError
+	if assert.ErrorAs(t, hiddenErr, &hidden) {
+		assert.Equal(t, "error.genre.hidden", hidden.Message)
+		assert.Equal(t, []dto.HiddenReasonDTO{{Rule: policy.RuleExcludedGenre, Value: "27"}}, hidden.Reasons)
+	}
+	var apiErr *utils.ApiError
+	if assert.ErrorAs(t, unknownErr, &apiErr) {
+		assert.Equal(t, "error.genre.not_found", apiErr.Message)
+	}
+
+	movieRepo.AssertExpectations(t)
+}
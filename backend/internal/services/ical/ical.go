@@ -0,0 +1,92 @@
+// Package ical writes iCalendar (RFC 5545) feeds of all-day events, the format
+// calendar apps subscribe to.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Lines longer than this many bytes are folded, as the RFC requires.
+const maxLineLength = 75
+
+// Calendar is a feed of events. ProductID identifies the app producing it.
+type Calendar struct {
+	ProductID string
+	Name      string
+	Events    []Event
+}
+
+// Event is an all-day event. UID must be unique and stay the same across
+// updates, so calendar apps replace the event instead of duplicating it.
+type Event struct {
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+	URL         string
+}
+
+// Write encodes the calendar. Stamp is the time the feed was generated at.
+func Write(w io.Writer, calendar Calendar, stamp time.Time) error {
+	bw := bufio.NewWriter(w)
+	line := func(name string, value string) {
+		writeFolded(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", escape(calendar.ProductID))
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if calendar.Name != "" {
+		line("X-WR-CALNAME", escape(calendar.Name))
+	}
+
+	for _, event := range calendar.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(event.UID))
+		line("DTSTAMP", stamp.UTC().Format("20060102T150405Z"))
+		writeFolded(bw, "DTSTART;VALUE=DATE:"+event.Date.Format("20060102"))
+		writeFolded(bw, "DTEND;VALUE=DATE:"+event.Date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		if event.URL != "" {
+			line("URL", event.URL)
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// escape escapes the characters with a meaning in text values.
+func escape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// writeFolded writes a content line ended by CRLF, folding it into lines of
+// at most maxLineLength bytes without splitting UTF-8 characters.
+func writeFolded(w *bufio.Writer, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = maxLineLength - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,64 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrite(t *testing.T) {
+	var out strings.Builder
+	calendar := Calendar{
+		ProductID: "-//Movie Tracker//Releases//EN",
+		Name:      "Lançamentos",
+		Events: []Event{{
+			UID:         "603-theatrical-BR@movie-tracker",
+			Date:        time.Date(2026, 11, 20, 0, 0, 0, 0, time.UTC),
+			Summary:     "Matrix, nos cinemas",
+			Description: "Linha 1\nLinha 2; fim",
+			URL:         "https://example.com/movies/603",
+		}},
+	}
+
+	err := Write(&out, calendar, time.Date(2026, 10, 17, 12, 30, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "BEGIN:VCALENDAR\r\n"+
+		"VERSION:2.0\r\n"+
+		"PRODID:-//Movie Tracker//Releases//EN\r\n"+
+		"CALSCALE:GREGORIAN\r\n"+
+		"METHOD:PUBLISH\r\n"+
+		"X-WR-CALNAME:Lançamentos\r\n"+
+		"BEGIN:VEVENT\r\n"+
+		"UID:603-theatrical-BR@movie-tracker\r\n"+
+		"DTSTAMP:20261017T123000Z\r\n"+
+		"DTSTART;VALUE=DATE:20261120\r\n"+
+		"DTEND;VALUE=DATE:20261121\r\n"+
+		"SUMMARY:Matrix\\, nos cinemas\r\n"+
+		"DESCRIPTION:Linha 1\\nLinha 2\\; fim\r\n"+
+		"URL:https://example.com/movies/603\r\n"+
+		"TRANSP:TRANSPARENT\r\n"+
+		"END:VEVENT\r\n"+
+		"END:VCALENDAR\r\n", out.String())
+}
+
+func TestWrite_FoldsLongLines(t *testing.T) {
+	var out strings.Builder
+	summary := strings.Repeat("ação ", 40)
+
+	err := Write(&out, Calendar{Events: []Event{{UID: "1", Summary: summary}}}, time.Now())
+
+	assert.NoError(t, err)
+	var unfolded strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+		} else {
+			unfolded.WriteString("\n" + line)
+		}
+	}
+	assert.Contains(t, unfolded.String(), "\nSUMMARY:"+summary+"\n")
+}
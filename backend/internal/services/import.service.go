@@ -0,0 +1,324 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/letterboxd"
+)
+
+const (
+	// Maximum number of TMDB searches in flight while matching an import.
+	importSearchConcurrency = 4
+
+	// Maximum number of candidates reported for an ambiguous row.
+	importMaxCandidates = 5
+)
+
+// Letterboxd exports the English titles, so films are searched in English for
+// the titles to be comparable.
+var letterboxdLocale = dto.LocaleDTO{Language: "en-US"}
+
+type IImportService interface {
+	IService
+	ImportLetterboxd(ctx context.Context, userID int32, rows []letterboxd.Row, dryRun bool) (dto.ImportReportDTO, error)
+}
+
+type ImportService struct {
+	movieRepo        repositories.IMovieRepository
+	transactor       repositories.ITransactor
+	watchlistService IWatchList
+	diaryService     IDiaryService
+}
+
+func newImportService(params ServicesParams) IImportService {
+	return &ImportService{
+		movieRepo:  params.Repos.MovieRepo,
+		transactor: params.Repos.Transactor,
+	}
+}
+
+func (s *ImportService) ProvideServices(services Services) {
+	s.watchlistService = services.WatchlistService
+	s.diaryService = services.DiaryService
+}
+
+type importFilm struct {
+	name string
+	year string
+}
+
+type importMatch struct {
+	movieID    *int32
+	candidates []dto.ImportCandidateDTO
+	err        error
+}
+
+// ImportLetterboxd matches every row to a TMDB movie by title and year. Unless
+// dryRun is set, matched rows are then written to the watchlist and diary;
+// ambiguous and unmatched rows are never imported. Rows whose search failed
+// are reported apart so they can be retried by importing the file again.
+func (s *ImportService) ImportLetterboxd(ctx context.Context, userID int32, rows []letterboxd.Row, dryRun bool) (dto.ImportReportDTO, error) {
+	report := dto.ImportReportDTO{
+		DryRun:    dryRun,
+		Matched:   make([]dto.ImportRowDTO, 0),
+		Ambiguous: make([]dto.ImportRowDTO, 0),
+		Unmatched: make([]dto.ImportRowDTO, 0),
+		Failed:    make([]dto.ImportRowDTO, 0),
+	}
+
+	matches := s.matchFilms(ctx, rows)
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+
+	for _, row := range rows {
+		reportRow := dto.ImportRowDTO{
+			Source:    string(row.Kind),
+			Line:      row.Line,
+			Name:      row.Name,
+			Year:      row.Year,
+			WatchedOn: row.WatchedDate,
+			Rewatch:   row.Rewatch,
+		}
+		if row.Rating != nil {
+			rating := letterboxd.ConvertRating(*row.Rating)
+			reportRow.Rating = &rating
+		}
+
+		match := matches[importFilm{row.Name, row.Year}]
+		switch {
+		case match.err != nil:
+			report.Failed = append(report.Failed, reportRow)
+		case match.movieID != nil:
+			reportRow.MovieID = match.movieID
+			report.Matched = append(report.Matched, reportRow)
+		case len(match.candidates) > 0:
+			reportRow.Candidates = match.candidates
+			report.Ambiguous = append(report.Ambiguous, reportRow)
+		default:
+			report.Unmatched = append(report.Unmatched, reportRow)
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	var summary dto.ImportSummaryDTO
+	err := s.transactor.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		summary, err = s.commit(ctx, userID, report.Matched)
+		return err
+	})
+	if err != nil {
+		return report, err
+	}
+
+	report.Imported = &summary
+	return report, nil
+}
+
+// matchFilms searches each distinct title and year only once.
+func (s *ImportService) matchFilms(ctx context.Context, rows []letterboxd.Row) map[importFilm]importMatch {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	matches := make(map[importFilm]importMatch)
+	seen := make(map[importFilm]bool)
+	slots := make(chan struct{}, importSearchConcurrency)
+
+	for _, row := range rows {
+		film := importFilm{row.Name, row.Year}
+		if seen[film] {
+			continue
+		}
+		seen[film] = true
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			match := s.matchFilm(ctx, film)
+
+			mu.Lock()
+			matches[film] = match
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+	return matches
+}
+
+func (s *ImportService) matchFilm(ctx context.Context, film importFilm) importMatch {
+	var match importMatch
+
+	results, err := s.movieRepo.SearchMovies(ctx, film.name, 1, letterboxdLocale)
+	if err != nil {
+		slog.Warn("failed to search imported film", "name", film.name, "year", film.year, "error", err)
+		match.err = err
+		return match
+	}
+	if len(results.Results) == 0 {
+		return match
+	}
+
+	candidates := results.Results
+	if film.year != "" {
+		sameYear := slices.DeleteFunc(slices.Clone(candidates), func(movie dto.TMDBMovieDTO) bool {
+			return !strings.HasPrefix(movie.ReleaseDate, film.year)
+		})
+		if len(sameYear) > 0 {
+			candidates = sameYear
+		}
+	}
+
+	if len(candidates) > 1 {
+		sameTitle := slices.DeleteFunc(slices.Clone(candidates), func(movie dto.TMDBMovieDTO) bool {
+			return !strings.EqualFold(movie.Title, film.name) && !strings.EqualFold(movie.OriginalTitle, film.name)
+		})
+		if len(sameTitle) > 0 {
+			candidates = sameTitle
+		}
+	}
+
+	if len(candidates) == 1 && (film.year == "" || strings.HasPrefix(candidates[0].ReleaseDate, film.year)) {
+		movieID := int32(candidates[0].ID)
+		match.movieID = &movieID
+		return match
+	}
+
+	for _, movie := range candidates[:min(len(candidates), importMaxCandidates)] {
+		match.candidates = append(match.candidates, dto.ImportCandidateDTO{
+			ID:            movie.ID,
+			Title:         movie.Title,
+			OriginalTitle: movie.OriginalTitle,
+			Year:          movie.ReleaseDate[:min(len(movie.ReleaseDate), 4)],
+		})
+	}
+
+	return match
+}
+
+type importedMovie struct {
+	watched bool
+	rating  *int
+}
+
+type importDiaryEntry struct {
+	movieID   int32
+	watchedOn string
+}
+
+// commit writes the matched rows. ImportLetterboxd runs it in a transaction, so
+// a failed import leaves nothing behind. Watched, rated or logged movies end up as
+// watched; watchlist rows only add movies the user doesn't have yet. Diary rows
+// already logged for the same day are skipped so imports can be repeated.
+func (s *ImportService) commit(ctx context.Context, userID int32, rows []dto.ImportRowDTO) (dto.ImportSummaryDTO, error) {
+	var summary dto.ImportSummaryDTO
+
+	watchlist, err := s.watchlistService.GetByUser(ctx, userID)
+	if err != nil {
+		return summary, err
+	}
+
+	existing := make(map[int32]bool, len(watchlist))
+	for _, item := range watchlist {
+		existing[item.MovieID] = true
+	}
+
+	movies := make(map[int32]*importedMovie)
+	order := make([]int32, 0)
+	for _, row := range rows {
+		movie, ok := movies[*row.MovieID]
+		if !ok {
+			movie = &importedMovie{}
+			movies[*row.MovieID] = movie
+			order = append(order, *row.MovieID)
+		}
+
+		if row.Source != string(letterboxd.KindWatchlist) {
+			movie.watched = true
+		}
+		if row.Rating != nil && (movie.rating == nil || row.Source == string(letterboxd.KindRatings)) {
+			rating := int(*row.Rating)
+			movie.rating = &rating
+		}
+	}
+
+	for _, movieID := range order {
+		movie := movies[movieID]
+
+		switch {
+		case existing[movieID] && movie.watched:
+			_, err = s.watchlistService.UpdateWatchlistItem(ctx, userID, int(movieID), string(model.WatchStatus_Watched), nil, "", movie.rating)
+			summary.WatchlistUpdated++
+		case existing[movieID]:
+			continue
+		default:
+			createDTO := dto.WatchListCreateDTO{MovieID: movieID, Status: model.WatchStatus_PlanToWatch}
+			if movie.watched {
+				createDTO.Status = model.WatchStatus_Watched
+			}
+			if movie.rating != nil {
+				rating := int32(*movie.rating)
+				createDTO.Rating = &rating
+			}
+			_, err = s.watchlistService.AddToWatchlist(ctx, userID, createDTO)
+			summary.WatchlistAdded++
+		}
+
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	events, err := s.diaryService.GetByUser(ctx, userID, nil)
+	if err != nil {
+		return summary, err
+	}
+
+	logged := make(map[importDiaryEntry]bool, len(events))
+	for _, event := range events {
+		logged[importDiaryEntry{event.MovieID, event.WatchedOn}] = true
+	}
+
+	for _, row := range rows {
+		if row.Source != string(letterboxd.KindDiary) {
+			continue
+		}
+
+		watchedOn := row.WatchedOn
+		if _, err := time.Parse(time.DateOnly, watchedOn); err != nil {
+			continue
+		}
+
+		key := importDiaryEntry{*row.MovieID, watchedOn}
+		if logged[key] {
+			continue
+		}
+		logged[key] = true
+
+		_, err = s.diaryService.LogWatch(ctx, userID, dto.WatchEventCreateDTO{
+			MovieID:   *row.MovieID,
+			WatchedOn: watchedOn,
+			Rating:    row.Rating,
+			Rewatch:   row.Rewatch,
+		})
+		if err != nil {
+			return summary, err
+		}
+		summary.DiaryEntries++
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/letterboxd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportService_ImportLetterboxd_DryRunClassifiesRows(t *testing.T) {
+	mockRepo := new(MockMovieRepository)
+	service := &ImportService{movieRepo: mockRepo}
+
+	mockRepo.On("SearchMovies", "Alien", 1, letterboxdLocale).Return(dto.Pagination[dto.TMDBMovieDTO]{
+		Results: []dto.TMDBMovieDTO{
+			{ID: 348, Title: "Alien", ReleaseDate: "1979-05-25"},
+			{ID: 8077, Title: "Alien³", ReleaseDate: "1992-05-22"},
+		},
+	}, nil)
+	mockRepo.On("SearchMovies", "Solaris", 1, letterboxdLocale).Return(dto.Pagination[dto.TMDBMovieDTO]{
+		Results: []dto.TMDBMovieDTO{
+			{ID: 593, Title: "Solaris", ReleaseDate: "1972-03-20"},
+			{ID: 2103, Title: "Solaris", ReleaseDate: "2002-11-27"},
+		},
+	}, nil)
+	mockRepo.On("SearchMovies", "Nonexistent", 1, letterboxdLocale).Return(dto.Pagination[dto.TMDBMovieDTO]{}, nil)
+
+	rating := 4.5
+	rows := []letterboxd.Row{
+		{Kind: letterboxd.KindRatings, Line: 2, Name: "Alien", Year: "1979", Rating: &rating},
+		{Kind: letterboxd.KindWatched, Line: 2, Name: "Alien", Year: "1979"},
+		{Kind: letterboxd.KindWatched, Line: 3, Name: "Solaris"},
+		{Kind: letterboxd.KindWatchlist, Line: 2, Name: "Nonexistent", Year: "2001"},
+	}
+
+	report, err := service.ImportLetterboxd(context.Background(), 1, rows, true)
+
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Nil(t, report.Imported)
+
+	assert.Len(t, report.Matched, 2)
+	assert.Equal(t, int32(348), *report.Matched[0].MovieID)
+	assert.Equal(t, int32(9), *report.Matched[0].Rating)
+
+	assert.Len(t, report.Ambiguous, 1)
+	assert.Len(t, report.Ambiguous[0].Candidates, 2)
+
+	assert.Len(t, report.Unmatched, 1)
+	assert.Equal(t, "Nonexistent", report.Unmatched[0].Name)
+
+	// Each distinct film is searched only once.
+	mockRepo.AssertNumberOfCalls(t, "SearchMovies", 3)
+}
+
+func TestImportService_ImportLetterboxd_ReportsFailedSearches(t *testing.T) {
+	mockRepo := new(MockMovieRepository)
+	service := &ImportService{movieRepo: mockRepo}
+
+	mockRepo.On("SearchMovies", "Alien", 1, letterboxdLocale).Return(dto.Pagination[dto.TMDBMovieDTO]{
+		Results: []dto.TMDBMovieDTO{{ID: 348, Title: "Alien", ReleaseDate: "1979-05-25"}},
+	}, nil)
+	mockRepo.On("SearchMovies", "Solaris", 1, letterboxdLocale).Return(dto.Pagination[dto.TMDBMovieDTO]{}, errors.New("upstream down"))
+
+	rows := []letterboxd.Row{
+		{Kind: letterboxd.KindWatched, Line: 2, Name: "Alien", Year: "1979"},
+		{Kind: letterboxd.KindWatched, Line: 3, Name: "Solaris", Year: "1972"},
+	}
+
+	report, err := service.ImportLetterboxd(context.Background(), 1, rows, true)
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Matched, 1)
+	assert.Empty(t, report.Unmatched)
+	// A failed search isn't mistaken for a film TMDB doesn't know
+	if assert.Len(t, report.Failed, 1) {
+		assert.Equal(t, "Solaris", report.Failed[0].Name)
+	}
+}
+
+func TestImportService_ImportLetterboxd_CommitsInOneTransaction(t *testing.T) {
+	mockRepo := new(MockMovieRepository)
+	mockWatchListRepo := new(MockWatchListRepository)
+	transactor := &fakeTransactor{}
+	service := &ImportService{
+		movieRepo:        mockRepo,
+		transactor:       transactor,
+		watchlistService: &WatchListService{repo: mockWatchListRepo},
+	}
+
+	mockRepo.On("SearchMovies", "Alien", 1, letterboxdLocale).Return(dto.Pagination[dto.TMDBMovieDTO]{
+		Results: []dto.TMDBMovieDTO{{ID: 348, Title: "Alien", ReleaseDate: "1979-05-25"}},
+	}, nil)
+	mockWatchListRepo.On("GetByUser", int32(1)).Return([]model.Watchlist(nil), errors.New("connection reset"))
+
+	rows := []letterboxd.Row{{Kind: letterboxd.KindWatched, Line: 2, Name: "Alien", Year: "1979"}}
+
+	report, err := service.ImportLetterboxd(context.Background(), 1, rows, false)
+
+	assert.Error(t, err)
+	assert.Nil(t, report.Imported)
+	assert.Equal(t, 1, transactor.calls)
+	assert.True(t, transactor.rolledBack)
+}
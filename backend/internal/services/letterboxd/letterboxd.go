@@ -0,0 +1,165 @@
+// Package letterboxd reads the CSV files of a Letterboxd account export.
+package letterboxd
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Largest CSV accepted from an export, uncompressed.
+const maxFileSize = 20 << 20
+
+// ErrFileTooLarge is returned for export files over maxFileSize.
+var ErrFileTooLarge = errors.New("file too large")
+
+type Kind string
+
+const (
+	KindWatched   Kind = "watched"
+	KindRatings   Kind = "ratings"
+	KindDiary     Kind = "diary"
+	KindWatchlist Kind = "watchlist"
+)
+
+// Row is a single film entry of one of the export files. Rating and
+// WatchedDate are only present in ratings and diary files.
+type Row struct {
+	Kind        Kind
+	Line        int
+	Date        string
+	Name        string
+	Year        string
+	Rating      *float64
+	Rewatch     bool
+	WatchedDate string
+}
+
+// KindFromFilename tells which export file a file name refers to.
+func KindFromFilename(name string) (Kind, bool) {
+	switch kind := Kind(strings.TrimSuffix(strings.ToLower(path.Base(name)), ".csv")); kind {
+	case KindWatched, KindRatings, KindDiary, KindWatchlist:
+		return kind, true
+	default:
+		return "", false
+	}
+}
+
+// ParseZip reads the supported files at the root of an export archive. Files
+// in sub-folders (deleted or orphaned entries) are ignored.
+func ParseZip(r io.ReaderAt, size int64) ([]Row, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for _, file := range archive.File {
+		kind, ok := KindFromFilename(file.Name)
+		if !ok || strings.Contains(file.Name, "/") {
+			continue
+		}
+
+		content, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		fileRows, err := ParseCSV(kind, content)
+		content.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+
+		rows = append(rows, fileRows...)
+	}
+
+	return rows, nil
+}
+
+// ParseCSV reads one export file. Columns are looked up by their header so
+// extra or reordered columns don't matter. Files over maxFileSize are rejected
+// with ErrFileTooLarge rather than read in part.
+func ParseCSV(kind Kind, r io.Reader) ([]Row, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+
+	if _, ok := columns["Name"]; !ok {
+		return nil, fmt.Errorf("missing Name column")
+	}
+
+	var rows []Row
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := Row{
+			Kind:        kind,
+			Line:        line,
+			Date:        field("Date"),
+			Name:        field("Name"),
+			Year:        field("Year"),
+			Rewatch:     strings.EqualFold(field("Rewatch"), "yes"),
+			WatchedDate: field("Watched Date"),
+		}
+
+		if rating := field("Rating"); rating != "" {
+			stars, err := strconv.ParseFloat(rating, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid rating %q", line, rating)
+			}
+			row.Rating = &stars
+		}
+
+		if row.Name != "" {
+			rows = append(rows, row)
+		}
+	}
+
+	return rows, nil
+}
+
+// ConvertRating maps the 0.5–5 star scale onto our 1–10 rating.
+func ConvertRating(stars float64) int32 {
+	return int32(min(10, max(1, math.Round(stars*2))))
+}
@@ -0,0 +1,82 @@
+package letterboxd
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV_Diary(t *testing.T) {
+	// Arrange
+	content := "Date,Name,Year,Letterboxd URI,Rating,Rewatch,Tags,Watched Date\n" +
+		"2024-01-02,\"Crouching Tiger, Hidden Dragon\",2000,https://boxd.it/abc,4.5,Yes,,2024-01-01\n" +
+		"2024-02-03,Parasite,2019,https://boxd.it/def,,,,2024-02-03\n"
+
+	// Act
+	rows, err := ParseCSV(KindDiary, strings.NewReader(content))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	assert.Equal(t, KindDiary, rows[0].Kind)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "Crouching Tiger, Hidden Dragon", rows[0].Name)
+	assert.Equal(t, "2000", rows[0].Year)
+	assert.Equal(t, 4.5, *rows[0].Rating)
+	assert.True(t, rows[0].Rewatch)
+	assert.Equal(t, "2024-01-01", rows[0].WatchedDate)
+
+	assert.Equal(t, "Parasite", rows[1].Name)
+	assert.Nil(t, rows[1].Rating)
+	assert.False(t, rows[1].Rewatch)
+}
+
+func TestParseCSV_TooLarge(t *testing.T) {
+	// Arrange
+	content := "Date,Name,Year\n" + strings.Repeat("2024-01-02,Parasite,2019\n", maxFileSize/25+1)
+
+	// Act
+	rows, err := ParseCSV(KindWatched, strings.NewReader(content))
+
+	// Assert
+	assert.ErrorIs(t, err, ErrFileTooLarge)
+	assert.Nil(t, rows)
+}
+
+func TestParseZip_IgnoresSubfolders(t *testing.T) {
+	// Arrange
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	files := map[string]string{
+		"watchlist.csv":         "Date,Name,Year,Letterboxd URI\n2024-01-01,Dune: Part Two,2024,https://boxd.it/1\n",
+		"ratings.csv":           "Date,Name,Year,Letterboxd URI,Rating\n2024-01-01,Heat,1995,https://boxd.it/2,5\n",
+		"profile.csv":           "Date Joined,Username\n2020-01-01,someone\n",
+		"deleted/watchlist.csv": "Date,Name,Year,Letterboxd URI\n2024-01-01,Cats,2019,https://boxd.it/3\n",
+	}
+	for name, content := range files {
+		w, _ := archive.Create(name)
+		w.Write([]byte(content))
+	}
+	archive.Close()
+
+	// Act
+	rows, err := ParseZip(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+
+	names := []string{rows[0].Name, rows[1].Name}
+	assert.ElementsMatch(t, []string{"Dune: Part Two", "Heat"}, names)
+}
+
+func TestConvertRating(t *testing.T) {
+	assert.Equal(t, int32(1), ConvertRating(0.5))
+	assert.Equal(t, int32(7), ConvertRating(3.5))
+	assert.Equal(t, int32(10), ConvertRating(5))
+	assert.Equal(t, int32(1), ConvertRating(0))
+}
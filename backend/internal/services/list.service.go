@@ -0,0 +1,275 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+type IListService interface {
+	IService
+	GetByUser(ctx context.Context, requesterID int32, ownerID int32) ([]dto.ListDTO, error)
+	GetByID(ctx context.Context, requesterID int32, id int32) (dto.ListDTO, error)
+	Create(ctx context.Context, userID int32, createDTO dto.ListCreateDTO) (dto.ListDTO, error)
+	Update(ctx context.Context, userID int32, id int32, updateDTO dto.ListUpdateDTO) (dto.ListDTO, error)
+	Delete(ctx context.Context, userID int32, id int32) error
+	AddEntry(ctx context.Context, userID int32, id int32, createDTO dto.ListEntryCreateDTO) (dto.ListEntryDTO, error)
+	RemoveEntry(ctx context.Context, userID int32, id int32, movieID int32) error
+	ReorderEntries(ctx context.Context, userID int32, id int32, reorderDTO dto.ListReorderDTO) (dto.ListDTO, error)
+}
+
+type ListService struct {
+	repo         repositories.IListRepository
+	movieService IMovieService
+}
+
+func newListService(params ServicesParams) IListService {
+	return &ListService{
+		repo: params.Repos.ListRepo,
+	}
+}
+
+func (s *ListService) ProvideServices(services Services) {
+	s.movieService = services.MovieService
+}
+
+// GetByUser lists every list of the owner when they are the requester, and
+// only their public lists otherwise.
+func (s *ListService) GetByUser(ctx context.Context, requesterID int32, ownerID int32) ([]dto.ListDTO, error) {
+	lists, err := s.repo.FindByUser(ctx, ownerID, requesterID != ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	listDTOs := make([]dto.ListDTO, len(lists))
+	for i, list := range lists {
+		listDTOs[i].FromModel(list)
+	}
+
+	return listDTOs, nil
+}
+
+// GetByID returns the list with its entries. Private lists are only visible to their owner.
+func (s *ListService) GetByID(ctx context.Context, requesterID int32, id int32) (dto.ListDTO, error) {
+	var listDTO dto.ListDTO
+
+	list, err := s.findVisible(ctx, requesterID, id)
+	if err != nil {
+		return listDTO, err
+	}
+
+	listDTO.FromModel(list)
+	if listDTO.Entries, err = s.getEntries(ctx, id); err != nil {
+		return listDTO, err
+	}
+
+	return listDTO, nil
+}
+
+func (s *ListService) Create(ctx context.Context, userID int32, createDTO dto.ListCreateDTO) (dto.ListDTO, error) {
+	var listDTO dto.ListDTO
+
+	list, err := s.repo.Create(ctx, model.Lists{
+		UserID:      userID,
+		Name:        createDTO.Name,
+		Description: createDTO.Description,
+		Visibility:  utils.FallbackZero(createDTO.Visibility, model.ListVisibility_Private),
+	})
+	if err != nil {
+		return listDTO, err
+	}
+
+	listDTO.FromModel(list)
+	return listDTO, nil
+}
+
+func (s *ListService) Update(ctx context.Context, userID int32, id int32, updateDTO dto.ListUpdateDTO) (dto.ListDTO, error) {
+	var listDTO dto.ListDTO
+
+	list, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return listDTO, err
+	}
+
+	list.Name = utils.Fallback(updateDTO.Name, list.Name)
+	list.Visibility = utils.Fallback(updateDTO.Visibility, list.Visibility)
+	if updateDTO.Description != nil {
+		list.Description = updateDTO.Description
+	}
+
+	if list, err = s.repo.Update(ctx, list); err != nil {
+		return listDTO, err
+	}
+
+	listDTO.FromModel(list)
+	return listDTO, nil
+}
+
+func (s *ListService) Delete(ctx context.Context, userID int32, id int32) error {
+	if _, err := s.findOwned(ctx, userID, id); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// AddEntry appends a movie to the list after checking it exists in the catalog
+// and isn't in the list yet.
+func (s *ListService) AddEntry(ctx context.Context, userID int32, id int32, createDTO dto.ListEntryCreateDTO) (dto.ListEntryDTO, error) {
+	var entryDTO dto.ListEntryDTO
+
+	if _, err := s.findOwned(ctx, userID, id); err != nil {
+		return entryDTO, err
+	}
+
+	entries, err := s.repo.GetEntries(ctx, id)
+	if err != nil {
+		return entryDTO, err
+	}
+
+	if slices.ContainsFunc(entries, func(entry model.ListEntries) bool { return entry.MovieID == createDTO.MovieID }) {
+		return entryDTO, utils.NewConflictError("error.list.duplicate_entry")
+	}
+
+	if _, err = s.movieService.GetByID(ctx, int(createDTO.MovieID), dto.ViewerDTO{UserID: userID}); err != nil {
+		var hiddenErr *policy.HiddenError
+		switch {
+		case errors.As(err, &hiddenErr):
+			return entryDTO, hiddenErr
+		case isNotFound(err):
+			return entryDTO, utils.NewNotFoundError("error.list.movie_not_found")
+		default:
+			return entryDTO, err
+		}
+	}
+
+	entry, err := s.repo.AddEntry(ctx, model.ListEntries{
+		ListID:  id,
+		MovieID: createDTO.MovieID,
+		Note:    createDTO.Note,
+	})
+	if err != nil {
+		return entryDTO, err
+	}
+
+	entryDTO.FromModel(entry)
+	return entryDTO, nil
+}
+
+func (s *ListService) RemoveEntry(ctx context.Context, userID int32, id int32, movieID int32) error {
+	if _, err := s.findOwned(ctx, userID, id); err != nil {
+		return err
+	}
+
+	err := s.repo.RemoveEntry(ctx, id, movieID)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return utils.NewNotFoundError("error.list.entry_not_found")
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReorderEntries rearranges the list in the given order, which must contain every movie of the list exactly once.
+func (s *ListService) ReorderEntries(ctx context.Context, userID int32, id int32, reorderDTO dto.ListReorderDTO) (dto.ListDTO, error) {
+	var listDTO dto.ListDTO
+
+	list, err := s.findOwned(ctx, userID, id)
+	if err != nil {
+		return listDTO, err
+	}
+
+	entries, err := s.repo.GetEntries(ctx, id)
+	if err != nil {
+		return listDTO, err
+	}
+
+	current := make([]int32, len(entries))
+	for i, entry := range entries {
+		current[i] = entry.MovieID
+	}
+
+	requested := slices.Clone(reorderDTO.MovieIDs)
+	slices.Sort(current)
+	slices.Sort(requested)
+	if !slices.Equal(current, requested) {
+		return listDTO, utils.NewBadRequestError("error.list.invalid_order")
+	}
+
+	if err = s.repo.ReorderEntries(ctx, id, reorderDTO.MovieIDs); err != nil {
+		return listDTO, err
+	}
+
+	listDTO.FromModel(list)
+	if listDTO.Entries, err = s.getEntries(ctx, id); err != nil {
+		return listDTO, err
+	}
+
+	return listDTO, nil
+}
+
+func (s *ListService) getEntries(ctx context.Context, id int32) ([]dto.ListEntryDTO, error) {
+	entries, err := s.repo.GetEntries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entryDTOs := make([]dto.ListEntryDTO, len(entries))
+	for i, entry := range entries {
+		entryDTOs[i].FromModel(entry)
+	}
+
+	return entryDTOs, nil
+}
+
+func (s *ListService) find(ctx context.Context, id int32) (model.Lists, error) {
+	list, err := s.repo.FindOne(ctx, id)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return list, utils.NewNotFoundError("error.list.not_found")
+		default:
+			return list, err
+		}
+	}
+
+	return list, nil
+}
+
+// findVisible hides private lists of other users as if they didn't exist.
+func (s *ListService) findVisible(ctx context.Context, requesterID int32, id int32) (model.Lists, error) {
+	list, err := s.find(ctx, id)
+	if err != nil {
+		return list, err
+	}
+
+	if list.UserID != requesterID && list.Visibility == model.ListVisibility_Private {
+		return model.Lists{}, utils.NewNotFoundError("error.list.not_found")
+	}
+
+	return list, nil
+}
+
+func (s *ListService) findOwned(ctx context.Context, userID int32, id int32) (model.Lists, error) {
+	list, err := s.findVisible(ctx, userID, id)
+	if err != nil {
+		return list, err
+	}
+
+	if list.UserID != userID {
+		return model.Lists{}, utils.NewForbiddenError("error.list.not_owner")
+	}
+
+	return list, nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock do repositório de listas
+type MockListRepository struct {
+	mock.Mock
+}
+
+func (m *MockListRepository) FindByUser(_ context.Context, userID int32, publicOnly bool) ([]model.Lists, error) {
+	args := m.Called(userID, publicOnly)
+	return args.Get(0).([]model.Lists), args.Error(1)
+}
+
+func (m *MockListRepository) FindOne(_ context.Context, id int32) (model.Lists, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Lists), args.Error(1)
+}
+
+func (m *MockListRepository) Create(_ context.Context, list model.Lists) (model.Lists, error) {
+	args := m.Called(list)
+	return args.Get(0).(model.Lists), args.Error(1)
+}
+
+func (m *MockListRepository) Update(_ context.Context, list model.Lists) (model.Lists, error) {
+	args := m.Called(list)
+	return args.Get(0).(model.Lists), args.Error(1)
+}
+
+func (m *MockListRepository) Delete(_ context.Context, id int32) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockListRepository) GetEntries(_ context.Context, listID int32) ([]model.ListEntries, error) {
+	args := m.Called(listID)
+	return args.Get(0).([]model.ListEntries), args.Error(1)
+}
+
+func (m *MockListRepository) AddEntry(_ context.Context, entry model.ListEntries) (model.ListEntries, error) {
+	args := m.Called(entry)
+	return args.Get(0).(model.ListEntries), args.Error(1)
+}
+
+func (m *MockListRepository) RemoveEntry(_ context.Context, listID int32, movieID int32) error {
+	args := m.Called(listID, movieID)
+	return args.Error(0)
+}
+
+func (m *MockListRepository) ReorderEntries(_ context.Context, listID int32, movieIDs []int32) error {
+	args := m.Called(listID, movieIDs)
+	return args.Error(0)
+}
+
+func assertApiError(t *testing.T, err error, code int, message string) {
+	apiErr, ok := err.(*utils.ApiError)
+	if assert.True(t, ok, "expected *utils.ApiError, got %v", err) {
+		assert.Equal(t, code, apiErr.Code)
+		assert.Equal(t, message, apiErr.Message)
+	}
+}
+
+func TestListService_GetByID_Visibility(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockListRepository)
+	service := &ListService{repo: mockRepo}
+
+	mockRepo.On("FindOne", int32(1)).Return(model.Lists{ID: 1, UserID: 10, Visibility: model.ListVisibility_Private}, nil)
+	mockRepo.On("FindOne", int32(2)).Return(model.Lists{ID: 2, UserID: 10, Visibility: model.ListVisibility_Unlisted}, nil)
+	mockRepo.On("GetEntries", int32(1)).Return([]model.ListEntries{{ListID: 1, MovieID: 550, Position: 1}}, nil)
+	mockRepo.On("GetEntries", int32(2)).Return([]model.ListEntries{}, nil)
+
+	// Act & Assert
+	list, err := service.GetByID(context.Background(), 10, 1)
+	assert.NoError(t, err)
+	assert.Len(t, list.Entries, 1)
+
+	_, err = service.GetByID(context.Background(), 20, 1)
+	assertApiError(t, err, http.StatusNotFound, "error.list.not_found")
+
+	list, err = service.GetByID(context.Background(), 20, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), list.ID)
+
+	err = service.Delete(context.Background(), 20, 2)
+	assertApiError(t, err, http.StatusForbidden, "error.list.not_owner")
+
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestListService_ReorderEntries(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockListRepository)
+	service := &ListService{repo: mockRepo}
+
+	mockRepo.On("FindOne", int32(1)).Return(model.Lists{ID: 1, UserID: 10}, nil)
+	mockRepo.On("GetEntries", int32(1)).Return([]model.ListEntries{
+		{ListID: 1, MovieID: 550, Position: 1},
+		{ListID: 1, MovieID: 680, Position: 2},
+		{ListID: 1, MovieID: 13, Position: 3},
+	}, nil)
+	mockRepo.On("ReorderEntries", int32(1), []int32{13, 550, 680}).Return(nil)
+
+	// Act & Assert
+	_, err := service.ReorderEntries(context.Background(), 10, 1, dto.ListReorderDTO{MovieIDs: []int32{13, 550}})
+	assertApiError(t, err, http.StatusBadRequest, "error.list.invalid_order")
+
+	_, err = service.ReorderEntries(context.Background(), 10, 1, dto.ListReorderDTO{MovieIDs: []int32{13, 550, 550}})
+	assertApiError(t, err, http.StatusBadRequest, "error.list.invalid_order")
+
+	_, err = service.ReorderEntries(context.Background(), 10, 1, dto.ListReorderDTO{MovieIDs: []int32{13, 550, 680}})
+	assert.NoError(t, err)
+
+	mockRepo.AssertNumberOfCalls(t, "ReorderEntries", 1)
+}
+
+func TestListService_AddEntry_MovieLookupErrors(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockListRepository)
+	movieRepo := new(MockMovieRepository)
+	profileRepo := new(MockParentalProfileRepository)
+	service := &ListService{
+		repo:         mockRepo,
+		movieService: &MovieService{movieRepo: movieRepo, genreRepo: newMockGenreRepository(), contentPolicy: &ContentPolicyService{repo: profileRepo}},
+	}
+
+	mockRepo.On("FindOne", int32(1)).Return(model.Lists{ID: 1, UserID: 10}, nil)
+	mockRepo.On("GetEntries", int32(1)).Return([]model.ListEntries{}, nil)
+	movieRepo.On("GetByID", 404).Return(dto.TMDBMovieDTO{}, utils.NewNotFoundError("error.movie.not_found"))
+	movieRepo.On("GetByID", 500).Return(dto.TMDBMovieDTO{}, errors.New("upstream down"))
+	profileRepo.On("FindByUser", int32(10)).Return(model.ParentalProfiles{}, qrm.ErrNoRows)
+
+	// Act & Assert
+	_, err := service.AddEntry(context.Background(), 10, 1, dto.ListEntryCreateDTO{MovieID: 404})
+	assertApiError(t, err, http.StatusNotFound, "error.list.movie_not_found")
+
+	// Only a missing movie is a 404, upstream failures are reported as they are
+	_, err = service.AddEntry(context.Background(), 10, 1, dto.ListEntryCreateDTO{MovieID: 500})
+	assert.EqualError(t, err, "upstream down")
+
+	mockRepo.AssertNotCalled(t, "AddEntry", mock.Anything)
+}
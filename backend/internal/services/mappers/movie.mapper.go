@@ -2,20 +2,27 @@ package mappers
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
 )
 
-func MapFromTMDBToMovieDTO(tmdbMovie dto.TMDBMovieDTO) dto.MovieDTO {
+// MapFromTMDBToMovieDTO maps a TMDB movie, picking the title, overview and
+// tagline of its translation to the locale when TMDB has one.
+func MapFromTMDBToMovieDTO(tmdbMovie dto.TMDBMovieDTO, locale dto.LocaleDTO) dto.MovieDTO {
 	year := ""
 	if len(tmdbMovie.ReleaseDate) >= 4 {
 		year = tmdbMovie.ReleaseDate[:4]
 	}
 
-	// Mapear gêneros
-	genres := make([]string, 0)
-	for _, genre := range tmdbMovie.Genres {
-		genres = append(genres, genre.Name)
+	// Mapear gêneros; as listas do TMDB trazem só os IDs
+	genres := slices.Clone(tmdbMovie.Genres)
+	if len(genres) == 0 {
+		genres = make([]dto.GenreDTO, len(tmdbMovie.GenreIDs))
+		for i, id := range tmdbMovie.GenreIDs {
+			genres[i] = dto.GenreDTO{ID: id}
+		}
 	}
 
 	// Mapear background path
@@ -30,27 +37,8 @@ func MapFromTMDBToMovieDTO(tmdbMovie dto.TMDBMovieDTO) dto.MovieDTO {
 		duration = fmt.Sprintf("%d", tmdbMovie.Runtime)
 	}
 
-	// Buscar tradução em português
-	title := tmdbMovie.Title
-	description := tmdbMovie.Overview
-	tagline := tmdbMovie.Tagline
-
-	if tmdbMovie.Translations != nil {
-		for _, translation := range tmdbMovie.Translations.Translations {
-			if translation.ISO6391 == "pt" || translation.ISO31661 == "BR" {
-				if translation.Data.Title != "" {
-					title = translation.Data.Title
-				}
-				if translation.Data.Overview != "" {
-					description = translation.Data.Overview
-				}
-				if translation.Data.Tagline != "" {
-					tagline = translation.Data.Tagline
-				}
-				break
-			}
-		}
-	}
+	// Traduzir para o idioma pedido
+	title, description, tagline := translateMovie(tmdbMovie, locale)
 
 	// Converter slices para []any
 	prodCompanies := make([]any, len(tmdbMovie.ProductionCompanies))
@@ -66,6 +54,15 @@ func MapFromTMDBToMovieDTO(tmdbMovie dto.TMDBMovieDTO) dto.MovieDTO {
 		spokenLangs[i] = l
 	}
 
+	// Elenco e equipe, só nos detalhes
+	cast, crew := mapCredits(tmdbMovie.Credits)
+
+	// Vídeos no idioma pedido, ou em inglês
+	videos, trailer := mapVideos(tmdbMovie.Videos, locale)
+
+	// Onde assistir, no país da região pedida
+	watchProviders := tmdbMovie.WatchAvailability(utils.FallbackZero(locale.Region, locale.CountryCode()))
+
 	return dto.MovieDTO{
 		ID:                  tmdbMovie.ID,
 		Title:               title,
@@ -73,7 +70,8 @@ func MapFromTMDBToMovieDTO(tmdbMovie dto.TMDBMovieDTO) dto.MovieDTO {
 		BackgroundPath:      backgroundPath,
 		Year:                year,
 		Description:         description,
-		Genre:               genres,
+		Genre:               GenreNames(genres),
+		Genres:              genres,
 		Duration:            duration,
 		Tagline:             tagline,
 		VoteAverage:         tmdbMovie.VoteAverage,
@@ -91,13 +89,83 @@ func MapFromTMDBToMovieDTO(tmdbMovie dto.TMDBMovieDTO) dto.MovieDTO {
 		ProductionCompanies: prodCompanies,
 		ProductionCountries: prodCountries,
 		SpokenLanguages:     spokenLangs,
+		Cast:                cast,
+		Crew:                crew,
+		WatchProviders:      watchProviders,
+		Videos:              videos,
+		Trailer:             trailer,
+	}
+}
+
+// GenreNames lists the names of the genres, skipping the ones not named.
+func GenreNames(genres []dto.GenreDTO) []string {
+	names := make([]string, 0, len(genres))
+	for _, genre := range genres {
+		if genre.Name != "" {
+			names = append(names, genre.Name)
+		}
 	}
+	return names
 }
 
-func MapFromTMDBToMovieDTOs(tmdbMovies []dto.TMDBMovieDTO) []dto.MovieDTO {
+func MapFromTMDBToMovieDTOs(tmdbMovies []dto.TMDBMovieDTO, locale dto.LocaleDTO) []dto.MovieDTO {
 	movies := make([]dto.MovieDTO, len(tmdbMovies))
 	for i, tmdbMovie := range tmdbMovies {
-		movies[i] = MapFromTMDBToMovieDTO(tmdbMovie)
+		movies[i] = MapFromTMDBToMovieDTO(tmdbMovie, locale)
 	}
 	return movies
 }
+
+// translateMovie resolves each text field from the translation to the exact
+// language variant (e.g. pt-BR), then any translation to the same language
+// (e.g. pt-PT), and finally the movie's own fields, which TMDB returns in the
+// language they were requested in. Translations often leave some fields empty,
+// so every field falls back on its own.
+func translateMovie(tmdbMovie dto.TMDBMovieDTO, locale dto.LocaleDTO) (title string, description string, tagline string) {
+	title = tmdbMovie.Title
+	description = tmdbMovie.Overview
+	tagline = tmdbMovie.Tagline
+
+	for _, data := range translationsFor(tmdbMovie.Translations, locale) {
+		if data.Title != "" {
+			title = data.Title
+		}
+		if data.Overview != "" {
+			description = data.Overview
+		}
+		if data.Tagline != "" {
+			tagline = data.Tagline
+		}
+	}
+
+	return title, description, tagline
+}
+
+// translationsFor returns the translations to the locale's language, the
+// least preferred first so better matches override it when applied in order.
+func translationsFor(translations *dto.TranslationsDTO, locale dto.LocaleDTO) []dto.TranslationDataDTO {
+	languageCode := locale.LanguageCode()
+	if translations == nil || languageCode == "" {
+		return nil
+	}
+
+	countryCode := locale.CountryCode()
+	var exact, sameLanguage []dto.TranslationDTO
+	for _, translation := range translations.Translations {
+		if translation.ISO6391 != languageCode {
+			continue
+		}
+		if translation.ISO31661 == countryCode {
+			exact = append(exact, translation)
+		} else {
+			sameLanguage = append(sameLanguage, translation)
+		}
+	}
+
+	candidates := append(exact, sameLanguage...)
+	data := make([]dto.TranslationDataDTO, len(candidates))
+	for i, candidate := range candidates {
+		data[len(candidates)-1-i] = candidate.Data
+	}
+	return data
+}
@@ -10,14 +10,14 @@ import (
 func TestMapFromTMDBToMovieDTO(t *testing.T) {
 	// Arrange
 	tmdbMovie := dto.TMDBMovieDTO{
-		ID:            123,
-		OriginalTitle: "Test Movie",
-		PosterPath:    "/test-poster.jpg",
-		ReleaseDate:   "2023-05-15",
+		ID:          123,
+		Title:       "Test Movie",
+		PosterPath:  "/test-poster.jpg",
+		ReleaseDate: "2023-05-15",
 	}
 
 	// Act
-	result := MapFromTMDBToMovieDTO(tmdbMovie)
+	result := MapFromTMDBToMovieDTO(tmdbMovie, dto.LocaleDTO{})
 
 	// Assert
 	assert.Equal(t, 123, result.ID)
@@ -30,21 +30,21 @@ func TestMapFromTMDBToMovieDTOs(t *testing.T) {
 	// Arrange
 	tmdbMovies := []dto.TMDBMovieDTO{
 		{
-			ID:            123,
-			OriginalTitle: "Test Movie 1",
-			PosterPath:    "/test-poster1.jpg",
-			ReleaseDate:   "2023-05-15",
+			ID:          123,
+			Title:       "Test Movie 1",
+			PosterPath:  "/test-poster1.jpg",
+			ReleaseDate: "2023-05-15",
 		},
 		{
-			ID:            456,
-			OriginalTitle: "Test Movie 2",
-			PosterPath:    "/test-poster2.jpg",
-			ReleaseDate:   "2022-10-20",
+			ID:          456,
+			Title:       "Test Movie 2",
+			PosterPath:  "/test-poster2.jpg",
+			ReleaseDate: "2022-10-20",
 		},
 	}
 
 	// Act
-	results := MapFromTMDBToMovieDTOs(tmdbMovies)
+	results := MapFromTMDBToMovieDTOs(tmdbMovies, dto.LocaleDTO{})
 
 	// Assert
 	assert.Len(t, results, 2)
@@ -59,3 +59,161 @@ func TestMapFromTMDBToMovieDTOs(t *testing.T) {
 	assert.Equal(t, "/test-poster2.jpg", results[1].PosterPath)
 	assert.Equal(t, "2022", results[1].Year)
 }
+
+func TestMapFromTMDBToMovieDTO_Genres(t *testing.T) {
+	detailed := MapFromTMDBToMovieDTO(dto.TMDBMovieDTO{ID: 1, Genres: []dto.GenreDTO{{ID: 18, Name: "Drama"}}}, dto.LocaleDTO{})
+	listed := MapFromTMDBToMovieDTO(dto.TMDBMovieDTO{ID: 2, GenreIDs: []int{28, 12}}, dto.LocaleDTO{})
+	none := MapFromTMDBToMovieDTO(dto.TMDBMovieDTO{ID: 3}, dto.LocaleDTO{})
+
+	assert.Equal(t, []dto.GenreDTO{{ID: 18, Name: "Drama"}}, detailed.Genres)
+	assert.Equal(t, []string{"Drama"}, detailed.Genre)
+	// Listed movies only carry the IDs, named later in the viewer's language
+	assert.Equal(t, []dto.GenreDTO{{ID: 28}, {ID: 12}}, listed.Genres)
+	assert.Empty(t, listed.Genre)
+	assert.NotNil(t, none.Genres)
+}
+
+func TestMapFromTMDBToMovieDTO_Translations(t *testing.T) {
+	// Arrange
+	tmdbMovie := dto.TMDBMovieDTO{
+		ID:            123,
+		Title:         "Alien, o Oitavo Passageiro",
+		OriginalTitle: "Alien",
+		Overview:      "Sinopse em português",
+		Tagline:       "Tagline em português",
+		Translations: &dto.TranslationsDTO{
+			Translations: []dto.TranslationDTO{
+				{ISO6391: "en", ISO31661: "GB", Data: dto.TranslationDataDTO{Title: "Alien", Overview: "British overview"}},
+				{ISO6391: "en", ISO31661: "US", Data: dto.TranslationDataDTO{Title: "Alien", Tagline: "In space no one can hear you scream."}},
+				{ISO6391: "fr", ISO31661: "FR", Data: dto.TranslationDataDTO{Title: "Alien, le huitième passager"}},
+			},
+		},
+	}
+
+	// Act
+	english := MapFromTMDBToMovieDTO(tmdbMovie, dto.LocaleDTO{Language: "en-US"})
+	french := MapFromTMDBToMovieDTO(tmdbMovie, dto.LocaleDTO{Language: "fr", Region: "CA"})
+	japanese := MapFromTMDBToMovieDTO(tmdbMovie, dto.LocaleDTO{Language: "ja-JP"})
+
+	// Assert
+	// Fields missing from the exact variant come from another one of the same language
+	assert.Equal(t, "Alien", english.Title)
+	assert.Equal(t, "In space no one can hear you scream.", english.Tagline)
+	assert.Equal(t, "British overview", english.Description)
+
+	// Fields missing from every translation keep the language the movie was fetched in
+	assert.Equal(t, "Alien, le huitième passager", french.Title)
+	assert.Equal(t, "Sinopse em português", french.Description)
+
+	assert.Equal(t, "Alien, o Oitavo Passageiro", japanese.Title)
+}
+
+func TestMapFromTMDBToMovieDTO_Credits(t *testing.T) {
+	// Arrange
+	credits := &dto.CreditsDTO{
+		Crew: []dto.CrewCreditDTO{
+			{ID: 1, Name: "Director", Job: "Director", Department: "Directing"},
+			{ID: 2, Name: "Caterer", Job: "Craft Service", Department: "Crew"},
+		},
+	}
+	for i := range maxCastMembers + 5 {
+		// Billed in reverse, so the cast must be sorted
+		credits.Cast = append(credits.Cast, dto.CastCreditDTO{ID: 100 + i, Order: maxCastMembers + 4 - i})
+	}
+
+	// Act
+	result := MapFromTMDBToMovieDTO(dto.TMDBMovieDTO{ID: 123, Credits: credits}, dto.LocaleDTO{})
+	listed := MapFromTMDBToMovieDTO(dto.TMDBMovieDTO{ID: 123}, dto.LocaleDTO{})
+
+	// Assert
+	assert.Len(t, result.Cast, maxCastMembers)
+	assert.Equal(t, 100+maxCastMembers+4, result.Cast[0].PersonID)
+	assert.Equal(t, []dto.CrewDTO{{PersonID: 1, Name: "Director", Job: "Director", Department: "Directing"}}, result.Crew)
+
+	// Movies from lists come without credits
+	assert.Nil(t, listed.Cast)
+	assert.Nil(t, listed.Crew)
+}
+
+func TestMapFromTMDBToMovieDTO_WatchProviders(t *testing.T) {
+	tmdbMovie := dto.TMDBMovieDTO{
+		ID: 603,
+		WatchProviders: &dto.WatchProvidersDTO{Results: map[string]dto.WatchAvailabilityDTO{
+			"BR": {Link: "https://www.themoviedb.org/movie/603/watch?locale=BR", Flatrate: []dto.WatchProviderDTO{{ProviderID: 8, ProviderName: "Netflix"}}},
+			"US": {Link: "https://www.themoviedb.org/movie/603/watch?locale=US", Flatrate: []dto.WatchProviderDTO{{ProviderID: 9, ProviderName: "Amazon Prime Video"}}},
+		}},
+	}
+
+	brazil := MapFromTMDBToMovieDTO(tmdbMovie, dto.LocaleDTO{Language: "en-US", Region: "BR"})
+	fromLanguage := MapFromTMDBToMovieDTO(tmdbMovie, dto.LocaleDTO{Language: "en-US"})
+	japan := MapFromTMDBToMovieDTO(tmdbMovie, dto.LocaleDTO{Language: "ja-JP", Region: "JP"})
+	listed := MapFromTMDBToMovieDTO(dto.TMDBMovieDTO{ID: 603}, dto.LocaleDTO{Region: "BR"})
+
+	if assert.NotNil(t, brazil.WatchProviders) {
+		assert.Equal(t, "BR", brazil.WatchProviders.Country)
+		assert.Equal(t, "Netflix", brazil.WatchProviders.Flatrate[0].ProviderName)
+	}
+	if assert.NotNil(t, fromLanguage.WatchProviders) {
+		assert.Equal(t, "US", fromLanguage.WatchProviders.Country)
+	}
+	assert.Nil(t, japan.WatchProviders)
+	assert.Nil(t, listed.WatchProviders)
+}
+
+func TestMapFromTMDBToMovieDTO_Videos(t *testing.T) {
+	tmdbMovie := dto.TMDBMovieDTO{
+		ID: 603,
+		Videos: &dto.VideosDTO{Results: []dto.TMDBVideoDTO{
+			{ID: "1", ISO6391: "pt", ISO31661: "BR", Name: "Trailer Dublado", Key: "dub", Site: "YouTube", Type: "Trailer", Size: 720},
+			{ID: "2", ISO6391: "pt", ISO31661: "PT", Name: "Trailer Oficial", Key: "pt", Site: "YouTube", Type: "Trailer", Official: true, Size: 1080},
+			{ID: "3", ISO6391: "pt", ISO31661: "BR", Name: "Trailer Legendado", Key: "sub", Site: "YouTube", Type: "Trailer", Official: true, Size: 1080},
+			{ID: "4", ISO6391: "pt", ISO31661: "BR", Name: "Bastidores", Key: "123", Site: "Vimeo", Type: "Featurette", Official: true, Size: 1080},
+			{ID: "5", ISO6391: "en", ISO31661: "US", Name: "Official Teaser", Key: "teaser", Site: "YouTube", Type: "Teaser", Official: true, Size: 1080},
+			{ID: "6", ISO6391: "en", ISO31661: "US", Name: "Trailer 2", Key: "second", Site: "YouTube", Type: "Trailer", Official: true, Size: 1080, PublishedAt: "1999-02-01T00:00:00.000Z"},
+			{ID: "7", ISO6391: "en", ISO31661: "US", Name: "Official Trailer", Key: "first", Site: "YouTube", Type: "Trailer", Official: true, Size: 1080, PublishedAt: "1998-12-01T00:00:00.000Z"},
+		}},
+	}
+
+	brazil := MapFromTMDBToMovieDTO(tmdbMovie, dto.LocaleDTO{Language: "pt-BR"})
+	japan := MapFromTMDBToMovieDTO(tmdbMovie, dto.LocaleDTO{Language: "ja-JP"})
+	listed := MapFromTMDBToMovieDTO(dto.TMDBMovieDTO{ID: 603}, dto.LocaleDTO{Language: "pt-BR"})
+
+	assert.Len(t, brazil.Videos, 4)
+	assert.Equal(t, "https://vimeo.com/123", brazil.Videos[3].URL)
+	if assert.NotNil(t, brazil.Trailer) {
+		assert.Equal(t, "Trailer Legendado", brazil.Trailer.Name)
+		assert.Equal(t, "https://www.youtube.com/watch?v=sub", brazil.Trailer.URL)
+	}
+
+	// Nothing in Japanese, so the English videos are shown
+	assert.Len(t, japan.Videos, 3)
+	if assert.NotNil(t, japan.Trailer) {
+		assert.Equal(t, "Official Trailer", japan.Trailer.Name)
+	}
+
+	assert.Nil(t, listed.Videos)
+	assert.Nil(t, listed.Trailer)
+}
+
+func TestMapFromTMDBToPersonDTO_Translations(t *testing.T) {
+	profilePath := "/profile.jpg"
+	tmdbPerson := dto.TMDBPersonDTO{
+		ID:          525,
+		Name:        "Christopher Nolan",
+		Biography:   "English biography",
+		ProfilePath: &profilePath,
+		Translations: &dto.TranslationsDTO{
+			Translations: []dto.TranslationDTO{
+				{ISO6391: "pt", ISO31661: "BR", Data: dto.TranslationDataDTO{Biography: "Biografia em português"}},
+			},
+		},
+	}
+
+	portuguese := MapFromTMDBToPersonDTO(tmdbPerson, dto.LocaleDTO{Language: "pt-BR"})
+	japanese := MapFromTMDBToPersonDTO(tmdbPerson, dto.LocaleDTO{Language: "ja-JP"})
+
+	assert.Equal(t, "Biografia em português", portuguese.Biography)
+	assert.Equal(t, "/profile.jpg", portuguese.ProfilePath)
+	assert.Equal(t, []string{}, portuguese.AlsoKnownAs)
+	assert.Equal(t, "English biography", japanese.Biography)
+}
@@ -0,0 +1,93 @@
+package mappers
+
+import (
+	"slices"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+// Number of actors listed in movie details, in billing order.
+const maxCastMembers = 20
+
+// Crew jobs listed in movie details. TMDB credits everyone down to the
+// caterers, so only the jobs people look titles up by are kept.
+var keyCrewJobs = []string{
+	"Director",
+	"Screenplay",
+	"Writer",
+	"Novel",
+	"Story",
+	"Producer",
+	"Original Music Composer",
+	"Director of Photography",
+}
+
+// MapFromTMDBToPersonDTO maps a TMDB person, picking the biography of its
+// translation to the locale when TMDB has one.
+func MapFromTMDBToPersonDTO(tmdbPerson dto.TMDBPersonDTO, locale dto.LocaleDTO) dto.PersonDTO {
+	biography := tmdbPerson.Biography
+	for _, data := range translationsFor(tmdbPerson.Translations, locale) {
+		if data.Biography != "" {
+			biography = data.Biography
+		}
+	}
+
+	alsoKnownAs := tmdbPerson.AlsoKnownAs
+	if alsoKnownAs == nil {
+		alsoKnownAs = make([]string, 0)
+	}
+
+	return dto.PersonDTO{
+		ID:                 tmdbPerson.ID,
+		Name:               tmdbPerson.Name,
+		AlsoKnownAs:        alsoKnownAs,
+		Biography:          biography,
+		Birthday:           utils.Fallback(tmdbPerson.Birthday, ""),
+		Deathday:           utils.Fallback(tmdbPerson.Deathday, ""),
+		PlaceOfBirth:       utils.Fallback(tmdbPerson.PlaceOfBirth, ""),
+		KnownForDepartment: tmdbPerson.KnownForDepartment,
+		ProfilePath:        utils.Fallback(tmdbPerson.ProfilePath, ""),
+		Homepage:           utils.Fallback(tmdbPerson.Homepage, ""),
+		ImdbID:             utils.Fallback(tmdbPerson.ImdbID, ""),
+		Popularity:         tmdbPerson.Popularity,
+	}
+}
+
+// mapCredits returns the top billed cast and the key crew of a movie, none
+// when it was fetched without its credits.
+func mapCredits(credits *dto.CreditsDTO) ([]dto.CastDTO, []dto.CrewDTO) {
+	if credits == nil {
+		return nil, nil
+	}
+
+	members := slices.SortedStableFunc(slices.Values(credits.Cast), func(a, b dto.CastCreditDTO) int {
+		return a.Order - b.Order
+	})
+
+	cast := make([]dto.CastDTO, 0, min(len(members), maxCastMembers))
+	for _, member := range members[:min(len(members), maxCastMembers)] {
+		cast = append(cast, dto.CastDTO{
+			PersonID:    member.ID,
+			Name:        member.Name,
+			Character:   member.Character,
+			ProfilePath: utils.Fallback(member.ProfilePath, ""),
+		})
+	}
+
+	crew := make([]dto.CrewDTO, 0)
+	for _, member := range credits.Crew {
+		if !slices.Contains(keyCrewJobs, member.Job) {
+			continue
+		}
+		crew = append(crew, dto.CrewDTO{
+			PersonID:    member.ID,
+			Name:        member.Name,
+			Job:         member.Job,
+			Department:  member.Department,
+			ProfilePath: utils.Fallback(member.ProfilePath, ""),
+		})
+	}
+
+	return cast, crew
+}
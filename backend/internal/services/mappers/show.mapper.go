@@ -0,0 +1,133 @@
+package mappers
+
+import (
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+// MapFromTMDBToShowDTO maps a TMDB show, picking the name, overview and
+// tagline of its translation to the locale when TMDB has one. Specials are
+// listed among the seasons like TMDB does, as season 0.
+func MapFromTMDBToShowDTO(tmdbShow dto.TMDBShowDTO, locale dto.LocaleDTO) dto.ShowDTO {
+	year := ""
+	if len(tmdbShow.FirstAirDate) >= 4 {
+		year = tmdbShow.FirstAirDate[:4]
+	}
+
+	genres := make([]string, 0)
+	for _, genre := range tmdbShow.Genres {
+		genres = append(genres, genre.Name)
+	}
+
+	backgroundPath := ""
+	if tmdbShow.BackdropPath != nil {
+		backgroundPath = *tmdbShow.BackdropPath
+	}
+
+	var seasons []dto.SeasonSummaryDTO
+	for _, season := range tmdbShow.Seasons {
+		seasons = append(seasons, dto.SeasonSummaryDTO{
+			SeasonNumber: season.SeasonNumber,
+			Name:         season.Name,
+			EpisodeCount: season.EpisodeCount,
+			AirDate:      season.AirDate,
+			PosterPath:   season.PosterPath,
+		})
+	}
+
+	var nextEpisode *dto.EpisodeDTO
+	if tmdbShow.NextEpisodeToAir != nil {
+		episode := MapFromTMDBToEpisodeDTO(*tmdbShow.NextEpisodeToAir)
+		nextEpisode = &episode
+	}
+
+	name, description, tagline := translateShow(tmdbShow, locale)
+
+	return dto.ShowDTO{
+		ID:               tmdbShow.ID,
+		Name:             name,
+		OriginalName:     tmdbShow.OriginalName,
+		PosterPath:       tmdbShow.PosterPath,
+		BackgroundPath:   backgroundPath,
+		Year:             year,
+		Description:      description,
+		Tagline:          tagline,
+		Genre:            genres,
+		Status:           tmdbShow.Status,
+		FirstAirDate:     tmdbShow.FirstAirDate,
+		LastAirDate:      tmdbShow.LastAirDate,
+		OriginalLanguage: tmdbShow.OriginalLanguage,
+		VoteAverage:      tmdbShow.VoteAverage,
+		VoteCount:        tmdbShow.VoteCount,
+		Popularity:       tmdbShow.Popularity,
+		NumberOfSeasons:  tmdbShow.NumberOfSeasons,
+		NumberOfEpisodes: tmdbShow.NumberOfEpisodes,
+		Seasons:          seasons,
+		NextEpisodeToAir: nextEpisode,
+	}
+}
+
+func MapFromTMDBToShowDTOs(tmdbShows []dto.TMDBShowDTO, locale dto.LocaleDTO) []dto.ShowDTO {
+	shows := make([]dto.ShowDTO, len(tmdbShows))
+	for i, tmdbShow := range tmdbShows {
+		shows[i] = MapFromTMDBToShowDTO(tmdbShow, locale)
+	}
+	return shows
+}
+
+// MapFromTMDBToSeasonDTO maps a season, which TMDB already returns in the
+// language it was requested in.
+func MapFromTMDBToSeasonDTO(showID int, tmdbSeason dto.TMDBSeasonDTO) dto.SeasonDTO {
+	episodes := make([]dto.EpisodeDTO, len(tmdbSeason.Episodes))
+	for i, episode := range tmdbSeason.Episodes {
+		episodes[i] = MapFromTMDBToEpisodeDTO(episode)
+	}
+
+	return dto.SeasonDTO{
+		ShowID:       showID,
+		SeasonNumber: tmdbSeason.SeasonNumber,
+		Name:         tmdbSeason.Name,
+		Description:  tmdbSeason.Overview,
+		AirDate:      tmdbSeason.AirDate,
+		PosterPath:   tmdbSeason.PosterPath,
+		Episodes:     episodes,
+	}
+}
+
+func MapFromTMDBToEpisodeDTO(tmdbEpisode dto.TMDBEpisodeDTO) dto.EpisodeDTO {
+	stillPath := ""
+	if tmdbEpisode.StillPath != nil {
+		stillPath = *tmdbEpisode.StillPath
+	}
+
+	return dto.EpisodeDTO{
+		SeasonNumber:  tmdbEpisode.SeasonNumber,
+		EpisodeNumber: tmdbEpisode.EpisodeNumber,
+		Name:          tmdbEpisode.Name,
+		Description:   tmdbEpisode.Overview,
+		AirDate:       tmdbEpisode.AirDate,
+		Runtime:       tmdbEpisode.Runtime,
+		StillPath:     stillPath,
+	}
+}
+
+// translateShow resolves the texts of the show like translateMovie does.
+func translateShow(tmdbShow dto.TMDBShowDTO, locale dto.LocaleDTO) (name string, description string, tagline string) {
+	name = utils.FallbackZero(tmdbShow.Name, tmdbShow.OriginalName)
+	description = tmdbShow.Overview
+	tagline = tmdbShow.Tagline
+
+	for _, data := range translationsFor(tmdbShow.Translations, locale) {
+		if data.Name != "" {
+			name = data.Name
+		}
+		if data.Overview != "" {
+			description = data.Overview
+		}
+		if data.Tagline != "" {
+			tagline = data.Tagline
+		}
+	}
+
+	return name, description, tagline
+}
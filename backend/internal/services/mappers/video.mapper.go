@@ -0,0 +1,99 @@
+package mappers
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+// Language of the videos shown when there are none in the requested one.
+const fallbackVideoLanguage = "en"
+
+// mapVideos returns the videos of a movie in the locale's language, or the
+// English ones when it has none, along with the preferred trailer among them.
+// Movies fetched without their videos have neither.
+func mapVideos(videos *dto.VideosDTO, locale dto.LocaleDTO) ([]dto.VideoDTO, *dto.VideoDTO) {
+	if videos == nil {
+		return nil, nil
+	}
+
+	inLanguage := videosIn(videos.Results, cmp.Or(locale.LanguageCode(), fallbackVideoLanguage))
+	if len(inLanguage) == 0 {
+		inLanguage = videosIn(videos.Results, fallbackVideoLanguage)
+	}
+
+	return inLanguage, preferredTrailer(inLanguage, locale.CountryCode())
+}
+
+func videosIn(videos []dto.TMDBVideoDTO, language string) []dto.VideoDTO {
+	mapped := make([]dto.VideoDTO, 0)
+	for _, video := range videos {
+		if video.ISO6391 != language {
+			continue
+		}
+		mapped = append(mapped, dto.VideoDTO{
+			ID:          video.ID,
+			Name:        video.Name,
+			Type:        video.Type,
+			Site:        video.Site,
+			Key:         video.Key,
+			URL:         videoURL(video.Site, video.Key),
+			Language:    video.ISO6391,
+			Country:     video.ISO31661,
+			Official:    video.Official,
+			Size:        video.Size,
+			PublishedAt: video.PublishedAt,
+		})
+	}
+	return mapped
+}
+
+// preferredTrailer picks the trailer to feature among the videos, nil when
+// there are no trailers or teasers on a site that can be linked to. Trailers
+// win over teasers, then official ones, the ones made for the country, those
+// on YouTube, the sharpest and finally the first published, as later cuts
+// tend to be re-releases or extended versions.
+func preferredTrailer(videos []dto.VideoDTO, country string) *dto.VideoDTO {
+	candidates := slices.DeleteFunc(slices.Clone(videos), func(video dto.VideoDTO) bool {
+		return (video.Type != "Trailer" && video.Type != "Teaser") || video.URL == ""
+	})
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	preferred := slices.MinFunc(candidates, func(a, b dto.VideoDTO) int {
+		return cmp.Or(
+			rankFirst(a.Type == "Trailer", b.Type == "Trailer"),
+			rankFirst(a.Official, b.Official),
+			rankFirst(a.Country == country, b.Country == country),
+			rankFirst(a.Site == "YouTube", b.Site == "YouTube"),
+			cmp.Compare(b.Size, a.Size),
+			cmp.Compare(a.PublishedAt, b.PublishedAt),
+		)
+	})
+	return &preferred
+}
+
+// rankFirst orders the one meeting the condition before the other.
+func rankFirst(a bool, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func videoURL(site string, key string) string {
+	switch site {
+	case "YouTube":
+		return "https://www.youtube.com/watch?v=" + key
+	case "Vimeo":
+		return "https://vimeo.com/" + key
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+const (
+	defaultMoviePageSize = 20
+
+	// Number of results in every TMDB page.
+	upstreamPageSize = 20
+
+	// TMDB refuses to serve pages past this one.
+	maxUpstreamPage = 500
+
+	// Maximum number of upstream pages read to fill a single page. Heavily
+	// filtered results come back short rather than stalling the request.
+	maxUpstreamPagesPerPage = 5
+)
+
+// movieCursor is the position in the upstream results where a page starts,
+// along with what is needed to keep the page numbers and totals right.
+type movieCursor struct {
+	UpstreamPage int `json:"p"`
+	Offset       int `json:"o"`
+	Page         int `json:"n"`
+	Shown        int `json:"s"` // results returned by the previous pages
+}
+
+func (c movieCursor) encode() string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodeMovieCursor returns where the page asked for starts. Without a cursor
+// the page starts where it would if no movie were hidden, which is where the
+// upstream results reach the number of movies in the pages before it.
+func decodeMovieCursor(query dto.MoviePageQueryDTO, pageSize int) (movieCursor, error) {
+	if query.Cursor == "" {
+		page := max(query.Page, 1)
+		shown := (page - 1) * pageSize
+		return movieCursor{
+			UpstreamPage: shown/upstreamPageSize + 1,
+			Offset:       shown % upstreamPageSize,
+			Page:         page,
+			Shown:        shown,
+		}, nil
+	}
+
+	var cursor movieCursor
+
+	payload, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+	if err == nil {
+		err = json.Unmarshal(payload, &cursor)
+	}
+	if err == nil && (cursor.UpstreamPage < 1 || cursor.Offset < 0 || cursor.Page < 1 || cursor.Shown < 0) {
+		err = errors.New("cursor out of range")
+	}
+	if err != nil {
+		return cursor, utils.NewBadRequestError("error.movie.invalid_cursor")
+	}
+
+	return cursor, nil
+}
+
+// paginateMovies reads upstream pages from the cursor on, dropping the
+// movies the policy hides, until the page is full. The next cursor points
+// right after the last movie read, so no movie is skipped or repeated when
+// the upstream pages stay the same, which the movie cache sees to.
+func paginateMovies(fetch func(page int) (dto.Pagination[dto.TMDBMovieDTO], error), rules dto.ContentPolicyDTO, query dto.MoviePageQueryDTO) (dto.CursorPagination[dto.TMDBMovieDTO], error) {
+	return paginateMoviesExcept(fetch, rules, nil, query)
+}
+
+// paginateMoviesExcept pages movies the same way as paginateMovies, also
+// dropping the excluded ones.
+func paginateMoviesExcept(fetch func(page int) (dto.Pagination[dto.TMDBMovieDTO], error), rules dto.ContentPolicyDTO, excluded map[int]bool, query dto.MoviePageQueryDTO) (dto.CursorPagination[dto.TMDBMovieDTO], error) {
+	return paginate(fetch, func(movie dto.TMDBMovieDTO) (int, bool) {
+		return movie.ID, !excluded[movie.ID] && len(policy.Evaluate(rules, movie)) == 0
+	}, query)
+}
+
+// paginateShows pages shows the same way as paginateMovies.
+func paginateShows(fetch func(page int) (dto.Pagination[dto.TMDBShowDTO], error), rules dto.ContentPolicyDTO, query dto.MoviePageQueryDTO) (dto.CursorPagination[dto.TMDBShowDTO], error) {
+	return paginate(fetch, func(show dto.TMDBShowDTO) (int, bool) {
+		return show.ID, len(policy.EvaluateShow(rules, show)) == 0
+	}, query)
+}
+
+// paginate fills a page with the titles inspect reports as shown, along with
+// their ID.
+func paginate[T any](fetch func(page int) (dto.Pagination[T], error), inspect func(T) (int, bool), query dto.MoviePageQueryDTO) (dto.CursorPagination[T], error) {
+	pageSize := utils.FallbackZero(query.PageSize, defaultMoviePageSize)
+	page := dto.CursorPagination[T]{
+		Results:  make([]T, 0, pageSize),
+		PageSize: pageSize,
+	}
+
+	cursor, err := decodeMovieCursor(query, pageSize)
+	if err != nil {
+		return page, err
+	}
+	page.Page = cursor.Page
+
+	position := cursor
+	seen := make(map[int]bool)
+	evaluated, upstreamTotal := 0, 0
+	// Large page numbers may start past the pages TMDB serves
+	exhausted := position.UpstreamPage > maxUpstreamPage
+
+	for fetched := 0; !exhausted && len(page.Results) < pageSize && fetched < maxUpstreamPagesPerPage; fetched++ {
+		upstream, err := fetch(position.UpstreamPage)
+		if err != nil {
+			if fetched == 0 {
+				return page, err
+			}
+			// Serve what was read, the next cursor retries the failed page
+			slog.Warn("failed to backfill movie page", "upstream_page", position.UpstreamPage, "error", err)
+			break
+		}
+		upstreamTotal = upstream.TotalResults
+
+		for position.Offset < len(upstream.Results) && len(page.Results) < pageSize {
+			result := upstream.Results[position.Offset]
+			position.Offset++
+			evaluated++
+
+			// Upstream pages may overlap when the ranking shifts
+			id, shown := inspect(result)
+			if seen[id] || !shown {
+				continue
+			}
+			seen[id] = true
+			page.Results = append(page.Results, result)
+		}
+
+		if position.Offset < len(upstream.Results) {
+			break
+		}
+
+		if position.UpstreamPage >= min(upstream.TotalPages, maxUpstreamPage) {
+			exhausted = true
+			break
+		}
+		position.UpstreamPage++
+		position.Offset = 0
+	}
+
+	shown := cursor.Shown + len(page.Results)
+	if exhausted {
+		page.TotalResults = shown
+		page.TotalPages = page.Page
+		page.TotalsEstimated = query.Cursor == "" && query.Page > 1
+		return page, nil
+	}
+
+	next := movieCursor{
+		UpstreamPage: position.UpstreamPage,
+		Offset:       position.Offset,
+		Page:         page.Page + 1,
+		Shown:        shown,
+	}.encode()
+	page.NextCursor = &next
+
+	// Expect the rest of the upstream results to be shown in the same
+	// proportion as the ones read for this page
+	read := (position.UpstreamPage-1)*upstreamPageSize + position.Offset
+	remaining := max(min(upstreamTotal, maxUpstreamPage*upstreamPageSize)-read, 0)
+	estimate := shown
+	if evaluated > 0 {
+		estimate += int(math.Round(float64(remaining) * float64(len(page.Results)) / float64(evaluated)))
+	}
+
+	page.TotalResults = max(estimate, shown+1)
+	page.TotalPages = max((page.TotalResults+pageSize-1)/pageSize, page.Page+1)
+	page.TotalsEstimated = true
+
+	return page, nil
+}
@@ -1,67 +1,189 @@
 package services
 
 import (
+	"context"
 	"github.com/movie-tracker/MovieTracker/internal/repositories"
 	"github.com/movie-tracker/MovieTracker/internal/services/dto"
 	"github.com/movie-tracker/MovieTracker/internal/services/mappers"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
 )
 
+// IMovieService serves the movie catalog translated to the viewer's locale
+// and filtered by their content policy.
 type IMovieService interface {
 	IService
-	GetByID(id int) (dto.MovieDTO, error)
-	DiscoverMovies(page int) (dto.Pagination[dto.MovieDTO], error)
-	SearchMovies(query string, page int) (dto.Pagination[dto.MovieDTO], error)
+	GetByID(ctx context.Context, id int, viewer dto.ViewerDTO) (dto.MovieDTO, error)
+	DiscoverMovies(ctx context.Context, filters dto.MovieDiscoverFiltersDTO, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (dto.CursorPagination[dto.MovieDTO], error)
+	SearchMovies(ctx context.Context, query string, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (dto.CursorPagination[dto.MovieDTO], error)
+	GetSimilar(ctx context.Context, id int, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (dto.CursorPagination[dto.MovieDTO], error)
+	GetRecommendations(ctx context.Context, id int, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (dto.CursorPagination[dto.MovieDTO], error)
 }
 
 type MovieService struct {
-	movieRepo repositories.IMovieRepository
+	movieRepo     repositories.IMovieRepository
+	watchListRepo repositories.IWatchListRepository
+	genreRepo     repositories.IGenreRepository
+	defaultLocale dto.LocaleDTO
+	contentPolicy IContentPolicyService
 }
 
 func newMovieService(params ServicesParams) IMovieService {
 	return &MovieService{
-		movieRepo: params.Repos.MovieRepo,
+		movieRepo:     params.Repos.MovieRepo,
+		watchListRepo: params.Repos.WatchListRepo,
+		genreRepo:     params.Repos.GenreRepo,
+		defaultLocale: dto.LocaleDTO{
+			Language: params.Cfg.DefaultLanguage,
+			Region:   params.Cfg.DefaultRegion,
+		},
 	}
 }
 
-func (s *MovieService) DiscoverMovies(page int) (movies dto.Pagination[dto.MovieDTO], err error) {
-	tmdbMovies, err := s.movieRepo.DiscoverMovies(page)
+// DiscoverMovies returns a page of the movies matching the filters. Hiding
+// the watchlisted movies only applies to logged in viewers.
+func (s *MovieService) DiscoverMovies(ctx context.Context, filters dto.MovieDiscoverFiltersDTO, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (movies dto.CursorPagination[dto.MovieDTO], err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
 	if err != nil {
 		return movies, err
 	}
 
-	movies.TotalPages = tmdbMovies.TotalPages
-	movies.TotalResults = tmdbMovies.TotalResults
-	movies.Page = tmdbMovies.Page
-	movies.Results = mappers.MapFromTMDBToMovieDTOs(tmdbMovies.Results)
+	var watchlisted map[int]bool
+	if filters.HideWatchlisted && viewer.UserID != 0 {
+		if watchlisted, err = s.watchlistedMovies(ctx, viewer.UserID); err != nil {
+			return movies, err
+		}
+	}
 
-	return movies, nil
+	tmdbMovies, err := paginateMoviesExcept(func(upstreamPage int) (dto.Pagination[dto.TMDBMovieDTO], error) {
+		return s.movieRepo.DiscoverMovies(ctx, upstreamPage, filters, viewer.Locale, *viewer.Policy)
+	}, *viewer.Policy, watchlisted, page)
+	if err != nil {
+		return movies, err
+	}
+
+	return s.mapMoviePage(ctx, tmdbMovies, viewer.Locale), nil
 }
 
-func (s *MovieService) GetByID(id int) (movie dto.MovieDTO, err error) {
-	tmdbMovie, err := s.movieRepo.GetByID(id)
+// GetByID returns the movie, or a policy.HiddenError explaining why the
+// viewer's policy hides it.
+func (s *MovieService) GetByID(ctx context.Context, id int, viewer dto.ViewerDTO) (movie dto.MovieDTO, err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
 	if err != nil {
 		return movie, err
 	}
 
-	movie = mappers.MapFromTMDBToMovieDTO(tmdbMovie)
+	tmdbMovie, err := getVisibleMovie(ctx, s.movieRepo, id, *viewer.Policy)
+	if err != nil {
+		return movie, err
+	}
 
-	return movie, nil
+	movies := []dto.MovieDTO{mappers.MapFromTMDBToMovieDTO(tmdbMovie, viewer.Locale)}
+	nameGenres(ctx, s.genreRepo, viewer.Locale, movies)
+
+	return movies[0], nil
 }
 
-func (s *MovieService) SearchMovies(query string, page int) (movies dto.Pagination[dto.MovieDTO], err error) {
-	tmdbMovies, err := s.movieRepo.SearchMovies(query, page)
+func (s *MovieService) SearchMovies(ctx context.Context, query string, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (movies dto.CursorPagination[dto.MovieDTO], err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
 	if err != nil {
 		return movies, err
 	}
 
-	movies.TotalPages = tmdbMovies.TotalPages
-	movies.TotalResults = tmdbMovies.TotalResults
-	movies.Page = tmdbMovies.Page
-	movies.Results = mappers.MapFromTMDBToMovieDTOs(tmdbMovies.Results)
+	tmdbMovies, err := paginateMovies(func(upstreamPage int) (dto.Pagination[dto.TMDBMovieDTO], error) {
+		return s.movieRepo.SearchMovies(ctx, query, upstreamPage, viewer.Locale)
+	}, *viewer.Policy, page)
+	if err != nil {
+		return movies, err
+	}
+
+	return s.mapMoviePage(ctx, tmdbMovies, viewer.Locale), nil
+}
+
+// GetSimilar returns the movies similar to the one asked for, or a
+// policy.HiddenError when the policy hides that one.
+func (s *MovieService) GetSimilar(ctx context.Context, id int, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (dto.CursorPagination[dto.MovieDTO], error) {
+	return s.getRelated(ctx, id, page, viewer, s.movieRepo.GetSimilar)
+}
+
+// GetRecommendations returns the movies recommended to those who liked the
+// one asked for, or a policy.HiddenError when the policy hides that one.
+func (s *MovieService) GetRecommendations(ctx context.Context, id int, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (dto.CursorPagination[dto.MovieDTO], error) {
+	return s.getRelated(ctx, id, page, viewer, s.movieRepo.GetRecommendations)
+}
+
+// getRelated pages the movies fetch relates to the movie, once the movie
+// itself is known to be visible.
+func (s *MovieService) getRelated(ctx context.Context, id int, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO, fetch func(ctx context.Context, id int, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error)) (movies dto.CursorPagination[dto.MovieDTO], err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
+	if err != nil {
+		return movies, err
+	}
+
+	if _, err = getVisibleMovie(ctx, s.movieRepo, id, *viewer.Policy); err != nil {
+		return movies, err
+	}
+
+	tmdbMovies, err := paginateMovies(func(upstreamPage int) (dto.Pagination[dto.TMDBMovieDTO], error) {
+		return fetch(ctx, id, upstreamPage, viewer.Locale)
+	}, *viewer.Policy, page)
+	if err != nil {
+		return movies, err
+	}
 
-	return movies, nil
+	return s.mapMoviePage(ctx, tmdbMovies, viewer.Locale), nil
 }
 
 func (s *MovieService) ProvideServices(svcs Services) {
+	s.contentPolicy = svcs.ContentPolicyService
+}
 
+// resolveViewer fills the locale with the defaults and resolves the policy.
+func (s *MovieService) resolveViewer(ctx context.Context, viewer dto.ViewerDTO) (dto.ViewerDTO, error) {
+	viewer.Locale = viewer.Locale.WithFallback(s.defaultLocale)
+	return s.contentPolicy.Resolve(ctx, viewer)
+}
+
+// watchlistedMovies returns the IDs of the movies on the user's watchlist.
+func (s *MovieService) watchlistedMovies(ctx context.Context, userID int32) (map[int]bool, error) {
+	items, err := s.watchListRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[int]bool, len(items))
+	for _, item := range items {
+		ids[int(item.MovieID)] = true
+	}
+	return ids, nil
+}
+
+// getVisibleMovie fetches the movie, or a policy.HiddenError when the rules
+// hide it.
+func getVisibleMovie(ctx context.Context, repo repositories.IMovieRepository, id int, rules dto.ContentPolicyDTO) (dto.TMDBMovieDTO, error) {
+	movie, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return movie, err
+	}
+
+	if reasons := policy.Evaluate(rules, movie); len(reasons) > 0 {
+		return movie, policy.NewHiddenError(reasons)
+	}
+
+	return movie, nil
+}
+
+// mapMoviePage maps the page of movies with their genres named.
+func (s *MovieService) mapMoviePage(ctx context.Context, tmdbMovies dto.CursorPagination[dto.TMDBMovieDTO], locale dto.LocaleDTO) dto.CursorPagination[dto.MovieDTO] {
+	movies := mappers.MapFromTMDBToMovieDTOs(tmdbMovies.Results, locale)
+	nameGenres(ctx, s.genreRepo, locale, movies)
+
+	return dto.CursorPagination[dto.MovieDTO]{
+		Results:         movies,
+		Page:            tmdbMovies.Page,
+		PageSize:        tmdbMovies.PageSize,
+		NextCursor:      tmdbMovies.NextCursor,
+		TotalPages:      tmdbMovies.TotalPages,
+		TotalResults:    tmdbMovies.TotalResults,
+		TotalsEstimated: tmdbMovies.TotalsEstimated,
+	}
 }
@@ -1,9 +1,14 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
 	"github.com/movie-tracker/MovieTracker/internal/services/dto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,21 +19,96 @@ type MockMovieRepository struct {
 	mock.Mock
 }
 
-func (m *MockMovieRepository) DiscoverMovies() (dto.Pagination[dto.TMDBMovieDTO], error) {
-	args := m.Called()
+func (m *MockMovieRepository) DiscoverMovies(_ context.Context, page int, filters dto.MovieDiscoverFiltersDTO, locale dto.LocaleDTO, rules dto.ContentPolicyDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
+	args := m.Called(page, filters, locale, rules)
 	return args.Get(0).(dto.Pagination[dto.TMDBMovieDTO]), args.Error(1)
 }
 
-func (m *MockMovieRepository) GetByID(id int) (dto.TMDBMovieDTO, error) {
+func (m *MockMovieRepository) SearchMovies(_ context.Context, query string, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
+	args := m.Called(query, page, locale)
+	return args.Get(0).(dto.Pagination[dto.TMDBMovieDTO]), args.Error(1)
+}
+
+func (m *MockMovieRepository) GetByID(_ context.Context, id int) (dto.TMDBMovieDTO, error) {
 	args := m.Called(id)
 	return args.Get(0).(dto.TMDBMovieDTO), args.Error(1)
 }
 
+func (m *MockMovieRepository) GetSimilar(_ context.Context, id int, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
+	args := m.Called(id, page, locale)
+	return args.Get(0).(dto.Pagination[dto.TMDBMovieDTO]), args.Error(1)
+}
+
+func (m *MockMovieRepository) GetRecommendations(_ context.Context, id int, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBMovieDTO], error) {
+	args := m.Called(id, page, locale)
+	return args.Get(0).(dto.Pagination[dto.TMDBMovieDTO]), args.Error(1)
+}
+
+type MockWatchListRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatchListRepository) GetByUser(_ context.Context, userID int32) ([]model.Watchlist, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]model.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchListRepository) FindByUser(_ context.Context, userID int32, query dto.WatchListQueryDTO) ([]model.Watchlist, int64, error) {
+	args := m.Called(userID, query)
+	return args.Get(0).([]model.Watchlist), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockWatchListRepository) FindBatch(_ context.Context, userID int32, after *model.Watchlist, limit int) ([]model.Watchlist, error) {
+	args := m.Called(userID, after, limit)
+	return args.Get(0).([]model.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchListRepository) AddToWatchlist(_ context.Context, userID int32, createDTO dto.WatchListCreateDTO) (model.Watchlist, error) {
+	args := m.Called(userID, createDTO)
+	return args.Get(0).(model.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchListRepository) UpdateWatchlistItem(_ context.Context, userID int32, movieID int, status string, favorite *bool, comments string, rating *int) (model.Watchlist, error) {
+	args := m.Called(userID, movieID, status, favorite, comments, rating)
+	return args.Get(0).(model.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchListRepository) RemoveFromWatchlist(_ context.Context, userID int32, movieID int) error {
+	return m.Called(userID, movieID).Error(0)
+}
+
+func (m *MockWatchListRepository) UpdateStatus(_ context.Context, userID int32, movieID int, status string) (model.Watchlist, error) {
+	args := m.Called(userID, movieID, status)
+	return args.Get(0).(model.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchListRepository) ToggleFavorite(_ context.Context, userID int32, movieID int, favorite bool) (model.Watchlist, error) {
+	args := m.Called(userID, movieID, favorite)
+	return args.Get(0).(model.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchListRepository) UpdateRating(_ context.Context, userID int32, movieID int, rating *int) (model.Watchlist, error) {
+	args := m.Called(userID, movieID, rating)
+	return args.Get(0).(model.Watchlist), args.Error(1)
+}
+
+func (m *MockWatchListRepository) GetStatusHistory(_ context.Context, userID int32, movieID int) ([]model.WatchlistStatusHistory, error) {
+	args := m.Called(userID, movieID)
+	return args.Get(0).([]model.WatchlistStatusHistory), args.Error(1)
+}
+
+func (m *MockWatchListRepository) FindRatedSince(_ context.Context, since time.Time) ([]model.Watchlist, error) {
+	args := m.Called(since)
+	return args.Get(0).([]model.Watchlist), args.Error(1)
+}
+
 func TestMovieService_DiscoverMovies_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockMovieRepository)
 	service := &MovieService{
-		movieRepo: mockRepo,
+		movieRepo:     mockRepo,
+		genreRepo:     newMockGenreRepository(),
+		contentPolicy: &ContentPolicyService{},
 	}
 
 	// Configurar o comportamento do mock
@@ -38,30 +118,33 @@ func TestMovieService_DiscoverMovies_Success(t *testing.T) {
 		TotalResults: 200,
 		Results: []dto.TMDBMovieDTO{
 			{
-				ID:            123,
-				OriginalTitle: "Test Movie 1",
-				PosterPath:    "/poster1.jpg",
-				ReleaseDate:   "2023-01-15",
+				ID:          123,
+				Title:       "Test Movie 1",
+				PosterPath:  "/poster1.jpg",
+				ReleaseDate: "2023-01-15",
 			},
 			{
-				ID:            456,
-				OriginalTitle: "Test Movie 2",
-				PosterPath:    "/poster2.jpg",
-				ReleaseDate:   "2022-10-20",
+				ID:          456,
+				Title:       "Test Movie 2",
+				PosterPath:  "/poster2.jpg",
+				ReleaseDate: "2022-10-20",
 			},
 		},
 	}
 
-	mockRepo.On("DiscoverMovies").Return(tmdbPagination, nil)
+	mockRepo.On("DiscoverMovies", 1, dto.MovieDiscoverFiltersDTO{}, dto.LocaleDTO{}, dto.ContentPolicyDTO{}).Return(tmdbPagination, nil)
 
 	// Act
-	result, err := service.DiscoverMovies()
+	result, err := service.DiscoverMovies(context.Background(), dto.MovieDiscoverFiltersDTO{}, dto.MoviePageQueryDTO{PageSize: 2}, dto.ViewerDTO{})
 
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, 1, result.Page)
-	assert.Equal(t, 10, result.TotalPages)
-	assert.Equal(t, 200, result.TotalResults)
+	// The short upstream page counts as read in full when estimating
+	assert.Equal(t, 91, result.TotalPages)
+	assert.Equal(t, 182, result.TotalResults)
+	assert.True(t, result.TotalsEstimated)
+	assert.NotNil(t, result.NextCursor)
 	assert.Len(t, result.Results, 2)
 
 	// Verificar se o mapeamento foi feito corretamente
@@ -76,14 +159,16 @@ func TestMovieService_DiscoverMovies_Error(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockMovieRepository)
 	service := &MovieService{
-		movieRepo: mockRepo,
+		movieRepo:     mockRepo,
+		genreRepo:     newMockGenreRepository(),
+		contentPolicy: &ContentPolicyService{},
 	}
 
 	expectedError := errors.New("API error")
-	mockRepo.On("DiscoverMovies").Return(dto.Pagination[dto.TMDBMovieDTO]{}, expectedError)
+	mockRepo.On("DiscoverMovies", 1, dto.MovieDiscoverFiltersDTO{}, dto.LocaleDTO{}, dto.ContentPolicyDTO{}).Return(dto.Pagination[dto.TMDBMovieDTO]{}, expectedError)
 
 	// Act
-	result, err := service.DiscoverMovies()
+	result, err := service.DiscoverMovies(context.Background(), dto.MovieDiscoverFiltersDTO{}, dto.MoviePageQueryDTO{}, dto.ViewerDTO{})
 
 	// Assert
 	assert.Error(t, err)
@@ -93,24 +178,63 @@ func TestMovieService_DiscoverMovies_Error(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestMovieService_DiscoverMovies_HidesWatchlisted(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockMovieRepository)
+	watchListRepo := new(MockWatchListRepository)
+	service := &MovieService{
+		movieRepo:     mockRepo,
+		genreRepo:     newMockGenreRepository(),
+		watchListRepo: watchListRepo,
+		contentPolicy: &ContentPolicyService{},
+	}
+
+	filters := dto.MovieDiscoverFiltersDTO{WithGenres: []int{878}, HideWatchlisted: true}
+	mockRepo.On("DiscoverMovies", 1, filters, dto.LocaleDTO{}, dto.ContentPolicyDTO{}).Return(dto.Pagination[dto.TMDBMovieDTO]{
+		Page:         1,
+		TotalPages:   1,
+		TotalResults: 3,
+		Results:      []dto.TMDBMovieDTO{{ID: 1}, {ID: 2}, {ID: 3}},
+	}, nil)
+	watchListRepo.On("GetByUser", int32(7)).Return([]model.Watchlist{{MovieID: 2, UserID: 7}}, nil)
+
+	// Act
+	anonymous, err := service.DiscoverMovies(context.Background(), filters, dto.MoviePageQueryDTO{}, dto.ViewerDTO{})
+	assert.NoError(t, err)
+	user, err := service.DiscoverMovies(context.Background(), filters, dto.MoviePageQueryDTO{}, dto.ViewerDTO{UserID: 7, Policy: &dto.ContentPolicyDTO{}})
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Len(t, anonymous.Results, 3)
+	if assert.Len(t, user.Results, 2) {
+		assert.Equal(t, 1, user.Results[0].ID)
+		assert.Equal(t, 3, user.Results[1].ID)
+	}
+
+	mockRepo.AssertExpectations(t)
+	watchListRepo.AssertNumberOfCalls(t, "GetByUser", 1)
+}
+
 func TestMovieService_GetByID_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockMovieRepository)
 	service := &MovieService{
-		movieRepo: mockRepo,
+		movieRepo:     mockRepo,
+		genreRepo:     newMockGenreRepository(),
+		contentPolicy: &ContentPolicyService{},
 	}
 
 	tmdbMovie := dto.TMDBMovieDTO{
-		ID:            123,
-		OriginalTitle: "Test Movie",
-		PosterPath:    "/poster.jpg",
-		ReleaseDate:   "2023-05-15",
+		ID:          123,
+		Title:       "Test Movie",
+		PosterPath:  "/poster.jpg",
+		ReleaseDate: "2023-05-15",
 	}
 
 	mockRepo.On("GetByID", 123).Return(tmdbMovie, nil)
 
 	// Act
-	movie, err := service.GetByID(123)
+	movie, err := service.GetByID(context.Background(), 123, dto.ViewerDTO{})
 
 	// Assert
 	assert.NoError(t, err)
@@ -126,14 +250,16 @@ func TestMovieService_GetByID_Error(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockMovieRepository)
 	service := &MovieService{
-		movieRepo: mockRepo,
+		movieRepo:     mockRepo,
+		genreRepo:     newMockGenreRepository(),
+		contentPolicy: &ContentPolicyService{},
 	}
 
 	expectedError := errors.New("movie not found")
 	mockRepo.On("GetByID", 999).Return(dto.TMDBMovieDTO{}, expectedError)
 
 	// Act
-	movie, err := service.GetByID(999)
+	movie, err := service.GetByID(context.Background(), 999, dto.ViewerDTO{})
 
 	// Assert
 	assert.Error(t, err)
@@ -143,3 +269,121 @@ func TestMovieService_GetByID_Error(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestMovieService_SearchMovies_FillsDefaultLocale(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockMovieRepository)
+	service := &MovieService{
+		movieRepo:     mockRepo,
+		genreRepo:     newMockGenreRepository(),
+		defaultLocale: dto.LocaleDTO{Language: "pt-BR", Region: "BR"},
+		contentPolicy: &ContentPolicyService{},
+	}
+
+	mockRepo.On("SearchMovies", "alien", 1, dto.LocaleDTO{Language: "en-US", Region: "BR"}).
+		Return(dto.Pagination[dto.TMDBMovieDTO]{Page: 1}, nil)
+
+	// Act
+	_, err := service.SearchMovies(context.Background(), "alien", dto.MoviePageQueryDTO{}, dto.ViewerDTO{Locale: dto.LocaleDTO{Language: "en-US"}})
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+// upstreamMovies builds TMDB pages of 20 movies with consecutive IDs, the ones
+// in hidden belonging to an excluded genre.
+func upstreamMovies(totalPages int, hidden ...int) func(page int) (dto.Pagination[dto.TMDBMovieDTO], error) {
+	return func(page int) (dto.Pagination[dto.TMDBMovieDTO], error) {
+		results := make([]dto.TMDBMovieDTO, 20)
+		for i := range results {
+			results[i].ID = (page-1)*20 + i + 1
+			if slices.Contains(hidden, results[i].ID) {
+				results[i].GenreIDs = []int{27}
+			}
+		}
+		return dto.Pagination[dto.TMDBMovieDTO]{Page: page, TotalPages: totalPages, TotalResults: totalPages * 20, Results: results}, nil
+	}
+}
+
+func movieIDs(movies []dto.TMDBMovieDTO) []int {
+	ids := make([]int, len(movies))
+	for i, movie := range movies {
+		ids[i] = movie.ID
+	}
+	return ids
+}
+
+func TestPaginateMovies_BackfillsAndContinues(t *testing.T) {
+	// Arrange
+	rules := dto.ContentPolicyDTO{ExcludedGenres: []int{27}}
+	// Half of the first page is hidden
+	fetch := upstreamMovies(3, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20)
+
+	// Act
+	first, err := paginateMovies(fetch, rules, dto.MoviePageQueryDTO{PageSize: 15})
+	assert.NoError(t, err)
+	second, err := paginateMovies(fetch, rules, dto.MoviePageQueryDTO{PageSize: 15, Cursor: *first.NextCursor})
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []int{1, 3, 5, 7, 9, 11, 13, 15, 17, 19, 21, 22, 23, 24, 25}, movieIDs(first.Results))
+	assert.Equal(t, 1, first.Page)
+	assert.True(t, first.TotalsEstimated)
+
+	assert.Equal(t, []int{26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40}, movieIDs(second.Results))
+	assert.Equal(t, 2, second.Page)
+}
+
+func TestPaginateMovies_LastPageHasExactTotals(t *testing.T) {
+	// Arrange
+	rules := dto.ContentPolicyDTO{ExcludedGenres: []int{27}}
+	fetch := upstreamMovies(2, 1, 40)
+
+	// Act
+	first, _ := paginateMovies(fetch, rules, dto.MoviePageQueryDTO{PageSize: 30})
+	last, err := paginateMovies(fetch, rules, dto.MoviePageQueryDTO{PageSize: 30, Cursor: *first.NextCursor})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, last.Results, 8)
+	assert.Nil(t, last.NextCursor)
+	assert.False(t, last.TotalsEstimated)
+	assert.Equal(t, 38, last.TotalResults)
+	assert.Equal(t, 2, last.TotalPages)
+}
+
+func TestPaginateMovies_PageNumber(t *testing.T) {
+	// Arrange
+	var fetched []int
+	fetch := func(page int) (dto.Pagination[dto.TMDBMovieDTO], error) {
+		fetched = append(fetched, page)
+		return upstreamMovies(3)(page)
+	}
+
+	// Act
+	second, errSecond := paginateMovies(fetch, dto.ContentPolicyDTO{}, dto.MoviePageQueryDTO{Page: 2, PageSize: 7})
+	fourth, errFourth := paginateMovies(fetch, dto.ContentPolicyDTO{}, dto.MoviePageQueryDTO{Page: 4, PageSize: 7})
+
+	// Assert
+	assert.NoError(t, errors.Join(errSecond, errFourth))
+	assert.Equal(t, []int{8, 9, 10, 11, 12, 13, 14}, movieIDs(second.Results))
+	assert.Equal(t, 2, second.Page)
+	// The fourth page starts at the 22nd movie, on the second upstream page
+	assert.Equal(t, []int{22, 23, 24, 25, 26, 27, 28}, movieIDs(fourth.Results))
+	assert.Equal(t, 4, fourth.Page)
+	assert.Equal(t, []int{1, 2}, fetched)
+
+	// Past the last upstream page TMDB serves
+	last, err := paginateMovies(fetch, dto.ContentPolicyDTO{}, dto.MoviePageQueryDTO{Page: 500, PageSize: 60})
+	assert.NoError(t, err)
+	assert.Empty(t, last.Results)
+	assert.Nil(t, last.NextCursor)
+	assert.Equal(t, []int{1, 2}, fetched)
+}
+
+func TestPaginateMovies_InvalidCursor(t *testing.T) {
+	_, err := paginateMovies(upstreamMovies(1), dto.ContentPolicyDTO{}, dto.MoviePageQueryDTO{Cursor: "not-a-cursor"})
+
+	assertApiError(t, err, http.StatusBadRequest, "error.movie.invalid_cursor")
+}
@@ -0,0 +1,606 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/mappers"
+	"github.com/movie-tracker/MovieTracker/internal/services/notify"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+const (
+	defaultNotificationPageSize = 20
+
+	// Days a release is still notified after it happened, so releases aren't
+	// missed while the scans are down.
+	releaseNoticeDays = 7
+
+	// Users whose watchlists are loaded at once while scanning.
+	notificationScanBatchSize = 100
+
+	// Key of the advisory lock held while scanning, so that when several
+	// instances of the API run only one of them scans at a time.
+	notificationScanLockKey = 0x6e6f74696679
+)
+
+// INotificationService fills the inbox of every user, periodically scanning
+// for the movies of their watchlist that came out or started streaming on
+// their services, and for the ratings of the users they follow. Besides the
+// inbox, notifications go through the channels each user set up.
+type INotificationService interface {
+	IService
+	Find(ctx context.Context, userID int32, query dto.NotificationQueryDTO) (dto.Pagination[dto.NotificationDTO], error)
+	CountUnread(ctx context.Context, userID int32) (dto.UnreadCountDTO, error)
+	MarkRead(ctx context.Context, userID int32, id int32) (dto.NotificationDTO, error)
+	MarkAllRead(ctx context.Context, userID int32) error
+	GetSettings(ctx context.Context, userID int32) (dto.NotificationSettingsDTO, error)
+	SaveSettings(ctx context.Context, userID int32, settings dto.NotificationSettingsDTO) (dto.NotificationSettingsDTO, error)
+	Run(ctx context.Context)
+	Scan(ctx context.Context, since time.Time, now time.Time) time.Time
+}
+
+type NotificationService struct {
+	notificationRepo repositories.INotificationRepository
+	settingsRepo     repositories.INotificationSettingsRepository
+	followRepo       repositories.IUserFollowRepository
+	watchListRepo    repositories.IWatchListRepository
+	availabilityRepo repositories.IWatchlistAvailabilityRepository
+	movieRepo        repositories.IMovieRepository
+	userRepo         repositories.IUserRepository
+	locker           repositories.ILocker
+	channels         []notify.Channel
+	interval         time.Duration
+	defaultLocale    dto.LocaleDTO
+	appURL           string
+	contentPolicy    IContentPolicyService
+	streamingService IStreamingService
+}
+
+func newNotificationService(params ServicesParams) INotificationService {
+	channels, err := notify.NewChannels(params.Cfg.Notify, params.Conns.Mailer)
+	utils.PanicOnError(err)
+
+	return &NotificationService{
+		notificationRepo: params.Repos.NotificationRepo,
+		settingsRepo:     params.Repos.NotifySettingsRepo,
+		followRepo:       params.Repos.UserFollowRepo,
+		watchListRepo:    params.Repos.WatchListRepo,
+		availabilityRepo: params.Repos.AvailabilityRepo,
+		movieRepo:        params.Repos.MovieRepo,
+		userRepo:         params.Repos.UserRepo,
+		locker:           params.Repos.Locker,
+		channels:         channels,
+		interval:         time.Duration(params.Cfg.Notify.Interval) * time.Minute,
+		defaultLocale: dto.LocaleDTO{
+			Language: params.Cfg.DefaultLanguage,
+			Region:   params.Cfg.DefaultRegion,
+		},
+		appURL: params.Cfg.AppURL,
+	}
+}
+
+func (s *NotificationService) ProvideServices(svcs Services) {
+	s.contentPolicy = svcs.ContentPolicyService
+	s.streamingService = svcs.StreamingService
+}
+
+// Find returns a page of the inbox of the user, the newest first.
+func (s *NotificationService) Find(ctx context.Context, userID int32, query dto.NotificationQueryDTO) (dto.Pagination[dto.NotificationDTO], error) {
+	var page dto.Pagination[dto.NotificationDTO]
+
+	query.Page = utils.FallbackZero(query.Page, 1)
+	query.PageSize = utils.FallbackZero(query.PageSize, defaultNotificationPageSize)
+
+	notifications, total, err := s.notificationRepo.FindByUser(ctx, userID, query)
+	if err != nil {
+		return page, err
+	}
+
+	page.Results = make([]dto.NotificationDTO, len(notifications))
+	for i, notification := range notifications {
+		page.Results[i] = s.toDTO(notification)
+	}
+	page.Page = query.Page
+	page.TotalResults = int(total)
+	page.TotalPages = (page.TotalResults + query.PageSize - 1) / query.PageSize
+
+	return page, nil
+}
+
+func (s *NotificationService) CountUnread(ctx context.Context, userID int32) (dto.UnreadCountDTO, error) {
+	count, err := s.notificationRepo.CountUnread(ctx, userID)
+	return dto.UnreadCountDTO{Count: count}, err
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, userID int32, id int32) (dto.NotificationDTO, error) {
+	notification, err := s.notificationRepo.MarkRead(ctx, userID, id)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return dto.NotificationDTO{}, utils.NewNotFoundError("error.notification.not_found")
+		default:
+			return dto.NotificationDTO{}, err
+		}
+	}
+
+	return s.toDTO(notification), nil
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID int32) error {
+	return s.notificationRepo.MarkAllRead(ctx, userID)
+}
+
+// GetSettings returns where the notifications of the user are delivered
+// besides the inbox, nowhere unless they set it up.
+func (s *NotificationService) GetSettings(ctx context.Context, userID int32) (dto.NotificationSettingsDTO, error) {
+	var settingsDTO dto.NotificationSettingsDTO
+
+	settings, err := s.settingsRepo.FindByUser(ctx, userID)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return settingsDTO, nil
+		default:
+			return settingsDTO, err
+		}
+	}
+
+	settingsDTO.FromModel(settings)
+	return settingsDTO, nil
+}
+
+// SaveSettings replaces the delivery settings of the user. Notifications are
+// only emailed to verified addresses, and only posted to public ones.
+func (s *NotificationService) SaveSettings(ctx context.Context, userID int32, settings dto.NotificationSettingsDTO) (dto.NotificationSettingsDTO, error) {
+	if settings.WebhookURL != "" && notify.ValidateWebhookURL(settings.WebhookURL) != nil {
+		return dto.NotificationSettingsDTO{}, utils.NewBadRequestError("error.notification.invalid_webhook_url")
+	}
+
+	if settings.Email {
+		user, err := s.userRepo.FindOne(ctx, userID)
+		if err != nil {
+			return dto.NotificationSettingsDTO{}, err
+		}
+		if user.EmailVerifiedAt == nil {
+			return dto.NotificationSettingsDTO{}, utils.NewBadRequestError("error.notification.email_not_verified")
+		}
+	}
+
+	var webhookURL *string
+	if settings.WebhookURL != "" {
+		webhookURL = &settings.WebhookURL
+	}
+
+	saved, err := s.settingsRepo.Save(ctx, model.NotificationSettings{
+		UserID:     userID,
+		Email:      settings.Email,
+		WebhookURL: webhookURL,
+	})
+	if err != nil {
+		return dto.NotificationSettingsDTO{}, err
+	}
+
+	var savedDTO dto.NotificationSettingsDTO
+	savedDTO.FromModel(saved)
+	return savedDTO, nil
+}
+
+// Run scans for new notifications right away and then every interval, until
+// the context is done. It returns immediately when the scans are disabled.
+func (s *NotificationService) Run(ctx context.Context) {
+	if s.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	since := time.Now().Add(-s.interval)
+	for {
+		since = s.Scan(ctx, since, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan notifies the releases and new streaming availability of the movies on
+// every watchlist, and the ratings given since the time passed. It returns
+// the time to pass to the next scan. Notifications are keyed by their event,
+// so scanning again never notifies the same event twice. While another
+// instance is scanning, it does nothing.
+func (s *NotificationService) Scan(ctx context.Context, since time.Time, now time.Time) time.Time {
+	next := since
+	locked, err := s.locker.TryLock(ctx, notificationScanLockKey, func(ctx context.Context) {
+		s.scanWatchlists(ctx, now)
+		next = s.scanRatings(ctx, since)
+	})
+	if err != nil {
+		slog.Error("scanning for notifications", "error", err)
+		return since
+	}
+	if !locked {
+		slog.Info("skipping the notification scan, another instance is running it")
+	}
+
+	return next
+}
+
+// scanWatchlists goes through the watchlists of every user, a batch of users
+// at a time, until the context is done.
+func (s *NotificationService) scanWatchlists(ctx context.Context, now time.Time) {
+	var afterID int32
+	for ctx.Err() == nil {
+		users, err := s.userRepo.FindActiveAfter(ctx, afterID, notificationScanBatchSize)
+		if err != nil {
+			slog.Error("finding the users to scan for notifications", "after_id", afterID, "error", err)
+			return
+		}
+
+		for _, user := range users {
+			if err := s.scanWatchlist(ctx, user, now); err != nil {
+				slog.Warn("scanning the watchlist for notifications", "user_id", user.ID, "error", err)
+			}
+		}
+
+		if len(users) < notificationScanBatchSize {
+			return
+		}
+		afterID = users[len(users)-1].ID
+	}
+}
+
+// scanWatchlist notifies the user of the movies still to watch released in
+// their region within the last days, and of those streaming on their
+// services.
+func (s *NotificationService) scanWatchlist(ctx context.Context, user model.Users, now time.Time) error {
+	viewer, err := s.viewerOf(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	items, movies, err := loadTrackedMovies(ctx, s.watchListRepo, s.movieRepo, user.ID, *viewer.Policy)
+	if err != nil {
+		return err
+	}
+
+	subscription, err := s.streamingService.GetSubscription(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[int32]model.WatchlistAvailability)
+	if !subscription.IsEmpty() {
+		lastSeen, err := s.availabilityRepo.FindByUser(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		for _, availability := range lastSeen {
+			seen[availability.MovieID] = availability
+		}
+	}
+
+	available, availability := availabilityNotifications(items, movies, subscription, seen, viewer.Locale)
+	notifications := append(releaseNotifications(items, movies, viewer.Locale, now), available...)
+	for _, notification := range notifications {
+		notification.UserID = user.ID
+		s.deliver(ctx, user, notification)
+	}
+
+	for i := range availability {
+		availability[i].UserID = user.ID
+	}
+	return s.availabilityRepo.Save(ctx, availability)
+}
+
+// scanRatings notifies the followers of the users who rated a movie since the
+// time passed, and returns the time of the last rating notified. Each rater,
+// follower and movie is loaded once per scan.
+func (s *NotificationService) scanRatings(ctx context.Context, since time.Time) time.Time {
+	rated, err := s.watchListRepo.FindRatedSince(ctx, since)
+	if err != nil {
+		slog.Warn("scanning the ratings for notifications", "error", err)
+		return since
+	}
+
+	users := make(map[int32]*model.Users)
+	movies := make(map[int32]*dto.TMDBMovieDTO)
+	for _, item := range rated {
+		if ctx.Err() != nil {
+			break
+		}
+		since = item.UpdatedAt
+
+		rater := s.scannedUser(ctx, users, item.UserID)
+		if rater == nil {
+			continue
+		}
+
+		followers, err := s.followRepo.FindFollowers(ctx, item.UserID)
+		if err != nil {
+			slog.Warn("finding the followers to notify", "user_id", item.UserID, "error", err)
+			continue
+		}
+
+		for _, follow := range followers {
+			follower := s.scannedUser(ctx, users, follow.FollowerID)
+			if follower == nil {
+				continue
+			}
+
+			movie := s.scannedMovie(ctx, movies, item.MovieID)
+			if movie == nil {
+				break
+			}
+
+			viewer, err := s.viewerOf(ctx, *follower)
+			if err != nil {
+				slog.Warn("resolving the follower to notify", "user_id", follower.ID, "error", err)
+				continue
+			}
+			if reasons := policy.Evaluate(*viewer.Policy, *movie); len(reasons) > 0 {
+				continue
+			}
+
+			notification := ratingNotification(*rater, item, *movie, viewer.Locale)
+			notification.UserID = follower.ID
+			s.deliver(ctx, *follower, notification)
+		}
+	}
+
+	return since
+}
+
+// scannedUser returns the user, loading them unless the scan already did, or
+// nil when they are deleted or failed to load.
+func (s *NotificationService) scannedUser(ctx context.Context, users map[int32]*model.Users, id int32) *model.Users {
+	if user, ok := users[id]; ok {
+		return user
+	}
+
+	user, err := s.userRepo.FindOne(ctx, id)
+	if err != nil {
+		if err != qrm.ErrNoRows {
+			slog.Warn("finding the user to notify", "user_id", id, "error", err)
+		}
+		users[id] = nil
+		return nil
+	}
+	if user.DeletedAt != nil {
+		users[id] = nil
+		return nil
+	}
+
+	users[id] = &user
+	return &user
+}
+
+// scannedMovie returns the movie, loading it unless the scan already did, or
+// nil when it failed to load.
+func (s *NotificationService) scannedMovie(ctx context.Context, movies map[int32]*dto.TMDBMovieDTO, id int32) *dto.TMDBMovieDTO {
+	if movie, ok := movies[id]; ok {
+		return movie
+	}
+
+	movie, err := s.movieRepo.GetByID(ctx, int(id))
+	if err != nil {
+		slog.Warn("leaving rating out of the notifications", "movie_id", id, "error", err)
+		movies[id] = nil
+		return nil
+	}
+
+	movies[id] = &movie
+	return &movie
+}
+
+// deliver adds the notification to the inbox of the user and sends it
+// through the channels they set up, unless the inbox already has it.
+// Failures are logged, so one channel failing doesn't stop the others.
+func (s *NotificationService) deliver(ctx context.Context, user model.Users, notification model.Notifications) {
+	created, err := s.notificationRepo.Create(ctx, notification)
+	if err != nil {
+		if err != qrm.ErrNoRows {
+			slog.Warn("creating notification", "user_id", user.ID, "key", notification.Key, "error", err)
+		}
+		return
+	}
+
+	if len(s.channels) == 0 {
+		return
+	}
+
+	recipient, err := s.recipientOf(ctx, user)
+	if err != nil {
+		slog.Warn("finding where to deliver notification", "user_id", user.ID, "error", err)
+		return
+	}
+
+	notificationDTO := s.toDTO(created)
+	for _, channel := range s.channels {
+		if err := channel.Send(ctx, recipient, notificationDTO); err != nil {
+			slog.Warn("delivering notification", "channel", channel.Name(), "notification_id", created.ID, "error", err)
+		}
+	}
+}
+
+// recipientOf returns the addresses the user set up for their notifications.
+func (s *NotificationService) recipientOf(ctx context.Context, user model.Users) (notify.Recipient, error) {
+	var recipient notify.Recipient
+
+	settings, err := s.settingsRepo.FindByUser(ctx, user.ID)
+	if err != nil {
+		if err == qrm.ErrNoRows {
+			return recipient, nil
+		}
+		return recipient, err
+	}
+
+	if settings.Email && user.EmailVerifiedAt != nil {
+		recipient.Email = user.Email
+	}
+	recipient.WebhookURL = utils.Fallback(settings.WebhookURL, "")
+
+	return recipient, nil
+}
+
+// viewerOf resolves the locale and policy of the user, as the scans have no
+// request to take them from.
+func (s *NotificationService) viewerOf(ctx context.Context, user model.Users) (dto.ViewerDTO, error) {
+	var userDTO dto.UserDTO
+	userDTO.FromModel(user)
+
+	viewer := dto.ViewerDTO{UserID: user.ID, Locale: userDTO.Locale().WithFallback(s.defaultLocale)}
+	return s.contentPolicy.Resolve(ctx, viewer)
+}
+
+func (s *NotificationService) toDTO(notification model.Notifications) dto.NotificationDTO {
+	var notificationDTO dto.NotificationDTO
+	notificationDTO.FromModel(notification)
+	notificationDTO.Link = fmt.Sprintf("%s/movie/%d", s.appURL, notification.MovieID)
+	return notificationDTO
+}
+
+// notificationText holds the formats of the title and body of a kind of
+// notification. Both get the movie title as their first argument.
+type notificationText struct {
+	title string
+	body  string
+}
+
+// Texts of the notifications by language, and kind of release or
+// notification.
+var notificationTexts = map[string]map[string]notificationText{
+	"en": {
+		dto.ReleaseTheatrical:     {"%[1]s is out in theaters", "%[1]s, from your watchlist, is now in theaters."},
+		dto.ReleaseDigital:        {"%[1]s is out on digital", "%[1]s, from your watchlist, can now be rented or bought online."},
+		dto.NotificationAvailable: {"%[1]s is streaming on %[2]s", "%[1]s, from your watchlist, is now on %[2]s."},
+		dto.NotificationRated:     {"%[2]s rated %[1]s", "%[2]s gave %[1]s a %[3]d out of 10."},
+	},
+	"pt": {
+		dto.ReleaseTheatrical:     {"%[1]s estreou nos cinemas", "%[1]s, da sua lista, já está nos cinemas."},
+		dto.ReleaseDigital:        {"%[1]s chegou ao digital", "%[1]s, da sua lista, já pode ser alugado ou comprado online."},
+		dto.NotificationAvailable: {"%[1]s está no %[2]s", "%[1]s, da sua lista, entrou no catálogo do %[2]s."},
+		dto.NotificationRated:     {"%[2]s avaliou %[1]s", "%[2]s deu nota %[3]d de 10 para %[1]s."},
+	},
+}
+
+// newNotification builds a notification of the movie in the language of the
+// locale, English when there are no texts in it.
+func newNotification(notificationType string, key string, movieID int32, text string, locale dto.LocaleDTO, args ...any) model.Notifications {
+	texts, ok := notificationTexts[locale.LanguageCode()]
+	if !ok {
+		texts = notificationTexts["en"]
+	}
+
+	return model.Notifications{
+		Type:    notificationType,
+		Key:     key,
+		MovieID: movieID,
+		Title:   fmt.Sprintf(texts[text].title, args...),
+		Body:    fmt.Sprintf(texts[text].body, args...),
+	}
+}
+
+// releaseNotifications notifies the releases of the movies in the region of
+// the locale within the last days.
+func releaseNotifications(items []model.Watchlist, movies []dto.TMDBMovieDTO, locale dto.LocaleDTO, now time.Time) []model.Notifications {
+	from := now.AddDate(0, 0, -releaseNoticeDays).Format(time.DateOnly)
+	until := now.Format(time.DateOnly)
+
+	var notifications []model.Notifications
+	for _, upcoming := range buildUpcoming(items, movies, locale.Region, from, until, locale) {
+		for _, release := range upcoming.Releases {
+			key := fmt.Sprintf("%s:%d:%s:%s", dto.NotificationReleased, upcoming.MovieID, release.Type, upcoming.Region)
+			notifications = append(notifications, newNotification(dto.NotificationReleased, key, upcoming.MovieID, release.Type, locale, upcoming.Movie.Title))
+		}
+	}
+	return notifications
+}
+
+// availabilityNotifications notifies the movies added to the services of the
+// subscription since the last scan, once per service, comparing where they
+// stream now with where they streamed then as seen by movie. Movies never seen
+// before, or last seen in another country, aren't notified, so the services
+// they already were on don't look new. It also returns where the movies stream
+// now, for those that changed, to be seen by the next scan.
+func availabilityNotifications(items []model.Watchlist, movies []dto.TMDBMovieDTO, subscription dto.StreamingSubscriptionDTO, seen map[int32]model.WatchlistAvailability, locale dto.LocaleDTO) ([]model.Notifications, []model.WatchlistAvailability) {
+	if subscription.IsEmpty() {
+		return nil, nil
+	}
+
+	var notifications []model.Notifications
+	var changed []model.WatchlistAvailability
+	for i, item := range items {
+		if movies[i].ID == 0 || movies[i].WatchProviders == nil {
+			continue
+		}
+
+		availability := utils.Fallback(movies[i].WatchAvailability(subscription.Country), dto.WatchAvailabilityDTO{})
+		providerIDs := availability.StreamingProviderIDs()
+
+		lastProviderIDs, wasSeen := seenProviderIDs(seen[item.MovieID], subscription.Country)
+		if wasSeen && slices.Equal(providerIDs, lastProviderIDs) {
+			continue
+		}
+
+		encoded, _ := json.Marshal(providerIDs)
+		changed = append(changed, model.WatchlistAvailability{
+			UserID:      item.UserID,
+			MovieID:     item.MovieID,
+			Country:     subscription.Country,
+			ProviderIds: string(encoded),
+		})
+		if !wasSeen {
+			continue
+		}
+
+		title := mappers.MapFromTMDBToMovieDTO(movies[i], locale).Title
+		for _, provider := range availability.StreamingOn(subscription.ProviderIDs) {
+			if slices.Contains(lastProviderIDs, provider.ProviderID) {
+				continue
+			}
+
+			key := fmt.Sprintf("%s:%d:%d", dto.NotificationAvailable, item.MovieID, provider.ProviderID)
+			notifications = append(notifications, newNotification(dto.NotificationAvailable, key, item.MovieID, dto.NotificationAvailable, locale, title, provider.ProviderName))
+		}
+	}
+	return notifications, changed
+}
+
+// seenProviderIDs returns the providers the movie was last seen streaming on
+// in the country, and whether it was seen there at all.
+func seenProviderIDs(availability model.WatchlistAvailability, country string) ([]int, bool) {
+	if availability.Country != country {
+		return nil, false
+	}
+
+	var providerIDs []int
+	if err := json.Unmarshal([]byte(availability.ProviderIds), &providerIDs); err != nil {
+		return nil, false
+	}
+	return providerIDs, true
+}
+
+// ratingNotification notifies a follower of the rater that they rated the
+// movie. Rating it again isn't notified.
+func ratingNotification(rater model.Users, item model.Watchlist, movie dto.TMDBMovieDTO, locale dto.LocaleDTO) model.Notifications {
+	title := mappers.MapFromTMDBToMovieDTO(movie, locale).Title
+	key := fmt.Sprintf("%s:%d:%d", dto.NotificationRated, rater.ID, item.MovieID)
+
+	notification := newNotification(dto.NotificationRated, key, item.MovieID, dto.NotificationRated, locale, title, utils.FallbackZero(rater.Name, rater.Username), utils.Fallback(item.Rating, 0))
+	notification.ActorID = &rater.ID
+	return notification
+}
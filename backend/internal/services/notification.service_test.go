@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) FindByUser(_ context.Context, userID int32, query dto.NotificationQueryDTO) ([]model.Notifications, int64, error) {
+	args := m.Called(userID, query)
+	return args.Get(0).([]model.Notifications), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNotificationRepository) CountUnread(_ context.Context, userID int32) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) Create(_ context.Context, notification model.Notifications) (model.Notifications, error) {
+	args := m.Called(notification)
+	return args.Get(0).(model.Notifications), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkRead(_ context.Context, userID int32, id int32) (model.Notifications, error) {
+	args := m.Called(userID, id)
+	return args.Get(0).(model.Notifications), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkAllRead(_ context.Context, userID int32) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+type MockNotificationSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationSettingsRepository) FindByUser(_ context.Context, userID int32) (model.NotificationSettings, error) {
+	args := m.Called(userID)
+	return args.Get(0).(model.NotificationSettings), args.Error(1)
+}
+
+func (m *MockNotificationSettingsRepository) Save(_ context.Context, settings model.NotificationSettings) (model.NotificationSettings, error) {
+	args := m.Called(settings)
+	return args.Get(0).(model.NotificationSettings), args.Error(1)
+}
+
+type MockChannel struct {
+	mock.Mock
+}
+
+func (m *MockChannel) Name() string {
+	return "mock"
+}
+
+func (m *MockChannel) Send(_ context.Context, recipient notify.Recipient, notification dto.NotificationDTO) error {
+	args := m.Called(recipient, notification)
+	return args.Error(0)
+}
+
+type MockLocker struct {
+	mock.Mock
+}
+
+func (m *MockLocker) TryLock(ctx context.Context, key int64, fn func(ctx context.Context)) (bool, error) {
+	args := m.Called(key)
+	if args.Bool(0) {
+		fn(ctx)
+	}
+	return args.Bool(0), args.Error(1)
+}
+
+func TestReleaseNotifications(t *testing.T) {
+	items := []model.Watchlist{{MovieID: 1}, {MovieID: 2}, {MovieID: 3}}
+	movies := []dto.TMDBMovieDTO{
+		releasedIn(1, map[int]string{dto.ReleaseTypeTheatrical: "2026-10-15", dto.ReleaseTypeDigital: "2026-12-01"}),
+		releasedIn(2, map[int]string{dto.ReleaseTypeTheatrical: "2026-09-01"}),
+		releasedIn(3, map[int]string{dto.ReleaseTypeDigital: "2026-10-17"}),
+	}
+	movies[0].Title = "Matrix"
+
+	notifications := releaseNotifications(items, movies, dto.LocaleDTO{Language: "pt-BR", Region: "BR"}, time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC))
+
+	if assert.Len(t, notifications, 2) {
+		assert.Equal(t, model.Notifications{
+			Type:    dto.NotificationReleased,
+			Key:     "released:1:theatrical:BR",
+			MovieID: 1,
+			Title:   "Matrix estreou nos cinemas",
+			Body:    "Matrix, da sua lista, já está nos cinemas.",
+		}, notifications[0])
+		assert.Equal(t, "released:3:digital:BR", notifications[1].Key)
+	}
+}
+
+func TestAvailabilityNotifications(t *testing.T) {
+	items := []model.Watchlist{{MovieID: 1}, {MovieID: 2}, {MovieID: 3}, {MovieID: 4}, {MovieID: 5}}
+	movies := []dto.TMDBMovieDTO{streamsOn(1, 8, 119), streamsOn(2, 8), {}, streamsOn(4, 8), streamsOn(5, 8)}
+	movies[0].Title = "Matrix"
+	movies[0].WatchProviders.Results["BR"].Flatrate[0].ProviderName = "Netflix"
+	subscription := dto.StreamingSubscriptionDTO{Country: "BR", ProviderIDs: []int{8, 2}}
+	seen := map[int32]model.WatchlistAvailability{
+		1: {MovieID: 1, Country: "BR", ProviderIds: "[119]"},
+		3: {MovieID: 3, Country: "BR", ProviderIds: "[]"},
+		4: {MovieID: 4, Country: "BR", ProviderIds: "[8]"},
+		5: {MovieID: 5, Country: "US", ProviderIds: "[]"},
+	}
+
+	notifications, availability := availabilityNotifications(items, movies, subscription, seen, dto.LocaleDTO{Language: "en-US"})
+
+	if assert.Len(t, notifications, 1) {
+		assert.Equal(t, model.Notifications{
+			Type:    dto.NotificationAvailable,
+			Key:     "available:1:8",
+			MovieID: 1,
+			Title:   "Matrix is streaming on Netflix",
+			Body:    "Matrix, from your watchlist, is now on Netflix.",
+		}, notifications[0])
+	}
+	assert.Equal(t, []model.WatchlistAvailability{
+		{MovieID: 1, Country: "BR", ProviderIds: "[8,119]"},
+		{MovieID: 2, Country: "BR", ProviderIds: "[8]"},
+		{MovieID: 5, Country: "BR", ProviderIds: "[8]"},
+	}, availability)
+
+	notifications, availability = availabilityNotifications(items, movies, dto.StreamingSubscriptionDTO{}, seen, dto.LocaleDTO{})
+	assert.Empty(t, notifications)
+	assert.Empty(t, availability)
+}
+
+func TestAvailabilityNotifications_SeenAsStored(t *testing.T) {
+	items := []model.Watchlist{{MovieID: 1}}
+	movies := []dto.TMDBMovieDTO{streamsOn(1, 8, 119)}
+	subscription := dto.StreamingSubscriptionDTO{Country: "BR", ProviderIDs: []int{8}}
+	seen := map[int32]model.WatchlistAvailability{1: {MovieID: 1, Country: "BR", ProviderIds: "[8, 119]"}}
+
+	notifications, availability := availabilityNotifications(items, movies, subscription, seen, dto.LocaleDTO{})
+
+	assert.Empty(t, notifications)
+	assert.Empty(t, availability)
+}
+
+func TestRatingNotification(t *testing.T) {
+	rating := int32(9)
+	rater := model.Users{ID: 4, Username: "ana"}
+
+	notification := ratingNotification(rater, model.Watchlist{MovieID: 603, UserID: 4, Rating: &rating}, dto.TMDBMovieDTO{ID: 603, Title: "Matrix"}, dto.LocaleDTO{Language: "fr-FR"})
+
+	assert.Equal(t, "rated:4:603", notification.Key)
+	assert.Equal(t, dto.NotificationRated, notification.Type)
+	assert.Equal(t, "ana rated Matrix", notification.Title)
+	assert.Equal(t, "ana gave Matrix a 9 out of 10.", notification.Body)
+	assert.Equal(t, int32(4), *notification.ActorID)
+}
+
+func TestNotificationService_Deliver(t *testing.T) {
+	notificationRepo := new(MockNotificationRepository)
+	settingsRepo := new(MockNotificationSettingsRepository)
+	channel := new(MockChannel)
+	service := &NotificationService{
+		notificationRepo: notificationRepo,
+		settingsRepo:     settingsRepo,
+		channels:         []notify.Channel{channel},
+		appURL:           "https://app.example.com",
+	}
+	verifiedAt := time.Now()
+	user := model.Users{ID: 1, Email: "ana@example.com", EmailVerifiedAt: &verifiedAt}
+	webhookURL := "https://hooks.example.com"
+
+	fresh := model.Notifications{UserID: 1, Key: "released:603:theatrical:BR", MovieID: 603}
+	notificationRepo.On("Create", fresh).Return(model.Notifications{ID: 7, UserID: 1, MovieID: 603}, nil)
+	duplicate := model.Notifications{UserID: 1, Key: "released:603:digital:BR", MovieID: 603}
+	notificationRepo.On("Create", duplicate).Return(model.Notifications{}, qrm.ErrNoRows)
+	settingsRepo.On("FindByUser", int32(1)).Return(model.NotificationSettings{UserID: 1, Email: true, WebhookURL: &webhookURL}, nil)
+	channel.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+	service.deliver(context.Background(), user, fresh)
+	service.deliver(context.Background(), user, duplicate)
+
+	channel.AssertNumberOfCalls(t, "Send", 1)
+	channel.AssertCalled(t, "Send",
+		notify.Recipient{Email: "ana@example.com", WebhookURL: "https://hooks.example.com"},
+		dto.NotificationDTO{ID: 7, MovieID: 603, Link: "https://app.example.com/movie/603"},
+	)
+}
+
+func TestNotificationService_RecipientOf_UnverifiedEmail(t *testing.T) {
+	settingsRepo := new(MockNotificationSettingsRepository)
+	service := &NotificationService{settingsRepo: settingsRepo}
+	settingsRepo.On("FindByUser", int32(1)).Return(model.NotificationSettings{UserID: 1, Email: true}, nil)
+	settingsRepo.On("FindByUser", int32(2)).Return(model.NotificationSettings{}, qrm.ErrNoRows)
+
+	recipient, err := service.recipientOf(context.Background(), model.Users{ID: 1, Email: "ana@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, notify.Recipient{}, recipient)
+
+	recipient, err = service.recipientOf(context.Background(), model.Users{ID: 2, Email: "bia@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, notify.Recipient{}, recipient)
+}
+
+func TestNotificationService_SaveSettings_InternalWebhook(t *testing.T) {
+	settingsRepo := new(MockNotificationSettingsRepository)
+	service := &NotificationService{settingsRepo: settingsRepo}
+
+	_, err := service.SaveSettings(context.Background(), 1, dto.NotificationSettingsDTO{WebhookURL: "https://169.254.169.254/latest/meta-data"})
+
+	assertApiError(t, err, http.StatusBadRequest, "error.notification.invalid_webhook_url")
+	settingsRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestNotificationService_Find(t *testing.T) {
+	notificationRepo := new(MockNotificationRepository)
+	service := &NotificationService{notificationRepo: notificationRepo, appURL: "https://app.example.com"}
+	query := dto.NotificationQueryDTO{Unread: true, Page: 2, PageSize: defaultNotificationPageSize}
+	notificationRepo.On("FindByUser", int32(1), query).Return([]model.Notifications{{ID: 3, MovieID: 603}}, int64(21), nil)
+
+	page, err := service.Find(context.Background(), 1, dto.NotificationQueryDTO{Unread: true, Page: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, page.Page)
+	assert.Equal(t, 2, page.TotalPages)
+	assert.Equal(t, 21, page.TotalResults)
+	if assert.Len(t, page.Results, 1) {
+		assert.Equal(t, "https://app.example.com/movie/603", page.Results[0].Link)
+	}
+}
+
+func TestNotificationService_MarkRead_NotFound(t *testing.T) {
+	notificationRepo := new(MockNotificationRepository)
+	service := &NotificationService{notificationRepo: notificationRepo}
+	notificationRepo.On("MarkRead", int32(1), int32(9)).Return(model.Notifications{}, qrm.ErrNoRows)
+
+	_, err := service.MarkRead(context.Background(), 1, 9)
+
+	assertApiError(t, err, http.StatusNotFound, "error.notification.not_found")
+}
+
+func TestNotificationService_Scan_LockedElsewhere(t *testing.T) {
+	locker := new(MockLocker)
+	userRepo := new(MockUserRepository)
+	service := &NotificationService{locker: locker, userRepo: userRepo}
+	since := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	locker.On("TryLock", int64(notificationScanLockKey)).Return(false, nil)
+
+	next := service.Scan(context.Background(), since, since.Add(time.Hour))
+
+	assert.Equal(t, since, next)
+	userRepo.AssertNotCalled(t, "FindActiveAfter", mock.Anything, mock.Anything)
+}
+
+func TestNotificationService_ScanWatchlists_Batches(t *testing.T) {
+	userRepo := new(MockUserRepository)
+	profileRepo := new(MockParentalProfileRepository)
+	service := &NotificationService{userRepo: userRepo, contentPolicy: &ContentPolicyService{repo: profileRepo}}
+
+	batch := make([]model.Users, notificationScanBatchSize)
+	for i := range batch {
+		batch[i] = model.Users{ID: int32(i + 1)}
+	}
+	userRepo.On("FindActiveAfter", int32(0), int64(notificationScanBatchSize)).Return(batch, nil)
+	userRepo.On("FindActiveAfter", int32(notificationScanBatchSize), int64(notificationScanBatchSize)).Return([]model.Users{{ID: 250}}, nil)
+	profileRepo.On("FindByUser", mock.Anything).Return(model.ParentalProfiles{}, errors.New("connection refused"))
+
+	service.scanWatchlists(context.Background(), time.Now())
+
+	userRepo.AssertNumberOfCalls(t, "FindActiveAfter", 2)
+	profileRepo.AssertNumberOfCalls(t, "FindByUser", notificationScanBatchSize+1)
+}
+
+func TestNotificationService_ScanRatings(t *testing.T) {
+	watchListRepo := new(MockWatchListRepository)
+	followRepo := new(MockUserFollowRepository)
+	userRepo := new(MockUserRepository)
+	movieRepo := new(MockMovieRepository)
+	profileRepo := new(MockParentalProfileRepository)
+	notificationRepo := new(MockNotificationRepository)
+	service := &NotificationService{
+		watchListRepo:    watchListRepo,
+		followRepo:       followRepo,
+		userRepo:         userRepo,
+		movieRepo:        movieRepo,
+		notificationRepo: notificationRepo,
+		contentPolicy:    &ContentPolicyService{repo: profileRepo},
+	}
+	since := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	rating := int32(8)
+
+	watchListRepo.On("FindRatedSince", since).Return([]model.Watchlist{
+		{UserID: 4, MovieID: 603, Rating: &rating, UpdatedAt: since.Add(time.Minute)},
+		{UserID: 5, MovieID: 603, Rating: &rating, UpdatedAt: since.Add(2 * time.Minute)},
+	}, nil)
+	followRepo.On("FindFollowers", int32(4)).Return([]model.UserFollows{{FollowerID: 1, FollowedID: 4}}, nil)
+	followRepo.On("FindFollowers", int32(5)).Return([]model.UserFollows{{FollowerID: 1, FollowedID: 5}}, nil)
+	userRepo.On("FindOne", int32(1)).Return(model.Users{ID: 1, Username: "ana"}, nil)
+	userRepo.On("FindOne", int32(4)).Return(model.Users{ID: 4, Username: "bia"}, nil)
+	userRepo.On("FindOne", int32(5)).Return(model.Users{ID: 5, Username: "caio"}, nil)
+	movieRepo.On("GetByID", 603).Return(dto.TMDBMovieDTO{ID: 603, Title: "Matrix"}, nil)
+	profileRepo.On("FindByUser", int32(1)).Return(model.ParentalProfiles{}, qrm.ErrNoRows)
+	notificationRepo.On("Create", mock.Anything).Return(model.Notifications{}, qrm.ErrNoRows)
+
+	next := service.scanRatings(context.Background(), since)
+
+	assert.Equal(t, since.Add(2*time.Minute), next)
+	notificationRepo.AssertNumberOfCalls(t, "Create", 2)
+	movieRepo.AssertNumberOfCalls(t, "GetByID", 1)
+	userRepo.AssertNumberOfCalls(t, "FindOne", 3)
+}
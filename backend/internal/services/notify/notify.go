@@ -0,0 +1,148 @@
+// Package notify delivers the notifications of the in-app inbox through other
+// channels, like email or webhooks.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/config"
+	"github.com/movie-tracker/MovieTracker/internal/mailer"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+)
+
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+// Recipient holds where a user wants their notifications delivered. Channels
+// skip recipients without an address of theirs.
+type Recipient struct {
+	Email      string
+	WebhookURL string
+}
+
+// Channel delivers notifications to their recipients.
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, recipient Recipient, notification dto.NotificationDTO) error
+}
+
+// NewChannels builds the channels enabled by the configuration.
+func NewChannels(cfg config.NotificationConfig, m mailer.Mailer) ([]Channel, error) {
+	channels := make([]Channel, 0, len(cfg.Channels))
+	for _, name := range cfg.Channels {
+		switch name {
+		case ChannelEmail:
+			channels = append(channels, NewEmailChannel(m))
+		case ChannelWebhook:
+			channels = append(channels, NewWebhookChannel(cfg.WebhookSecret, time.Duration(cfg.WebhookTimeout)*time.Second))
+		default:
+			return nil, fmt.Errorf("unknown notification channel %q", name)
+		}
+	}
+	return channels, nil
+}
+
+// EmailChannel sends every notification as a plain text email.
+type EmailChannel struct {
+	mailer mailer.Mailer
+}
+
+func NewEmailChannel(m mailer.Mailer) *EmailChannel {
+	return &EmailChannel{mailer: m}
+}
+
+func (c *EmailChannel) Name() string {
+	return ChannelEmail
+}
+
+func (c *EmailChannel) Send(_ context.Context, recipient Recipient, notification dto.NotificationDTO) error {
+	if recipient.Email == "" {
+		return nil
+	}
+
+	return c.mailer.Send(mailer.Message{
+		To:       recipient.Email,
+		Template: "notification_" + notification.Type,
+		Subject:  notification.Title,
+		Body:     fmt.Sprintf("%s\n\n%s\n", notification.Body, notification.Link),
+	})
+}
+
+// WebhookChannel posts every notification as JSON to the URL of the
+// recipient. With a secret, the body is signed with HMAC-SHA256 so receivers
+// can tell the request came from the API. Only public addresses are posted
+// to, and redirects aren't followed.
+type WebhookChannel struct {
+	secret  string
+	client  *http.Client
+	allowed func(netip.Addr) bool
+}
+
+func NewWebhookChannel(secret string, timeout time.Duration) *WebhookChannel {
+	return newWebhookChannel(secret, timeout, IsPublicAddress)
+}
+
+func newWebhookChannel(secret string, timeout time.Duration, allowed func(netip.Addr) bool) *WebhookChannel {
+	return &WebhookChannel{
+		secret:  secret,
+		client:  newWebhookClient(timeout, allowed),
+		allowed: allowed,
+	}
+}
+
+func (c *WebhookChannel) Name() string {
+	return ChannelWebhook
+}
+
+func (c *WebhookChannel) Send(ctx context.Context, recipient Recipient, notification dto.NotificationDTO) error {
+	if recipient.WebhookURL == "" {
+		return nil
+	}
+	if err := validateWebhookURL(recipient.WebhookURL, c.allowed); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, recipient.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Movie-Tracker-Event", notification.Type)
+	if c.secret != "" {
+		req.Header.Set("X-Movie-Tracker-Signature", "sha256="+Sign(c.secret, body))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of the body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/mappers"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+const (
+	// Maximum number of people resolved at the same time when listing the
+	// followed ones or building the feed.
+	personExpandConcurrency = 8
+
+	// How far back the feed goes when not asked otherwise.
+	defaultFeedDays = 365
+)
+
+// IPersonService serves the people in the catalog, the movies they worked
+// on, and the people each user follows. The feed gathers the releases of the
+// people followed, so a new film by a followed director shows up there.
+type IPersonService interface {
+	IService
+	GetByID(ctx context.Context, id int, viewer dto.ViewerDTO) (dto.PersonDTO, error)
+	GetMovies(ctx context.Context, id int, viewer dto.ViewerDTO) (dto.PersonMoviesDTO, error)
+	GetFollowing(ctx context.Context, userID int32, viewer dto.ViewerDTO) ([]dto.FollowedPersonDTO, error)
+	Follow(ctx context.Context, userID int32, personID int32, viewer dto.ViewerDTO) (dto.FollowedPersonDTO, error)
+	Unfollow(ctx context.Context, userID int32, personID int32) error
+	GetFeed(ctx context.Context, userID int32, query dto.FeedQueryDTO, viewer dto.ViewerDTO) ([]dto.FeedItemDTO, error)
+}
+
+type PersonService struct {
+	personRepo    repositories.IPersonRepository
+	followRepo    repositories.IFollowedPeopleRepository
+	genreRepo     repositories.IGenreRepository
+	defaultLocale dto.LocaleDTO
+	contentPolicy IContentPolicyService
+}
+
+func newPersonService(params ServicesParams) IPersonService {
+	return &PersonService{
+		personRepo: params.Repos.PersonRepo,
+		followRepo: params.Repos.FollowedPeopleRepo,
+		genreRepo:  params.Repos.GenreRepo,
+		defaultLocale: dto.LocaleDTO{
+			Language: params.Cfg.DefaultLanguage,
+			Region:   params.Cfg.DefaultRegion,
+		},
+	}
+}
+
+func (s *PersonService) ProvideServices(svcs Services) {
+	s.contentPolicy = svcs.ContentPolicyService
+}
+
+// GetByID returns the person, telling logged in viewers whether they follow
+// them.
+func (s *PersonService) GetByID(ctx context.Context, id int, viewer dto.ViewerDTO) (person dto.PersonDTO, err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
+	if err != nil {
+		return person, err
+	}
+
+	tmdbPerson, err := s.getVisiblePerson(ctx, id, *viewer.Policy)
+	if err != nil {
+		return person, err
+	}
+
+	person = mappers.MapFromTMDBToPersonDTO(tmdbPerson, viewer.Locale)
+
+	if viewer.UserID != 0 {
+		following, err := s.followRepo.IsFollowing(ctx, viewer.UserID, int32(id))
+		if err != nil {
+			return person, err
+		}
+		person.Following = &following
+	}
+
+	return person, nil
+}
+
+// GetMovies returns the movies the person acted in and worked on, leaving
+// out the ones the viewer's policy hides.
+func (s *PersonService) GetMovies(ctx context.Context, id int, viewer dto.ViewerDTO) (movies dto.PersonMoviesDTO, err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
+	if err != nil {
+		return movies, err
+	}
+
+	if _, err = s.getVisiblePerson(ctx, id, *viewer.Policy); err != nil {
+		return movies, err
+	}
+
+	credits, err := s.personRepo.GetMovieCredits(ctx, id, viewer.Locale)
+	if err != nil {
+		return movies, err
+	}
+
+	movies = dto.PersonMoviesDTO{
+		PersonID: id,
+		Cast:     make([]dto.PersonCreditDTO, 0, len(credits.Cast)),
+		Crew:     make([]dto.PersonCreditDTO, 0, len(credits.Crew)),
+	}
+	for _, credit := range sortByRelease(credits.Cast, func(c dto.TMDBPersonCastDTO) string { return c.ReleaseDate }) {
+		if len(policy.Evaluate(*viewer.Policy, credit.TMDBMovieDTO)) == 0 {
+			movies.Cast = append(movies.Cast, dto.PersonCreditDTO{
+				Movie:     mappers.MapFromTMDBToMovieDTO(credit.TMDBMovieDTO, viewer.Locale),
+				Character: credit.Character,
+			})
+		}
+	}
+	for _, credit := range sortByRelease(credits.Crew, func(c dto.TMDBPersonCrewDTO) string { return c.ReleaseDate }) {
+		if len(policy.Evaluate(*viewer.Policy, credit.TMDBMovieDTO)) == 0 {
+			movies.Crew = append(movies.Crew, dto.PersonCreditDTO{
+				Movie:      mappers.MapFromTMDBToMovieDTO(credit.TMDBMovieDTO, viewer.Locale),
+				Job:        credit.Job,
+				Department: credit.Department,
+			})
+		}
+	}
+
+	creditMovie := func(credit *dto.PersonCreditDTO) *dto.MovieDTO { return &credit.Movie }
+	nameGenresOf(ctx, s.genreRepo, viewer.Locale, movies.Cast, creditMovie)
+	nameGenresOf(ctx, s.genreRepo, viewer.Locale, movies.Crew, creditMovie)
+
+	return movies, nil
+}
+
+// GetFollowing lists the people the user follows, resolving them
+// concurrently. A person that fails to resolve only marks their own item.
+func (s *PersonService) GetFollowing(ctx context.Context, userID int32, viewer dto.ViewerDTO) ([]dto.FollowedPersonDTO, error) {
+	followed, err := s.followRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if viewer, err = s.resolveViewer(ctx, viewer); err != nil {
+		return nil, err
+	}
+
+	followedDTOs := make([]dto.FollowedPersonDTO, len(followed))
+	forEachConcurrently(len(followed), personExpandConcurrency, func(i int) {
+		person, err := s.getVisiblePerson(ctx, int(followed[i].PersonID), *viewer.Policy)
+		followedDTOs[i] = s.buildFollowed(followed[i], person, err, viewer)
+	})
+
+	return followedDTOs, nil
+}
+
+func (s *PersonService) Follow(ctx context.Context, userID int32, personID int32, viewer dto.ViewerDTO) (followedDTO dto.FollowedPersonDTO, err error) {
+	if viewer, err = s.resolveViewer(ctx, viewer); err != nil {
+		return followedDTO, err
+	}
+
+	person, err := s.getVisiblePerson(ctx, int(personID), *viewer.Policy)
+	if err != nil {
+		return followedDTO, err
+	}
+
+	followed, err := s.followRepo.Follow(ctx, userID, personID)
+	if err != nil {
+		return followedDTO, err
+	}
+
+	return s.buildFollowed(followed, person, nil, viewer), nil
+}
+
+func (s *PersonService) Unfollow(ctx context.Context, userID int32, personID int32) error {
+	return s.followRepo.Unfollow(ctx, userID, personID)
+}
+
+// GetFeed lists the movies of the people the user follows released in the
+// last days asked for, or announced, the most recent first. People that fail
+// to resolve are left out rather than failing the whole feed.
+func (s *PersonService) GetFeed(ctx context.Context, userID int32, query dto.FeedQueryDTO, viewer dto.ViewerDTO) ([]dto.FeedItemDTO, error) {
+	followed, err := s.followRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if viewer, err = s.resolveViewer(ctx, viewer); err != nil {
+		return nil, err
+	}
+
+	people := make([]personCredits, len(followed))
+	forEachConcurrently(len(followed), personExpandConcurrency, func(i int) {
+		id := int(followed[i].PersonID)
+
+		person, err := s.getVisiblePerson(ctx, id, *viewer.Policy)
+		if err == nil {
+			people[i].person = person
+			people[i].credits, err = s.personRepo.GetMovieCredits(ctx, id, viewer.Locale)
+		}
+		if err != nil {
+			slog.Warn("leaving person out of the feed", "person_id", id, "error", err)
+			people[i] = personCredits{}
+		}
+	})
+
+	days := cmp.Or(query.Days, defaultFeedDays)
+	since := time.Now().AddDate(0, 0, -days).Format(time.DateOnly)
+
+	feed := buildFeed(people, since, *viewer.Policy, viewer.Locale)
+	nameGenresOf(ctx, s.genreRepo, viewer.Locale, feed, func(item *dto.FeedItemDTO) *dto.MovieDTO { return &item.Movie })
+
+	return feed, nil
+}
+
+// getVisiblePerson fetches the person, or a policy.HiddenError when the rules
+// hide them.
+func (s *PersonService) getVisiblePerson(ctx context.Context, id int, rules dto.ContentPolicyDTO) (dto.TMDBPersonDTO, error) {
+	person, err := s.personRepo.GetPerson(ctx, id)
+	if err != nil {
+		return person, err
+	}
+
+	if reasons := policy.EvaluatePerson(rules, person); len(reasons) > 0 {
+		return person, policy.NewHiddenPersonError(reasons)
+	}
+
+	return person, nil
+}
+
+// buildFollowed describes a followed person. When the person failed to
+// resolve, the error is reported on the item instead.
+func (s *PersonService) buildFollowed(followed model.FollowedPeople, person dto.TMDBPersonDTO, personErr error, viewer dto.ViewerDTO) dto.FollowedPersonDTO {
+	var followedDTO dto.FollowedPersonDTO
+	followedDTO.FromModel(followed)
+
+	if personErr != nil {
+		message := "error.person.unavailable"
+		var apiErr *utils.ApiError
+		var hiddenErr *policy.HiddenError
+		if errors.As(personErr, &hiddenErr) {
+			message = hiddenErr.Message
+		} else if errors.As(personErr, &apiErr) {
+			message = apiErr.Message
+		}
+		followedDTO.PersonError = &message
+		return followedDTO
+	}
+
+	personDTO := mappers.MapFromTMDBToPersonDTO(person, viewer.Locale)
+	following := true
+	personDTO.Following = &following
+	followedDTO.Person = &personDTO
+
+	return followedDTO
+}
+
+// resolveViewer fills the locale with the defaults and resolves the policy.
+func (s *PersonService) resolveViewer(ctx context.Context, viewer dto.ViewerDTO) (dto.ViewerDTO, error) {
+	viewer.Locale = viewer.Locale.WithFallback(s.defaultLocale)
+	return s.contentPolicy.Resolve(ctx, viewer)
+}
+
+// personCredits is a followed person with their movies; both are empty when
+// the person couldn't be resolved.
+type personCredits struct {
+	person  dto.TMDBPersonDTO
+	credits dto.TMDBPersonMovieCreditsDTO
+}
+
+// buildFeed gathers the movies of the people released since the date
+// (YYYY-MM-DD), or later, the most recent first. A movie several of them
+// worked on appears once, with everyone's part in it.
+func buildFeed(people []personCredits, since string, rules dto.ContentPolicyDTO, locale dto.LocaleDTO) []dto.FeedItemDTO {
+	items := make([]dto.FeedItemDTO, 0)
+	index := make(map[int]int)
+
+	add := func(movie dto.TMDBMovieDTO, credit dto.FeedCreditDTO) {
+		if movie.ReleaseDate == "" || movie.ReleaseDate < since || len(policy.Evaluate(rules, movie)) > 0 {
+			return
+		}
+
+		i, ok := index[movie.ID]
+		if !ok {
+			i = len(items)
+			index[movie.ID] = i
+			items = append(items, dto.FeedItemDTO{Movie: mappers.MapFromTMDBToMovieDTO(movie, locale)})
+		}
+		if !slices.Contains(items[i].People, credit) {
+			items[i].People = append(items[i].People, credit)
+		}
+	}
+
+	for _, p := range people {
+		for _, credit := range p.credits.Cast {
+			add(credit.TMDBMovieDTO, dto.FeedCreditDTO{PersonID: p.person.ID, Name: p.person.Name, Character: credit.Character})
+		}
+		for _, credit := range p.credits.Crew {
+			add(credit.TMDBMovieDTO, dto.FeedCreditDTO{PersonID: p.person.ID, Name: p.person.Name, Job: credit.Job})
+		}
+	}
+
+	slices.SortStableFunc(items, func(a, b dto.FeedItemDTO) int {
+		return cmp.Or(cmp.Compare(b.Movie.ReleaseDate, a.Movie.ReleaseDate), cmp.Compare(a.Movie.ID, b.Movie.ID))
+	})
+
+	return items
+}
+
+// sortByRelease orders the credits the most recent first, the ones without
+// a release date, usually announced projects, leading.
+func sortByRelease[T any](credits []T, releaseDate func(T) string) []T {
+	return slices.SortedStableFunc(slices.Values(credits), func(a, b T) int {
+		dateA, dateB := releaseDate(a), releaseDate(b)
+		switch {
+		case dateA == dateB:
+			return 0
+		case dateA == "":
+			return -1
+		case dateB == "":
+			return 1
+		default:
+			return cmp.Compare(dateB, dateA)
+		}
+	})
+}
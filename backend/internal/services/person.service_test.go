@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPersonRepository struct {
+	mock.Mock
+}
+
+func (m *MockPersonRepository) GetPerson(_ context.Context, id int) (dto.TMDBPersonDTO, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.TMDBPersonDTO), args.Error(1)
+}
+
+func (m *MockPersonRepository) GetMovieCredits(_ context.Context, id int, locale dto.LocaleDTO) (dto.TMDBPersonMovieCreditsDTO, error) {
+	args := m.Called(id, locale)
+	return args.Get(0).(dto.TMDBPersonMovieCreditsDTO), args.Error(1)
+}
+
+type MockFollowedPeopleRepository struct {
+	mock.Mock
+}
+
+func (m *MockFollowedPeopleRepository) FindByUser(_ context.Context, userID int32) ([]model.FollowedPeople, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]model.FollowedPeople), args.Error(1)
+}
+
+func (m *MockFollowedPeopleRepository) IsFollowing(_ context.Context, userID int32, personID int32) (bool, error) {
+	args := m.Called(userID, personID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFollowedPeopleRepository) Follow(_ context.Context, userID int32, personID int32) (model.FollowedPeople, error) {
+	args := m.Called(userID, personID)
+	return args.Get(0).(model.FollowedPeople), args.Error(1)
+}
+
+func (m *MockFollowedPeopleRepository) Unfollow(_ context.Context, userID int32, personID int32) error {
+	return m.Called(userID, personID).Error(0)
+}
+
+func TestBuildFeed(t *testing.T) {
+	director := personCredits{
+		person: dto.TMDBPersonDTO{ID: 1, Name: "Director"},
+		credits: dto.TMDBPersonMovieCreditsDTO{Crew: []dto.TMDBPersonCrewDTO{
+			{TMDBMovieDTO: dto.TMDBMovieDTO{ID: 10, Title: "Old", ReleaseDate: "2020-01-01"}, Job: "Director"},
+			{TMDBMovieDTO: dto.TMDBMovieDTO{ID: 11, Title: "Recent", ReleaseDate: "2026-03-01"}, Job: "Director"},
+			{TMDBMovieDTO: dto.TMDBMovieDTO{ID: 12, Title: "Announced", ReleaseDate: "2027-05-01"}, Job: "Director"},
+			{TMDBMovieDTO: dto.TMDBMovieDTO{ID: 13, Title: "Undated"}, Job: "Director"},
+		}},
+	}
+	actor := personCredits{
+		person: dto.TMDBPersonDTO{ID: 2, Name: "Actor"},
+		credits: dto.TMDBPersonMovieCreditsDTO{Cast: []dto.TMDBPersonCastDTO{
+			{TMDBMovieDTO: dto.TMDBMovieDTO{ID: 11, Title: "Recent", ReleaseDate: "2026-03-01"}, Character: "Lead"},
+			{TMDBMovieDTO: dto.TMDBMovieDTO{ID: 14, Title: "Adult", ReleaseDate: "2026-04-01", Adult: true}, Character: "Lead"},
+		}},
+	}
+
+	feed := buildFeed([]personCredits{director, actor, {}}, "2025-10-17", dto.ContentPolicyDTO{}, dto.LocaleDTO{})
+
+	if assert.Len(t, feed, 2) {
+		assert.Equal(t, 12, feed[0].Movie.ID)
+		assert.Equal(t, 11, feed[1].Movie.ID)
+		assert.Equal(t, []dto.FeedCreditDTO{
+			{PersonID: 1, Name: "Director", Job: "Director"},
+			{PersonID: 2, Name: "Actor", Character: "Lead"},
+		}, feed[1].People)
+	}
+}
+
+func TestPersonService_GetByID_Following(t *testing.T) {
+	// Arrange
+	personRepo := new(MockPersonRepository)
+	followRepo := new(MockFollowedPeopleRepository)
+	service := &PersonService{personRepo: personRepo, followRepo: followRepo, contentPolicy: &ContentPolicyService{}}
+
+	personRepo.On("GetPerson", 525).Return(dto.TMDBPersonDTO{ID: 525, Name: "Christopher Nolan"}, nil)
+	followRepo.On("IsFollowing", int32(1), int32(525)).Return(true, nil)
+
+	// Act
+	anonymous, err := service.GetByID(context.Background(), 525, dto.ViewerDTO{})
+	assert.NoError(t, err)
+	follower, err := service.GetByID(context.Background(), 525, dto.ViewerDTO{UserID: 1, Policy: &dto.ContentPolicyDTO{}})
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "Christopher Nolan", anonymous.Name)
+	assert.Nil(t, anonymous.Following)
+	if assert.NotNil(t, follower.Following) {
+		assert.True(t, *follower.Following)
+	}
+
+	personRepo.AssertExpectations(t)
+	followRepo.AssertExpectations(t)
+}
+
+func TestPersonService_Follow_Unknown(t *testing.T) {
+	personRepo := new(MockPersonRepository)
+	followRepo := new(MockFollowedPeopleRepository)
+	service := &PersonService{personRepo: personRepo, followRepo: followRepo, contentPolicy: &ContentPolicyService{}}
+
+	personRepo.On("GetPerson", 1).Return(dto.TMDBPersonDTO{}, utils.NewNotFoundError("error.person.not_found"))
+
+	_, err := service.Follow(context.Background(), 1, 1, dto.ViewerDTO{})
+
+	assert.Error(t, err)
+	followRepo.AssertNotCalled(t, "Follow", mock.Anything, mock.Anything)
+}
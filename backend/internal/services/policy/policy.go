@@ -0,0 +1,199 @@
+// Package policy decides which titles the content policy hides, and why.
+package policy
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+// Rules reported in the reasons a title was hidden.
+const (
+	RuleAdult          = "adult"
+	RuleCertification  = "certification"
+	RuleExcludedGenre  = "excluded_genre"
+	RuleBlockedKeyword = "blocked_keyword"
+	RuleLowRating      = "low_rating"
+)
+
+// Evaluate returns the reasons the policy hides the movie, none when it is
+// shown. Rules that need data the movie lacks, like the certification of
+// titles coming from lists, don't hide it.
+func Evaluate(rules dto.ContentPolicyDTO, movie dto.TMDBMovieDTO) []dto.HiddenReasonDTO {
+	return evaluate(rules, title{
+		adult:         movie.Adult,
+		certification: movie.Certification(rules.CertificationCountry),
+		genreIDs:      movie.AllGenreIDs(),
+		names:         []string{movie.Title, movie.OriginalTitle},
+		voteAverage:   movie.VoteAverage,
+		voteCount:     movie.VoteCount,
+	})
+}
+
+// EvaluateShow returns the reasons the policy hides the show, like Evaluate.
+// Shows are rated on the TV scale of each country, so where it differs from
+// the movie one the allowed certifications must list its ratings too.
+func EvaluateShow(rules dto.ContentPolicyDTO, show dto.TMDBShowDTO) []dto.HiddenReasonDTO {
+	return evaluate(rules, title{
+		adult:         show.Adult,
+		certification: show.Certification(rules.CertificationCountry),
+		genreIDs:      show.AllGenreIDs(),
+		names:         []string{show.Name, show.OriginalName},
+		voteAverage:   show.VoteAverage,
+		voteCount:     show.VoteCount,
+	})
+}
+
+// EvaluatePerson returns the reasons the policy hides the person. Only adult
+// performers are hidden; the titles they worked on are checked one by one.
+func EvaluatePerson(rules dto.ContentPolicyDTO, person dto.TMDBPersonDTO) []dto.HiddenReasonDTO {
+	if person.Adult && !rules.IncludeAdult {
+		return []dto.HiddenReasonDTO{{Rule: RuleAdult}}
+	}
+	return nil
+}
+
+// EvaluateGenre returns the reasons the policy hides the genre: it being
+// one of the excluded.
+func EvaluateGenre(rules dto.ContentPolicyDTO, genreID int) []dto.HiddenReasonDTO {
+	if slices.Contains(rules.ExcludedGenres, genreID) {
+		return []dto.HiddenReasonDTO{{Rule: RuleExcludedGenre, Value: strconv.Itoa(genreID)}}
+	}
+	return nil
+}
+
+// title is what the rules look at, be it a movie or a show.
+type title struct {
+	adult         bool
+	certification string
+	genreIDs      []int
+	names         []string
+	voteAverage   float64
+	voteCount     int
+}
+
+func evaluate(rules dto.ContentPolicyDTO, t title) []dto.HiddenReasonDTO {
+	var reasons []dto.HiddenReasonDTO
+
+	if t.adult && !rules.IncludeAdult {
+		reasons = append(reasons, dto.HiddenReasonDTO{Rule: RuleAdult})
+	}
+
+	if len(rules.AllowedCertifications) > 0 {
+		if t.certification != "" && !slices.Contains(rules.AllowedCertifications, t.certification) {
+			reasons = append(reasons, dto.HiddenReasonDTO{Rule: RuleCertification, Value: t.certification})
+		}
+	}
+
+	for _, genreID := range t.genreIDs {
+		if slices.Contains(rules.ExcludedGenres, genreID) {
+			reasons = append(reasons, dto.HiddenReasonDTO{Rule: RuleExcludedGenre, Value: strconv.Itoa(genreID)})
+		}
+	}
+
+	names := make([]string, len(t.names))
+	for i, name := range t.names {
+		names[i] = words(name)
+	}
+	for _, keyword := range rules.BlockedKeywords {
+		keywordWords := words(keyword)
+		if strings.TrimSpace(keywordWords) == "" {
+			continue
+		}
+		if slices.ContainsFunc(names, func(name string) bool { return strings.Contains(name, keywordWords) }) {
+			reasons = append(reasons, dto.HiddenReasonDTO{Rule: RuleBlockedKeyword, Value: keyword})
+		}
+	}
+
+	if t.voteCount > 0 && t.voteCount >= rules.MinVoteCount && t.voteAverage < rules.MinVoteAverage {
+		reasons = append(reasons, dto.HiddenReasonDTO{Rule: RuleLowRating, Value: strconv.FormatFloat(t.voteAverage, 'f', 1, 64)})
+	}
+
+	return reasons
+}
+
+// Filter keeps the movies the policy shows.
+func Filter(rules dto.ContentPolicyDTO, movies []dto.TMDBMovieDTO) []dto.TMDBMovieDTO {
+	shown := make([]dto.TMDBMovieDTO, 0, len(movies))
+	for _, movie := range movies {
+		if len(Evaluate(rules, movie)) == 0 {
+			shown = append(shown, movie)
+		}
+	}
+	return shown
+}
+
+// WithProfile returns the policy with the overrides of a parental profile.
+// Adult titles are never set by the profile, and the rating scale only along
+// with the certifications allowed on it.
+func WithProfile(rules dto.ContentPolicyDTO, profile dto.ParentalProfileDTO) dto.ContentPolicyDTO {
+	if profile.AllowedCertifications != nil {
+		rules.AllowedCertifications = *profile.AllowedCertifications
+		rules.CertificationCountry = utils.Fallback(profile.CertificationCountry, rules.CertificationCountry)
+	}
+	if profile.ExcludedGenres != nil {
+		rules.ExcludedGenres = *profile.ExcludedGenres
+	}
+	if profile.BlockedKeywords != nil {
+		rules.BlockedKeywords = *profile.BlockedKeywords
+	}
+	if profile.MinVoteAverage != nil {
+		rules.MinVoteAverage = *profile.MinVoteAverage
+	}
+	if profile.MinVoteCount != nil {
+		rules.MinVoteCount = *profile.MinVoteCount
+	}
+	return rules
+}
+
+// HiddenError is returned when the title asked for is hidden by the policy,
+// along with the reasons.
+type HiddenError struct {
+	*utils.ApiError
+	Reasons []dto.HiddenReasonDTO `json:"reasons"`
+}
+
+func (e *HiddenError) BuildError() (int, any) {
+	return e.ApiError.Code, e
+}
+
+func NewHiddenError(reasons []dto.HiddenReasonDTO) *HiddenError {
+	return newHiddenError("error.movie.hidden", reasons)
+}
+
+func NewHiddenShowError(reasons []dto.HiddenReasonDTO) *HiddenError {
+	return newHiddenError("error.show.hidden", reasons)
+}
+
+func NewHiddenPersonError(reasons []dto.HiddenReasonDTO) *HiddenError {
+	return newHiddenError("error.person.hidden", reasons)
+}
+
+func NewHiddenGenreError(reasons []dto.HiddenReasonDTO) *HiddenError {
+	return newHiddenError("error.genre.hidden", reasons)
+}
+
+func newHiddenError(message string, reasons []dto.HiddenReasonDTO) *HiddenError {
+	return &HiddenError{
+		ApiError: &utils.ApiError{
+			Message: message,
+			Code:    http.StatusForbidden,
+		},
+		Reasons: reasons,
+	}
+}
+
+// words lowercases the text and reduces it to its words separated by single
+// spaces, padded with spaces so whole words can be matched with Contains.
+// That way "sex" blocks "Sex Tape" but not "Sexta-Feira 13".
+func words(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return " " + strings.Join(fields, " ") + " "
+}
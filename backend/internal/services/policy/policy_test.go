@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRules = dto.ContentPolicyDTO{
+	CertificationCountry:  "BR",
+	AllowedCertifications: []string{"L", "10", "12"},
+	ExcludedGenres:        []int{27},
+	BlockedKeywords:       []string{"sex", "adult film"},
+	MinVoteAverage:        3,
+	MinVoteCount:          100,
+}
+
+func TestEvaluate_Reasons(t *testing.T) {
+	// Arrange
+	movie := dto.TMDBMovieDTO{
+		Adult:       true,
+		Title:       "Sex Tape",
+		Genres:      []dto.GenreDTO{{ID: 35}, {ID: 27}},
+		VoteAverage: 2.5,
+		VoteCount:   300,
+		ReleaseDates: &dto.ReleaseDatesDTO{Results: []dto.CountryReleaseDatesDTO{
+			{ISO31661: "US", ReleaseDates: []dto.ReleaseDateDTO{{Certification: "R"}}},
+			{ISO31661: "BR", ReleaseDates: []dto.ReleaseDateDTO{{Certification: ""}, {Certification: "16"}}},
+		}},
+	}
+
+	// Act
+	reasons := Evaluate(testRules, movie)
+
+	// Assert
+	assert.Equal(t, []dto.HiddenReasonDTO{
+		{Rule: RuleAdult},
+		{Rule: RuleCertification, Value: "16"},
+		{Rule: RuleExcludedGenre, Value: "27"},
+		{Rule: RuleBlockedKeyword, Value: "sex"},
+		{Rule: RuleLowRating, Value: "2.5"},
+	}, reasons)
+}
+
+func TestEvaluate_Shown(t *testing.T) {
+	tests := []struct {
+		name  string
+		movie dto.TMDBMovieDTO
+	}{
+		{"keywords match whole words", dto.TMDBMovieDTO{Title: "Sexta-Feira 13", OriginalTitle: "Friday the 13th"}},
+		{"unknown certification", dto.TMDBMovieDTO{Title: "Alien", GenreIDs: []int{878}}},
+		{"too few votes to judge", dto.TMDBMovieDTO{Title: "Alien", VoteAverage: 1, VoteCount: 20}},
+		{"no votes", dto.TMDBMovieDTO{Title: "Alien"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Empty(t, Evaluate(testRules, tt.movie))
+		})
+	}
+}
+
+func TestEvaluate_MultiWordKeyword(t *testing.T) {
+	assert.NotEmpty(t, Evaluate(testRules, dto.TMDBMovieDTO{OriginalTitle: "The Adult-Film Star"}))
+	assert.Empty(t, Evaluate(testRules, dto.TMDBMovieDTO{OriginalTitle: "Young Adult"}))
+}
+
+func TestWithProfile(t *testing.T) {
+	// Arrange
+	genres := []int{}
+	minVoteCount := 10
+	profile := dto.ParentalProfileDTO{ExcludedGenres: &genres, MinVoteCount: &minVoteCount}
+
+	// Act
+	rules := WithProfile(testRules, profile)
+
+	// Assert
+	assert.Empty(t, rules.ExcludedGenres)
+	assert.Equal(t, 10, rules.MinVoteCount)
+	assert.Equal(t, testRules.AllowedCertifications, rules.AllowedCertifications)
+	assert.Equal(t, testRules.BlockedKeywords, rules.BlockedKeywords)
+	assert.False(t, rules.IncludeAdult)
+}
+
+func TestWithProfile_CertificationCountry(t *testing.T) {
+	// Arrange
+	certifications := []string{"G"}
+	country := "US"
+	onlyCountry := dto.ParentalProfileDTO{CertificationCountry: &country}
+	withCertifications := dto.ParentalProfileDTO{AllowedCertifications: &certifications, CertificationCountry: &country}
+
+	// Act
+	countryRules := WithProfile(testRules, onlyCountry)
+	certificationRules := WithProfile(testRules, withCertifications)
+
+	// Assert
+	assert.Equal(t, testRules.CertificationCountry, countryRules.CertificationCountry)
+	assert.Equal(t, testRules.AllowedCertifications, countryRules.AllowedCertifications)
+	assert.Equal(t, "US", certificationRules.CertificationCountry)
+	assert.Equal(t, []string{"G"}, certificationRules.AllowedCertifications)
+}
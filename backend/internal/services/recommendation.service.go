@@ -0,0 +1,203 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/mappers"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+)
+
+const (
+	// Number of watchlist movies the recommendations are drawn from.
+	maxRecommendationSeeds = 5
+
+	// Lowest rating that makes a watchlist movie a seed, favorites aside.
+	minSeedRating = 8
+
+	defaultRecommendationLimit = 20
+)
+
+// IRecommendationService recommends movies to users from what they liked.
+type IRecommendationService interface {
+	IService
+	GetForUser(ctx context.Context, userID int32, query dto.RecommendationQueryDTO, viewer dto.ViewerDTO) ([]dto.RecommendationDTO, error)
+}
+
+type RecommendationService struct {
+	movieRepo     repositories.IMovieRepository
+	watchListRepo repositories.IWatchListRepository
+	genreRepo     repositories.IGenreRepository
+	defaultLocale dto.LocaleDTO
+	contentPolicy IContentPolicyService
+}
+
+func newRecommendationService(params ServicesParams) IRecommendationService {
+	return &RecommendationService{
+		movieRepo:     params.Repos.MovieRepo,
+		watchListRepo: params.Repos.WatchListRepo,
+		genreRepo:     params.Repos.GenreRepo,
+		defaultLocale: dto.LocaleDTO{
+			Language: params.Cfg.DefaultLanguage,
+			Region:   params.Cfg.DefaultRegion,
+		},
+	}
+}
+
+func (s *RecommendationService) ProvideServices(svcs Services) {
+	s.contentPolicy = svcs.ContentPolicyService
+}
+
+// GetForUser gathers the TMDB recommendations for the movies the user rated
+// highly or favorited, ranking first the movies recommended for more of them
+// and higher up. Movies already on the watchlist and those the policy hides
+// are left out. Without any such movie, popular movies are recommended
+// instead.
+func (s *RecommendationService) GetForUser(ctx context.Context, userID int32, query dto.RecommendationQueryDTO, viewer dto.ViewerDTO) ([]dto.RecommendationDTO, error) {
+	items, err := s.watchListRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if viewer, err = s.resolveViewer(ctx, viewer); err != nil {
+		return nil, err
+	}
+
+	seeds := pickSeeds(items)
+
+	var lists []recommendationList
+	if len(seeds) == 0 {
+		popular, err := s.movieRepo.DiscoverMovies(ctx, 1, dto.MovieDiscoverFiltersDTO{}, viewer.Locale, *viewer.Policy)
+		if err != nil {
+			return nil, err
+		}
+		lists = []recommendationList{{movies: popular.Results}}
+	} else {
+		lists = make([]recommendationList, len(seeds))
+		errs := make([]error, len(seeds))
+		forEachConcurrently(len(seeds), len(seeds), func(i int) {
+			page, err := s.movieRepo.GetRecommendations(ctx, int(seeds[i]), 1, viewer.Locale)
+			lists[i] = recommendationList{seed: seeds[i], movies: page.Results}
+			errs[i] = err
+		})
+
+		// A seed failing only loses its share, unless they all did
+		failed := 0
+		for i, err := range errs {
+			if err != nil {
+				slog.Warn("leaving seed out of the recommendations", "movie_id", seeds[i], "error", err)
+				failed++
+			}
+		}
+		if failed == len(seeds) {
+			return nil, errs[0]
+		}
+	}
+
+	onList := make(map[int]bool, len(items))
+	for _, item := range items {
+		onList[int(item.MovieID)] = true
+	}
+
+	limit := cmp.Or(query.Limit, defaultRecommendationLimit)
+	recommendations := rankRecommendations(lists, onList, *viewer.Policy, viewer.Locale, limit)
+	nameGenresOf(ctx, s.genreRepo, viewer.Locale, recommendations, func(r *dto.RecommendationDTO) *dto.MovieDTO { return &r.Movie })
+
+	return recommendations, nil
+}
+
+// resolveViewer fills the locale with the defaults and resolves the policy.
+func (s *RecommendationService) resolveViewer(ctx context.Context, viewer dto.ViewerDTO) (dto.ViewerDTO, error) {
+	viewer.Locale = viewer.Locale.WithFallback(s.defaultLocale)
+	return s.contentPolicy.Resolve(ctx, viewer)
+}
+
+// pickSeeds returns the watchlist movies recommendations are drawn from: the
+// highest rated, then the favorites, the most recently updated first.
+func pickSeeds(items []model.Watchlist) []int32 {
+	candidates := make([]model.Watchlist, 0, len(items))
+	for _, item := range items {
+		if item.Favorite || (item.Rating != nil && *item.Rating >= minSeedRating) {
+			candidates = append(candidates, item)
+		}
+	}
+
+	rating := func(item model.Watchlist) int32 {
+		if item.Rating == nil {
+			return 0
+		}
+		return *item.Rating
+	}
+	slices.SortStableFunc(candidates, func(a, b model.Watchlist) int {
+		return cmp.Or(cmp.Compare(rating(b), rating(a)), b.UpdatedAt.Compare(a.UpdatedAt))
+	})
+
+	seeds := make([]int32, 0, maxRecommendationSeeds)
+	for _, item := range candidates[:min(len(candidates), maxRecommendationSeeds)] {
+		seeds = append(seeds, item.MovieID)
+	}
+	return seeds
+}
+
+// recommendationList is a list of movies recommended for a seed, in TMDB's
+// order; the seed is zero for popular movies.
+type recommendationList struct {
+	seed   int32
+	movies []dto.TMDBMovieDTO
+}
+
+// rankRecommendations merges the lists, scoring every movie by its position
+// in each list it appears in, so one recommended near the top for several
+// seeds ranks first. Ties go to the most popular.
+func rankRecommendations(lists []recommendationList, onList map[int]bool, rules dto.ContentPolicyDTO, locale dto.LocaleDTO, limit int) []dto.RecommendationDTO {
+	type candidate struct {
+		movie     dto.TMDBMovieDTO
+		score     float64
+		becauseOf []int32
+	}
+
+	candidates := make(map[int]*candidate)
+	for _, list := range lists {
+		for position, movie := range list.movies {
+			if onList[movie.ID] || len(policy.Evaluate(rules, movie)) > 0 {
+				continue
+			}
+
+			c, ok := candidates[movie.ID]
+			if !ok {
+				c = &candidate{movie: movie, becauseOf: make([]int32, 0)}
+				candidates[movie.ID] = c
+			}
+			c.score += 1 - float64(position)/float64(len(list.movies))
+			if list.seed != 0 && !slices.Contains(c.becauseOf, list.seed) {
+				c.becauseOf = append(c.becauseOf, list.seed)
+			}
+		}
+	}
+
+	ranked := make([]*candidate, 0, len(candidates))
+	for _, c := range candidates {
+		ranked = append(ranked, c)
+	}
+	slices.SortFunc(ranked, func(a, b *candidate) int {
+		return cmp.Or(
+			cmp.Compare(b.score, a.score),
+			cmp.Compare(b.movie.Popularity, a.movie.Popularity),
+			cmp.Compare(a.movie.ID, b.movie.ID),
+		)
+	})
+
+	recommendations := make([]dto.RecommendationDTO, 0, min(len(ranked), limit))
+	for _, c := range ranked[:min(len(ranked), limit)] {
+		recommendations = append(recommendations, dto.RecommendationDTO{
+			Movie:     mappers.MapFromTMDBToMovieDTO(c.movie, locale),
+			BecauseOf: c.becauseOf,
+		})
+	}
+	return recommendations
+}
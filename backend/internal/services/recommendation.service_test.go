@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickSeeds(t *testing.T) {
+	rating := func(value int32) *int32 { return &value }
+	now := time.Now()
+
+	items := []model.Watchlist{
+		{MovieID: 1, Rating: rating(6)},
+		{MovieID: 2, Favorite: true, UpdatedAt: now.Add(-time.Hour)},
+		{MovieID: 3, Rating: rating(9)},
+		{MovieID: 4, Favorite: true, UpdatedAt: now},
+		{MovieID: 5, Rating: rating(10)},
+		{MovieID: 6, Rating: rating(8)},
+		{MovieID: 7, Rating: rating(8), Favorite: true},
+		{MovieID: 8},
+	}
+
+	assert.Equal(t, []int32{5, 3, 6, 7, 4}, pickSeeds(items))
+	assert.Empty(t, pickSeeds(items[:1]))
+}
+
+func TestRankRecommendations(t *testing.T) {
+	lists := []recommendationList{
+		{seed: 1, movies: []dto.TMDBMovieDTO{{ID: 10}, {ID: 11}, {ID: 12}, {ID: 13, Adult: true}}},
+		{seed: 2, movies: []dto.TMDBMovieDTO{{ID: 12}, {ID: 14, Popularity: 2}, {ID: 1}}},
+	}
+
+	ranked := rankRecommendations(lists, map[int]bool{1: true, 2: true, 11: true}, dto.ContentPolicyDTO{}, dto.LocaleDTO{}, 3)
+
+	if assert.Len(t, ranked, 3) {
+		// Recommended for both seeds
+		assert.Equal(t, 12, ranked[0].Movie.ID)
+		assert.Equal(t, []int32{1, 2}, ranked[0].BecauseOf)
+		assert.Equal(t, 10, ranked[1].Movie.ID)
+		assert.Equal(t, 14, ranked[2].Movie.ID)
+		assert.Equal(t, []int32{2}, ranked[2].BecauseOf)
+	}
+}
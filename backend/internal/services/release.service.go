@@ -0,0 +1,290 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/ical"
+	"github.com/movie-tracker/MovieTracker/internal/services/mappers"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+const (
+	// Days ahead upcoming releases are listed for unless asked otherwise.
+	defaultUpcomingDays = 180
+
+	// Days a release stays in the calendar feed after it happened, so
+	// calendar apps don't drop it right away.
+	calendarPastDays = 30
+)
+
+// Statuses of the watchlist items whose releases are tracked.
+var trackedReleaseStatuses = []string{
+	string(model.WatchStatus_PlanToWatch),
+	string(model.WatchStatus_Unwatched),
+	string(model.WatchStatus_Watching),
+}
+
+// IReleaseService tracks the theatrical and digital releases of the movies on
+// the users' watchlists, and serves them as a calendar feed each user can
+// subscribe to with a secret URL.
+type IReleaseService interface {
+	IService
+	GetUpcoming(ctx context.Context, userID int32, query dto.UpcomingQueryDTO, viewer dto.ViewerDTO) ([]dto.UpcomingReleaseDTO, error)
+	GetCalendarFeed(ctx context.Context, userID int32) (dto.CalendarFeedDTO, error)
+	CreateCalendarFeed(ctx context.Context, userID int32) (dto.CalendarFeedDTO, error)
+	DeleteCalendarFeed(ctx context.Context, userID int32) error
+	WriteCalendar(ctx context.Context, token string, w io.Writer) error
+}
+
+type ReleaseService struct {
+	watchListRepo repositories.IWatchListRepository
+	movieRepo     repositories.IMovieRepository
+	genreRepo     repositories.IGenreRepository
+	userRepo      repositories.IUserRepository
+	feedRepo      repositories.ICalendarFeedRepository
+	defaultLocale dto.LocaleDTO
+	apiURL        string
+	appURL        string
+	contentPolicy IContentPolicyService
+}
+
+func newReleaseService(params ServicesParams) IReleaseService {
+	return &ReleaseService{
+		watchListRepo: params.Repos.WatchListRepo,
+		movieRepo:     params.Repos.MovieRepo,
+		genreRepo:     params.Repos.GenreRepo,
+		userRepo:      params.Repos.UserRepo,
+		feedRepo:      params.Repos.CalendarFeedRepo,
+		defaultLocale: dto.LocaleDTO{
+			Language: params.Cfg.DefaultLanguage,
+			Region:   params.Cfg.DefaultRegion,
+		},
+		apiURL: params.Cfg.ApiURL,
+		appURL: params.Cfg.AppURL,
+	}
+}
+
+func (s *ReleaseService) ProvideServices(svcs Services) {
+	s.contentPolicy = svcs.ContentPolicyService
+}
+
+// GetUpcoming lists the movies still to watch with a release in the region
+// within the next days, the soonest first. The region defaults to the
+// viewer's.
+func (s *ReleaseService) GetUpcoming(ctx context.Context, userID int32, query dto.UpcomingQueryDTO, viewer dto.ViewerDTO) ([]dto.UpcomingReleaseDTO, error) {
+	viewer, err := s.resolveViewer(ctx, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	region := cmp.Or(query.Region, viewer.Locale.Region)
+	today := time.Now()
+	from := today.Format(time.DateOnly)
+	until := today.AddDate(0, 0, cmp.Or(query.Days, defaultUpcomingDays)).Format(time.DateOnly)
+
+	return s.findReleases(ctx, userID, region, from, until, viewer)
+}
+
+func (s *ReleaseService) GetCalendarFeed(ctx context.Context, userID int32) (dto.CalendarFeedDTO, error) {
+	feed, err := s.feedRepo.FindByUser(ctx, userID)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return dto.CalendarFeedDTO{}, utils.NewNotFoundError("error.calendar.not_found")
+		default:
+			return dto.CalendarFeedDTO{}, err
+		}
+	}
+
+	return dto.CalendarFeedDTO{CreatedAt: feed.CreatedAt}, nil
+}
+
+// CreateCalendarFeed returns a new secret URL for the feed of the user. Only
+// its hash is stored, so the URL can't be shown again, and any previous URL
+// stops working.
+func (s *ReleaseService) CreateCalendarFeed(ctx context.Context, userID int32) (dto.CalendarFeedDTO, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return dto.CalendarFeedDTO{}, err
+	}
+
+	feed, err := s.feedRepo.Save(ctx, model.CalendarFeeds{UserID: userID, TokenHash: hashSecretToken(token)})
+	if err != nil {
+		return dto.CalendarFeedDTO{}, err
+	}
+
+	return dto.CalendarFeedDTO{
+		URL:       fmt.Sprintf("%s/api/calendar/%s.ics", s.apiURL, token),
+		CreatedAt: feed.CreatedAt,
+	}, nil
+}
+
+func (s *ReleaseService) DeleteCalendarFeed(ctx context.Context, userID int32) error {
+	return s.feedRepo.Delete(ctx, userID)
+}
+
+// WriteCalendar writes the feed the token gives access to, in the language and
+// region of its user. Releases of the last days are kept along with the
+// upcoming ones.
+func (s *ReleaseService) WriteCalendar(ctx context.Context, token string, w io.Writer) error {
+	feed, err := s.feedRepo.FindByTokenHash(ctx, hashSecretToken(token))
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return utils.NewNotFoundError("error.calendar.not_found")
+		default:
+			return err
+		}
+	}
+
+	user, err := s.userRepo.FindOne(ctx, feed.UserID)
+	if err != nil {
+		return err
+	}
+
+	var userDTO dto.UserDTO
+	userDTO.FromModel(user)
+
+	viewer, err := s.resolveViewer(ctx, dto.ViewerDTO{UserID: user.ID, Locale: userDTO.Locale()})
+	if err != nil {
+		return err
+	}
+
+	today := time.Now()
+	from := today.AddDate(0, 0, -calendarPastDays).Format(time.DateOnly)
+	releases, err := s.findReleases(ctx, user.ID, viewer.Locale.Region, from, "", viewer)
+	if err != nil {
+		return err
+	}
+
+	return ical.Write(w, buildCalendar(releases, viewer.Locale, s.appURL), today)
+}
+
+// findReleases resolves the tracked movies of the watchlist and keeps their
+// releases in the region between from and until, inclusive; an empty until
+// has no limit. Movies that fail to resolve or are hidden by the viewer's
+// policy are left out.
+func (s *ReleaseService) findReleases(ctx context.Context, userID int32, region string, from string, until string, viewer dto.ViewerDTO) ([]dto.UpcomingReleaseDTO, error) {
+	items, movies, err := loadTrackedMovies(ctx, s.watchListRepo, s.movieRepo, userID, *viewer.Policy)
+	if err != nil {
+		return nil, err
+	}
+
+	releases := buildUpcoming(items, movies, region, from, until, viewer.Locale)
+	nameGenresOf(ctx, s.genreRepo, viewer.Locale, releases, func(release *dto.UpcomingReleaseDTO) *dto.MovieDTO { return &release.Movie })
+
+	return releases, nil
+}
+
+// resolveViewer fills the locale with the defaults and resolves the policy.
+func (s *ReleaseService) resolveViewer(ctx context.Context, viewer dto.ViewerDTO) (dto.ViewerDTO, error) {
+	viewer.Locale = viewer.Locale.WithFallback(s.defaultLocale)
+	return s.contentPolicy.Resolve(ctx, viewer)
+}
+
+// loadTrackedMovies returns the watchlist items whose releases are tracked
+// along with their movies. The movies that fail to resolve or are hidden by
+// the rules are left zero.
+func loadTrackedMovies(ctx context.Context, watchListRepo repositories.IWatchListRepository, movieRepo repositories.IMovieRepository, userID int32, rules dto.ContentPolicyDTO) ([]model.Watchlist, []dto.TMDBMovieDTO, error) {
+	items, _, err := watchListRepo.FindByUser(ctx, userID, dto.WatchListQueryDTO{Status: trackedReleaseStatuses})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	movies := make([]dto.TMDBMovieDTO, len(items))
+	forEachConcurrently(len(items), movieExpandConcurrency, func(i int) {
+		movie, err := getVisibleMovie(ctx, movieRepo, int(items[i].MovieID), rules)
+		if err != nil {
+			var hiddenErr *policy.HiddenError
+			if !errors.As(err, &hiddenErr) {
+				slog.Warn("leaving movie out of the releases", "movie_id", items[i].MovieID, "error", err)
+			}
+			return
+		}
+		movies[i] = movie
+	})
+
+	return items, movies, nil
+}
+
+// buildUpcoming pairs the watchlist items with their movies, those that
+// failed to resolve being zero, and keeps the ones with releases in the
+// region between from and until, ordered by their first such release.
+func buildUpcoming(items []model.Watchlist, movies []dto.TMDBMovieDTO, region string, from string, until string, locale dto.LocaleDTO) []dto.UpcomingReleaseDTO {
+	upcoming := make([]dto.UpcomingReleaseDTO, 0)
+	for i, item := range items {
+		if movies[i].ID == 0 {
+			continue
+		}
+
+		releases := slices.DeleteFunc(movies[i].Releases(region), func(release dto.ReleaseDTO) bool {
+			return release.Date < from || (until != "" && release.Date > until)
+		})
+		if len(releases) == 0 {
+			continue
+		}
+
+		upcoming = append(upcoming, dto.UpcomingReleaseDTO{
+			MovieID:  item.MovieID,
+			Status:   item.Status,
+			Region:   region,
+			Releases: releases,
+			Movie:    mappers.MapFromTMDBToMovieDTO(movies[i], locale),
+		})
+	}
+
+	slices.SortStableFunc(upcoming, func(a, b dto.UpcomingReleaseDTO) int {
+		return cmp.Or(cmp.Compare(a.Releases[0].Date, b.Releases[0].Date), cmp.Compare(a.MovieID, b.MovieID))
+	})
+	return upcoming
+}
+
+// Names of the kinds of release in the calendar events, by language.
+var releaseLabels = map[string]map[string]string{
+	"en": {dto.ReleaseTheatrical: "in theaters", dto.ReleaseDigital: "on digital"},
+	"pt": {dto.ReleaseTheatrical: "nos cinemas", dto.ReleaseDigital: "em digital"},
+}
+
+// buildCalendar turns every release into an all-day event linking to the
+// movie in the web app. Event IDs only depend on the movie, the kind of
+// release and the region, so a postponed release moves instead of repeating.
+func buildCalendar(releases []dto.UpcomingReleaseDTO, locale dto.LocaleDTO, appURL string) ical.Calendar {
+	labels, ok := releaseLabels[locale.LanguageCode()]
+	if !ok {
+		labels = releaseLabels["en"]
+	}
+
+	calendar := ical.Calendar{
+		ProductID: "-//Movie Tracker//Releases//EN",
+		Name:      "Movie Tracker",
+	}
+	for _, upcoming := range releases {
+		for _, release := range upcoming.Releases {
+			date, err := time.Parse(time.DateOnly, release.Date)
+			if err != nil {
+				continue
+			}
+
+			calendar.Events = append(calendar.Events, ical.Event{
+				UID:         fmt.Sprintf("%d-%s-%s@movie-tracker", upcoming.MovieID, release.Type, upcoming.Region),
+				Date:        date,
+				Summary:     fmt.Sprintf("%s (%s)", upcoming.Movie.Title, labels[release.Type]),
+				Description: upcoming.Movie.Description,
+				URL:         fmt.Sprintf("%s/movie/%d", appURL, upcoming.MovieID),
+			})
+		}
+	}
+	return calendar
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCalendarFeedRepository struct {
+	mock.Mock
+}
+
+func (m *MockCalendarFeedRepository) FindByUser(_ context.Context, userID int32) (model.CalendarFeeds, error) {
+	args := m.Called(userID)
+	return args.Get(0).(model.CalendarFeeds), args.Error(1)
+}
+
+func (m *MockCalendarFeedRepository) FindByTokenHash(_ context.Context, tokenHash string) (model.CalendarFeeds, error) {
+	args := m.Called(tokenHash)
+	return args.Get(0).(model.CalendarFeeds), args.Error(1)
+}
+
+func (m *MockCalendarFeedRepository) Save(_ context.Context, feed model.CalendarFeeds) (model.CalendarFeeds, error) {
+	args := m.Called(feed)
+	return args.Get(0).(model.CalendarFeeds), args.Error(1)
+}
+
+func (m *MockCalendarFeedRepository) Delete(_ context.Context, userID int32) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+// releasedIn builds a movie released in Brazil on the dates, by TMDB type.
+func releasedIn(id int, dates map[int]string) dto.TMDBMovieDTO {
+	var releases []dto.ReleaseDateDTO
+	for releaseType, date := range dates {
+		releases = append(releases, dto.ReleaseDateDTO{Type: releaseType, ReleaseDate: date + "T00:00:00.000Z"})
+	}
+
+	return dto.TMDBMovieDTO{
+		ID:           id,
+		Title:        "Movie",
+		ReleaseDates: &dto.ReleaseDatesDTO{Results: []dto.CountryReleaseDatesDTO{{ISO31661: "BR", ReleaseDates: releases}}},
+	}
+}
+
+func TestTMDBMovie_Releases(t *testing.T) {
+	movie := releasedIn(1, map[int]string{
+		dto.ReleaseTypePremiere:          "2026-10-01",
+		dto.ReleaseTypeTheatricalLimited: "2026-11-20",
+		dto.ReleaseTypeTheatrical:        "2026-11-27",
+		dto.ReleaseTypeDigital:           "2026-11-20",
+		dto.ReleaseTypePhysical:          "2026-12-15",
+	})
+
+	assert.Equal(t, []dto.ReleaseDTO{
+		{Type: dto.ReleaseTheatrical, Date: "2026-11-20"},
+		{Type: dto.ReleaseDigital, Date: "2026-11-20"},
+	}, movie.Releases("BR"))
+	assert.Empty(t, movie.Releases("US"))
+	assert.Empty(t, dto.TMDBMovieDTO{ID: 1}.Releases("BR"))
+}
+
+func TestBuildUpcoming(t *testing.T) {
+	items := []model.Watchlist{
+		{MovieID: 1, Status: model.WatchStatus_PlanToWatch},
+		{MovieID: 2, Status: model.WatchStatus_Unwatched},
+		{MovieID: 3, Status: model.WatchStatus_PlanToWatch},
+		{MovieID: 4, Status: model.WatchStatus_Watching},
+		{MovieID: 5, Status: model.WatchStatus_PlanToWatch},
+	}
+	movies := []dto.TMDBMovieDTO{
+		releasedIn(1, map[int]string{dto.ReleaseTypeTheatrical: "2026-12-01", dto.ReleaseTypeDigital: "2027-03-01"}),
+		releasedIn(2, map[int]string{dto.ReleaseTypeTheatrical: "2026-09-01", dto.ReleaseTypeDigital: "2026-11-10"}),
+		releasedIn(3, map[int]string{dto.ReleaseTypeTheatrical: "2026-01-01"}),
+		{},
+		releasedIn(5, map[int]string{dto.ReleaseTypeTheatrical: "2026-11-10"}),
+	}
+
+	upcoming := buildUpcoming(items, movies, "BR", "2026-10-17", "2027-01-31", dto.LocaleDTO{Language: "pt-BR", Region: "BR"})
+
+	if assert.Len(t, upcoming, 3) {
+		assert.Equal(t, int32(2), upcoming[0].MovieID)
+		assert.Equal(t, []dto.ReleaseDTO{{Type: dto.ReleaseDigital, Date: "2026-11-10"}}, upcoming[0].Releases)
+		assert.Equal(t, model.WatchStatus_Unwatched, upcoming[0].Status)
+		assert.Equal(t, int32(5), upcoming[1].MovieID)
+		assert.Equal(t, int32(1), upcoming[2].MovieID)
+		assert.Equal(t, []dto.ReleaseDTO{{Type: dto.ReleaseTheatrical, Date: "2026-12-01"}}, upcoming[2].Releases)
+		assert.Equal(t, "BR", upcoming[2].Region)
+		assert.Equal(t, 1, upcoming[2].Movie.ID)
+	}
+}
+
+func TestBuildUpcoming_NoLimit(t *testing.T) {
+	items := []model.Watchlist{{MovieID: 1}}
+	movies := []dto.TMDBMovieDTO{
+		releasedIn(1, map[int]string{dto.ReleaseTypeTheatrical: "2026-12-01", dto.ReleaseTypeDigital: "2030-03-01"}),
+	}
+
+	upcoming := buildUpcoming(items, movies, "BR", "2026-10-17", "", dto.LocaleDTO{})
+
+	if assert.Len(t, upcoming, 1) {
+		assert.Len(t, upcoming[0].Releases, 2)
+	}
+}
+
+func TestBuildCalendar(t *testing.T) {
+	releases := []dto.UpcomingReleaseDTO{{
+		MovieID: 603,
+		Region:  "BR",
+		Releases: []dto.ReleaseDTO{
+			{Type: dto.ReleaseTheatrical, Date: "2026-11-20"},
+			{Type: dto.ReleaseDigital, Date: "2027-02-01"},
+		},
+		Movie: dto.MovieDTO{ID: 603, Title: "Matrix", Description: "Sinopse"},
+	}}
+
+	calendar := buildCalendar(releases, dto.LocaleDTO{Language: "pt-BR"}, "https://app.example.com")
+
+	if assert.Len(t, calendar.Events, 2) {
+		assert.Equal(t, "603-theatrical-BR@movie-tracker", calendar.Events[0].UID)
+		assert.Equal(t, "Matrix (nos cinemas)", calendar.Events[0].Summary)
+		assert.Equal(t, "2026-11-20", calendar.Events[0].Date.Format("2006-01-02"))
+		assert.Equal(t, "https://app.example.com/movie/603", calendar.Events[0].URL)
+		assert.Equal(t, "603-digital-BR@movie-tracker", calendar.Events[1].UID)
+		assert.Equal(t, "Matrix (em digital)", calendar.Events[1].Summary)
+	}
+
+	calendar = buildCalendar(releases, dto.LocaleDTO{Language: "fr-FR"}, "https://app.example.com")
+	assert.Equal(t, "Matrix (in theaters)", calendar.Events[0].Summary)
+}
+
+func TestReleaseService_WriteCalendar_UnknownToken(t *testing.T) {
+	feedRepo := new(MockCalendarFeedRepository)
+	feedRepo.On("FindByTokenHash", hashSecretToken("unknown")).Return(model.CalendarFeeds{}, qrm.ErrNoRows)
+	service := &ReleaseService{feedRepo: feedRepo}
+
+	var out bytes.Buffer
+	err := service.WriteCalendar(context.Background(), "unknown", &out)
+
+	assert.Equal(t, utils.NewNotFoundError("error.calendar.not_found"), err)
+	assert.Zero(t, out.Len())
+}
+
+func TestReleaseService_CreateCalendarFeed(t *testing.T) {
+	feedRepo := new(MockCalendarFeedRepository)
+	var saved model.CalendarFeeds
+	feedRepo.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(0).(model.CalendarFeeds)
+	}).Return(model.CalendarFeeds{UserID: 7}, nil)
+	service := &ReleaseService{feedRepo: feedRepo, apiURL: "https://api.example.com"}
+
+	feed, err := service.CreateCalendarFeed(context.Background(), 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), saved.UserID)
+	token, ok := strings.CutPrefix(feed.URL, "https://api.example.com/api/calendar/")
+	assert.True(t, ok)
+	token, ok = strings.CutSuffix(token, ".ics")
+	assert.True(t, ok)
+	assert.Equal(t, hashSecretToken(token), saved.TokenHash)
+}
@@ -11,10 +11,24 @@ type IService interface {
 }
 
 type Services struct {
-	AuthService      IAuthService
-	UserService      IUserService
-	MovieService     IMovieService
-	WatchlistService IWatchList
+	AuthService           IAuthService
+	UserService           IUserService
+	MovieService          IMovieService
+	WatchlistService      IWatchList
+	DiaryService          IDiaryService
+	ListService           IListService
+	ImportService         IImportService
+	SessionService        ISessionService
+	AccountService        IAccountService
+	ContentPolicyService  IContentPolicyService
+	ShowService           IShowService
+	ShowProgressService   IShowProgressService
+	PersonService         IPersonService
+	RecommendationService IRecommendationService
+	GenreService          IGenreService
+	StreamingService      IStreamingService
+	ReleaseService        IReleaseService
+	NotificationService   INotificationService
 }
 
 type ServicesParams struct {
@@ -31,16 +45,44 @@ func NewServices(cfg config.ApiConfig, conns connections.Connections, repos repo
 	}
 
 	var svcs = Services{
-		AuthService:      newAuthService(params),
-		UserService:      newUserService(params),
-		MovieService:     newMovieService(params),
-		WatchlistService: newWatchListService(params),
+		AuthService:           newAuthService(params),
+		UserService:           newUserService(params),
+		MovieService:          newMovieService(params),
+		WatchlistService:      newWatchListService(params),
+		DiaryService:          newDiaryService(params),
+		ListService:           newListService(params),
+		ImportService:         newImportService(params),
+		SessionService:        newSessionService(params),
+		AccountService:        newAccountService(params),
+		ContentPolicyService:  newContentPolicyService(params),
+		ShowService:           newShowService(params),
+		ShowProgressService:   newShowProgressService(params),
+		PersonService:         newPersonService(params),
+		RecommendationService: newRecommendationService(params),
+		GenreService:          newGenreService(params),
+		StreamingService:      newStreamingService(params),
+		ReleaseService:        newReleaseService(params),
+		NotificationService:   newNotificationService(params),
 	}
 
 	svcs.AuthService.ProvideServices(svcs)
 	svcs.UserService.ProvideServices(svcs)
 	svcs.MovieService.ProvideServices(svcs)
 	svcs.WatchlistService.ProvideServices(svcs)
+	svcs.DiaryService.ProvideServices(svcs)
+	svcs.ListService.ProvideServices(svcs)
+	svcs.ImportService.ProvideServices(svcs)
+	svcs.SessionService.ProvideServices(svcs)
+	svcs.AccountService.ProvideServices(svcs)
+	svcs.ContentPolicyService.ProvideServices(svcs)
+	svcs.ShowService.ProvideServices(svcs)
+	svcs.ShowProgressService.ProvideServices(svcs)
+	svcs.PersonService.ProvideServices(svcs)
+	svcs.RecommendationService.ProvideServices(svcs)
+	svcs.GenreService.ProvideServices(svcs)
+	svcs.StreamingService.ProvideServices(svcs)
+	svcs.ReleaseService.ProvideServices(svcs)
+	svcs.NotificationService.ProvideServices(svcs)
 
 	return svcs
 }
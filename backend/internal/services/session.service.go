@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+// Number of random bytes in refresh tokens and emailed tokens.
+const secretTokenSize = 32
+
+type ISessionService interface {
+	IService
+	Create(ctx context.Context, userID int32, client dto.SessionClientDTO) (dto.SessionDTO, string, error)
+	Refresh(ctx context.Context, refreshToken string) (dto.SessionDTO, string, error)
+	Validate(ctx context.Context, userID int32, sessionID int32) error
+	GetActive(ctx context.Context, userID int32, currentSessionID int32) ([]dto.SessionDTO, error)
+	Revoke(ctx context.Context, userID int32, sessionID int32) error
+	RevokeAll(ctx context.Context, userID int32) error
+}
+
+type SessionService struct {
+	repo            repositories.ISessionRepository
+	refreshTokenTTL time.Duration
+}
+
+func newSessionService(params ServicesParams) ISessionService {
+	return &SessionService{
+		repo:            params.Repos.SessionRepo,
+		refreshTokenTTL: time.Duration(params.Cfg.RefreshTokenTTL) * time.Minute,
+	}
+}
+
+func (s *SessionService) ProvideServices(services Services) {}
+
+// Create opens a session for the user and returns it along with its refresh
+// token. Only a hash of the token is stored.
+func (s *SessionService) Create(ctx context.Context, userID int32, client dto.SessionClientDTO) (dto.SessionDTO, string, error) {
+	var sessionDTO dto.SessionDTO
+
+	refreshToken, err := newSecretToken()
+	if err != nil {
+		return sessionDTO, "", err
+	}
+
+	session, err := s.repo.Create(ctx, model.Sessions{
+		UserID:    userID,
+		TokenHash: hashSecretToken(refreshToken),
+		UserAgent: nullableClientValue(client.UserAgent),
+		IpAddress: nullableClientValue(client.IPAddress),
+		ExpiresAt: time.Now().Add(s.refreshTokenTTL),
+	})
+	if err != nil {
+		return sessionDTO, "", err
+	}
+
+	sessionDTO.FromModel(session)
+	return sessionDTO, refreshToken, nil
+}
+
+// Refresh exchanges a refresh token for a new one. Every token can be used
+// once; presenting a token that was already exchanged revokes its session,
+// since either the client or an attacker holds a copy.
+func (s *SessionService) Refresh(ctx context.Context, refreshToken string) (dto.SessionDTO, string, error) {
+	var sessionDTO dto.SessionDTO
+
+	newToken, err := newSecretToken()
+	if err != nil {
+		return sessionDTO, "", err
+	}
+
+	tokenHash := hashSecretToken(refreshToken)
+	session, err := s.repo.Rotate(ctx, tokenHash, hashSecretToken(newToken), time.Now().Add(s.refreshTokenTTL))
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			if reused, err := s.repo.RevokeByPreviousToken(ctx, tokenHash); err == nil {
+				slog.Warn("refresh token reused, session revoked", "session_id", reused.ID, "user_id", reused.UserID)
+			}
+			return sessionDTO, "", utils.NewUnauthorizedError("error.session.invalid_refresh_token")
+		default:
+			return sessionDTO, "", err
+		}
+	}
+
+	sessionDTO.FromModel(session)
+	return sessionDTO, newToken, nil
+}
+
+// Validate checks that the session an access token was issued for is still
+// active and belongs to the user.
+func (s *SessionService) Validate(ctx context.Context, userID int32, sessionID int32) error {
+	session, err := s.repo.FindOne(ctx, sessionID)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return utils.NewUnauthorizedError("error.session.not_found")
+		default:
+			return err
+		}
+	}
+
+	if session.UserID != userID || session.RevokedAt != nil || !session.ExpiresAt.After(time.Now()) {
+		return utils.NewUnauthorizedError("error.session.revoked")
+	}
+
+	return nil
+}
+
+func (s *SessionService) GetActive(ctx context.Context, userID int32, currentSessionID int32) ([]dto.SessionDTO, error) {
+	sessions, err := s.repo.FindActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionDTOs := make([]dto.SessionDTO, len(sessions))
+	for i, session := range sessions {
+		sessionDTOs[i].FromModel(session)
+		sessionDTOs[i].Current = session.ID == currentSessionID
+	}
+
+	return sessionDTOs, nil
+}
+
+func (s *SessionService) Revoke(ctx context.Context, userID int32, sessionID int32) error {
+	_, err := s.repo.Revoke(ctx, userID, sessionID)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return utils.NewNotFoundError("error.session.not_found")
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RevokeAll signs the user out of every device.
+func (s *SessionService) RevokeAll(ctx context.Context, userID int32) error {
+	return s.repo.RevokeAllByUser(ctx, userID)
+}
+
+func newSecretToken() (string, error) {
+	token := make([]byte, secretTokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// The tokens are random, so a plain SHA-256 is enough to keep them unusable
+// if the table storing them leaks.
+func hashSecretToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func nullableClientValue(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
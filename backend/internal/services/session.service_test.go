@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) FindOne(_ context.Context, id int32) (model.Sessions, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Sessions), args.Error(1)
+}
+
+func (m *MockSessionRepository) FindActiveByUser(_ context.Context, userID int32) ([]model.Sessions, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]model.Sessions), args.Error(1)
+}
+
+func (m *MockSessionRepository) Create(_ context.Context, session model.Sessions) (model.Sessions, error) {
+	args := m.Called(session)
+	return args.Get(0).(model.Sessions), args.Error(1)
+}
+
+func (m *MockSessionRepository) Rotate(_ context.Context, tokenHash string, newTokenHash string, expiresAt time.Time) (model.Sessions, error) {
+	args := m.Called(tokenHash, newTokenHash, expiresAt)
+	return args.Get(0).(model.Sessions), args.Error(1)
+}
+
+func (m *MockSessionRepository) RevokeByPreviousToken(_ context.Context, tokenHash string) (model.Sessions, error) {
+	args := m.Called(tokenHash)
+	return args.Get(0).(model.Sessions), args.Error(1)
+}
+
+func (m *MockSessionRepository) Revoke(_ context.Context, userID int32, id int32) (model.Sessions, error) {
+	args := m.Called(userID, id)
+	return args.Get(0).(model.Sessions), args.Error(1)
+}
+
+func (m *MockSessionRepository) RevokeAllByUser(_ context.Context, userID int32) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func TestSessionService_Create_StoresTokenHash(t *testing.T) {
+	mockRepo := new(MockSessionRepository)
+	service := &SessionService{repo: mockRepo, refreshTokenTTL: time.Hour}
+
+	var stored model.Sessions
+	mockRepo.On("Create", mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(model.Sessions)
+	}).Return(model.Sessions{ID: 7, UserID: 1}, nil)
+
+	session, refreshToken, err := service.Create(context.Background(), 1, dto.SessionClientDTO{UserAgent: "curl/8.0"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), session.ID)
+	assert.NotEmpty(t, refreshToken)
+	assert.Equal(t, hashSecretToken(refreshToken), stored.TokenHash)
+	assert.NotEqual(t, refreshToken, stored.TokenHash)
+	assert.Equal(t, "curl/8.0", *stored.UserAgent)
+	assert.Nil(t, stored.IpAddress)
+}
+
+func TestSessionService_Refresh_RotatesToken(t *testing.T) {
+	mockRepo := new(MockSessionRepository)
+	service := &SessionService{repo: mockRepo, refreshTokenTTL: time.Hour}
+
+	mockRepo.On("Rotate", hashSecretToken("old-token"), mock.Anything, mock.Anything).
+		Return(model.Sessions{ID: 7, UserID: 1}, nil)
+
+	session, refreshToken, err := service.Refresh(context.Background(), "old-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), session.UserID)
+	assert.NotEqual(t, "old-token", refreshToken)
+	mockRepo.AssertCalled(t, "Rotate", hashSecretToken("old-token"), hashSecretToken(refreshToken), mock.Anything)
+}
+
+func TestSessionService_Refresh_ReusedTokenRevokesSession(t *testing.T) {
+	mockRepo := new(MockSessionRepository)
+	service := &SessionService{repo: mockRepo, refreshTokenTTL: time.Hour}
+
+	mockRepo.On("Rotate", hashSecretToken("stolen-token"), mock.Anything, mock.Anything).
+		Return(model.Sessions{}, qrm.ErrNoRows)
+	mockRepo.On("RevokeByPreviousToken", hashSecretToken("stolen-token")).
+		Return(model.Sessions{ID: 7, UserID: 1}, nil)
+
+	_, _, err := service.Refresh(context.Background(), "stolen-token")
+
+	assertApiError(t, err, http.StatusUnauthorized, "error.session.invalid_refresh_token")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSessionService_Validate(t *testing.T) {
+	mockRepo := new(MockSessionRepository)
+	service := &SessionService{repo: mockRepo}
+
+	revokedAt := time.Now().Add(-time.Minute)
+	mockRepo.On("FindOne", int32(1)).Return(model.Sessions{ID: 1, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	mockRepo.On("FindOne", int32(2)).Return(model.Sessions{ID: 2, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil)
+	mockRepo.On("FindOne", int32(3)).Return(model.Sessions{ID: 3, UserID: 1, ExpiresAt: time.Now().Add(-time.Hour)}, nil)
+	mockRepo.On("FindOne", int32(4)).Return(model.Sessions{}, qrm.ErrNoRows)
+
+	assert.NoError(t, service.Validate(context.Background(), 1, 1))
+	assertApiError(t, service.Validate(context.Background(), 2, 1), http.StatusUnauthorized, "error.session.revoked")
+	assertApiError(t, service.Validate(context.Background(), 1, 2), http.StatusUnauthorized, "error.session.revoked")
+	assertApiError(t, service.Validate(context.Background(), 1, 3), http.StatusUnauthorized, "error.session.revoked")
+	assertApiError(t, service.Validate(context.Background(), 1, 4), http.StatusUnauthorized, "error.session.not_found")
+}
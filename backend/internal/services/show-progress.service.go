@@ -0,0 +1,485 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/mappers"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+// Maximum number of shows resolved at the same time when listing the tracked ones.
+const showExpandConcurrency = 8
+
+// IShowProgressService tracks the shows users follow and the episodes they
+// watched. The status of a show uses the watchlist statuses and follows the
+// episodes marked: it becomes "watching" with the first one and "watched"
+// once every episode of a show that ended was seen. Set by hand, it holds
+// until the next episode is marked.
+type IShowProgressService interface {
+	IService
+	GetTracked(ctx context.Context, userID int32, query dto.ShowProgressQueryDTO, viewer dto.ViewerDTO) ([]dto.ShowProgressDTO, error)
+	GetUpNext(ctx context.Context, userID int32, viewer dto.ViewerDTO) ([]dto.ShowProgressDTO, error)
+	GetProgress(ctx context.Context, userID int32, showID int32, viewer dto.ViewerDTO) (dto.ShowProgressDTO, error)
+	Track(ctx context.Context, userID int32, showID int32, updateDTO dto.ShowProgressUpdateDTO, viewer dto.ViewerDTO) (dto.ShowProgressDTO, error)
+	Untrack(ctx context.Context, userID int32, showID int32) error
+	MarkEpisode(ctx context.Context, userID int32, showID int32, seasonNumber int32, episodeNumber int32, viewer dto.ViewerDTO) (dto.ShowProgressDTO, error)
+	UnmarkEpisode(ctx context.Context, userID int32, showID int32, seasonNumber int32, episodeNumber int32, viewer dto.ViewerDTO) (dto.ShowProgressDTO, error)
+	MarkSeason(ctx context.Context, userID int32, showID int32, seasonNumber int32, viewer dto.ViewerDTO) (dto.ShowProgressDTO, error)
+}
+
+type ShowProgressService struct {
+	repo          repositories.IShowProgressRepository
+	transactor    repositories.ITransactor
+	showRepo      repositories.IShowRepository
+	defaultLocale dto.LocaleDTO
+	contentPolicy IContentPolicyService
+}
+
+func newShowProgressService(params ServicesParams) IShowProgressService {
+	return &ShowProgressService{
+		repo:       params.Repos.ShowProgressRepo,
+		transactor: params.Repos.Transactor,
+		showRepo:   params.Repos.ShowRepo,
+		defaultLocale: dto.LocaleDTO{
+			Language: params.Cfg.DefaultLanguage,
+			Region:   params.Cfg.DefaultRegion,
+		},
+	}
+}
+
+func (s *ShowProgressService) ProvideServices(svcs Services) {
+	s.contentPolicy = svcs.ContentPolicyService
+}
+
+// GetTracked lists the tracked shows with their progress, resolving the
+// shows concurrently. A show that fails to resolve only marks its own item.
+func (s *ShowProgressService) GetTracked(ctx context.Context, userID int32, query dto.ShowProgressQueryDTO, viewer dto.ViewerDTO) ([]dto.ShowProgressDTO, error) {
+	statuses := make([]model.WatchStatus, len(query.Status))
+	for i, status := range query.Status {
+		statuses[i] = model.WatchStatus(status)
+	}
+
+	tracked, err := s.repo.FindByUser(ctx, userID, statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	showIDs := make([]int32, len(tracked))
+	for i, progress := range tracked {
+		showIDs[i] = progress.ShowID
+	}
+
+	episodes, err := s.repo.GetWatchedEpisodes(ctx, userID, showIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	watchedByShow := make(map[int32][]model.WatchedEpisodes)
+	for _, episode := range episodes {
+		watchedByShow[episode.ShowID] = append(watchedByShow[episode.ShowID], episode)
+	}
+
+	if viewer, err = s.resolveViewer(ctx, viewer); err != nil {
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+	progressDTOs := make([]dto.ShowProgressDTO, len(tracked))
+	slots := make(chan struct{}, showExpandConcurrency)
+
+	for i, progress := range tracked {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			show, err := getVisibleShow(ctx, s.showRepo, int(progress.ShowID), *viewer.Policy)
+			progressDTOs[i] = s.buildProgress(ctx, progress, show, err, watchedByShow[progress.ShowID], viewer, false)
+		}()
+	}
+
+	wg.Wait()
+	return progressDTOs, nil
+}
+
+// GetUpNext lists the shows being watched that have an aired episode left,
+// the most recently updated first.
+func (s *ShowProgressService) GetUpNext(ctx context.Context, userID int32, viewer dto.ViewerDTO) ([]dto.ShowProgressDTO, error) {
+	tracked, err := s.GetTracked(ctx, userID, dto.ShowProgressQueryDTO{Status: []string{string(model.WatchStatus_Watching)}}, viewer)
+	if err != nil {
+		return nil, err
+	}
+
+	upNext := make([]dto.ShowProgressDTO, 0, len(tracked))
+	for _, progress := range tracked {
+		if progress.NextEpisode != nil {
+			upNext = append(upNext, progress)
+		}
+	}
+
+	return upNext, nil
+}
+
+// GetProgress returns the progress of a tracked show season by season.
+func (s *ShowProgressService) GetProgress(ctx context.Context, userID int32, showID int32, viewer dto.ViewerDTO) (progressDTO dto.ShowProgressDTO, err error) {
+	progress, err := s.findTracked(ctx, userID, showID)
+	if err != nil {
+		return progressDTO, err
+	}
+
+	if viewer, err = s.resolveViewer(ctx, viewer); err != nil {
+		return progressDTO, err
+	}
+
+	show, err := getVisibleShow(ctx, s.showRepo, int(showID), *viewer.Policy)
+	if err != nil {
+		return progressDTO, err
+	}
+
+	watched, err := s.repo.GetWatchedEpisodes(ctx, userID, []int32{showID})
+	if err != nil {
+		return progressDTO, err
+	}
+
+	return s.buildProgress(ctx, progress, show, nil, watched, viewer, true), nil
+}
+
+// Track starts tracking the show, as planned to watch unless told otherwise,
+// or updates its tracking.
+func (s *ShowProgressService) Track(ctx context.Context, userID int32, showID int32, updateDTO dto.ShowProgressUpdateDTO, viewer dto.ViewerDTO) (progressDTO dto.ShowProgressDTO, err error) {
+	if viewer, err = s.resolveViewer(ctx, viewer); err != nil {
+		return progressDTO, err
+	}
+
+	if _, err = getVisibleShow(ctx, s.showRepo, int(showID), *viewer.Policy); err != nil {
+		return progressDTO, err
+	}
+
+	progress, err := s.findOrNew(ctx, userID, showID)
+	if err != nil {
+		return progressDTO, err
+	}
+
+	progress.Status = utils.Fallback(updateDTO.Status, progress.Status)
+	progress.Favorite = utils.Fallback(updateDTO.Favorite, progress.Favorite)
+	if updateDTO.Rating != nil {
+		progress.Rating = updateDTO.Rating
+	}
+
+	if _, err = s.repo.Save(ctx, progress); err != nil {
+		return progressDTO, err
+	}
+
+	return s.GetProgress(ctx, userID, showID, viewer)
+}
+
+func (s *ShowProgressService) Untrack(ctx context.Context, userID int32, showID int32) error {
+	if _, err := s.findTracked(ctx, userID, showID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, userID, showID)
+}
+
+// MarkEpisode records the episode as watched, tracking the show if needed.
+func (s *ShowProgressService) MarkEpisode(ctx context.Context, userID int32, showID int32, seasonNumber int32, episodeNumber int32, viewer dto.ViewerDTO) (dto.ShowProgressDTO, error) {
+	return s.markEpisodes(ctx, userID, showID, viewer, func(show dto.TMDBShowDTO) ([]episodeKey, error) {
+		season, ok := findSeason(show, int(seasonNumber))
+		if !ok || episodeNumber < 1 || int(episodeNumber) > season.EpisodeCount {
+			return nil, utils.NewNotFoundError("error.show.episode_not_found")
+		}
+		return []episodeKey{{int(seasonNumber), int(episodeNumber)}}, nil
+	})
+}
+
+// MarkSeason records every aired episode of the season as watched, tracking
+// the show if needed.
+func (s *ShowProgressService) MarkSeason(ctx context.Context, userID int32, showID int32, seasonNumber int32, viewer dto.ViewerDTO) (dto.ShowProgressDTO, error) {
+	return s.markEpisodes(ctx, userID, showID, viewer, func(show dto.TMDBShowDTO) ([]episodeKey, error) {
+		season, ok := findSeason(show, int(seasonNumber))
+		if !ok {
+			return nil, utils.NewNotFoundError("error.show.season_not_found")
+		}
+
+		keys := make([]episodeKey, 0, season.EpisodeCount)
+		for episode := 1; episode <= airedEpisodes(show, season); episode++ {
+			keys = append(keys, episodeKey{season.SeasonNumber, episode})
+		}
+		return keys, nil
+	})
+}
+
+func (s *ShowProgressService) UnmarkEpisode(ctx context.Context, userID int32, showID int32, seasonNumber int32, episodeNumber int32, viewer dto.ViewerDTO) (progressDTO dto.ShowProgressDTO, err error) {
+	progress, err := s.findTracked(ctx, userID, showID)
+	if err != nil {
+		return progressDTO, err
+	}
+
+	if viewer, err = s.resolveViewer(ctx, viewer); err != nil {
+		return progressDTO, err
+	}
+
+	show, err := getVisibleShow(ctx, s.showRepo, int(showID), *viewer.Policy)
+	if err != nil {
+		return progressDTO, err
+	}
+
+	// The status is derived from the episodes left, so both change together
+	var watched []model.WatchedEpisodes
+	err = s.transactor.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if err = s.repo.UnmarkWatched(ctx, userID, showID, seasonNumber, episodeNumber); err != nil {
+			return err
+		}
+
+		if watched, err = s.repo.GetWatchedEpisodes(ctx, userID, []int32{showID}); err != nil {
+			return err
+		}
+
+		progress.Status = deriveShowStatus(progress.Status, show, indexWatchedEpisodes(watched))
+		progress, err = s.repo.Save(ctx, progress)
+		return err
+	})
+	if err != nil {
+		return progressDTO, err
+	}
+
+	return s.buildProgress(ctx, progress, show, nil, watched, viewer, true), nil
+}
+
+// markEpisodes records the episodes selected from the show as watched and
+// updates the status of the show to match, in a single transaction. The show
+// is tracked first, as episodes can only be recorded for tracked shows.
+func (s *ShowProgressService) markEpisodes(ctx context.Context, userID int32, showID int32, viewer dto.ViewerDTO, selectEpisodes func(dto.TMDBShowDTO) ([]episodeKey, error)) (progressDTO dto.ShowProgressDTO, err error) {
+	if viewer, err = s.resolveViewer(ctx, viewer); err != nil {
+		return progressDTO, err
+	}
+
+	show, err := getVisibleShow(ctx, s.showRepo, int(showID), *viewer.Policy)
+	if err != nil {
+		return progressDTO, err
+	}
+
+	keys, err := selectEpisodes(show)
+	if err != nil {
+		return progressDTO, err
+	}
+
+	var progress model.ShowProgress
+	var watched []model.WatchedEpisodes
+	err = s.transactor.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		if progress, err = s.findOrNew(ctx, userID, showID); err != nil {
+			return err
+		}
+
+		if watched, err = s.repo.GetWatchedEpisodes(ctx, userID, []int32{showID}); err != nil {
+			return err
+		}
+
+		episodes := make([]model.WatchedEpisodes, len(keys))
+		for i, key := range keys {
+			episodes[i] = model.WatchedEpisodes{
+				ShowID:        showID,
+				UserID:        userID,
+				SeasonNumber:  int32(key.season),
+				EpisodeNumber: int32(key.episode),
+			}
+		}
+		watched = append(watched, episodes...)
+
+		progress.Status = deriveShowStatus(progress.Status, show, indexWatchedEpisodes(watched))
+		if progress, err = s.repo.Save(ctx, progress); err != nil {
+			return err
+		}
+
+		return s.repo.MarkWatched(ctx, episodes)
+	})
+	if err != nil {
+		return progressDTO, err
+	}
+
+	return s.buildProgress(ctx, progress, show, nil, watched, viewer, true), nil
+}
+
+// buildProgress sums up the progress of the show. When the show failed to
+// resolve, the error is reported on the item and the counts are left out.
+func (s *ShowProgressService) buildProgress(ctx context.Context, progress model.ShowProgress, show dto.TMDBShowDTO, showErr error, watched []model.WatchedEpisodes, viewer dto.ViewerDTO, withSeasons bool) dto.ShowProgressDTO {
+	var progressDTO dto.ShowProgressDTO
+	progressDTO.FromModel(progress)
+
+	if showErr != nil {
+		message := "error.show.unavailable"
+		var apiErr *utils.ApiError
+		var hiddenErr *policy.HiddenError
+		if errors.As(showErr, &hiddenErr) {
+			message = hiddenErr.Message
+			progressDTO.HiddenReasons = hiddenErr.Reasons
+		} else if errors.As(showErr, &apiErr) {
+			message = apiErr.Message
+		}
+		progressDTO.ShowError = &message
+		return progressDTO
+	}
+
+	showDTO := mappers.MapFromTMDBToShowDTO(show, viewer.Locale)
+	progressDTO.Show = &showDTO
+
+	seasons, next := summarizeProgress(show, indexWatchedEpisodes(watched))
+	for _, season := range seasons {
+		progressDTO.WatchedEpisodes += season.WatchedEpisodes
+		progressDTO.AiredEpisodes += season.AiredEpisodes
+	}
+	if withSeasons {
+		progressDTO.Seasons = seasons
+	}
+
+	if next != nil {
+		progressDTO.NextEpisode = s.describeEpisode(ctx, show.ID, *next, viewer.Locale)
+	}
+
+	return progressDTO
+}
+
+// describeEpisode looks the episode up in its season, keeping just its
+// numbers when the season can't be fetched.
+func (s *ShowProgressService) describeEpisode(ctx context.Context, showID int, key episodeKey, locale dto.LocaleDTO) *dto.EpisodeDTO {
+	episode := dto.EpisodeDTO{SeasonNumber: key.season, EpisodeNumber: key.episode}
+
+	season, err := s.showRepo.GetSeason(ctx, showID, key.season, locale)
+	if err != nil {
+		return &episode
+	}
+
+	for _, tmdbEpisode := range season.Episodes {
+		if tmdbEpisode.EpisodeNumber == key.episode {
+			episode = mappers.MapFromTMDBToEpisodeDTO(tmdbEpisode)
+			break
+		}
+	}
+	return &episode
+}
+
+func (s *ShowProgressService) findTracked(ctx context.Context, userID int32, showID int32) (model.ShowProgress, error) {
+	progress, err := s.repo.FindOne(ctx, userID, showID)
+	switch err {
+	case nil:
+		return progress, nil
+	case qrm.ErrNoRows:
+		return progress, utils.NewNotFoundError("error.show.not_tracked")
+	default:
+		return progress, err
+	}
+}
+
+// findOrNew returns the tracking of the show, or a new one planned to watch.
+func (s *ShowProgressService) findOrNew(ctx context.Context, userID int32, showID int32) (model.ShowProgress, error) {
+	progress, err := s.repo.FindOne(ctx, userID, showID)
+	if err == qrm.ErrNoRows {
+		return model.ShowProgress{ShowID: showID, UserID: userID, Status: model.WatchStatus_PlanToWatch}, nil
+	}
+	return progress, err
+}
+
+// resolveViewer fills the locale with the defaults and resolves the policy.
+func (s *ShowProgressService) resolveViewer(ctx context.Context, viewer dto.ViewerDTO) (dto.ViewerDTO, error) {
+	viewer.Locale = viewer.Locale.WithFallback(s.defaultLocale)
+	return s.contentPolicy.Resolve(ctx, viewer)
+}
+
+type episodeKey struct {
+	season  int
+	episode int
+}
+
+func indexWatchedEpisodes(episodes []model.WatchedEpisodes) map[episodeKey]model.WatchedEpisodes {
+	index := make(map[episodeKey]model.WatchedEpisodes, len(episodes))
+	for _, episode := range episodes {
+		index[episodeKey{int(episode.SeasonNumber), int(episode.EpisodeNumber)}] = episode
+	}
+	return index
+}
+
+// summarizeProgress counts the watched and aired episodes of every regular
+// season and finds the first aired episode not watched, nil when caught up.
+func summarizeProgress(show dto.TMDBShowDTO, watched map[episodeKey]model.WatchedEpisodes) ([]dto.SeasonProgressDTO, *episodeKey) {
+	var next *episodeKey
+	seasons := make([]dto.SeasonProgressDTO, 0, len(show.Seasons))
+
+	for _, season := range show.Seasons {
+		// Specials are left out, like TMDB does in its episode counts
+		if season.SeasonNumber == 0 {
+			continue
+		}
+
+		progress := dto.SeasonProgressDTO{SeasonNumber: season.SeasonNumber, AiredEpisodes: airedEpisodes(show, season)}
+		for episode := 1; episode <= progress.AiredEpisodes; episode++ {
+			key := episodeKey{season.SeasonNumber, episode}
+			if _, ok := watched[key]; ok {
+				progress.WatchedEpisodes++
+			} else if next == nil {
+				next = &key
+			}
+		}
+		seasons = append(seasons, progress)
+	}
+
+	return seasons, next
+}
+
+// airedEpisodes returns how many episodes of the season aired, given by the
+// last episode that aired. Every episode of specials counts as aired.
+func airedEpisodes(show dto.TMDBShowDTO, season dto.TMDBSeasonSummaryDTO) int {
+	last := show.LastEpisodeToAir
+	switch {
+	case season.SeasonNumber == 0:
+		return season.EpisodeCount
+	case last == nil || season.SeasonNumber > last.SeasonNumber:
+		return 0
+	case season.SeasonNumber == last.SeasonNumber:
+		return min(last.EpisodeNumber, season.EpisodeCount)
+	default:
+		return season.EpisodeCount
+	}
+}
+
+// deriveShowStatus returns the status of the show after the watched
+// episodes changed: watched when every episode of a show that ended was
+// seen, watching when some were, and otherwise the current status, unless it
+// claimed the show was being or had been watched.
+func deriveShowStatus(current model.WatchStatus, show dto.TMDBShowDTO, watched map[episodeKey]model.WatchedEpisodes) model.WatchStatus {
+	seasons, next := summarizeProgress(show, watched)
+
+	watchedCount := 0
+	for _, season := range seasons {
+		watchedCount += season.WatchedEpisodes
+	}
+
+	switch {
+	case watchedCount > 0 && next == nil && show.HasEnded():
+		return model.WatchStatus_Watched
+	case watchedCount > 0:
+		return model.WatchStatus_Watching
+	case current == model.WatchStatus_Watching || current == model.WatchStatus_Watched:
+		return model.WatchStatus_PlanToWatch
+	default:
+		return current
+	}
+}
+
+func findSeason(show dto.TMDBShowDTO, seasonNumber int) (dto.TMDBSeasonSummaryDTO, bool) {
+	for _, season := range show.Seasons {
+		if season.SeasonNumber == seasonNumber {
+			return season, true
+		}
+	}
+	return dto.TMDBSeasonSummaryDTO{}, false
+}
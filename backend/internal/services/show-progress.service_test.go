@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockShowRepository struct {
+	mock.Mock
+}
+
+func (m *MockShowRepository) DiscoverShows(_ context.Context, page int, locale dto.LocaleDTO, rules dto.ContentPolicyDTO) (dto.Pagination[dto.TMDBShowDTO], error) {
+	args := m.Called(page, locale, rules)
+	return args.Get(0).(dto.Pagination[dto.TMDBShowDTO]), args.Error(1)
+}
+
+func (m *MockShowRepository) SearchShows(_ context.Context, query string, page int, locale dto.LocaleDTO) (dto.Pagination[dto.TMDBShowDTO], error) {
+	args := m.Called(query, page, locale)
+	return args.Get(0).(dto.Pagination[dto.TMDBShowDTO]), args.Error(1)
+}
+
+func (m *MockShowRepository) GetShow(_ context.Context, id int) (dto.TMDBShowDTO, error) {
+	args := m.Called(id)
+	return args.Get(0).(dto.TMDBShowDTO), args.Error(1)
+}
+
+func (m *MockShowRepository) GetSeason(_ context.Context, showID int, seasonNumber int, locale dto.LocaleDTO) (dto.TMDBSeasonDTO, error) {
+	args := m.Called(showID, seasonNumber, locale)
+	return args.Get(0).(dto.TMDBSeasonDTO), args.Error(1)
+}
+
+func (m *MockShowRepository) GetEpisode(_ context.Context, showID int, seasonNumber int, episodeNumber int, locale dto.LocaleDTO) (dto.TMDBEpisodeDTO, error) {
+	args := m.Called(showID, seasonNumber, episodeNumber, locale)
+	return args.Get(0).(dto.TMDBEpisodeDTO), args.Error(1)
+}
+
+type MockShowProgressRepository struct {
+	mock.Mock
+}
+
+func (m *MockShowProgressRepository) FindByUser(_ context.Context, userID int32, statuses []model.WatchStatus) ([]model.ShowProgress, error) {
+	args := m.Called(userID, statuses)
+	return args.Get(0).([]model.ShowProgress), args.Error(1)
+}
+
+func (m *MockShowProgressRepository) FindOne(_ context.Context, userID int32, showID int32) (model.ShowProgress, error) {
+	args := m.Called(userID, showID)
+	return args.Get(0).(model.ShowProgress), args.Error(1)
+}
+
+func (m *MockShowProgressRepository) Save(_ context.Context, progress model.ShowProgress) (model.ShowProgress, error) {
+	args := m.Called(progress)
+	return args.Get(0).(model.ShowProgress), args.Error(1)
+}
+
+func (m *MockShowProgressRepository) Delete(_ context.Context, userID int32, showID int32) error {
+	return m.Called(userID, showID).Error(0)
+}
+
+func (m *MockShowProgressRepository) GetWatchedEpisodes(_ context.Context, userID int32, showIDs []int32) ([]model.WatchedEpisodes, error) {
+	args := m.Called(userID, showIDs)
+	return args.Get(0).([]model.WatchedEpisodes), args.Error(1)
+}
+
+func (m *MockShowProgressRepository) MarkWatched(_ context.Context, episodes []model.WatchedEpisodes) error {
+	return m.Called(episodes).Error(0)
+}
+
+func (m *MockShowProgressRepository) UnmarkWatched(_ context.Context, userID int32, showID int32, seasonNumber int32, episodeNumber int32) error {
+	return m.Called(userID, showID, seasonNumber, episodeNumber).Error(0)
+}
+
+// testShow has specials, a finished season and a second season airing its
+// second of three episodes.
+func testShow(status string) dto.TMDBShowDTO {
+	return dto.TMDBShowDTO{
+		ID:     42,
+		Name:   "Test Show",
+		Status: status,
+		Seasons: []dto.TMDBSeasonSummaryDTO{
+			{SeasonNumber: 0, EpisodeCount: 1},
+			{SeasonNumber: 1, EpisodeCount: 2},
+			{SeasonNumber: 2, EpisodeCount: 3},
+		},
+		LastEpisodeToAir: &dto.TMDBEpisodeDTO{SeasonNumber: 2, EpisodeNumber: 2},
+	}
+}
+
+func watchedSet(keys ...episodeKey) map[episodeKey]model.WatchedEpisodes {
+	watched := make(map[episodeKey]model.WatchedEpisodes, len(keys))
+	for _, key := range keys {
+		watched[key] = model.WatchedEpisodes{SeasonNumber: int32(key.season), EpisodeNumber: int32(key.episode)}
+	}
+	return watched
+}
+
+func TestSummarizeProgress_SkipsSpecialsAndUnairedEpisodes(t *testing.T) {
+	seasons, next := summarizeProgress(testShow("Returning Series"), watchedSet(episodeKey{0, 1}, episodeKey{1, 1}))
+
+	assert.Equal(t, []dto.SeasonProgressDTO{
+		{SeasonNumber: 1, WatchedEpisodes: 1, AiredEpisodes: 2},
+		{SeasonNumber: 2, WatchedEpisodes: 0, AiredEpisodes: 2},
+	}, seasons)
+	assert.Equal(t, &episodeKey{1, 2}, next)
+}
+
+func TestDeriveShowStatus(t *testing.T) {
+	allAired := watchedSet(episodeKey{1, 1}, episodeKey{1, 2}, episodeKey{2, 1}, episodeKey{2, 2})
+
+	// Caught up with a show that ended
+	assert.Equal(t, model.WatchStatus_Watched, deriveShowStatus(model.WatchStatus_PlanToWatch, testShow("Ended"), allAired))
+	// Caught up with a show that's still airing
+	assert.Equal(t, model.WatchStatus_Watching, deriveShowStatus(model.WatchStatus_PlanToWatch, testShow("Returning Series"), allAired))
+	// Everything unwatched
+	assert.Equal(t, model.WatchStatus_PlanToWatch, deriveShowStatus(model.WatchStatus_Watched, testShow("Ended"), nil))
+	assert.Equal(t, model.WatchStatus_Unwatched, deriveShowStatus(model.WatchStatus_Unwatched, testShow("Ended"), nil))
+}
+
+func TestShowProgressService_MarkEpisode_TracksShow(t *testing.T) {
+	// Arrange
+	showRepo := new(MockShowRepository)
+	progressRepo := new(MockShowProgressRepository)
+	transactor := &fakeTransactor{}
+	service := &ShowProgressService{repo: progressRepo, transactor: transactor, showRepo: showRepo, contentPolicy: &ContentPolicyService{}}
+
+	show := testShow("Returning Series")
+	episode := model.WatchedEpisodes{ShowID: 42, UserID: 1, SeasonNumber: 1, EpisodeNumber: 1}
+	saved := model.ShowProgress{ShowID: 42, UserID: 1, Status: model.WatchStatus_Watching}
+
+	showRepo.On("GetShow", 42).Return(show, nil)
+	showRepo.On("GetSeason", 42, 1, dto.LocaleDTO{}).Return(dto.TMDBSeasonDTO{}, errors.New("upstream down"))
+	progressRepo.On("FindOne", int32(1), int32(42)).Return(model.ShowProgress{}, qrm.ErrNoRows)
+	progressRepo.On("GetWatchedEpisodes", int32(1), []int32{42}).Return([]model.WatchedEpisodes{}, nil)
+	progressRepo.On("Save", saved).Return(saved, nil)
+	progressRepo.On("MarkWatched", []model.WatchedEpisodes{episode}).Return(nil)
+
+	// Act
+	progress, err := service.MarkEpisode(context.Background(), 1, 42, 1, 1, dto.ViewerDTO{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, model.WatchStatus_Watching, progress.Status)
+	assert.Equal(t, 1, progress.WatchedEpisodes)
+	assert.Equal(t, 4, progress.AiredEpisodes)
+	if assert.NotNil(t, progress.NextEpisode) {
+		assert.Equal(t, 1, progress.NextEpisode.SeasonNumber)
+		assert.Equal(t, 2, progress.NextEpisode.EpisodeNumber)
+	}
+	assert.Equal(t, 1, transactor.calls)
+	assert.False(t, transactor.rolledBack)
+
+	showRepo.AssertExpectations(t)
+	progressRepo.AssertExpectations(t)
+}
+
+func TestShowProgressService_MarkEpisode_RollsBackWhenEpisodesFail(t *testing.T) {
+	// Arrange
+	showRepo := new(MockShowRepository)
+	progressRepo := new(MockShowProgressRepository)
+	transactor := &fakeTransactor{}
+	service := &ShowProgressService{repo: progressRepo, transactor: transactor, showRepo: showRepo, contentPolicy: &ContentPolicyService{}}
+
+	showRepo.On("GetShow", 42).Return(testShow("Returning Series"), nil)
+	progressRepo.On("FindOne", int32(1), int32(42)).Return(model.ShowProgress{}, qrm.ErrNoRows)
+	progressRepo.On("GetWatchedEpisodes", int32(1), []int32{42}).Return([]model.WatchedEpisodes{}, nil)
+	progressRepo.On("Save", mock.Anything).Return(model.ShowProgress{ShowID: 42, UserID: 1, Status: model.WatchStatus_Watching}, nil)
+	progressRepo.On("MarkWatched", mock.Anything).Return(errors.New("connection reset"))
+
+	// Act
+	_, err := service.MarkEpisode(context.Background(), 1, 42, 1, 1, dto.ViewerDTO{})
+
+	// Assert
+	assert.EqualError(t, err, "connection reset")
+	// The show isn't left tracked as watching without the episode
+	assert.True(t, transactor.rolledBack)
+}
+
+func TestShowProgressService_UnmarkEpisode(t *testing.T) {
+	// Arrange
+	showRepo := new(MockShowRepository)
+	progressRepo := new(MockShowProgressRepository)
+	transactor := &fakeTransactor{}
+	service := &ShowProgressService{repo: progressRepo, transactor: transactor, showRepo: showRepo, contentPolicy: &ContentPolicyService{}}
+
+	tracked := model.ShowProgress{ShowID: 42, UserID: 1, Status: model.WatchStatus_Watching}
+	saved := model.ShowProgress{ShowID: 42, UserID: 1, Status: model.WatchStatus_PlanToWatch}
+
+	showRepo.On("GetShow", 42).Return(testShow("Returning Series"), nil)
+	showRepo.On("GetSeason", 42, 1, dto.LocaleDTO{}).Return(dto.TMDBSeasonDTO{}, errors.New("upstream down"))
+	progressRepo.On("FindOne", int32(1), int32(42)).Return(tracked, nil)
+	progressRepo.On("UnmarkWatched", int32(1), int32(42), int32(1), int32(1)).Return(nil)
+	progressRepo.On("GetWatchedEpisodes", int32(1), []int32{42}).Return([]model.WatchedEpisodes{}, nil)
+	progressRepo.On("Save", saved).Return(saved, nil)
+
+	// Act
+	progress, err := service.UnmarkEpisode(context.Background(), 1, 42, 1, 1, dto.ViewerDTO{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, model.WatchStatus_PlanToWatch, progress.Status)
+	assert.Equal(t, 1, transactor.calls)
+	assert.False(t, transactor.rolledBack)
+	progressRepo.AssertExpectations(t)
+}
+
+func TestShowProgressService_UnmarkEpisode_RollsBackWhenStatusFails(t *testing.T) {
+	// Arrange
+	showRepo := new(MockShowRepository)
+	progressRepo := new(MockShowProgressRepository)
+	transactor := &fakeTransactor{}
+	service := &ShowProgressService{repo: progressRepo, transactor: transactor, showRepo: showRepo, contentPolicy: &ContentPolicyService{}}
+
+	showRepo.On("GetShow", 42).Return(testShow("Returning Series"), nil)
+	progressRepo.On("FindOne", int32(1), int32(42)).Return(model.ShowProgress{ShowID: 42, UserID: 1, Status: model.WatchStatus_Watching}, nil)
+	progressRepo.On("UnmarkWatched", int32(1), int32(42), int32(1), int32(1)).Return(nil)
+	progressRepo.On("GetWatchedEpisodes", int32(1), []int32{42}).Return([]model.WatchedEpisodes{}, nil)
+	progressRepo.On("Save", mock.Anything).Return(model.ShowProgress{}, errors.New("connection reset"))
+
+	// Act
+	_, err := service.UnmarkEpisode(context.Background(), 1, 42, 1, 1, dto.ViewerDTO{})
+
+	// Assert
+	assert.EqualError(t, err, "connection reset")
+	assert.True(t, transactor.rolledBack)
+}
+
+func TestShowProgressService_MarkEpisode_UnknownSeason(t *testing.T) {
+	showRepo := new(MockShowRepository)
+	service := &ShowProgressService{repo: new(MockShowProgressRepository), transactor: &fakeTransactor{}, showRepo: showRepo, contentPolicy: &ContentPolicyService{}}
+
+	showRepo.On("GetShow", 42).Return(testShow("Returning Series"), nil)
+
+	_, err := service.MarkEpisode(context.Background(), 1, 42, 3, 1, dto.ViewerDTO{})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/mappers"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+)
+
+// IShowService serves the TV catalog translated to the viewer's locale and
+// filtered by their content policy. Seasons and episodes of a hidden show are
+// hidden along with it.
+type IShowService interface {
+	IService
+	DiscoverShows(ctx context.Context, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (dto.CursorPagination[dto.ShowDTO], error)
+	SearchShows(ctx context.Context, query string, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (dto.CursorPagination[dto.ShowDTO], error)
+	GetByID(ctx context.Context, id int, viewer dto.ViewerDTO) (dto.ShowDTO, error)
+	GetSeason(ctx context.Context, showID int, seasonNumber int, viewer dto.ViewerDTO) (dto.SeasonDTO, error)
+	GetEpisode(ctx context.Context, showID int, seasonNumber int, episodeNumber int, viewer dto.ViewerDTO) (dto.EpisodeDTO, error)
+}
+
+type ShowService struct {
+	showRepo      repositories.IShowRepository
+	progressRepo  repositories.IShowProgressRepository
+	defaultLocale dto.LocaleDTO
+	contentPolicy IContentPolicyService
+}
+
+func newShowService(params ServicesParams) IShowService {
+	return &ShowService{
+		showRepo:     params.Repos.ShowRepo,
+		progressRepo: params.Repos.ShowProgressRepo,
+		defaultLocale: dto.LocaleDTO{
+			Language: params.Cfg.DefaultLanguage,
+			Region:   params.Cfg.DefaultRegion,
+		},
+	}
+}
+
+func (s *ShowService) ProvideServices(svcs Services) {
+	s.contentPolicy = svcs.ContentPolicyService
+}
+
+func (s *ShowService) DiscoverShows(ctx context.Context, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (shows dto.CursorPagination[dto.ShowDTO], err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
+	if err != nil {
+		return shows, err
+	}
+
+	tmdbShows, err := paginateShows(func(upstreamPage int) (dto.Pagination[dto.TMDBShowDTO], error) {
+		return s.showRepo.DiscoverShows(ctx, upstreamPage, viewer.Locale, *viewer.Policy)
+	}, *viewer.Policy, page)
+	if err != nil {
+		return shows, err
+	}
+
+	return mapShowPage(tmdbShows, viewer.Locale), nil
+}
+
+func (s *ShowService) SearchShows(ctx context.Context, query string, page dto.MoviePageQueryDTO, viewer dto.ViewerDTO) (shows dto.CursorPagination[dto.ShowDTO], err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
+	if err != nil {
+		return shows, err
+	}
+
+	tmdbShows, err := paginateShows(func(upstreamPage int) (dto.Pagination[dto.TMDBShowDTO], error) {
+		return s.showRepo.SearchShows(ctx, query, upstreamPage, viewer.Locale)
+	}, *viewer.Policy, page)
+	if err != nil {
+		return shows, err
+	}
+
+	return mapShowPage(tmdbShows, viewer.Locale), nil
+}
+
+// GetByID returns the show, or a policy.HiddenError explaining why the
+// viewer's policy hides it.
+func (s *ShowService) GetByID(ctx context.Context, id int, viewer dto.ViewerDTO) (show dto.ShowDTO, err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
+	if err != nil {
+		return show, err
+	}
+
+	tmdbShow, err := getVisibleShow(ctx, s.showRepo, id, *viewer.Policy)
+	if err != nil {
+		return show, err
+	}
+
+	return mappers.MapFromTMDBToShowDTO(tmdbShow, viewer.Locale), nil
+}
+
+// GetSeason returns the season with its episodes, marking the ones the
+// viewer watched.
+func (s *ShowService) GetSeason(ctx context.Context, showID int, seasonNumber int, viewer dto.ViewerDTO) (season dto.SeasonDTO, err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
+	if err != nil {
+		return season, err
+	}
+
+	if _, err = getVisibleShow(ctx, s.showRepo, showID, *viewer.Policy); err != nil {
+		return season, err
+	}
+
+	tmdbSeason, err := s.showRepo.GetSeason(ctx, showID, seasonNumber, viewer.Locale)
+	if err != nil {
+		return season, err
+	}
+
+	season = mappers.MapFromTMDBToSeasonDTO(showID, tmdbSeason)
+
+	watched, err := s.watchedEpisodes(ctx, viewer.UserID, showID)
+	if err != nil {
+		return season, err
+	}
+	for i, episode := range season.Episodes {
+		if watchedAt, ok := watched[episodeKey{episode.SeasonNumber, episode.EpisodeNumber}]; ok {
+			season.Episodes[i].WatchedAt = &watchedAt.WatchedAt
+		}
+	}
+
+	return season, nil
+}
+
+func (s *ShowService) GetEpisode(ctx context.Context, showID int, seasonNumber int, episodeNumber int, viewer dto.ViewerDTO) (episode dto.EpisodeDTO, err error) {
+	viewer, err = s.resolveViewer(ctx, viewer)
+	if err != nil {
+		return episode, err
+	}
+
+	if _, err = getVisibleShow(ctx, s.showRepo, showID, *viewer.Policy); err != nil {
+		return episode, err
+	}
+
+	tmdbEpisode, err := s.showRepo.GetEpisode(ctx, showID, seasonNumber, episodeNumber, viewer.Locale)
+	if err != nil {
+		return episode, err
+	}
+
+	episode = mappers.MapFromTMDBToEpisodeDTO(tmdbEpisode)
+
+	watched, err := s.watchedEpisodes(ctx, viewer.UserID, showID)
+	if err != nil {
+		return episode, err
+	}
+	if watchedAt, ok := watched[episodeKey{seasonNumber, episodeNumber}]; ok {
+		episode.WatchedAt = &watchedAt.WatchedAt
+	}
+
+	return episode, nil
+}
+
+// watchedEpisodes returns the episodes of the show the user watched, none
+// for anonymous viewers.
+func (s *ShowService) watchedEpisodes(ctx context.Context, userID int32, showID int) (map[episodeKey]model.WatchedEpisodes, error) {
+	if userID == 0 {
+		return nil, nil
+	}
+
+	episodes, err := s.progressRepo.GetWatchedEpisodes(ctx, userID, []int32{int32(showID)})
+	if err != nil {
+		return nil, err
+	}
+
+	return indexWatchedEpisodes(episodes), nil
+}
+
+// resolveViewer fills the locale with the defaults and resolves the policy.
+func (s *ShowService) resolveViewer(ctx context.Context, viewer dto.ViewerDTO) (dto.ViewerDTO, error) {
+	viewer.Locale = viewer.Locale.WithFallback(s.defaultLocale)
+	return s.contentPolicy.Resolve(ctx, viewer)
+}
+
+// getVisibleShow fetches the show, or a policy.HiddenError when the rules
+// hide it.
+func getVisibleShow(ctx context.Context, repo repositories.IShowRepository, id int, rules dto.ContentPolicyDTO) (dto.TMDBShowDTO, error) {
+	show, err := repo.GetShow(ctx, id)
+	if err != nil {
+		return show, err
+	}
+
+	if reasons := policy.EvaluateShow(rules, show); len(reasons) > 0 {
+		return show, policy.NewHiddenShowError(reasons)
+	}
+
+	return show, nil
+}
+
+func mapShowPage(tmdbShows dto.CursorPagination[dto.TMDBShowDTO], locale dto.LocaleDTO) dto.CursorPagination[dto.ShowDTO] {
+	return dto.CursorPagination[dto.ShowDTO]{
+		Results:         mappers.MapFromTMDBToShowDTOs(tmdbShows.Results, locale),
+		Page:            tmdbShows.Page,
+		PageSize:        tmdbShows.PageSize,
+		NextCursor:      tmdbShows.NextCursor,
+		TotalPages:      tmdbShows.TotalPages,
+		TotalResults:    tmdbShows.TotalResults,
+		TotalsEstimated: tmdbShows.TotalsEstimated,
+	}
+}
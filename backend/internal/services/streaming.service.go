@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+// IStreamingService serves the streaming services available in each country
+// and the ones each user is subscribed to.
+type IStreamingService interface {
+	IService
+	GetProviders(ctx context.Context, country string) ([]dto.WatchProviderDTO, error)
+	GetSubscription(ctx context.Context, userID int32) (dto.StreamingSubscriptionDTO, error)
+	SaveSubscription(ctx context.Context, userID int32, subscription dto.StreamingSubscriptionDTO) (dto.StreamingSubscriptionDTO, error)
+	DeleteSubscription(ctx context.Context, userID int32) error
+}
+
+type StreamingService struct {
+	providerRepo     repositories.IWatchProviderRepository
+	subscriptionRepo repositories.IStreamingSubscriptionRepository
+	defaultCountry   string
+}
+
+func newStreamingService(params ServicesParams) IStreamingService {
+	return &StreamingService{
+		providerRepo:     params.Repos.WatchProviderRepo,
+		subscriptionRepo: params.Repos.SubscriptionRepo,
+		defaultCountry:   params.Cfg.DefaultRegion,
+	}
+}
+
+func (s *StreamingService) ProvideServices(svcs Services) {}
+
+// GetProviders lists the providers movies are available on in the country,
+// or in the default region when none is given.
+func (s *StreamingService) GetProviders(ctx context.Context, country string) ([]dto.WatchProviderDTO, error) {
+	return s.providerRepo.GetMovieProviders(ctx, utils.FallbackZero(country, s.defaultCountry))
+}
+
+// GetSubscription returns the streaming services of the user, empty when they
+// have none set up.
+func (s *StreamingService) GetSubscription(ctx context.Context, userID int32) (dto.StreamingSubscriptionDTO, error) {
+	subscription := dto.StreamingSubscriptionDTO{ProviderIDs: []int{}}
+
+	saved, err := s.subscriptionRepo.FindByUser(ctx, userID)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return subscription, nil
+		default:
+			return subscription, err
+		}
+	}
+
+	err = subscription.FromModel(saved)
+	return subscription, err
+}
+
+// SaveSubscription replaces the streaming services of the user. Every
+// provider must be available in the country.
+func (s *StreamingService) SaveSubscription(ctx context.Context, userID int32, subscription dto.StreamingSubscriptionDTO) (dto.StreamingSubscriptionDTO, error) {
+	providers, err := s.providerRepo.GetMovieProviders(ctx, subscription.Country)
+	if err != nil {
+		return dto.StreamingSubscriptionDTO{}, err
+	}
+
+	providerIDs := append(make([]int, 0, len(subscription.ProviderIDs)), subscription.ProviderIDs...)
+	slices.Sort(providerIDs)
+	providerIDs = slices.Compact(providerIDs)
+	for _, id := range providerIDs {
+		known := slices.ContainsFunc(providers, func(provider dto.WatchProviderDTO) bool { return provider.ProviderID == id })
+		if !known {
+			return dto.StreamingSubscriptionDTO{}, utils.NewValidationError("error.streaming.unknown_provider", fmt.Errorf("provider %d is not available in %s", id, subscription.Country))
+		}
+	}
+
+	encoded, err := json.Marshal(providerIDs)
+	if err != nil {
+		return dto.StreamingSubscriptionDTO{}, err
+	}
+
+	saved, err := s.subscriptionRepo.Save(ctx, model.StreamingSubscriptions{
+		UserID:      userID,
+		Country:     subscription.Country,
+		ProviderIds: string(encoded),
+	})
+	if err != nil {
+		return dto.StreamingSubscriptionDTO{}, err
+	}
+
+	var savedSubscription dto.StreamingSubscriptionDTO
+	err = savedSubscription.FromModel(saved)
+	return savedSubscription, err
+}
+
+func (s *StreamingService) DeleteSubscription(ctx context.Context, userID int32) error {
+	return s.subscriptionRepo.Delete(ctx, userID)
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockWatchProviderRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatchProviderRepository) GetMovieProviders(_ context.Context, country string) ([]dto.WatchProviderDTO, error) {
+	args := m.Called(country)
+	return args.Get(0).([]dto.WatchProviderDTO), args.Error(1)
+}
+
+type MockStreamingSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockStreamingSubscriptionRepository) FindByUser(_ context.Context, userID int32) (model.StreamingSubscriptions, error) {
+	args := m.Called(userID)
+	return args.Get(0).(model.StreamingSubscriptions), args.Error(1)
+}
+
+func (m *MockStreamingSubscriptionRepository) Save(_ context.Context, subscription model.StreamingSubscriptions) (model.StreamingSubscriptions, error) {
+	args := m.Called(subscription)
+	return args.Get(0).(model.StreamingSubscriptions), args.Error(1)
+}
+
+func (m *MockStreamingSubscriptionRepository) Delete(_ context.Context, userID int32) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+// streamsOn builds a movie streaming in Brazil on the providers.
+func streamsOn(id int, providerIDs ...int) dto.TMDBMovieDTO {
+	availability := dto.WatchAvailabilityDTO{Link: "https://www.themoviedb.org/movie/watch"}
+	for _, providerID := range providerIDs {
+		availability.Flatrate = append(availability.Flatrate, dto.WatchProviderDTO{ProviderID: providerID})
+	}
+	availability.Rent = []dto.WatchProviderDTO{{ProviderID: 2}}
+
+	return dto.TMDBMovieDTO{
+		ID:             id,
+		WatchProviders: &dto.WatchProvidersDTO{Results: map[string]dto.WatchAvailabilityDTO{"BR": availability}},
+	}
+}
+
+func TestWatchAvailability_StreamingOn(t *testing.T) {
+	availability := dto.WatchAvailabilityDTO{
+		Flatrate: []dto.WatchProviderDTO{{ProviderID: 8}, {ProviderID: 119}},
+		Ads:      []dto.WatchProviderDTO{{ProviderID: 8}, {ProviderID: 300}},
+		Rent:     []dto.WatchProviderDTO{{ProviderID: 2}},
+	}
+
+	providers := availability.StreamingOn([]int{2, 8, 300})
+
+	assert.Equal(t, []dto.WatchProviderDTO{{ProviderID: 8}, {ProviderID: 300}}, providers)
+	assert.Empty(t, availability.StreamingOn([]int{2}))
+}
+
+func TestStreamingService_GetSubscription_NoneSetUp(t *testing.T) {
+	subscriptionRepo := new(MockStreamingSubscriptionRepository)
+	subscriptionRepo.On("FindByUser", int32(1)).Return(model.StreamingSubscriptions{}, qrm.ErrNoRows)
+	service := &StreamingService{subscriptionRepo: subscriptionRepo}
+
+	subscription, err := service.GetSubscription(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.True(t, subscription.IsEmpty())
+	assert.Equal(t, []int{}, subscription.ProviderIDs)
+}
+
+func TestStreamingService_SaveSubscription(t *testing.T) {
+	providerRepo := new(MockWatchProviderRepository)
+	providerRepo.On("GetMovieProviders", "BR").Return([]dto.WatchProviderDTO{{ProviderID: 8}, {ProviderID: 119}}, nil)
+	subscriptionRepo := new(MockStreamingSubscriptionRepository)
+	subscriptionRepo.On("Save", model.StreamingSubscriptions{UserID: 1, Country: "BR", ProviderIds: "[8,119]"}).
+		Return(model.StreamingSubscriptions{UserID: 1, Country: "BR", ProviderIds: "[8, 119]"}, nil)
+	service := &StreamingService{providerRepo: providerRepo, subscriptionRepo: subscriptionRepo}
+
+	t.Run("saves the providers sorted once each", func(t *testing.T) {
+		subscription, err := service.SaveSubscription(context.Background(), 1, dto.StreamingSubscriptionDTO{Country: "BR", ProviderIDs: []int{119, 8, 119}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.StreamingSubscriptionDTO{Country: "BR", ProviderIDs: []int{8, 119}}, subscription)
+	})
+
+	t.Run("rejects providers not in the country", func(t *testing.T) {
+		_, err := service.SaveSubscription(context.Background(), 1, dto.StreamingSubscriptionDTO{Country: "BR", ProviderIDs: []int{8, 9}})
+
+		var validationErr *utils.ValidationError
+		if assert.ErrorAs(t, err, &validationErr) {
+			assert.Equal(t, "error.streaming.unknown_provider", validationErr.Message)
+		}
+	})
+
+	subscriptionRepo.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestWatchListService_Find_AvailableOnMine(t *testing.T) {
+	subscriptionRepo := new(MockStreamingSubscriptionRepository)
+	subscriptionRepo.On("FindByUser", int32(1)).Return(model.StreamingSubscriptions{UserID: 1, Country: "BR", ProviderIds: "[8, 337]"}, nil)
+	watchListRepo := new(MockWatchListRepository)
+	watchListRepo.On("FindByUser", int32(1), dto.WatchListQueryDTO{Status: []string{"plan to watch"}, AvailableOn: "mine"}).Return([]model.Watchlist{
+		{UserID: 1, MovieID: 1},
+		{UserID: 1, MovieID: 2},
+		{UserID: 1, MovieID: 3},
+		{UserID: 1, MovieID: 4},
+		{UserID: 1, MovieID: 5},
+	}, int64(5), nil)
+	movieRepo := new(MockMovieRepository)
+	movieRepo.On("GetByID", 1).Return(streamsOn(1, 8, 119), nil)
+	movieRepo.On("GetByID", 2).Return(streamsOn(2, 119), nil)
+	movieRepo.On("GetByID", 3).Return(dto.TMDBMovieDTO{}, errors.New("upstream down"))
+	movieRepo.On("GetByID", 4).Return(streamsOn(4, 337), nil)
+	movieRepo.On("GetByID", 5).Return(dto.TMDBMovieDTO{ID: 5}, nil)
+	service := &WatchListService{
+		repo:             watchListRepo,
+		movieRepo:        movieRepo,
+		streamingService: &StreamingService{subscriptionRepo: subscriptionRepo},
+	}
+
+	page, err := service.Find(context.Background(), 1, dto.WatchListQueryDTO{AvailableOn: "mine", Page: 2, PageSize: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, page.TotalResults)
+	assert.Equal(t, 2, page.TotalPages)
+	assert.Equal(t, 2, page.Page)
+	if assert.Len(t, page.Results, 1) {
+		assert.Equal(t, int32(4), page.Results[0].MovieID)
+		assert.Equal(t, []dto.WatchProviderDTO{{ProviderID: 337}}, page.Results[0].StreamingOn)
+	}
+	movieRepo.AssertExpectations(t)
+}
+
+func TestWatchListService_Find_AvailableOnMine_NoServices(t *testing.T) {
+	subscriptionRepo := new(MockStreamingSubscriptionRepository)
+	subscriptionRepo.On("FindByUser", int32(1)).Return(model.StreamingSubscriptions{}, qrm.ErrNoRows)
+	service := &WatchListService{streamingService: &StreamingService{subscriptionRepo: subscriptionRepo}}
+
+	_, err := service.Find(context.Background(), 1, dto.WatchListQueryDTO{AvailableOn: "mine"})
+
+	var apiErr *utils.ApiError
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, "error.watchlist.no_streaming_services", apiErr.Message)
+	}
+}
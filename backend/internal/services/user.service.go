@@ -1,43 +1,59 @@
 package services
 
 import (
+	"context"
+	"strings"
+
 	"github.com/go-jet/jet/v2/qrm"
 	"github.com/movie-tracker/MovieTracker/internal/repositories"
 	"github.com/movie-tracker/MovieTracker/internal/services/dto"
 	"github.com/movie-tracker/MovieTracker/internal/utils"
 	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/text/language"
 )
 
 const COST = 14
 
 type IUserService interface {
 	IService
-	FindAll() ([]dto.UserDTO, error)
-	FindByEmail(email string) (dto.UserDTO, error)
-	FindByUsername(username string) (dto.UserDTO, error)
-	Create(dto.UserCreateDTO) (dto.UserDTO, error)
-	ValidatePassword(username string, password string) error
+	FindAll(ctx context.Context) ([]dto.UserDTO, error)
+	FindByEmail(ctx context.Context, email string) (dto.UserDTO, error)
+	FindByUsername(ctx context.Context, username string) (dto.UserDTO, error)
+	FindByID(ctx context.Context, id int32) (dto.UserDTO, error)
+	Create(ctx context.Context, userCreateDTO dto.UserCreateDTO) (dto.UserDTO, error)
+	ValidatePassword(ctx context.Context, username string, password string) error
+	ChangePassword(ctx context.Context, userID int32, currentPassword string, newPassword string) error
+	SetPassword(ctx context.Context, userID int32, password string) error
+	MarkEmailVerified(ctx context.Context, userID int32) (dto.UserDTO, error)
+	UpdatePreferences(ctx context.Context, userID int32, preferences dto.UserPreferencesDTO) (dto.UserDTO, error)
+	Follow(ctx context.Context, followerID int32, followedID int32) error
+	Unfollow(ctx context.Context, followerID int32, followedID int32) error
+	GetFollowRequests(ctx context.Context, userID int32) ([]dto.FollowRequestDTO, error)
+	AcceptFollower(ctx context.Context, userID int32, followerID int32) error
+	RemoveFollower(ctx context.Context, userID int32, followerID int32) error
 }
 
 type UserService struct {
-	userRepo repositories.IUserRepository
+	userRepo   repositories.IUserRepository
+	followRepo repositories.IUserFollowRepository
 }
 
 func newUserService(params ServicesParams) IUserService {
 	repos := params.Repos
 
 	return UserService{
-		userRepo: repos.UserRepo,
+		userRepo:   repos.UserRepo,
+		followRepo: repos.UserFollowRepo,
 	}
 }
 
 func (s UserService) ProvideServices(services Services) {}
 
-func (s UserService) FindAll() ([]dto.UserDTO, error) {
+func (s UserService) FindAll(ctx context.Context) ([]dto.UserDTO, error) {
 	var err error
 	var userDTOs = make([]dto.UserDTO, 0)
 
-	users, err := s.userRepo.FindAll()
+	users, err := s.userRepo.FindAll(ctx)
 	if err != nil {
 		return userDTOs, err
 	}
@@ -53,11 +69,11 @@ func (s UserService) FindAll() ([]dto.UserDTO, error) {
 	return userDTOs, err
 }
 
-func (s UserService) FindByEmail(email string) (dto.UserDTO, error) {
+func (s UserService) FindByEmail(ctx context.Context, email string) (dto.UserDTO, error) {
 	var err error
 	var userDTO dto.UserDTO
 
-	user, err := s.userRepo.FindByEmail(email)
+	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		switch err {
 		case qrm.ErrNoRows:
@@ -71,11 +87,11 @@ func (s UserService) FindByEmail(email string) (dto.UserDTO, error) {
 	return userDTO, err
 }
 
-func (s UserService) FindByUsername(username string) (dto.UserDTO, error) {
+func (s UserService) FindByUsername(ctx context.Context, username string) (dto.UserDTO, error) {
 	var err error
 	var userDTO dto.UserDTO
 
-	user, err := s.userRepo.FindByUsername(username)
+	user, err := s.userRepo.FindByUsername(ctx, username)
 	if err != nil {
 		switch err {
 		case qrm.ErrNoRows:
@@ -89,10 +105,28 @@ func (s UserService) FindByUsername(username string) (dto.UserDTO, error) {
 	return userDTO, err
 }
 
-func (s UserService) Create(userDTO dto.UserCreateDTO) (createdUserDTO dto.UserDTO, err error) {
+func (s UserService) FindByID(ctx context.Context, id int32) (dto.UserDTO, error) {
+	var err error
+	var userDTO dto.UserDTO
+
+	user, err := s.userRepo.FindOne(ctx, id)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return userDTO, utils.NewNotFoundError("error.user.not_found")
+		default:
+			return userDTO, err
+		}
+	}
+
+	userDTO.FromModel(user)
+	return userDTO, err
+}
+
+func (s UserService) Create(ctx context.Context, userDTO dto.UserCreateDTO) (createdUserDTO dto.UserDTO, err error) {
 	user := userDTO.ToModel()
 
-	createdUser, err := s.userRepo.Create(user)
+	createdUser, err := s.userRepo.Create(ctx, user)
 
 	if err != nil {
 		return
@@ -103,9 +137,9 @@ func (s UserService) Create(userDTO dto.UserCreateDTO) (createdUserDTO dto.UserD
 	return
 }
 
-func (s UserService) ValidatePassword(username string, password string) error {
+func (s UserService) ValidatePassword(ctx context.Context, username string, password string) error {
 	var err error
-	user, err := s.userRepo.FindByUsername(username)
+	user, err := s.userRepo.FindByUsername(ctx, username)
 
 	if err != nil {
 		return utils.NewUnauthorizedError("error.auth.invalid_credentials")
@@ -119,3 +153,157 @@ func (s UserService) ValidatePassword(username string, password string) error {
 
 	return nil
 }
+
+func (s UserService) ChangePassword(ctx context.Context, userID int32, currentPassword string, newPassword string) error {
+	user, err := s.userRepo.FindOne(ctx, userID)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return utils.NewNotFoundError("error.user.not_found")
+		default:
+			return err
+		}
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword))
+	if err != nil {
+		return utils.NewUnauthorizedError("error.auth.invalid_credentials")
+	}
+
+	return s.SetPassword(ctx, userID, newPassword)
+}
+
+func (s UserService) SetPassword(ctx context.Context, userID int32, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), COST)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.userRepo.UpdatePassword(ctx, userID, string(hash))
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return utils.NewNotFoundError("error.user.not_found")
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s UserService) MarkEmailVerified(ctx context.Context, userID int32) (dto.UserDTO, error) {
+	var userDTO dto.UserDTO
+
+	user, err := s.userRepo.MarkEmailVerified(ctx, userID)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return userDTO, utils.NewNotFoundError("error.user.not_found")
+		default:
+			return userDTO, err
+		}
+	}
+
+	userDTO.FromModel(user)
+	return userDTO, nil
+}
+
+// UpdatePreferences stores the language and region the user wants movie data
+// in. Clearing them falls back to the browser language.
+func (s UserService) UpdatePreferences(ctx context.Context, userID int32, preferences dto.UserPreferencesDTO) (dto.UserDTO, error) {
+	var userDTO dto.UserDTO
+
+	var lang, region *string
+	if preferences.Language != nil {
+		tag := language.Make(*preferences.Language).String()
+		lang = &tag
+	}
+	if preferences.Region != nil {
+		code := strings.ToUpper(*preferences.Region)
+		region = &code
+	}
+
+	user, err := s.userRepo.UpdatePreferences(ctx, userID, lang, region)
+	if err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return userDTO, utils.NewNotFoundError("error.user.not_found")
+		default:
+			return userDTO, err
+		}
+	}
+
+	userDTO.FromModel(user)
+	return userDTO, nil
+}
+
+// Follow asks the followed user to let the follower get notified of their
+// ratings, which only happens once they accept. Following someone already
+// followed does nothing.
+func (s UserService) Follow(ctx context.Context, followerID int32, followedID int32) error {
+	if followerID == followedID {
+		return utils.NewBadRequestError("error.user.cannot_follow_self")
+	}
+
+	if _, err := s.userRepo.FindOne(ctx, followedID); err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return utils.NewNotFoundError("error.user.not_found")
+		default:
+			return err
+		}
+	}
+
+	_, err := s.followRepo.Follow(ctx, followerID, followedID)
+	return err
+}
+
+func (s UserService) Unfollow(ctx context.Context, followerID int32, followedID int32) error {
+	return s.followRepo.Unfollow(ctx, followerID, followedID)
+}
+
+// GetFollowRequests lists the users waiting for the user to accept them as
+// followers, oldest first.
+func (s UserService) GetFollowRequests(ctx context.Context, userID int32) ([]dto.FollowRequestDTO, error) {
+	requests := make([]dto.FollowRequestDTO, 0)
+
+	follows, err := s.followRepo.FindRequests(ctx, userID)
+	if err != nil {
+		return requests, err
+	}
+
+	for _, follow := range follows {
+		follower, err := s.userRepo.FindOne(ctx, follow.FollowerID)
+		if err != nil {
+			return requests, err
+		}
+
+		var request dto.FollowRequestDTO
+		request.FromModel(follow, follower)
+		requests = append(requests, request)
+	}
+
+	return requests, nil
+}
+
+// AcceptFollower lets the follower get notified of the ratings of the user.
+// Accepting someone already following does nothing.
+func (s UserService) AcceptFollower(ctx context.Context, userID int32, followerID int32) error {
+	if _, err := s.followRepo.Accept(ctx, followerID, userID); err != nil {
+		switch err {
+		case qrm.ErrNoRows:
+			return utils.NewNotFoundError("error.user.follow_request_not_found")
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveFollower stops the follower following the user, declining their
+// request if they weren't accepted yet.
+func (s UserService) RemoveFollower(ctx context.Context, userID int32, followerID int32) error {
+	return s.followRepo.Unfollow(ctx, followerID, userID)
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserRepository struct {
+	repositories.IUserRepository
+	mock.Mock
+}
+
+func (m *MockUserRepository) FindOne(_ context.Context, id int32) (model.Users, error) {
+	args := m.Called(id)
+	return args.Get(0).(model.Users), args.Error(1)
+}
+
+func (m *MockUserRepository) FindActiveAfter(_ context.Context, afterID int32, limit int64) ([]model.Users, error) {
+	args := m.Called(afterID, limit)
+	return args.Get(0).([]model.Users), args.Error(1)
+}
+
+type MockUserFollowRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserFollowRepository) FindFollowers(_ context.Context, userID int32) ([]model.UserFollows, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]model.UserFollows), args.Error(1)
+}
+
+func (m *MockUserFollowRepository) FindRequests(_ context.Context, userID int32) ([]model.UserFollows, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]model.UserFollows), args.Error(1)
+}
+
+func (m *MockUserFollowRepository) Follow(_ context.Context, followerID int32, followedID int32) (model.UserFollows, error) {
+	args := m.Called(followerID, followedID)
+	return args.Get(0).(model.UserFollows), args.Error(1)
+}
+
+func (m *MockUserFollowRepository) Accept(_ context.Context, followerID int32, followedID int32) (model.UserFollows, error) {
+	args := m.Called(followerID, followedID)
+	return args.Get(0).(model.UserFollows), args.Error(1)
+}
+
+func (m *MockUserFollowRepository) Unfollow(_ context.Context, followerID int32, followedID int32) error {
+	args := m.Called(followerID, followedID)
+	return args.Error(0)
+}
+
+func TestUserService_Follow(t *testing.T) {
+	// Arrange
+	userRepo := new(MockUserRepository)
+	followRepo := new(MockUserFollowRepository)
+	service := UserService{userRepo: userRepo, followRepo: followRepo}
+
+	userRepo.On("FindOne", int32(2)).Return(model.Users{ID: 2}, nil)
+	userRepo.On("FindOne", int32(3)).Return(model.Users{}, qrm.ErrNoRows)
+	followRepo.On("Follow", int32(1), int32(2)).Return(model.UserFollows{FollowerID: 1, FollowedID: 2}, nil)
+
+	// Act
+	errFollow := service.Follow(context.Background(), 1, 2)
+	errSelf := service.Follow(context.Background(), 1, 1)
+	errMissing := service.Follow(context.Background(), 1, 3)
+
+	// Assert
+	assert.NoError(t, errFollow)
+	assertApiError(t, errSelf, http.StatusBadRequest, "error.user.cannot_follow_self")
+	assertApiError(t, errMissing, http.StatusNotFound, "error.user.not_found")
+	followRepo.AssertNotCalled(t, "Accept", mock.Anything, mock.Anything)
+}
+
+func TestUserService_GetFollowRequests(t *testing.T) {
+	// Arrange
+	userRepo := new(MockUserRepository)
+	followRepo := new(MockUserFollowRepository)
+	service := UserService{userRepo: userRepo, followRepo: followRepo}
+	requestedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	followRepo.On("FindRequests", int32(2)).Return([]model.UserFollows{{FollowerID: 1, FollowedID: 2, FollowedAt: requestedAt}}, nil)
+	userRepo.On("FindOne", int32(1)).Return(model.Users{ID: 1, Name: "Ana", Username: "ana", Email: "ana@example.com"}, nil)
+
+	// Act
+	requests, err := service.GetFollowRequests(context.Background(), 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, int32(1), requests[0].FollowerID)
+	assert.Equal(t, "ana", requests[0].Username)
+	assert.Equal(t, requestedAt, requests[0].RequestedAt)
+}
+
+func TestUserService_AcceptFollower(t *testing.T) {
+	// Arrange
+	followRepo := new(MockUserFollowRepository)
+	service := UserService{followRepo: followRepo}
+	acceptedAt := time.Now()
+
+	followRepo.On("Accept", int32(1), int32(2)).Return(model.UserFollows{FollowerID: 1, FollowedID: 2, AcceptedAt: &acceptedAt}, nil)
+	followRepo.On("Accept", int32(3), int32(2)).Return(model.UserFollows{}, qrm.ErrNoRows)
+
+	// Act
+	errAccepted := service.AcceptFollower(context.Background(), 2, 1)
+	errMissing := service.AcceptFollower(context.Background(), 2, 3)
+
+	// Assert
+	assert.NoError(t, errAccepted)
+	assertApiError(t, errMissing, http.StatusNotFound, "error.user.follow_request_not_found")
+}
+
+func TestUserService_RemoveFollower(t *testing.T) {
+	// Arrange
+	followRepo := new(MockUserFollowRepository)
+	service := UserService{followRepo: followRepo}
+
+	followRepo.On("Unfollow", int32(1), int32(2)).Return(nil)
+
+	// Act
+	err := service.RemoveFollower(context.Background(), 2, 1)
+
+	// Assert
+	assert.NoError(t, err)
+	followRepo.AssertExpectations(t)
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+const (
+	WatchlistExportCSV        = "csv"
+	WatchlistExportJSON       = "json"
+	WatchlistExportLetterboxd = "letterboxd"
+
+	// Number of items read and resolved at a time while exporting.
+	watchlistExportBatchSize = 100
+)
+
+// watchlistEncoder writes the items of an export in one of the supported formats.
+type watchlistEncoder interface {
+	begin() error
+	write(item dto.WatchListExportDTO) error
+	flush() error
+	end() error
+}
+
+// Export streams the whole watchlist of a user to w, one batch at a time, so
+// the list is never held in memory at once. Nothing is written to w until the
+// first batch has been read, so early failures can still be reported normally.
+func (s *WatchListService) Export(ctx context.Context, userID int32, format string, locale dto.LocaleDTO, w io.Writer) error {
+	viewer := dto.ViewerDTO{UserID: userID, Locale: locale}
+
+	var encoder watchlistEncoder
+	var last *model.Watchlist
+	for {
+		batch, err := s.repo.FindBatch(ctx, userID, last, watchlistExportBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if encoder == nil {
+			encoder = newWatchlistEncoder(format, w)
+			if err = encoder.begin(); err != nil {
+				return err
+			}
+		}
+
+		for _, item := range s.AttachMovies(ctx, toWatchListDTOs(batch), viewer) {
+			if err = encoder.write(toWatchListExportDTO(item)); err != nil {
+				return err
+			}
+		}
+
+		if err = encoder.flush(); err != nil {
+			return err
+		}
+
+		if len(batch) < watchlistExportBatchSize {
+			break
+		}
+		last = &batch[len(batch)-1]
+	}
+
+	return encoder.end()
+}
+
+// ExportContentType returns the media type and file extension of an export format.
+func ExportContentType(format string) (string, string) {
+	if format == WatchlistExportJSON {
+		return "application/json", "json"
+	}
+	return "text/csv; charset=utf-8", "csv"
+}
+
+func newWatchlistEncoder(format string, w io.Writer) watchlistEncoder {
+	switch format {
+	case WatchlistExportJSON:
+		return &jsonWatchlistEncoder{w: w, encoder: json.NewEncoder(w)}
+	case WatchlistExportLetterboxd:
+		return &letterboxdWatchlistEncoder{w: w, csv: csv.NewWriter(w)}
+	default:
+		return &csvWatchlistEncoder{w: w, csv: csv.NewWriter(w)}
+	}
+}
+
+func toWatchListExportDTO(item dto.WatchListMovieDTO) dto.WatchListExportDTO {
+	export := dto.WatchListExportDTO{
+		MovieID:         item.MovieID,
+		Status:          item.Status,
+		Favorite:        item.Favorite,
+		Rating:          item.Rating,
+		Comments:        item.Comments,
+		AddedAt:         item.AddedAt,
+		StatusChangedAt: item.StatusChangedAt,
+	}
+
+	if item.Movie != nil {
+		export.Title = item.Movie.Title
+		export.Year = item.Movie.Year
+		export.ImdbID = item.Movie.ImdbID
+	}
+
+	return export
+}
+
+// flushWriter pushes the bytes written so far to the client when w is an HTTP response.
+func flushWriter(w io.Writer) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+type csvWatchlistEncoder struct {
+	w   io.Writer
+	csv *csv.Writer
+}
+
+func (e *csvWatchlistEncoder) begin() error {
+	return e.csv.Write([]string{"movie_id", "title", "year", "imdb_id", "status", "favorite", "rating", "comments", "added_at", "status_changed_at"})
+}
+
+func (e *csvWatchlistEncoder) write(item dto.WatchListExportDTO) error {
+	return e.csv.Write([]string{
+		strconv.Itoa(int(item.MovieID)),
+		item.Title,
+		item.Year,
+		utils.Fallback(item.ImdbID, ""),
+		string(item.Status),
+		strconv.FormatBool(item.Favorite),
+		ratingValue(item.Rating),
+		utils.Fallback(item.Comments, ""),
+		item.AddedAt.Format(time.RFC3339),
+		item.StatusChangedAt.Format(time.RFC3339),
+	})
+}
+
+func (e *csvWatchlistEncoder) flush() error {
+	e.csv.Flush()
+	flushWriter(e.w)
+	return e.csv.Error()
+}
+
+func (e *csvWatchlistEncoder) end() error {
+	return e.flush()
+}
+
+// letterboxdWatchlistEncoder writes the columns understood by the Letterboxd
+// film importer. Importing a film there logs it as watched, so only watched
+// items are exported.
+type letterboxdWatchlistEncoder struct {
+	w   io.Writer
+	csv *csv.Writer
+}
+
+func (e *letterboxdWatchlistEncoder) begin() error {
+	return e.csv.Write([]string{"tmdbID", "imdbID", "Title", "Year", "Rating10", "WatchedDate", "Review"})
+}
+
+func (e *letterboxdWatchlistEncoder) write(item dto.WatchListExportDTO) error {
+	if item.Status != model.WatchStatus_Watched {
+		return nil
+	}
+
+	return e.csv.Write([]string{
+		strconv.Itoa(int(item.MovieID)),
+		utils.Fallback(item.ImdbID, ""),
+		item.Title,
+		item.Year,
+		ratingValue(item.Rating),
+		item.StatusChangedAt.Format(time.DateOnly),
+		utils.Fallback(item.Comments, ""),
+	})
+}
+
+func (e *letterboxdWatchlistEncoder) flush() error {
+	e.csv.Flush()
+	flushWriter(e.w)
+	return e.csv.Error()
+}
+
+func (e *letterboxdWatchlistEncoder) end() error {
+	return e.flush()
+}
+
+// jsonWatchlistEncoder writes a JSON array one element at a time.
+type jsonWatchlistEncoder struct {
+	w       io.Writer
+	encoder *json.Encoder
+	written bool
+}
+
+func (e *jsonWatchlistEncoder) begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonWatchlistEncoder) write(item dto.WatchListExportDTO) error {
+	if e.written {
+		if _, err := io.WriteString(e.w, ","); err != nil {
+			return err
+		}
+	}
+	e.written = true
+
+	return e.encoder.Encode(item)
+}
+
+func (e *jsonWatchlistEncoder) flush() error {
+	flushWriter(e.w)
+	return nil
+}
+
+func (e *jsonWatchlistEncoder) end() error {
+	_, err := io.WriteString(e.w, "]\n")
+	return err
+}
+
+func ratingValue(rating *int32) string {
+	if rating == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*rating))
+}
@@ -1,211 +1,285 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
 	"github.com/movie-tracker/MovieTracker/internal/repositories"
 	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/services/policy"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+)
+
+const (
+	// Maximum number of movies resolved at the same time when expanding a watchlist.
+	movieExpandConcurrency = 8
+
+	defaultWatchlistPageSize = 20
 )
 
 type IWatchList interface {
 	IService
-	GetByUser(userID int32) ([]dto.WatchListDTO, error)
-	AddToWatchlist(userID int32, createDTO dto.WatchListCreateDTO) (dto.WatchListDTO, error)
-	UpdateWatchlistItem(userID int32, movieID int, status string, favorite *bool, comments string, rating *int) (dto.WatchListDTO, error)
-	RemoveFromWatchlist(userID int32, movieID int) error
-	UpdateStatus(userID int32, movieID int, status string) (dto.WatchListDTO, error)
-	ToggleFavorite(userID int32, movieID int, favorite bool) (dto.WatchListDTO, error)
-	UpdateRating(userID int32, movieID int, rating *int) (dto.WatchListDTO, error)
+	GetByUser(ctx context.Context, userID int32) ([]dto.WatchListDTO, error)
+	Find(ctx context.Context, userID int32, query dto.WatchListQueryDTO) (dto.Pagination[dto.WatchListDTO], error)
+	AttachMovies(ctx context.Context, items []dto.WatchListDTO, viewer dto.ViewerDTO) []dto.WatchListMovieDTO
+	AddToWatchlist(ctx context.Context, userID int32, createDTO dto.WatchListCreateDTO) (dto.WatchListDTO, error)
+	UpdateWatchlistItem(ctx context.Context, userID int32, movieID int, status string, favorite *bool, comments string, rating *int) (dto.WatchListDTO, error)
+	RemoveFromWatchlist(ctx context.Context, userID int32, movieID int) error
+	UpdateStatus(ctx context.Context, userID int32, movieID int, status string) (dto.WatchListDTO, error)
+	ToggleFavorite(ctx context.Context, userID int32, movieID int, favorite bool) (dto.WatchListDTO, error)
+	UpdateRating(ctx context.Context, userID int32, movieID int, rating *int) (dto.WatchListDTO, error)
+	GetStatusHistory(ctx context.Context, userID int32, movieID int) ([]dto.WatchListStatusChangeDTO, error)
+	Export(ctx context.Context, userID int32, format string, locale dto.LocaleDTO, w io.Writer) error
 }
 
 type WatchListService struct {
-	repo repositories.IWatchListRepository
+	repo             repositories.IWatchListRepository
+	movieRepo        repositories.IMovieRepository
+	movieService     IMovieService
+	contentPolicy    IContentPolicyService
+	streamingService IStreamingService
 }
 
 func newWatchListService(params ServicesParams) IWatchList {
 	return &WatchListService{
-		repo: params.Repos.WatchListRepo,
+		repo:      params.Repos.WatchListRepo,
+		movieRepo: params.Repos.MovieRepo,
 	}
 }
 
-func (s *WatchListService) ProvideServices(Services) {}
+func (s *WatchListService) ProvideServices(services Services) {
+	s.movieService = services.MovieService
+	s.contentPolicy = services.ContentPolicyService
+	s.streamingService = services.StreamingService
+}
 
-func (s *WatchListService) GetByUser(userID int32) ([]dto.WatchListDTO, error) {
-	watchlistItems, err := s.repo.GetByUser(userID)
+func (s *WatchListService) GetByUser(ctx context.Context, userID int32) ([]dto.WatchListDTO, error) {
+	watchlistItems, err := s.repo.GetByUser(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	var watchlistDTOs = make([]dto.WatchListDTO, 0)
-	for _, item := range watchlistItems {
-		var commentsPtr *string
-		if item.Comments != nil {
-			commentsPtr = item.Comments
-		}
+	return toWatchListDTOs(watchlistItems), nil
+}
 
-		var ratingPtr *int32
-		if item.Rating != nil {
-			val := int32(*item.Rating)
-			ratingPtr = &val
-		}
+func (s *WatchListService) Find(ctx context.Context, userID int32, query dto.WatchListQueryDTO) (dto.Pagination[dto.WatchListDTO], error) {
+	var page dto.Pagination[dto.WatchListDTO]
 
-		watchlistDTO := dto.WatchListDTO{
-			MovieID:  item.MovieID,
-			UserID:   item.UserID,
-			Status:   item.Status,
-			Favorite: item.Favorite,
-			Comments: commentsPtr,
-			Rating:   ratingPtr,
-		}
-		watchlistDTOs = append(watchlistDTOs, watchlistDTO)
+	if query.IsPaginated() {
+		query.Page = utils.FallbackZero(query.Page, 1)
+		query.PageSize = utils.FallbackZero(query.PageSize, defaultWatchlistPageSize)
 	}
 
-	return watchlistDTOs, nil
-}
+	if query.AvailableOn == "mine" {
+		return s.findStreamable(ctx, userID, query)
+	}
 
-func (s *WatchListService) AddToWatchlist(userID int32, createDTO dto.WatchListCreateDTO) (dto.WatchListDTO, error) {
-	watchListItem, err := s.repo.AddToWatchlist(userID, createDTO)
+	watchlistItems, total, err := s.repo.FindByUser(ctx, userID, query)
 	if err != nil {
-		return dto.WatchListDTO{}, err
+		return page, err
 	}
 
-	var commentsPtr *string
-	if watchListItem.Comments != nil {
-		commentsPtr = watchListItem.Comments
+	page.Results = toWatchListDTOs(watchlistItems)
+	page.TotalResults = int(total)
+	page.Page = 1
+	page.TotalPages = 1
+
+	if query.IsPaginated() {
+		page.Page = query.Page
+		page.TotalPages = (page.TotalResults + query.PageSize - 1) / query.PageSize
 	}
 
-	var ratingPtr *int32
-	if watchListItem.Rating != nil {
-		val := int32(*watchListItem.Rating)
-		ratingPtr = &val
+	return page, nil
+}
+
+// findStreamable returns the items streaming on the user's services in their
+// country right now, plan to watch ones unless the query says otherwise.
+// Availability comes with the cached movie details, so the matching items are
+// all resolved and the page is cut afterwards. Movies that can't be resolved
+// are left out.
+func (s *WatchListService) findStreamable(ctx context.Context, userID int32, query dto.WatchListQueryDTO) (dto.Pagination[dto.WatchListDTO], error) {
+	var page dto.Pagination[dto.WatchListDTO]
+
+	subscription, err := s.streamingService.GetSubscription(ctx, userID)
+	if err != nil {
+		return page, err
+	}
+	if subscription.IsEmpty() {
+		return page, utils.NewBadRequestError("error.watchlist.no_streaming_services")
 	}
 
-	watchlistDTO := dto.WatchListDTO{
-		MovieID:  watchListItem.MovieID,
-		UserID:   watchListItem.UserID,
-		Status:   watchListItem.Status,
-		Favorite: watchListItem.Favorite,
-		Comments: commentsPtr,
-		Rating:   ratingPtr,
+	if len(query.Status) == 0 {
+		query.Status = []string{string(model.WatchStatus_PlanToWatch)}
 	}
 
-	return watchlistDTO, nil
-}
+	unpaged := query
+	unpaged.Page, unpaged.PageSize = 0, 0
 
-func (s *WatchListService) UpdateWatchlistItem(userID int32, movieID int, status string, favorite *bool, comments string, rating *int) (dto.WatchListDTO, error) {
-	watchlistItem, err := s.repo.UpdateWatchlistItem(userID, movieID, status, favorite, comments, rating)
+	watchlistItems, _, err := s.repo.FindByUser(ctx, userID, unpaged)
 	if err != nil {
-		return dto.WatchListDTO{}, err
+		return page, err
 	}
 
-	var commentsPtr *string
-	if watchlistItem.Comments != nil {
-		commentsPtr = watchlistItem.Comments
-	}
+	streamingOn := make([][]dto.WatchProviderDTO, len(watchlistItems))
+	forEachConcurrently(len(watchlistItems), movieExpandConcurrency, func(i int) {
+		movie, err := s.movieRepo.GetByID(ctx, int(watchlistItems[i].MovieID))
+		if err != nil {
+			slog.Warn("failed to resolve watchlist movie availability", "movie_id", watchlistItems[i].MovieID, "error", err)
+			return
+		}
+		if availability := movie.WatchAvailability(subscription.Country); availability != nil {
+			streamingOn[i] = availability.StreamingOn(subscription.ProviderIDs)
+		}
+	})
 
-	var ratingPtr *int32
-	if watchlistItem.Rating != nil {
-		val := int32(*watchlistItem.Rating)
-		ratingPtr = &val
+	items := make([]dto.WatchListDTO, 0, len(watchlistItems))
+	for i, item := range toWatchListDTOs(watchlistItems) {
+		if len(streamingOn[i]) > 0 {
+			item.StreamingOn = streamingOn[i]
+			items = append(items, item)
+		}
 	}
 
-	watchlistDTO := dto.WatchListDTO{
-		MovieID:  watchlistItem.MovieID,
-		UserID:   watchlistItem.UserID,
-		Status:   watchlistItem.Status,
-		Favorite: watchlistItem.Favorite,
-		Comments: commentsPtr,
-		Rating:   ratingPtr,
+	page.Results = items
+	page.TotalResults = len(items)
+	page.Page = 1
+	page.TotalPages = 1
+
+	if query.IsPaginated() {
+		start := min((query.Page-1)*query.PageSize, len(items))
+		end := min(start+query.PageSize, len(items))
+		page.Results = items[start:end]
+		page.Page = query.Page
+		page.TotalPages = (page.TotalResults + query.PageSize - 1) / query.PageSize
 	}
 
-	return watchlistDTO, nil
+	return page, nil
 }
 
-func (s *WatchListService) RemoveFromWatchlist(userID int32, movieID int) error {
-	return s.repo.RemoveFromWatchlist(userID, movieID)
+// AttachMovies resolves the movie of every item concurrently. A failed lookup
+// only marks its own item so the rest of the watchlist is still returned, and
+// movies hidden by the viewer's policy carry the reasons instead.
+func (s *WatchListService) AttachMovies(ctx context.Context, items []dto.WatchListDTO, viewer dto.ViewerDTO) []dto.WatchListMovieDTO {
+	// Resolve the policy once rather than for every item
+	if resolved, err := s.contentPolicy.Resolve(ctx, viewer); err == nil {
+		viewer = resolved
+	}
+
+	expanded := make([]dto.WatchListMovieDTO, len(items))
+	forEachConcurrently(len(items), movieExpandConcurrency, func(i int) {
+		expanded[i].WatchListDTO = items[i]
+
+		movie, err := s.movieService.GetByID(ctx, int(items[i].MovieID), viewer)
+		if err != nil {
+			message := "error.movie.unavailable"
+			var apiErr *utils.ApiError
+			var hiddenErr *policy.HiddenError
+			if errors.As(err, &hiddenErr) {
+				message = hiddenErr.Message
+				expanded[i].HiddenReasons = hiddenErr.Reasons
+			} else if errors.As(err, &apiErr) {
+				message = apiErr.Message
+			}
+			expanded[i].MovieError = &message
+			return
+		}
+
+		expanded[i].Movie = &movie
+	})
+
+	return expanded
 }
 
-func (s *WatchListService) UpdateStatus(userID int32, movieID int, status string) (dto.WatchListDTO, error) {
-	watchlistItem, err := s.repo.UpdateStatus(userID, movieID, status)
+func (s *WatchListService) AddToWatchlist(ctx context.Context, userID int32, createDTO dto.WatchListCreateDTO) (dto.WatchListDTO, error) {
+	watchListItem, err := s.repo.AddToWatchlist(ctx, userID, createDTO)
 	if err != nil {
 		return dto.WatchListDTO{}, err
 	}
 
-	var commentsPtr *string
-	if watchlistItem.Comments != nil {
-		commentsPtr = watchlistItem.Comments
-	}
+	var watchlistDTO dto.WatchListDTO
+	watchlistDTO.FromModel(watchListItem)
 
-	var ratingPtr *int32
-	if watchlistItem.Rating != nil {
-		val := int32(*watchlistItem.Rating)
-		ratingPtr = &val
-	}
+	return watchlistDTO, nil
+}
 
-	watchlistDTO := dto.WatchListDTO{
-		MovieID:  watchlistItem.MovieID,
-		UserID:   watchlistItem.UserID,
-		Status:   watchlistItem.Status,
-		Favorite: watchlistItem.Favorite,
-		Comments: commentsPtr,
-		Rating:   ratingPtr,
+func (s *WatchListService) UpdateWatchlistItem(ctx context.Context, userID int32, movieID int, status string, favorite *bool, comments string, rating *int) (dto.WatchListDTO, error) {
+	watchlistItem, err := s.repo.UpdateWatchlistItem(ctx, userID, movieID, status, favorite, comments, rating)
+	if err != nil {
+		return dto.WatchListDTO{}, err
 	}
 
+	var watchlistDTO dto.WatchListDTO
+	watchlistDTO.FromModel(watchlistItem)
+
 	return watchlistDTO, nil
 }
 
-func (s *WatchListService) ToggleFavorite(userID int32, movieID int, favorite bool) (dto.WatchListDTO, error) {
-	watchlistItem, err := s.repo.ToggleFavorite(userID, movieID, favorite)
+func (s *WatchListService) RemoveFromWatchlist(ctx context.Context, userID int32, movieID int) error {
+	return s.repo.RemoveFromWatchlist(ctx, userID, movieID)
+}
+
+func (s *WatchListService) UpdateStatus(ctx context.Context, userID int32, movieID int, status string) (dto.WatchListDTO, error) {
+	watchlistItem, err := s.repo.UpdateStatus(ctx, userID, movieID, status)
 	if err != nil {
 		return dto.WatchListDTO{}, err
 	}
 
-	var commentsPtr *string
-	if watchlistItem.Comments != nil {
-		commentsPtr = watchlistItem.Comments
-	}
+	var watchlistDTO dto.WatchListDTO
+	watchlistDTO.FromModel(watchlistItem)
 
-	var ratingPtr *int32
-	if watchlistItem.Rating != nil {
-		val := int32(*watchlistItem.Rating)
-		ratingPtr = &val
-	}
+	return watchlistDTO, nil
+}
 
-	watchlistDTO := dto.WatchListDTO{
-		MovieID:  watchlistItem.MovieID,
-		UserID:   watchlistItem.UserID,
-		Status:   watchlistItem.Status,
-		Favorite: watchlistItem.Favorite,
-		Comments: commentsPtr,
-		Rating:   ratingPtr,
+func (s *WatchListService) ToggleFavorite(ctx context.Context, userID int32, movieID int, favorite bool) (dto.WatchListDTO, error) {
+	watchlistItem, err := s.repo.ToggleFavorite(ctx, userID, movieID, favorite)
+	if err != nil {
+		return dto.WatchListDTO{}, err
 	}
 
+	var watchlistDTO dto.WatchListDTO
+	watchlistDTO.FromModel(watchlistItem)
+
 	return watchlistDTO, nil
 }
 
-func (s *WatchListService) UpdateRating(userID int32, movieID int, rating *int) (dto.WatchListDTO, error) {
-	watchlistItem, err := s.repo.UpdateRating(userID, movieID, rating)
+func (s *WatchListService) UpdateRating(ctx context.Context, userID int32, movieID int, rating *int) (dto.WatchListDTO, error) {
+	watchlistItem, err := s.repo.UpdateRating(ctx, userID, movieID, rating)
 	if err != nil {
 		return dto.WatchListDTO{}, err
 	}
 
-	var commentsPtr *string
-	if watchlistItem.Comments != nil {
-		commentsPtr = watchlistItem.Comments
+	var watchlistDTO dto.WatchListDTO
+	watchlistDTO.FromModel(watchlistItem)
+
+	return watchlistDTO, nil
+}
+
+func (s *WatchListService) GetStatusHistory(ctx context.Context, userID int32, movieID int) ([]dto.WatchListStatusChangeDTO, error) {
+	history, err := s.repo.GetStatusHistory(ctx, userID, movieID)
+	if err != nil {
+		return nil, err
 	}
 
-	var ratingPtr *int32
-	if watchlistItem.Rating != nil {
-		val := int32(*watchlistItem.Rating)
-		ratingPtr = &val
+	changes := make([]dto.WatchListStatusChangeDTO, len(history))
+	for i, change := range history {
+		changes[i] = dto.WatchListStatusChangeDTO{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			ChangedAt:  change.ChangedAt,
+		}
 	}
 
-	watchlistDTO := dto.WatchListDTO{
-		MovieID:  watchlistItem.MovieID,
-		UserID:   watchlistItem.UserID,
-		Status:   watchlistItem.Status,
-		Favorite: watchlistItem.Favorite,
-		Comments: commentsPtr,
-		Rating:   ratingPtr,
+	return changes, nil
+}
+
+func toWatchListDTOs(items []model.Watchlist) []dto.WatchListDTO {
+	var watchlistDTOs = make([]dto.WatchListDTO, len(items))
+	for i, item := range items {
+		watchlistDTOs[i].FromModel(item)
 	}
 
-	return watchlistDTO, nil
+	return watchlistDTOs
 }
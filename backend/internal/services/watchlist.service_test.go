@@ -0,0 +1,181 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/movie-tracker/MovieTracker/internal/database/movie-tracker/public/model"
+	"github.com/movie-tracker/MovieTracker/internal/services/dto"
+	"github.com/movie-tracker/MovieTracker/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWatchListService_AttachMovies_PartialFailure(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockMovieRepository)
+	contentPolicy := &ContentPolicyService{}
+	service := &WatchListService{
+		movieService:  &MovieService{movieRepo: mockRepo, genreRepo: newMockGenreRepository(), contentPolicy: contentPolicy},
+		contentPolicy: contentPolicy,
+	}
+
+	mockRepo.On("GetByID", 123).Return(dto.TMDBMovieDTO{ID: 123, Title: "Test Movie", ReleaseDate: "2023-05-15"}, nil)
+	mockRepo.On("GetByID", 456).Return(dto.TMDBMovieDTO{}, errors.New("upstream down"))
+	mockRepo.On("GetByID", 789).Return(dto.TMDBMovieDTO{}, utils.NewNotFoundError("error.movie.not_found"))
+
+	items := []dto.WatchListDTO{
+		{MovieID: 123, UserID: 1},
+		{MovieID: 456, UserID: 1},
+		{MovieID: 789, UserID: 1},
+	}
+
+	// Act
+	result := service.AttachMovies(context.Background(), items, dto.ViewerDTO{})
+
+	// Assert
+	assert.Len(t, result, 3)
+
+	assert.Equal(t, int32(123), result[0].MovieID)
+	assert.Equal(t, "Test Movie", result[0].Movie.Title)
+	assert.Nil(t, result[0].MovieError)
+
+	assert.Equal(t, int32(456), result[1].MovieID)
+	assert.Nil(t, result[1].Movie)
+	assert.Equal(t, "error.movie.unavailable", *result[1].MovieError)
+
+	assert.Equal(t, int32(789), result[2].MovieID)
+	assert.Equal(t, "error.movie.not_found", *result[2].MovieError)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWatchListService_ExportEncoders(t *testing.T) {
+	imdbID := "tt0078748"
+	rating := int32(9)
+	changedAt := time.Date(2024, 2, 3, 10, 0, 0, 0, time.UTC)
+	items := []dto.WatchListExportDTO{
+		{MovieID: 348, Title: "Alien", Year: "1979", ImdbID: &imdbID, Status: model.WatchStatus_Watched, Rating: &rating, StatusChangedAt: changedAt},
+		{MovieID: 593, Title: "Solaris", Year: "1972", Status: model.WatchStatus_PlanToWatch},
+	}
+
+	export := func(format string) string {
+		var buf bytes.Buffer
+		encoder := newWatchlistEncoder(format, &buf)
+		assert.NoError(t, encoder.begin())
+		for _, item := range items {
+			assert.NoError(t, encoder.write(item))
+		}
+		assert.NoError(t, encoder.end())
+		return buf.String()
+	}
+
+	// JSON is written as a single valid array
+	var decoded []dto.WatchListExportDTO
+	assert.NoError(t, json.Unmarshal([]byte(export(WatchlistExportJSON)), &decoded))
+	assert.Len(t, decoded, 2)
+	assert.Equal(t, "tt0078748", *decoded[0].ImdbID)
+
+	// CSV keeps every item
+	lines := strings.Split(strings.TrimSpace(export(WatchlistExportCSV)), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[2], "593,Solaris,1972,,plan to watch,false,,"))
+
+	// Letterboxd only keeps watched items
+	assert.Equal(t,
+		"tmdbID,imdbID,Title,Year,Rating10,WatchedDate,Review\n348,tt0078748,Alien,1979,9,2024-02-03,\n",
+		export(WatchlistExportLetterboxd))
+}
+
+func TestWatchListService_Export_ReadsBatchesByKey(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockWatchListRepository)
+	movieRepo := new(MockMovieRepository)
+	profileRepo := new(MockParentalProfileRepository)
+	contentPolicy := &ContentPolicyService{repo: profileRepo}
+	service := &WatchListService{
+		repo:          mockRepo,
+		movieService:  &MovieService{movieRepo: movieRepo, genreRepo: newMockGenreRepository(), contentPolicy: contentPolicy},
+		contentPolicy: contentPolicy,
+	}
+
+	addedAt := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+	first := make([]model.Watchlist, watchlistExportBatchSize)
+	for i := range first {
+		first[i] = model.Watchlist{MovieID: int32(i + 1), UserID: 1, Status: model.WatchStatus_PlanToWatch, AddedAt: addedAt}
+	}
+	second := []model.Watchlist{{MovieID: 1000, UserID: 1, Status: model.WatchStatus_Watched, AddedAt: addedAt.Add(time.Hour)}}
+
+	mockRepo.On("FindBatch", int32(1), (*model.Watchlist)(nil), watchlistExportBatchSize).Return(first, nil)
+	mockRepo.On("FindBatch", int32(1), &first[len(first)-1], watchlistExportBatchSize).Return(second, nil)
+	movieRepo.On("GetByID", mock.Anything).Return(dto.TMDBMovieDTO{Title: "Movie"}, nil)
+	profileRepo.On("FindByUser", int32(1)).Return(model.ParentalProfiles{}, qrm.ErrNoRows)
+
+	// Act
+	var buf bytes.Buffer
+	err := service.Export(context.Background(), 1, WatchlistExportCSV, dto.LocaleDTO{}, &buf)
+
+	// Assert: every item once, the next batch starting after the last one read
+	assert.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), 1+watchlistExportBatchSize+1)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNumberOfCalls(t, "FindBatch", 2)
+}
+
+func TestWatchListService_GetStatusHistory_StartsWithInitialStatus(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockWatchListRepository)
+	service := &WatchListService{repo: mockRepo}
+
+	planToWatch, watching := model.WatchStatus_PlanToWatch, model.WatchStatus_Watching
+	addedAt := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+	mockRepo.On("GetStatusHistory", int32(1), 550).Return([]model.WatchlistStatusHistory{
+		{ID: 1, MovieID: 550, UserID: 1, ToStatus: planToWatch, ChangedAt: addedAt},
+		{ID: 2, MovieID: 550, UserID: 1, FromStatus: &planToWatch, ToStatus: watching, ChangedAt: addedAt.Add(24 * time.Hour)},
+		{ID: 3, MovieID: 550, UserID: 1, FromStatus: &watching, ToStatus: model.WatchStatus_Watched, ChangedAt: addedAt.Add(48 * time.Hour)},
+	}, nil)
+
+	// Act
+	history, err := service.GetStatusHistory(context.Background(), 1, 550)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, history, 3) {
+		assert.Nil(t, history[0].FromStatus)
+		assert.Equal(t, planToWatch, history[0].ToStatus)
+		assert.Equal(t, addedAt, history[0].ChangedAt)
+		assert.Equal(t, []model.WatchStatus{planToWatch, watching, model.WatchStatus_Watched}, []model.WatchStatus{history[0].ToStatus, history[1].ToStatus, history[2].ToStatus})
+		assert.Equal(t, &planToWatch, history[1].FromStatus)
+		assert.Equal(t, &watching, history[2].FromStatus)
+	}
+}
+
+func TestWatchListService_UpdateStatus_NoOp(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockWatchListRepository)
+	service := &WatchListService{repo: mockRepo}
+
+	changedAt := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+	item := model.Watchlist{MovieID: 550, UserID: 1, Status: model.WatchStatus_Watched, StatusChangedAt: changedAt, UpdatedAt: changedAt.Add(time.Hour)}
+	mockRepo.On("UpdateStatus", int32(1), 550, "watched").Return(item, nil)
+	mockRepo.On("GetStatusHistory", int32(1), 550).Return([]model.WatchlistStatusHistory{
+		{ID: 1, MovieID: 550, UserID: 1, ToStatus: model.WatchStatus_Watched, ChangedAt: changedAt},
+	}, nil)
+
+	// Act
+	updated, err := service.UpdateStatus(context.Background(), 1, 550, "watched")
+	assert.NoError(t, err)
+	history, err := service.GetStatusHistory(context.Background(), 1, 550)
+
+	// Assert: setting the current status again leaves status_changed_at and the history alone
+	assert.NoError(t, err)
+	assert.Equal(t, changedAt, updated.StatusChangedAt)
+	assert.Len(t, history, 1)
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,93 @@
+package tmdb
+
+import (
+	"sync"
+	"time"
+)
+
+type breakerState string
+
+const (
+	breakerClosed   breakerState = "closed"
+	breakerOpen     breakerState = "open"
+	breakerHalfOpen breakerState = "half-open"
+)
+
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+	outcomeIgnored
+)
+
+// breaker stops calling TMDB after a run of consecutive failures. Once the
+// cooldown is over a single probe request is let through: the breaker closes
+// again when it succeeds and stays open for another cooldown otherwise.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+		state:     breakerClosed,
+	}
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// The probe is still in flight
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *breaker) record(result outcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch result {
+	case outcomeSuccess:
+		b.state = breakerClosed
+		b.failures = 0
+	case outcomeFailure:
+		b.failures++
+		if b.state == breakerHalfOpen || (b.threshold > 0 && b.failures >= b.threshold) {
+			b.state = breakerOpen
+			b.openedAt = b.now()
+		}
+	case outcomeIgnored:
+		if b.state == breakerHalfOpen {
+			// Let the next request probe instead
+			b.state = breakerOpen
+		}
+	}
+}
+
+func (b *breaker) currentState() breakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
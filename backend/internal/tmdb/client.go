@@ -0,0 +1,170 @@
+// Package tmdb holds the HTTP client shared by every request to the TMDB API.
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/config"
+)
+
+const (
+	// Wait before the first retry, doubled on every following one.
+	baseBackoff = 250 * time.Millisecond
+	maxBackoff  = 5 * time.Second
+
+	// Longer Retry-After waits aren't worth holding the request for; the
+	// response is returned instead.
+	maxRetryAfter = 10 * time.Second
+)
+
+// ErrCircuitOpen is returned without calling TMDB while it is considered down.
+var ErrCircuitOpen = errors.New("tmdb: circuit breaker open")
+
+type Client struct {
+	http       *http.Client
+	token      string
+	maxRetries int
+	breaker    *breaker
+
+	// sleep waits between attempts, returning early when the context is done.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func NewClient(cfg config.TMDBConfig) *Client {
+	client := &Client{
+		http:       &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second},
+		token:      cfg.ApiKey,
+		maxRetries: cfg.MaxRetries,
+		breaker:    newBreaker(cfg.BreakerThreshold, time.Duration(cfg.BreakerCooldown)*time.Second),
+		sleep:      sleepContext,
+	}
+	publishBreakerState(client.breaker)
+
+	return client
+}
+
+// Do sends an authenticated request to TMDB. Network errors, 429 and 5xx
+// responses are retried with a jittered exponential backoff, waiting at least
+// what Retry-After asks for. When the retries run out the last response is
+// returned, so callers still handle every status themselves. The circuit
+// breaker counts the call once, by its final outcome, however many attempts
+// it took.
+func (c *Client) Do(ctx context.Context, method string, endpoint string) (*http.Response, error) {
+	route := routeOf(method, endpoint)
+
+	if !c.breaker.allow() {
+		recordRejection(route)
+		return nil, ErrCircuitOpen
+	}
+
+	resp, err := c.retry(ctx, route, method, endpoint)
+
+	switch {
+	case ctx.Err() != nil:
+		// The caller gave up, which says nothing about TMDB
+		c.breaker.record(outcomeIgnored)
+	case isFailure(resp, err):
+		c.breaker.record(outcomeFailure)
+	default:
+		c.breaker.record(outcomeSuccess)
+	}
+
+	return resp, err
+}
+
+// retry sends the request until it succeeds, fails for good or runs out of
+// retries.
+func (c *Client) retry(ctx context.Context, route string, method string, endpoint string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		start := time.Now()
+		resp, err := c.send(ctx, method, endpoint)
+		recordCall(route, resp, err, time.Since(start))
+
+		if ctx.Err() != nil || !isRetryable(resp, err) || attempt >= c.maxRetries {
+			return resp, err
+		}
+
+		wait := backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				if after > maxRetryAfter {
+					return resp, nil
+				}
+				wait = max(wait, after)
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		recordRetry(route)
+		if err := c.sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method string, endpoint string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	req.Header.Set("Accept", "application/json")
+
+	return c.http.Do(req)
+}
+
+// isFailure tells whether the attempt counts against TMDB's health. Rate
+// limiting doesn't; it is handled by waiting.
+func isFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+func isRetryable(resp *http.Response, err error) bool {
+	return isFailure(resp, err) || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// backoff returns the wait before the given retry, spread over the upper half
+// of the exponential delay so concurrent requests don't retry in lockstep.
+func backoff(attempt int) time.Duration {
+	delay := min(baseBackoff<<attempt, maxBackoff)
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// retryAfter reads the Retry-After header, either in seconds or as a date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
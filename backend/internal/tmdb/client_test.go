@@ -0,0 +1,194 @@
+package tmdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/movie-tracker/MovieTracker/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a client whose waits are recorded instead of slept.
+func newTestClient(cfg config.TMDBConfig) (*Client, *[]time.Duration) {
+	var waits []time.Duration
+
+	client := NewClient(cfg)
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	return client, &waits
+}
+
+func TestClient_Do_RetriesServerErrors(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, waits := newTestClient(config.TMDBConfig{ApiKey: "secret", MaxRetries: 3})
+
+	resp, err := client.Do(context.Background(), http.MethodGet, server.URL+"/3/movie/1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), requests.Load())
+	if assert.Len(t, *waits, 2) {
+		assert.GreaterOrEqual(t, (*waits)[0], baseBackoff/2)
+		assert.LessOrEqual(t, (*waits)[0], baseBackoff)
+		assert.GreaterOrEqual(t, (*waits)[1], baseBackoff)
+	}
+}
+
+func TestClient_Do_HonoursRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, waits := newTestClient(config.TMDBConfig{MaxRetries: 3})
+
+	resp, err := client.Do(context.Background(), http.MethodGet, server.URL)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []time.Duration{2 * time.Second}, *waits)
+}
+
+func TestClient_Do_GivesUpAfterMaxRetries(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(config.TMDBConfig{MaxRetries: 2})
+
+	resp, err := client.Do(context.Background(), http.MethodGet, server.URL)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestClient_Do_DoesNotRetryClientErrors(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(config.TMDBConfig{MaxRetries: 3})
+
+	resp, err := client.Do(context.Background(), http.MethodGet, server.URL)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestClient_Do_CircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(config.TMDBConfig{BreakerThreshold: 2, BreakerCooldown: 30})
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+
+	// Two failures open the breaker, then calls fail fast
+	client.Do(context.Background(), http.MethodGet, server.URL)
+	client.Do(context.Background(), http.MethodGet, server.URL)
+	_, err := client.Do(context.Background(), http.MethodGet, server.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), requests.Load())
+
+	// After the cooldown a failed probe opens it again
+	now = now.Add(31 * time.Second)
+	client.Do(context.Background(), http.MethodGet, server.URL)
+	_, err = client.Do(context.Background(), http.MethodGet, server.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(3), requests.Load())
+
+	// And a successful one closes it
+	now = now.Add(31 * time.Second)
+	healthy.Store(true)
+	resp, err := client.Do(context.Background(), http.MethodGet, server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, breakerClosed, client.breaker.currentState())
+}
+
+func TestClient_Do_CircuitBreakerCountsCallsNotAttempts(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(config.TMDBConfig{MaxRetries: 3, BreakerThreshold: 2, BreakerCooldown: 30})
+
+	// A call failing on every attempt is a single failure
+	client.Do(context.Background(), http.MethodGet, server.URL)
+	assert.Equal(t, int32(4), requests.Load())
+	assert.Equal(t, breakerClosed, client.breaker.currentState())
+
+	client.Do(context.Background(), http.MethodGet, server.URL)
+	assert.Equal(t, int32(8), requests.Load())
+	assert.Equal(t, breakerOpen, client.breaker.currentState())
+}
+
+func TestClient_Do_CircuitBreakerForgetsRetriedFailures(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every call fails once before succeeding
+		if requests.Add(1)%2 == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := newTestClient(config.TMDBConfig{MaxRetries: 1, BreakerThreshold: 2, BreakerCooldown: 30})
+
+	for range 3 {
+		resp, err := client.Do(context.Background(), http.MethodGet, server.URL)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	assert.Equal(t, breakerClosed, client.breaker.currentState())
+}
+
+func TestRouteOf(t *testing.T) {
+	assert.Equal(t, "GET /3/movie/:id", routeOf(http.MethodGet, "https://api.themoviedb.org/3/movie/603?language=en"))
+	assert.Equal(t, "GET /3/discover/movie", routeOf(http.MethodGet, "https://api.themoviedb.org/3/discover/movie?page=2"))
+}
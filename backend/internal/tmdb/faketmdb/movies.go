@@ -0,0 +1,264 @@
+package faketmdb
+
+import (
+	"cmp"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func (m record) int(name string) int {
+	value, _ := m[name].(float64)
+	return int(value)
+}
+
+func (m record) float(name string) float64 {
+	value, _ := m[name].(float64)
+	return value
+}
+
+func (m record) bool(name string) bool {
+	value, _ := m[name].(bool)
+	return value
+}
+
+func (m record) string(name string) string {
+	value, _ := m[name].(string)
+	return value
+}
+
+func (m record) genreIDs() []int {
+	genres, _ := m["genres"].([]any)
+
+	ids := make([]int, 0, len(genres))
+	for _, g := range genres {
+		if id, ok := g.(map[string]any)["id"].(float64); ok {
+			ids = append(ids, int(id))
+		}
+	}
+	return ids
+}
+
+func (m record) translations() []map[string]any {
+	wrapper, _ := m["translations"].(map[string]any)
+	list, _ := wrapper["translations"].([]any)
+
+	translations := make([]map[string]any, 0, len(list))
+	for _, t := range list {
+		translations = append(translations, t.(map[string]any))
+	}
+	return translations
+}
+
+// translation returns the data of the translation to the language, preferring
+// the one of its region.
+func (m record) translation(language string) map[string]any {
+	base, region, _ := strings.Cut(language, "-")
+
+	var match map[string]any
+	for _, t := range m.translations() {
+		if !strings.EqualFold(t["iso_639_1"].(string), base) {
+			continue
+		}
+		if match == nil || strings.EqualFold(t["iso_3166_1"].(string), region) {
+			match, _ = t["data"].(map[string]any)
+		}
+	}
+	return match
+}
+
+// titles returns every title of the movie, or name of the show, search
+// matching any of them.
+func (m record) titles() []string {
+	titles := []string{m.string("title"), m.string("original_title"), m.string("name"), m.string("original_name")}
+	for _, t := range m.translations() {
+		if data, ok := t["data"].(map[string]any); ok {
+			for _, field := range []string{"title", "name"} {
+				if title, _ := data[field].(string); title != "" {
+					titles = append(titles, title)
+				}
+			}
+		}
+	}
+	return titles
+}
+
+func (m record) certification(country string) string {
+	wrapper, _ := m["release_dates"].(map[string]any)
+	results, _ := wrapper["results"].([]any)
+
+	for _, result := range results {
+		result := result.(map[string]any)
+		if result["iso_3166_1"] != country {
+			continue
+		}
+		dates, _ := result["release_dates"].([]any)
+		for _, date := range dates {
+			if certification, _ := date.(map[string]any)["certification"].(string); certification != "" {
+				return certification
+			}
+		}
+	}
+	return ""
+}
+
+// videos returns the videos in the languages listed in include_video_language,
+// or else in the language of the request, as TMDB filters them. "null" stands
+// for the videos without a language.
+func (m record) videos(q url.Values) map[string]any {
+	languages := []string{baseLanguage(q.Get("language"))}
+	if include := q.Get("include_video_language"); include != "" {
+		languages = strings.Split(include, ",")
+	}
+
+	wrapper, _ := m["videos"].(map[string]any)
+	results, _ := wrapper["results"].([]any)
+
+	kept := make([]any, 0, len(results))
+	for _, video := range results {
+		language, _ := video.(map[string]any)["iso_639_1"].(string)
+		if slices.Contains(languages, cmp.Or(language, "null")) {
+			kept = append(kept, video)
+		}
+	}
+	return map[string]any{"results": kept}
+}
+
+// matchesDiscover applies the discover filters the API sends. Unknown
+// parameters are ignored, like TMDB does.
+func matchesDiscover(m record, q url.Values) bool {
+	if m.bool("adult") && q.Get("include_adult") != "true" {
+		return false
+	}
+
+	if certifications := q.Get("certification"); certifications != "" {
+		certification := m.certification(q.Get("certification_country"))
+		if !slices.Contains(strings.Split(certifications, "|"), certification) {
+			return false
+		}
+	}
+
+	if !matchesGenres(m.genreIDs(), q.Get("with_genres"), q.Get("without_genres")) {
+		return false
+	}
+
+	if language := q.Get("with_original_language"); language != "" && m.string("original_language") != language {
+		return false
+	}
+
+	if minimum, err := strconv.Atoi(q.Get("vote_count.gte")); err == nil && m.int("vote_count") < minimum {
+		return false
+	}
+	if minimum, err := strconv.ParseFloat(q.Get("vote_average.gte"), 64); err == nil && m.float("vote_average") < minimum {
+		return false
+	}
+	if maximum, err := strconv.ParseFloat(q.Get("vote_average.lte"), 64); err == nil && m.float("vote_average") > maximum {
+		return false
+	}
+
+	if minimum, err := strconv.Atoi(q.Get("with_runtime.gte")); err == nil && m.int("runtime") < minimum {
+		return false
+	}
+	if maximum, err := strconv.Atoi(q.Get("with_runtime.lte")); err == nil && m.int("runtime") > maximum {
+		return false
+	}
+
+	releaseDate := cmp.Or(m.string("release_date"), m.string("first_air_date"))
+	for _, prefix := range []string{"primary_release_date", "release_date", "first_air_date"} {
+		if from := q.Get(prefix + ".gte"); from != "" && releaseDate < from {
+			return false
+		}
+		if to := q.Get(prefix + ".lte"); to != "" && releaseDate > to {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesGenres checks the genre filters, where commas mean all of the
+// genres and pipes any of them.
+func matchesGenres(genres []int, with string, without string) bool {
+	if strings.Contains(with, "|") {
+		if !slices.ContainsFunc(strings.Split(with, "|"), func(id string) bool { return containsID(genres, id) }) {
+			return false
+		}
+	} else if with != "" {
+		for _, id := range strings.Split(with, ",") {
+			if !containsID(genres, id) {
+				return false
+			}
+		}
+	}
+
+	for _, id := range strings.FieldsFunc(without, func(r rune) bool { return r == ',' || r == '|' }) {
+		if containsID(genres, id) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsID(ids []int, id string) bool {
+	value, err := strconv.Atoi(strings.TrimSpace(id))
+	return err == nil && slices.Contains(ids, value)
+}
+
+func sortResults(results []record, sortBy string) {
+	field, order, _ := strings.Cut(sortBy, ".")
+	if field == "" {
+		field, order = "popularity", "desc"
+	}
+	if field == "primary_release_date" {
+		field = "release_date"
+	}
+
+	slices.SortStableFunc(results, func(a, b record) int {
+		var result int
+		switch field {
+		case "title", "original_title", "release_date", "name", "original_name", "first_air_date":
+			result = cmp.Compare(a.string(field), b.string(field))
+		default:
+			result = cmp.Compare(a.float(field), b.float(field))
+		}
+
+		if order == "desc" {
+			result = -result
+		}
+		return cmp.Or(result, cmp.Compare(a.int("id"), b.int("id")))
+	})
+}
+
+// relatedMovies stands in for TMDB's similar movies, the ones sharing the
+// most genres with the movie, and its recommendations, the best rated among
+// those sharing any. Adult movies are never related.
+func relatedMovies(catalog []record, m record, kind string) []record {
+	genres := m.genreIDs()
+	shared := func(other record) int {
+		count := 0
+		for _, id := range other.genreIDs() {
+			if slices.Contains(genres, id) {
+				count++
+			}
+		}
+		return count
+	}
+
+	results := make([]record, 0)
+	for _, other := range catalog {
+		if other.int("id") != m.int("id") && !other.bool("adult") && shared(other) > 0 {
+			results = append(results, other)
+		}
+	}
+
+	sortResults(results, "popularity.desc")
+	slices.SortStableFunc(results, func(a, b record) int {
+		if kind == "similar" {
+			return cmp.Compare(shared(b), shared(a))
+		}
+		return cmp.Compare(b.float("vote_average"), a.float("vote_average"))
+	})
+	return results
+}
@@ -0,0 +1,92 @@
+package faketmdb
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+func (s *Server) personResource(w http.ResponseWriter, r *http.Request, segments []string) {
+	id, err := strconv.Atoi(segments[0])
+	if err != nil {
+		notFound(w)
+		return
+	}
+
+	person := find(s.people, id)
+	if person == nil {
+		notFound(w)
+		return
+	}
+
+	language := r.URL.Query().Get("language")
+	switch {
+	case len(segments) == 1:
+		writeJSON(w, http.StatusOK, s.personDetails(person, language, r.URL.Query().Get("append_to_response")))
+	case len(segments) == 2 && segments[1] == "translations":
+		writeJSON(w, http.StatusOK, withID(appended(person, "translations"), id))
+	case len(segments) == 2 && segments[1] == "movie_credits":
+		writeJSON(w, http.StatusOK, s.movieCredits(id, language))
+	default:
+		notFound(w)
+	}
+}
+
+// personDetails returns the person with the biography in the language and
+// the translations when asked for.
+func (s *Server) personDetails(person record, language string, appendToResponse string) map[string]any {
+	details := make(map[string]any, len(person))
+	for name, value := range person {
+		details[name] = value
+	}
+	delete(details, "translations")
+
+	if data := person.translation(language); data != nil {
+		if biography, _ := data["biography"].(string); biography != "" {
+			details["biography"] = biography
+		}
+	}
+
+	for _, name := range strings.Split(appendToResponse, ",") {
+		if name == "translations" {
+			details[name] = person[name]
+		}
+	}
+	return details
+}
+
+// movieCredits gathers the parts the person had in the movies of the
+// catalog, each movie as lists show it.
+func (s *Server) movieCredits(id int, language string) map[string]any {
+	cast := make([]map[string]any, 0)
+	crew := make([]map[string]any, 0)
+
+	for _, m := range s.movies {
+		credits, _ := m["credits"].(map[string]any)
+
+		members, _ := credits["cast"].([]any)
+		for _, member := range members {
+			if member := record(member.(map[string]any)); member.int("id") == id {
+				item := s.listItem(m, language)
+				item["character"] = member.string("character")
+				item["order"] = member.int("order")
+				item["credit_id"] = fmt.Sprintf("cast-%d-%d", m.int("id"), id)
+				cast = append(cast, item)
+			}
+		}
+
+		members, _ = credits["crew"].([]any)
+		for _, member := range members {
+			if member := record(member.(map[string]any)); member.int("id") == id {
+				item := s.listItem(m, language)
+				item["job"] = member.string("job")
+				item["department"] = member.string("department")
+				item["credit_id"] = fmt.Sprintf("crew-%d-%d-%s", m.int("id"), id, member.string("job"))
+				crew = append(crew, item)
+			}
+		}
+	}
+
+	return map[string]any{"id": id, "cast": cast, "crew": crew}
+}
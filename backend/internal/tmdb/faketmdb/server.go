@@ -0,0 +1,396 @@
+// Package faketmdb is an in-memory stand-in for the TMDB API, serving a small
+// fixed catalog so the API can run and be tested without network access.
+//
+// It answers the endpoints the API uses under /3, with the same shapes as
+// TMDB: discover, search, movie details (with translations, release dates,
+// credits, watch providers and videos appended on request), credits, watch
+// providers, videos, similar and recommended movies, the genre and watch
+// provider lists, and for TV the show details (with translations and content
+// ratings), seasons and episodes, and people (with translations) along with
+// their movie credits. Any bearer token is accepted.
+package faketmdb
+
+import (
+	"cmp"
+	"embed"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+const (
+	pageSize = 20
+	maxPage  = 500
+)
+
+// record is a movie or show fixture as TMDB returns it from the details
+// endpoint, along with everything that can be appended to it.
+type record map[string]any
+
+type genre struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Server struct {
+	movies   []record
+	shows    []record
+	people   []record
+	genres   map[string][]genre
+	tvGenres map[string][]genre
+
+	mu       sync.Mutex
+	requests int
+}
+
+// New loads the fixtures into a server.
+func New() *Server {
+	s := &Server{}
+	mustLoad("fixtures/movies.json", &s.movies)
+	mustLoad("fixtures/shows.json", &s.shows)
+	mustLoad("fixtures/people.json", &s.people)
+	mustLoad("fixtures/genres.json", &s.genres)
+	mustLoad("fixtures/tv-genres.json", &s.tvGenres)
+
+	return s
+}
+
+// Handler returns the HTTP handler serving the fake API.
+func Handler() http.Handler {
+	return New()
+}
+
+func mustLoad(name string, value any) {
+	content, err := fixtures.ReadFile(name)
+	if err != nil {
+		panic(err)
+	}
+	if err = json.Unmarshal(content, value); err != nil {
+		panic(err)
+	}
+}
+
+// Requests returns how many requests the server answered, to check what
+// reached it past the caches.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	s.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeStatus(w, http.StatusUnauthorized, 7, "Invalid API key: You must be granted a valid key.")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeStatus(w, http.StatusMethodNotAllowed, 3, "Authentication failed: You do not have permissions to access the service.")
+		return
+	}
+
+	path, ok := strings.CutPrefix(r.URL.Path, "/3/")
+	if !ok {
+		notFound(w)
+		return
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	switch {
+	case path == "authentication":
+		writeJSON(w, http.StatusOK, map[string]any{"success": true, "status_code": 1, "status_message": "Success."})
+	case path == "discover/movie":
+		s.discover(w, r, s.movies)
+	case path == "discover/tv":
+		s.discover(w, r, s.shows)
+	case path == "search/movie":
+		s.search(w, r, s.movies)
+	case path == "search/tv":
+		s.search(w, r, s.shows)
+	case path == "genre/movie/list":
+		s.genreList(w, r, s.genres)
+	case path == "genre/tv/list":
+		s.genreList(w, r, s.tvGenres)
+	case path == "watch/providers/movie":
+		s.watchProviders(w, r)
+	case len(segments) >= 2 && segments[0] == "movie":
+		s.movieResource(w, r, segments[1:])
+	case len(segments) >= 2 && segments[0] == "tv":
+		s.showResource(w, r, segments[1:])
+	case len(segments) >= 2 && segments[0] == "person":
+		s.personResource(w, r, segments[1:])
+	default:
+		notFound(w)
+	}
+}
+
+func (s *Server) discover(w http.ResponseWriter, r *http.Request, catalog []record) {
+	q := r.URL.Query()
+
+	results := make([]record, 0, len(catalog))
+	for _, m := range catalog {
+		if matchesDiscover(m, q) {
+			results = append(results, m)
+		}
+	}
+
+	sortResults(results, q.Get("sort_by"))
+	s.writePage(w, r, results)
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request, catalog []record) {
+	q := r.URL.Query()
+	query := strings.ToLower(strings.TrimSpace(q.Get("query")))
+
+	results := make([]record, 0)
+	for _, m := range catalog {
+		if query == "" || (m.bool("adult") && q.Get("include_adult") != "true") {
+			continue
+		}
+		if slices.ContainsFunc(m.titles(), func(title string) bool {
+			return strings.Contains(strings.ToLower(title), query)
+		}) {
+			results = append(results, m)
+		}
+	}
+
+	sortResults(results, "popularity.desc")
+	s.writePage(w, r, results)
+}
+
+func (s *Server) genreList(w http.ResponseWriter, r *http.Request, genres map[string][]genre) {
+	writeJSON(w, http.StatusOK, map[string]any{"genres": localizedGenres(genres, r.URL.Query().Get("language"))})
+}
+
+// watchProviders lists every provider the movies are available on in the
+// watch region, by display priority.
+func (s *Server) watchProviders(w http.ResponseWriter, r *http.Request) {
+	region := r.URL.Query().Get("watch_region")
+
+	seen := make(map[int]bool)
+	providers := make([]record, 0)
+	for _, m := range s.movies {
+		byRegion, _ := m["watch/providers"].(map[string]any)
+		results, _ := byRegion["results"].(map[string]any)
+		availability, _ := results[region].(map[string]any)
+		for _, kind := range []string{"flatrate", "free", "ads", "rent", "buy"} {
+			list, _ := availability[kind].([]any)
+			for _, provider := range list {
+				provider := record(provider.(map[string]any))
+				if id := provider.int("provider_id"); !seen[id] {
+					seen[id] = true
+					providers = append(providers, provider)
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(providers, func(a, b record) int {
+		return cmp.Or(cmp.Compare(a.int("display_priority"), b.int("display_priority")), cmp.Compare(a.int("provider_id"), b.int("provider_id")))
+	})
+	writeJSON(w, http.StatusOK, map[string]any{"results": providers})
+}
+
+func (s *Server) movieResource(w http.ResponseWriter, r *http.Request, segments []string) {
+	id, err := strconv.Atoi(segments[0])
+	if err != nil {
+		notFound(w)
+		return
+	}
+
+	m := find(s.movies, id)
+	if m == nil {
+		notFound(w)
+		return
+	}
+
+	language := r.URL.Query().Get("language")
+	switch {
+	case len(segments) == 1:
+		details := s.details(m, language, r.URL.Query().Get("append_to_response"), s.genres)
+		if _, ok := details["videos"]; ok {
+			details["videos"] = m.videos(r.URL.Query())
+		}
+		writeJSON(w, http.StatusOK, details)
+	case len(segments) == 2 && segments[1] == "videos":
+		writeJSON(w, http.StatusOK, withID(m.videos(r.URL.Query()), id))
+	case len(segments) == 2 && isAppendable(segments[1]):
+		writeJSON(w, http.StatusOK, withID(appended(m, segments[1]), id))
+	case len(segments) == 3 && segments[1]+"/"+segments[2] == "watch/providers":
+		writeJSON(w, http.StatusOK, withID(appended(m, "watch/providers"), id))
+	case len(segments) == 2 && (segments[1] == "similar" || segments[1] == "recommendations"):
+		s.writePage(w, r, relatedMovies(s.movies, m, segments[1]))
+	default:
+		notFound(w)
+	}
+}
+
+func find(catalog []record, id int) record {
+	for _, m := range catalog {
+		if m.int("id") == id {
+			return m
+		}
+	}
+	return nil
+}
+
+// details returns the movie or show in the language with the requested
+// extras.
+func (s *Server) details(m record, language string, appendToResponse string, genres map[string][]genre) map[string]any {
+	details := s.localize(m, language)
+	for name := range m {
+		if isAppendable(name) || name == "episodes" {
+			delete(details, name)
+		}
+	}
+
+	for _, name := range strings.Split(appendToResponse, ",") {
+		if isAppendable(name) {
+			details[name] = appended(m, name)
+		}
+	}
+
+	details["genres"] = genresOf(m, localizedGenres(genres, language))
+	return details
+}
+
+// listItem returns the movie or show as lists show it: without the details
+// and with bare genre IDs.
+func (s *Server) listItem(m record, language string) map[string]any {
+	item := s.localize(m, language)
+	for _, name := range []string{"belongs_to_collection", "budget", "genres", "homepage", "imdb_id",
+		"production_companies", "production_countries", "revenue", "runtime", "spoken_languages", "status", "tagline",
+		"episode_run_time", "in_production", "languages", "last_air_date", "last_episode_to_air", "next_episode_to_air",
+		"number_of_episodes", "number_of_seasons", "seasons", "type"} {
+		delete(item, name)
+	}
+	if _, ok := m["title"]; ok {
+		delete(item, "origin_country")
+	}
+	for name := range m {
+		if isAppendable(name) || name == "episodes" {
+			delete(item, name)
+		}
+	}
+
+	item["genre_ids"] = m.genreIDs()
+	return item
+}
+
+// localize copies the movie or show with its title (or name), overview and
+// tagline in the language, keeping the original ones when there's no
+// translation.
+func (s *Server) localize(m record, language string) map[string]any {
+	localized := make(map[string]any, len(m))
+	for name, value := range m {
+		localized[name] = value
+	}
+
+	if data := m.translation(language); data != nil {
+		for _, field := range []string{"title", "name", "overview", "tagline"} {
+			if text, _ := data[field].(string); text != "" {
+				localized[field] = text
+			}
+		}
+	}
+
+	return localized
+}
+
+func localizedGenres(genres map[string][]genre, language string) []genre {
+	if localized, ok := genres[baseLanguage(language)]; ok {
+		return localized
+	}
+	return genres["en"]
+}
+
+func genresOf(m record, all []genre) []genre {
+	genres := make([]genre, 0)
+	for _, id := range m.genreIDs() {
+		for _, g := range all {
+			if g.ID == id {
+				genres = append(genres, g)
+			}
+		}
+	}
+	return genres
+}
+
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, results []record) {
+	page := 1
+	if value := r.URL.Query().Get("page"); value != "" {
+		var err error
+		if page, err = strconv.Atoi(value); err != nil || page < 1 || page > maxPage {
+			writeJSON(w, http.StatusBadRequest, map[string]any{
+				"success": false, "status_code": 22, "status_message": "Invalid page: Pages start at 1 and max at 500.",
+			})
+			return
+		}
+	}
+
+	language := r.URL.Query().Get("language")
+	items := make([]map[string]any, 0, pageSize)
+	for i := (page - 1) * pageSize; i < len(results) && len(items) < pageSize; i++ {
+		items = append(items, s.listItem(results[i], language))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"page":          page,
+		"results":       items,
+		"total_pages":   (len(results) + pageSize - 1) / pageSize,
+		"total_results": len(results),
+	})
+}
+
+func isAppendable(name string) bool {
+	return name == "translations" || name == "release_dates" || name == "credits" || name == "content_ratings" || name == "watch/providers" || name == "videos"
+}
+
+// appended returns the extra of the movie or show, or an empty one, as TMDB
+// returns, when the fixture has none.
+func appended(m record, name string) map[string]any {
+	if value, ok := m[name].(map[string]any); ok {
+		return value
+	}
+	if name == "watch/providers" {
+		return map[string]any{"results": map[string]any{}}
+	}
+	return map[string]any{}
+}
+
+func withID(value map[string]any, id int) map[string]any {
+	copied := map[string]any{"id": id}
+	for name, field := range value {
+		copied[name] = field
+	}
+	return copied
+}
+
+func baseLanguage(language string) string {
+	base, _, _ := strings.Cut(language, "-")
+	return strings.ToLower(base)
+}
+
+func notFound(w http.ResponseWriter) {
+	writeStatus(w, http.StatusNotFound, 34, "The resource you requested could not be found.")
+}
+
+func writeStatus(w http.ResponseWriter, status int, code int, message string) {
+	writeJSON(w, status, map[string]any{"success": false, "status_code": code, "status_message": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,133 @@
+package faketmdb
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+func (s *Server) showResource(w http.ResponseWriter, r *http.Request, segments []string) {
+	id, err := strconv.Atoi(segments[0])
+	if err != nil {
+		notFound(w)
+		return
+	}
+
+	show := find(s.shows, id)
+	if show == nil {
+		notFound(w)
+		return
+	}
+
+	language := r.URL.Query().Get("language")
+	switch {
+	case len(segments) == 1:
+		writeJSON(w, http.StatusOK, s.details(show, language, r.URL.Query().Get("append_to_response"), s.tvGenres))
+	case len(segments) == 2 && isAppendable(segments[1]):
+		appended := show[segments[1]].(map[string]any)
+		writeJSON(w, http.StatusOK, withID(appended, id))
+	case len(segments) >= 3 && segments[1] == "season":
+		s.seasonResource(w, show, segments[2:], language)
+	default:
+		notFound(w)
+	}
+}
+
+// seasonResource answers a season of the show, or one of its episodes, in the
+// language.
+func (s *Server) seasonResource(w http.ResponseWriter, show record, segments []string, language string) {
+	number, err := strconv.Atoi(segments[0])
+	if err != nil {
+		notFound(w)
+		return
+	}
+
+	season := show.season(number)
+	if season == nil {
+		notFound(w)
+		return
+	}
+
+	episodes := show.episodes(number)
+	switch {
+	case len(segments) == 1:
+		localized := make(map[string]any, len(season)+1)
+		for name, value := range season {
+			localized[name] = value
+		}
+		localized["name"] = seasonName(season, language)
+
+		list := make([]map[string]any, len(episodes))
+		for i, episode := range episodes {
+			list[i] = localizeEpisode(episode, language)
+		}
+		localized["episodes"] = list
+
+		writeJSON(w, http.StatusOK, localized)
+	case len(segments) == 3 && segments[1] == "episode":
+		for _, episode := range episodes {
+			if strconv.Itoa(episode.int("episode_number")) == segments[2] {
+				writeJSON(w, http.StatusOK, localizeEpisode(episode, language))
+				return
+			}
+		}
+		notFound(w)
+	default:
+		notFound(w)
+	}
+}
+
+func (m record) season(number int) record {
+	seasons, _ := m["seasons"].([]any)
+	for _, season := range seasons {
+		if season := record(season.(map[string]any)); season.int("season_number") == number {
+			return season
+		}
+	}
+	return nil
+}
+
+func (m record) episodes(season int) []record {
+	bySeason, _ := m["episodes"].(map[string]any)
+	list, _ := bySeason[strconv.Itoa(season)].([]any)
+
+	episodes := make([]record, 0, len(list))
+	for _, episode := range list {
+		episodes = append(episodes, episode.(map[string]any))
+	}
+	return episodes
+}
+
+// seasonName names the season the way TMDB does in the language, which only
+// Portuguese differs from the fixtures in.
+func seasonName(season record, language string) string {
+	if baseLanguage(language) != "pt" {
+		return season.string("name")
+	}
+	if number := season.int("season_number"); number > 0 {
+		return fmt.Sprintf("Temporada %d", number)
+	}
+	return "Especiais"
+}
+
+// localizeEpisode copies the episode with its name and overview in the
+// language. Episodes list their translations directly, not wrapped like the
+// show's.
+func localizeEpisode(episode record, language string) map[string]any {
+	localized := make(map[string]any, len(episode))
+	for name, value := range episode {
+		localized[name] = value
+	}
+	delete(localized, "translations")
+
+	list, _ := episode["translations"].([]any)
+	wrapped := record{"translations": map[string]any{"translations": list}}
+	if data := wrapped.translation(language); data != nil {
+		for _, field := range []string{"name", "overview"} {
+			if value, _ := data[field].(string); value != "" {
+				localized[field] = value
+			}
+		}
+	}
+	return localized
+}
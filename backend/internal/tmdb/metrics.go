@@ -0,0 +1,76 @@
+package tmdb
+
+import (
+	"expvar"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The upstream calls are published under "tmdb" in /debug/vars, keyed by
+// route (the method and the path with its IDs replaced by ":id"):
+//
+//	calls       number of attempts per route and status ("error" when no response came)
+//	latency_ms  total time spent per route, to divide by its calls
+//	retries     attempts retried per route
+//	rejected    calls failed fast per route while the circuit breaker was open
+//	breaker     state of the circuit breaker
+var (
+	metrics   = expvar.NewMap("tmdb")
+	calls     = newMetricsMap("calls")
+	latencyMs = newMetricsMap("latency_ms")
+	retries   = newMetricsMap("retries")
+	rejected  = newMetricsMap("rejected")
+)
+
+func newMetricsMap(name string) *expvar.Map {
+	m := new(expvar.Map).Init()
+	metrics.Set(name, m)
+	return m
+}
+
+func publishBreakerState(b *breaker) {
+	metrics.Set("breaker", expvar.Func(func() any {
+		return b.currentState()
+	}))
+}
+
+func recordCall(route string, resp *http.Response, err error, elapsed time.Duration) {
+	status := "error"
+	if resp != nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	calls.Add(route+" "+status, 1)
+	latencyMs.Add(route, elapsed.Milliseconds())
+
+	slog.Debug("tmdb call", "route", route, "status", status, "elapsed", elapsed, "error", err)
+}
+
+func recordRetry(route string) {
+	retries.Add(route, 1)
+}
+
+func recordRejection(route string) {
+	rejected.Add(route, 1)
+}
+
+func routeOf(method string, endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return method
+	}
+
+	// Keep the API version, the first segment
+	segments := strings.Split(u.Path, "/")
+	for i := 2; i < len(segments); i++ {
+		if _, err := strconv.Atoi(segments[i]); err == nil {
+			segments[i] = ":id"
+		}
+	}
+
+	return method + " " + strings.Join(segments, "/")
+}
@@ -68,6 +68,27 @@ func NewUnauthorizedError(message string) *ApiError {
 	}
 }
 
+func NewForbiddenError(message string) *ApiError {
+	return &ApiError{
+		Message: FallbackZero(message, "error.forbidden"),
+		Code:    http.StatusForbidden,
+	}
+}
+
+func NewConflictError(message string) *ApiError {
+	return &ApiError{
+		Message: FallbackZero(message, "error.conflict"),
+		Code:    http.StatusConflict,
+	}
+}
+
+func NewServiceUnavailableError(message string) *ApiError {
+	return &ApiError{
+		Message: FallbackZero(message, "error.service_unavailable"),
+		Code:    http.StatusServiceUnavailable,
+	}
+}
+
 type ValidationError struct {
 	*ApiError
 	Fields map[string]string `json:"fields"`
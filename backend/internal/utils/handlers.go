@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"log/slog"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,6 +25,19 @@ func MakeHandler(f func(*gin.Context) error) gin.HandlerFunc {
 			return
 		}
 
+		// Failures caused by the request being cancelled aren't server errors
+		switch requestErr := c.Request.Context().Err(); {
+		case errors.Is(requestErr, context.DeadlineExceeded):
+			c.AbortWithStatusJSON(http.StatusGatewayTimeout, gin.H{
+				"error": &ApiError{Message: "error.request_timeout", Code: http.StatusGatewayTimeout},
+			})
+			return
+		case errors.Is(requestErr, context.Canceled):
+			// The client went away, there is no one to answer
+			c.Abort()
+			return
+		}
+
 		slog.Error("error handling request", "error", err)
 		c.AbortWithError(500, err)
 	}
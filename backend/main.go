@@ -1,7 +1,16 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"expvar"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/movie-tracker/MovieTracker/internal/config"
@@ -51,11 +60,28 @@ func main() {
 	_services := services.NewServices(cfg, _connections, _repositories)
 	_controllers := controllers.NewControllers(cfg, _services)
 
+	// Stop on interrupt or termination, letting the background work finish
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Scan for new notifications in the background
+	scanning := make(chan struct{})
+	go func() {
+		defer close(scanning)
+		_services.NotificationService.Run(ctx)
+	}()
+
 	utils.RegisterValidations()
 	server := gin.Default()
 	server.Use(middlewares.CORSMiddleware(cfg))
+	server.Use(middlewares.LocaleMiddleware())
+	server.Use(middlewares.DeadlineMiddleware(time.Duration(cfg.RequestTimeout)*time.Second, map[string]time.Duration{
+		"/api/import/letterboxd": time.Duration(cfg.LongRequestTimeout) * time.Second,
+		"/api/watchlist/export":  time.Duration(cfg.LongRequestTimeout) * time.Second,
+	}))
 
 	public := server.Group("/api")
+	public.Use(middlewares.OptionalJwtAuthMiddleware(_services.AuthService))
 	authenticated := server.Group("/api")
 	authenticated.Use(middlewares.JwtAuthMiddleware(_services.AuthService))
 
@@ -67,6 +93,34 @@ func main() {
 	// Swagger endpoint
 	server.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Runtime and upstream call metrics, on an internal listener
+	if cfg.DebugAddr != "" {
+		debug := http.NewServeMux()
+		debug.Handle("/debug/vars", expvar.Handler())
+		go func() {
+			if err := http.ListenAndServe(cfg.DebugAddr, debug); err != nil {
+				slog.Error("debug listener stopped", "address", cfg.DebugAddr, "error", err)
+			}
+		}()
+	}
+
 	address := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
-	server.Run(address)
+	api := &http.Server{Addr: address, Handler: server}
+	go func() {
+		if err := api.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("server stopped", "address", address, "error", err)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	slog.Info("shutting down")
+
+	// Requests in flight get as long as the longest ones may take
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.LongRequestTimeout)*time.Second)
+	defer cancel()
+	if err := api.Shutdown(shutdownCtx); err != nil {
+		slog.Error("shutting down the server", "error", err)
+	}
+	<-scanning
 }
@@ -15,6 +15,8 @@ export const API_CONFIG = {
     // Auth
     LOGIN: '/auth/login',
     REGISTER: '/auth/register',
+    REFRESH: '/auth/refresh',
+    LOGOUT: '/auth/logout',
     PROFILE: '/auth/profile',
     
     // Users
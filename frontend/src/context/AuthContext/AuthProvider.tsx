@@ -1,8 +1,10 @@
 import { useQuery, useQueryClient } from '@tanstack/react-query';
+import { useEffect } from 'react';
 
 import useLocalStorage from '@/hooks/useLocalStorage';
 import { authService } from '@/services/authService';
 import UserDTO from '@/services/dto/user.dto';
+import { clearSession, saveSession, scheduleRefresh } from '@/services/session';
 
 import AuthContext, { IAuthContext } from './auth.context';
 
@@ -10,6 +12,11 @@ function AuthProvider({ children }: { children?: React.ReactNode }) {
   const [authToken, setAuthToken] = useLocalStorage('authToken');
   const queryClient = useQueryClient();
 
+  // Keep the access token of a restored session fresh
+  useEffect(() => {
+    scheduleRefresh();
+  }, []);
+
   const profileQuery = useQuery<UserDTO, Error>({
     queryKey: ['profile'],
     queryFn: async () => authService.getProfile(),
@@ -19,6 +26,7 @@ function AuthProvider({ children }: { children?: React.ReactNode }) {
 
   // Se há token mas a query falhou (token inválido), limpar o token
   if (authToken && profileQuery.error) {
+    clearSession();
     setAuthToken(null);
     queryClient.invalidateQueries({ queryKey: ['profile'] });
   }
@@ -27,11 +35,14 @@ function AuthProvider({ children }: { children?: React.ReactNode }) {
   const isAuthenticated = !!authToken && !profileQuery.error;
 
   async function login(username: string, password: string) {
-    const { authToken: token } = await authService.login({ username, password });
-    setAuthToken(token);
+    const tokens = await authService.login({ username, password });
+    saveSession(tokens);
+    setAuthToken(tokens.authToken);
   }
 
   async function logout() {
+    await authService.logout();
+    clearSession();
     setAuthToken(null);
     queryClient.invalidateQueries({ queryKey: ['profile'] });
   }
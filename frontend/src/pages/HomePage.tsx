@@ -56,7 +56,7 @@ const HomePage = () => {
     error: moviesError,
   } = useInfiniteQuery({
     queryKey: ["movies-infinite", debouncedSearchTerm],
-    queryFn: async ({ pageParam = 1 }) => {
+    queryFn: async ({ pageParam }) => {
       try {
         let result;
         if (debouncedSearchTerm) {
@@ -69,13 +69,8 @@ const HomePage = () => {
         throw error;
       }
     },
-    getNextPageParam: (lastPage) => {
-      if (lastPage && lastPage.page && lastPage.total_pages && lastPage.page < lastPage.total_pages) {
-        return lastPage.page + 1;
-      }
-      return undefined;
-    },
-    initialPageParam: 1,
+    getNextPageParam: (lastPage) => lastPage?.next_cursor ?? undefined,
+    initialPageParam: '',
     staleTime: 10 * 60 * 1000,
     retry: 2,
     retryDelay: (attemptIndex) => Math.min(1000 * 2 ** attemptIndex, 15000),
@@ -1,7 +1,7 @@
 import { useState, useEffect } from "react";
 import { useParams, Link } from "react-router-dom";
 import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
-import { ArrowLeft, Star, Clock, Calendar, Heart } from "lucide-react";
+import { ArrowLeft, Star, Clock, Calendar, Heart, Play } from "lucide-react";
 import { Button } from "@/components/ui/button";
 import { Badge } from "@/components/ui/badge";
 import { Textarea } from "@/components/ui/textarea";
@@ -297,12 +297,20 @@ const MovieDetails = () => {
                   </div>
                 </div>
                 <div className="flex flex-wrap gap-2 mb-6">
-                  {(movie.genre ?? []).map((genre) => (
-                    <Badge key={genre} variant="outline" className="border-yellow-400 text-yellow-400">
-                      {genre}
+                  {(movie.genres ?? []).filter((genre) => genre.name).map((genre) => (
+                    <Badge key={genre.id} variant="outline" className="border-yellow-400 text-yellow-400">
+                      {genre.name}
                     </Badge>
                   ))}
                 </div>
+                {movie.trailer?.url && (
+                  <a href={movie.trailer.url} target="_blank" rel="noopener noreferrer" className="inline-block mb-6">
+                    <Button className="bg-yellow-400 text-black hover:bg-yellow-300">
+                      <Play className="h-4 w-4 mr-2" />
+                      Assistir trailer
+                    </Button>
+                  </a>
+                )}
               </div>
               <Card className="bg-black/60 backdrop-blur-sm border-white/20">
                 <CardHeader>
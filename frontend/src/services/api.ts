@@ -3,6 +3,8 @@ import axios, { AxiosError, InternalAxiosRequestConfig } from 'axios';
 import ApiConfig from '@/config';
 import { IApiError } from '@/utils/errors';
 
+import { getAuthToken, refreshSession } from './session';
+
 export const httpClient = axios.create({
   baseURL: ApiConfig.apiUrl,
 });
@@ -14,7 +16,7 @@ function setAuthToken(config: InternalAxiosRequestConfig) {
     ...config,
   };
 
-  const token = localStorage.getItem('authToken');
+  const token = getAuthToken();
   if (token) {
     newConfig.headers.Authorization = `Bearer ${token}`;
   }
@@ -43,7 +45,30 @@ function handleApiError(error: unknown) {
   throw apiError;
 }
 
+// Refreshes the session once when the access token is rejected and repeats
+// the request with the new token
+async function retryWithRefreshedToken(error: unknown) {
+  if (!axios.isAxiosError(error) || error.response?.status !== 401 || !error.config) {
+    throw error;
+  }
+
+  const config = error.config as InternalAxiosRequestConfig & { retried?: boolean };
+  const rejected = config.headers.Authorization;
+  if (config.retried || typeof rejected !== 'string') {
+    throw error;
+  }
+
+  const token = await refreshSession(rejected.replace(/^Bearer /, ''));
+  if (!token) {
+    throw error;
+  }
+
+  config.retried = true;
+  return apiClient.request(config);
+}
+
 httpClient.interceptors.response.use(undefined, handleApiError);
 apiClient.interceptors.request.use(setAuthToken);
+apiClient.interceptors.response.use(undefined, retryWithRefreshedToken);
 
 export default apiClient;
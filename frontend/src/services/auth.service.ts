@@ -1,7 +1,8 @@
 import { httpClient } from './api';
+import { SessionTokensDTO } from './session';
 
 export async function login(username: string, password: string) {
-  const response = await httpClient.post<{ authToken: string }>('/auth/login', {
+  const response = await httpClient.post<SessionTokensDTO>('/auth/login', {
     username,
     password,
   });
@@ -1,4 +1,5 @@
 import { getApiUrl, API_CONFIG } from '@/config/api';
+import { authFetch, SessionTokensDTO } from './session';
 
 export interface LoginRequestDTO {
   username: string;
@@ -13,9 +14,7 @@ export interface RegisterRequestDTO {
   password: string;
 }
 
-export interface AuthResponseDTO {
-  authToken: string;
-}
+export type AuthResponseDTO = SessionTokensDTO;
 
 export interface UserDTO {
   id: number;
@@ -88,14 +87,14 @@ export const authService = {
     return response.json();
   },
 
+  // Logout: revoke the session on the server, then forget it locally
+  async logout(): Promise<void> {
+    await authFetch(getApiUrl(API_CONFIG.ENDPOINTS.LOGOUT), { method: 'POST' }).catch(() => undefined);
+  },
+
   // Get user profile
   async getProfile(): Promise<UserDTO> {
-    const token = localStorage.getItem('authToken');
-    const response = await fetch(getApiUrl('/users/profile'), {
-      headers: {
-        'Authorization': token ? `Bearer ${token}` : '',
-      },
-    });
+    const response = await authFetch(getApiUrl('/users/profile'));
     
     if (!response.ok) {
       const errorData = await response.json().catch(() => ({}));
@@ -107,12 +106,7 @@ export const authService = {
 
   // Get user by email
   async getUserByEmail(email: string): Promise<UserDTO> {
-    const token = localStorage.getItem('authToken');
-    const response = await fetch(getApiUrl(`/users/by-email/${email}`), {
-      headers: {
-        'Authorization': token ? `Bearer ${token}` : '',
-      },
-    });
+    const response = await authFetch(getApiUrl(`/users/by-email/${email}`));
     
     if (!response.ok) {
       const errorData = await response.json().catch(() => ({}));
@@ -1,25 +1,25 @@
 import { MovieDTO } from '@/types/movie';
 import { getApiUrl, API_CONFIG } from '@/config/api';
+import { authFetch } from './session';
 
 interface PaginatedResponse<T> {
   results: T[];
   page: number;
+  page_size: number;
+  next_cursor: string | null;
   total_pages: number;
   total_results: number;
+  totals_estimated: boolean;
 }
 
-const getAuthHeaders = () => {
-  const token = localStorage.getItem('authToken');
-  return {
-    'Content-Type': 'application/json',
-    'Authorization': token ? `Bearer ${token}` : '',
-  };
-};
+const getJsonHeaders = () => ({
+  'Content-Type': 'application/json',
+});
 
 export const movieService = {
-  async getMovies(page: number): Promise<PaginatedResponse<MovieDTO>> {
-    const response = await fetch(getApiUrl(API_CONFIG.ENDPOINTS.MOVIES) + `?page=${page}`, {
-      headers: getAuthHeaders(),
+  async getMovies(cursor: string = ''): Promise<PaginatedResponse<MovieDTO>> {
+    const response = await authFetch(getApiUrl(API_CONFIG.ENDPOINTS.MOVIES) + `?cursor=${encodeURIComponent(cursor)}`, {
+      headers: getJsonHeaders(),
     });
     
     if (!response.ok) {
@@ -30,9 +30,9 @@ export const movieService = {
     return response.json();
   },
 
-  async searchMovies(query: string, page: number = 1): Promise<PaginatedResponse<MovieDTO>> {
-    const response = await fetch(getApiUrl(API_CONFIG.ENDPOINTS.MOVIES) + `/search?query=${encodeURIComponent(query)}&page=${page}`, {
-      headers: getAuthHeaders(),
+  async searchMovies(query: string, cursor: string = ''): Promise<PaginatedResponse<MovieDTO>> {
+    const response = await authFetch(getApiUrl(API_CONFIG.ENDPOINTS.MOVIES) + `/search?query=${encodeURIComponent(query)}&cursor=${encodeURIComponent(cursor)}`, {
+      headers: getJsonHeaders(),
     });
     
     if (!response.ok) {
@@ -44,8 +44,8 @@ export const movieService = {
   },
 
   async getMovieById(id: number): Promise<MovieDTO> {
-    const response = await fetch(`http://localhost:8888/api/movies/${id}`, {
-      headers: getAuthHeaders(),
+    const response = await authFetch(`http://localhost:8888/api/movies/${id}`, {
+      headers: getJsonHeaders(),
     });
     
     if (!response.ok) {
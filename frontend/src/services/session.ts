@@ -0,0 +1,138 @@
+import { getApiUrl, API_CONFIG } from '@/config/api';
+
+const AUTH_TOKEN_KEY = 'authToken';
+const REFRESH_TOKEN_KEY = 'refreshToken';
+
+// Access tokens are renewed this long before they expire
+const REFRESH_MARGIN_MS = 60 * 1000;
+
+export interface SessionTokensDTO {
+  authToken: string;
+  refreshToken: string;
+  expiresIn: number;
+}
+
+let refreshing: Promise<string | null> | null = null;
+let refreshTimer: ReturnType<typeof setTimeout> | undefined;
+
+export function getAuthToken() {
+  return localStorage.getItem(AUTH_TOKEN_KEY);
+}
+
+export function saveSession(tokens: SessionTokensDTO) {
+  localStorage.setItem(AUTH_TOKEN_KEY, tokens.authToken);
+  localStorage.setItem(REFRESH_TOKEN_KEY, tokens.refreshToken);
+  scheduleRefresh();
+}
+
+export function clearSession() {
+  clearTimeout(refreshTimer);
+  localStorage.removeItem(AUTH_TOKEN_KEY);
+  localStorage.removeItem(REFRESH_TOKEN_KEY);
+}
+
+// Milliseconds since the epoch at which the JWT expires, if it says so
+function tokenExpiry(token: string): number | null {
+  try {
+    const payload = token.split('.')[1].replace(/-/g, '+').replace(/_/g, '/');
+    const { exp } = JSON.parse(atob(payload)) as { exp?: number };
+    return typeof exp === 'number' ? exp * 1000 : null;
+  } catch {
+    return null;
+  }
+}
+
+function expiresSoon(token: string) {
+  const expiry = tokenExpiry(token);
+  return expiry !== null && expiry - Date.now() <= REFRESH_MARGIN_MS;
+}
+
+// Renews the access token shortly before it expires, so requests don't have to
+// fail first
+export function scheduleRefresh() {
+  clearTimeout(refreshTimer);
+
+  const token = getAuthToken();
+  const expiry = token ? tokenExpiry(token) : null;
+  if (!token || expiry === null) {
+    return;
+  }
+
+  refreshTimer = setTimeout(() => {
+    void refreshSession(token);
+  }, Math.max(expiry - Date.now() - REFRESH_MARGIN_MS, 0));
+}
+
+// Exchanges the refresh token for new tokens and returns the new access token,
+// or null when the session is gone. The refresh token is rotated and reusing it
+// revokes the session, so concurrent callers, even from other tabs, share a
+// single request and a token another tab already renewed is used as is.
+export function refreshSession(staleToken: string | null = getAuthToken()): Promise<string | null> {
+  if (!refreshing) {
+    const refresh = () => refreshUnlessRenewed(staleToken);
+    refreshing = (navigator.locks ? navigator.locks.request('session-refresh', refresh) : refresh()).finally(() => {
+      refreshing = null;
+    });
+  }
+
+  return refreshing;
+}
+
+async function refreshUnlessRenewed(staleToken: string | null) {
+  const current = getAuthToken();
+  if (current && current !== staleToken && !expiresSoon(current)) {
+    scheduleRefresh();
+    return current;
+  }
+
+  const refreshToken = localStorage.getItem(REFRESH_TOKEN_KEY);
+  if (!refreshToken) {
+    return null;
+  }
+
+  let response: Response;
+  try {
+    response = await fetch(getApiUrl(API_CONFIG.ENDPOINTS.REFRESH), {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+      },
+      body: JSON.stringify({ refreshToken }),
+    });
+  } catch {
+    // Offline: keep the session and try again on the next request
+    return null;
+  }
+
+  if (!response.ok) {
+    if (response.status === 401) {
+      clearSession();
+    }
+    return null;
+  }
+
+  const tokens: SessionTokensDTO = await response.json();
+  saveSession(tokens);
+  return tokens.authToken;
+}
+
+// Sends an authenticated request. When the access token is rejected the
+// session is refreshed once and the request repeated with the new token.
+export async function authFetch(input: string, init: RequestInit = {}) {
+  const send = (token: string | null) => {
+    const headers = new Headers(init.headers);
+    if (token) {
+      headers.set('Authorization', `Bearer ${token}`);
+    }
+    return fetch(input, { ...init, headers });
+  };
+
+  const token = getAuthToken();
+  const response = await send(token);
+  if (response.status !== 401 || !token) {
+    return response;
+  }
+
+  const renewed = await refreshSession(token);
+  return renewed ? send(renewed) : response;
+}
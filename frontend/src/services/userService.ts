@@ -1,19 +1,16 @@
 import { getApiUrl, API_CONFIG } from '@/config/api';
+import { authFetch } from './session';
 import { authService, UserDTO } from './authService';
 
-const getAuthHeaders = () => {
-  const token = localStorage.getItem('authToken');
-  return {
-    'Content-Type': 'application/json',
-    'Authorization': token ? `Bearer ${token}` : '',
-  };
-};
+const getJsonHeaders = () => ({
+  'Content-Type': 'application/json',
+});
 
 export const userService = {
   // Buscar todos os usuários
   async getUsers(): Promise<UserDTO[]> {
-    const response = await fetch(getApiUrl(API_CONFIG.ENDPOINTS.USERS), {
-      headers: getAuthHeaders(),
+    const response = await authFetch(getApiUrl(API_CONFIG.ENDPOINTS.USERS), {
+      headers: getJsonHeaders(),
     });
     
     if (!response.ok) {
@@ -31,8 +28,8 @@ export const userService = {
 
   // Buscar usuário por email
   async getUserByEmail(email: string): Promise<UserDTO> {
-    const response = await fetch(getApiUrl(API_CONFIG.ENDPOINTS.USER_BY_EMAIL(email)), {
-      headers: getAuthHeaders(),
+    const response = await authFetch(getApiUrl(API_CONFIG.ENDPOINTS.USER_BY_EMAIL(email)), {
+      headers: getJsonHeaders(),
     });
     
     if (!response.ok) {
@@ -45,9 +42,9 @@ export const userService = {
 
   // Criar novo usuário
   async createUser(userData: { username: string; email: string; password: string }): Promise<UserDTO> {
-    const response = await fetch(getApiUrl(API_CONFIG.ENDPOINTS.USERS), {
+    const response = await authFetch(getApiUrl(API_CONFIG.ENDPOINTS.USERS), {
       method: 'POST',
-      headers: getAuthHeaders(),
+      headers: getJsonHeaders(),
       body: JSON.stringify(userData),
     });
     
@@ -4,20 +4,17 @@ import {
     UpdateWatchlistRequestDTO 
   } from '@/types/movie';
   import { getApiUrl, API_CONFIG } from '@/config/api';
+  import { authFetch } from './session';
   
-  const getAuthHeaders = () => {
-    const token = localStorage.getItem('authToken');
-    return {
-      'Content-Type': 'application/json',
-      'Authorization': token ? `Bearer ${token}` : '',
-    };
-  };
+  const getJsonHeaders = () => ({
+    'Content-Type': 'application/json',
+  });
   
   export const watchlistService = {
     // Buscar watchlist do usuário
     async getUserWatchlist(): Promise<WatchListDTO[]> {
-      const response = await fetch(getApiUrl(API_CONFIG.ENDPOINTS.WATCHLIST), {
-        headers: getAuthHeaders(),
+      const response = await authFetch(getApiUrl(API_CONFIG.ENDPOINTS.WATCHLIST), {
+        headers: getJsonHeaders(),
       });
       
       if (!response.ok) {
@@ -30,9 +27,9 @@ import {
   
     // Adicionar filme à watchlist
     async addToWatchlist(data: WatchListCreateDTO): Promise<WatchListDTO> {
-      const response = await fetch(getApiUrl(API_CONFIG.ENDPOINTS.WATCHLIST), {
+      const response = await authFetch(getApiUrl(API_CONFIG.ENDPOINTS.WATCHLIST), {
         method: 'POST',
-        headers: getAuthHeaders(),
+        headers: getJsonHeaders(),
         body: JSON.stringify(data),
       });
       
@@ -50,9 +47,9 @@ import {
       const payload = { status };
       console.log('[PATCH STATUS] URL:', url);
       console.log('[PATCH STATUS] Payload:', payload);
-      const response = await fetch(url, {
+      const response = await authFetch(url, {
         method: 'PATCH',
-        headers: getAuthHeaders(),
+        headers: getJsonHeaders(),
         body: JSON.stringify(payload),
       });
       console.log('[PATCH STATUS] Response status:', response.status);
@@ -72,9 +69,9 @@ import {
       const payload = { favorite };
       console.log('[PATCH FAVORITE] URL:', url);
       console.log('[PATCH FAVORITE] Payload:', payload);
-      const response = await fetch(url, {
+      const response = await authFetch(url, {
         method: 'PATCH',
-        headers: getAuthHeaders(),
+        headers: getJsonHeaders(),
         body: JSON.stringify(payload),
       });
       console.log('[PATCH FAVORITE] Response status:', response.status);
@@ -94,9 +91,9 @@ import {
       const payload = { rating };
       console.log('[PATCH RATING] URL:', url);
       console.log('[PATCH RATING] Payload:', payload);
-      const response = await fetch(url, {
+      const response = await authFetch(url, {
         method: 'PATCH',
-        headers: getAuthHeaders(),
+        headers: getJsonHeaders(),
         body: JSON.stringify(payload),
       });
       console.log('[PATCH RATING] Response status:', response.status);
@@ -118,9 +115,9 @@ import {
       console.log('📤 Comments tipo:', typeof data.comments);
       console.log('📤 JSON sendo enviado:', JSON.stringify(data));
       
-      const response = await fetch(getApiUrl(API_CONFIG.ENDPOINTS.WATCHLIST_BY_ID(movieId)), {
+      const response = await authFetch(getApiUrl(API_CONFIG.ENDPOINTS.WATCHLIST_BY_ID(movieId)), {
         method: 'PUT',
-        headers: getAuthHeaders(),
+        headers: getJsonHeaders(),
         body: JSON.stringify(data),
       });
       
@@ -139,9 +136,9 @@ import {
   
     // Remover da watchlist
     async removeFromWatchlist(movieId: number): Promise<void> {
-      const response = await fetch(getApiUrl(API_CONFIG.ENDPOINTS.WATCHLIST_BY_ID(movieId)), {
+      const response = await authFetch(getApiUrl(API_CONFIG.ENDPOINTS.WATCHLIST_BY_ID(movieId)), {
         method: 'DELETE',
-        headers: getAuthHeaders(),
+        headers: getJsonHeaders(),
       });
       
       if (!response.ok) {
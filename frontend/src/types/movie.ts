@@ -14,6 +14,42 @@ export interface Stats {
   toWatch: number;
 }
 
+export interface Genre {
+  id: number;
+  name: string;
+}
+
+export interface WatchProvider {
+  provider_id: number;
+  provider_name: string;
+  logo_path: string;
+  display_priority: number;
+}
+
+export interface WatchAvailability {
+  country?: string;
+  link: string;
+  flatrate?: WatchProvider[];
+  free?: WatchProvider[];
+  ads?: WatchProvider[];
+  rent?: WatchProvider[];
+  buy?: WatchProvider[];
+}
+
+export interface Video {
+  id: string;
+  name: string;
+  type: string;
+  site: string;
+  key: string;
+  url: string;
+  language: string;
+  country: string;
+  official: boolean;
+  size: number;
+  published_at: string;
+}
+
 export interface MovieDTO {
   id: number;
   title: string;
@@ -21,7 +57,9 @@ export interface MovieDTO {
   background_path: string;
   year: string;
   description: string;
+  /** @deprecated names differ by language, use genres */
   genre: string[];
+  genres?: Genre[];
   duration: string;
   tmdb_id?: number;
   tagline?: string;
@@ -40,6 +78,9 @@ export interface MovieDTO {
   production_companies?: any[];
   production_countries?: any[];
   spoken_languages?: any[];
+  watch_providers?: WatchAvailability;
+  videos?: Video[];
+  trailer?: Video;
 }
 
 export type WatchListStatus = 'unwatched' | 'watching' | 'plan to watch' | 'watched';
@@ -52,6 +93,7 @@ export interface WatchListDTO {
   favorite: boolean;
   comments?: string;
   rating?: number;
+  streaming_on?: WatchProvider[];
 }
 
 export interface WatchListCreateDTO {
@@ -80,3 +122,43 @@ export interface UpdateWatchlistRequestDTO {
   comments?: string | null;
   rating?: number | null;
 }
+
+export type ReleaseType = 'theatrical' | 'digital';
+
+export interface Release {
+  type: ReleaseType;
+  date: string;
+  note?: string;
+}
+
+export interface UpcomingRelease {
+  movie_id: number;
+  status: WatchListStatus;
+  region: string;
+  releases: Release[];
+  movie: MovieDTO;
+}
+
+export interface CalendarFeed {
+  url?: string;
+  created_at: string;
+}
+
+export type NotificationType = 'released' | 'available' | 'rated';
+
+export interface Notification {
+  id: number;
+  type: NotificationType;
+  movie_id: number;
+  actor_id?: number;
+  title: string;
+  body: string;
+  link: string;
+  created_at: string;
+  read_at: string | null;
+}
+
+export interface NotificationSettings {
+  email: boolean;
+  webhook_url?: string;
+}